@@ -25,6 +25,7 @@ import (
 	"github.com/0xPolygonHermez/zkevm-node/gasprice"
 	"github.com/0xPolygonHermez/zkevm-node/jsonrpc"
 	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/client"
+	jsonrpcTypes "github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
 	"github.com/0xPolygonHermez/zkevm-node/log"
 	"github.com/0xPolygonHermez/zkevm-node/merkletree"
 	"github.com/0xPolygonHermez/zkevm-node/metrics"
@@ -141,7 +142,10 @@ func start(cliCtx *cli.Context) error {
 		EventID:    event.EventID_NodeComponentStarted,
 	}
 
-	var poolInstance *pool.Pool
+	var (
+		poolInstance *pool.Pool
+		seq          *sequencer.Sequencer
+	)
 
 	if c.Metrics.ProfilingEnabled {
 		go startProfilingHttpServer(c.Metrics)
@@ -171,7 +175,7 @@ func start(cliCtx *cli.Context) error {
 			if poolInstance == nil {
 				poolInstance = createPool(c.Pool, c.State.Batch.Constraints, l2ChainID, st, eventLog)
 			}
-			seq := createSequencer(*c, poolInstance, st, etherman, eventLog)
+			seq = createSequencer(*c, poolInstance, st, etherman, eventLog)
 			go seq.Start(cliCtx.Context)
 		case SEQUENCE_SENDER:
 			ev.Component = event.Component_Sequence_Sender
@@ -240,6 +244,10 @@ func start(cliCtx *cli.Context) error {
 		}
 	}
 
+	if c.RPC.Admin.Enabled {
+		go runAdminServer(*c, seq, poolInstance, st, stateSqlDB, needsExecutor)
+	}
+
 	if c.Metrics.Enabled {
 		go startMetricsHttpServer(c.Metrics)
 	}
@@ -321,8 +329,10 @@ func runSynchronizer(cfg config.Config, etherman *etherman.Client, ethTxManagerS
 }
 
 func runJSONRPCServer(c config.Config, etherman *etherman.Client, chainID uint64, pool *pool.Pool, st *state.State, apis map[string]bool) {
-	var err error
-	storage := jsonrpc.NewStorage()
+	storage, err := jsonrpc.NewFilterStorage(c.RPC, c.State.DB)
+	if err != nil {
+		log.Fatal(err)
+	}
 	c.RPC.MaxCumulativeGasUsed = c.State.Batch.Constraints.MaxCumulativeGasUsed
 	c.RPC.L2Coinbase = c.SequenceSender.L2Coinbase
 	c.RPC.ZKCountersLimits = jsonrpc.ZKCountersLimits{
@@ -364,14 +374,14 @@ func runJSONRPCServer(c config.Config, etherman *etherman.Client, chainID uint64
 	if _, ok := apis[jsonrpc.APIZKEVM]; ok {
 		services = append(services, jsonrpc.Service{
 			Name:    jsonrpc.APIZKEVM,
-			Service: jsonrpc.NewZKEVMEndpoints(c.RPC, pool, st, etherman),
+			Service: jsonrpc.NewZKEVMEndpoints(c.RPC, chainID, pool, st, etherman),
 		})
 	}
 
 	if _, ok := apis[jsonrpc.APITxPool]; ok {
 		services = append(services, jsonrpc.Service{
 			Name:    jsonrpc.APITxPool,
-			Service: &jsonrpc.TxPoolEndpoints{},
+			Service: jsonrpc.NewTxPoolEndpoints(pool),
 		})
 	}
 
@@ -382,6 +392,13 @@ func runJSONRPCServer(c config.Config, etherman *etherman.Client, chainID uint64
 		})
 	}
 
+	if _, ok := apis[jsonrpc.APITrace]; ok {
+		services = append(services, jsonrpc.Service{
+			Name:    jsonrpc.APITrace,
+			Service: jsonrpc.NewTraceEndpoints(c.RPC, st, etherman),
+		})
+	}
+
 	if _, ok := apis[jsonrpc.APIWeb3]; ok {
 		services = append(services, jsonrpc.Service{
 			Name:    jsonrpc.APIWeb3,
@@ -394,6 +411,42 @@ func runJSONRPCServer(c config.Config, etherman *etherman.Client, chainID uint64
 	}
 }
 
+func runAdminServer(c config.Config, seq *sequencer.Sequencer, pool *pool.Pool, st *state.State, stateSqlDB *pgxpool.Pool, needsExecutor bool) {
+	healthChecks := map[string]jsonrpc.HealthCheck{
+		"stateDB": func(ctx context.Context) error {
+			return stateSqlDB.Ping(ctx)
+		},
+	}
+	if needsExecutor {
+		healthChecks["executor"] = func(ctx context.Context) error {
+			_, _, err := st.GetStoredFlushID(ctx)
+			return err
+		}
+	}
+
+	// the interfaces must stay nil when the components are not running in this node
+	var sequencerIntf jsonrpcTypes.SequencerInterface
+	if seq != nil {
+		sequencerIntf = seq
+	}
+	var poolIntf jsonrpcTypes.PoolInterface
+	if pool != nil {
+		poolIntf = pool
+	}
+
+	services := []jsonrpc.Service{{
+		Name:    jsonrpc.APIAdmin,
+		Service: jsonrpc.NewAdminEndpoints(sequencerIntf, poolIntf, healthChecks),
+	}}
+	server, err := jsonrpc.NewAdminServer(c.RPC, services)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := server.Start(); err != nil {
+		log.Fatal(err)
+	}
+}
+
 func createSequencer(cfg config.Config, pool *pool.Pool, st *state.State, etherman *etherman.Client, eventLog *event.EventLog) *sequencer.Sequencer {
 	seq, err := sequencer.New(cfg.Sequencer, cfg.State.Batch, cfg.Pool, pool, st, etherman, eventLog)
 	if err != nil {
@@ -487,6 +540,7 @@ func newState(ctx context.Context, c *config.Config, etherman *etherman.Client,
 		MaxLogsBlockRange:            c.RPC.MaxLogsBlockRange,
 		MaxNativeBlockHashBlockRange: c.RPC.MaxNativeBlockHashBlockRange,
 		AvoidForkIDInMemory:          avoidForkIDInMemory,
+		Archive:                      c.State.Archive,
 	}
 	stateDb := pgstatestorage.NewPostgresStorage(stateCfg, sqlDB)
 
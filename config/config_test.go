@@ -13,6 +13,7 @@ import (
 	"github.com/0xPolygonHermez/zkevm-node/config"
 	"github.com/0xPolygonHermez/zkevm-node/config/types"
 	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/sequencer"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -129,6 +130,34 @@ func Test_Defaults(t *testing.T) {
 			path:          "Sequencer.Finalizer.BatchMaxDeltaTimestamp",
 			expectedValue: types.NewDuration(10 * time.Second),
 		},
+		{
+			path:          "Sequencer.Finalizer.TxOrderingPolicy",
+			expectedValue: "gasprice",
+		},
+		{
+			path:          "Sequencer.Finalizer.Throttle.Enabled",
+			expectedValue: false,
+		},
+		{
+			path:          "Sequencer.Finalizer.Throttle.Sender.MaxBatchResourcesPct",
+			expectedValue: uint32(20),
+		},
+		{
+			path:          "Sequencer.Finalizer.Throttle.Sender.MaxTxsPerL2Block",
+			expectedValue: uint64(20),
+		},
+		{
+			path:          "Sequencer.Finalizer.Throttle.Contract.MaxBatchResourcesPct",
+			expectedValue: uint32(50),
+		},
+		{
+			path:          "Sequencer.Finalizer.Throttle.Contract.MaxTxsPerL2Block",
+			expectedValue: uint64(0),
+		},
+		{
+			path:          "Sequencer.Finalizer.Throttle.SenderOverrides",
+			expectedValue: []sequencer.ThrottleOverrideCfg{},
+		},
 		{
 			path:          "Sequencer.Finalizer.Metrics.Interval",
 			expectedValue: types.NewDuration(60 * time.Minute),
@@ -153,6 +182,30 @@ func Test_Defaults(t *testing.T) {
 			path:          "Sequencer.StreamServer.Enabled",
 			expectedValue: false,
 		},
+		{
+			path:          "Sequencer.LeaderElection.Enabled",
+			expectedValue: false,
+		},
+		{
+			path:          "Sequencer.LeaderElection.NodeID",
+			expectedValue: "",
+		},
+		{
+			path:          "Sequencer.LeaderElection.LeaseDuration",
+			expectedValue: types.NewDuration(15 * time.Second),
+		},
+		{
+			path:          "Sequencer.LeaderElection.RenewInterval",
+			expectedValue: types.NewDuration(5 * time.Second),
+		},
+		{
+			path:          "Sequencer.Preconfirmations.Enabled",
+			expectedValue: false,
+		},
+		{
+			path:          "Sequencer.Preconfirmations.PrivateKey",
+			expectedValue: types.KeystoreFileConfig{Path: "/pk/sequencer.keystore", Password: "testonly"},
+		},
 		{
 			path:          "SequenceSender.WaitPeriodSendSequence",
 			expectedValue: types.NewDuration(5 * time.Second),
@@ -294,6 +347,14 @@ func Test_Defaults(t *testing.T) {
 			path:          "Pool.GlobalQueue",
 			expectedValue: uint64(1024),
 		},
+		{
+			path:          "Pool.MaxBundleTxs",
+			expectedValue: uint64(0),
+		},
+		{
+			path:          "Pool.FailedBundlePenalty",
+			expectedValue: types.NewDuration(10 * time.Minute),
+		},
 		{
 			path:          "Pool.EffectiveGasPrice.Enabled",
 			expectedValue: false,
@@ -406,10 +467,94 @@ func Test_Defaults(t *testing.T) {
 			path:          "RPC.MaxNativeBlockHashBlockRange",
 			expectedValue: uint64(60000),
 		},
+		{
+			path:          "RPC.MaxTracesBlockRange",
+			expectedValue: uint64(100),
+		},
+		{
+			path:          "RPC.MaxL1BlockRange",
+			expectedValue: uint64(1000),
+		},
+		{
+			path:          "RPC.MaxStorageProofKeys",
+			expectedValue: uint64(100),
+		},
 		{
 			path:          "RPC.EnableHttpLog",
 			expectedValue: true,
 		},
+		{
+			path:          "RPC.FilterStorage",
+			expectedValue: "memory",
+		},
+		{
+			path:          "RPC.FilterTimeout",
+			expectedValue: types.NewDuration(5 * time.Minute),
+		},
+		{
+			path:          "RPC.RateLimit.Enabled",
+			expectedValue: false,
+		},
+		{
+			path:          "RPC.RateLimit.APIKeyHeader",
+			expectedValue: "X-API-Key",
+		},
+		{
+			path:          "RPC.RateLimit.TrustedProxies",
+			expectedValue: []string{},
+		},
+		{
+			path:          "RPC.GraphQL.Enabled",
+			expectedValue: false,
+		},
+		{
+			path:          "RPC.GraphQL.MaxBlockRange",
+			expectedValue: uint64(100),
+		},
+		{
+			path:          "RPC.GraphQL.MaxDepth",
+			expectedValue: 10,
+		},
+		{
+			path:          "RPC.GraphQL.MaxParallelism",
+			expectedValue: 10,
+		},
+		{
+			path:          "RPC.GraphQL.MaxBlocks",
+			expectedValue: uint64(1000),
+		},
+		{
+			path:          "RPC.GraphQL.MaxExecutorCalls",
+			expectedValue: uint64(10),
+		},
+		{
+			path:          "RPC.Cache.Enabled",
+			expectedValue: false,
+		},
+		{
+			path:          "RPC.Cache.Backend",
+			expectedValue: "memory",
+		},
+		{
+			path:          "RPC.Cache.Size",
+			expectedValue: 10000,
+		},
+		{
+			path:          "RPC.Admin.Enabled",
+			expectedValue: false,
+		},
+		{
+			path:          "RPC.Admin.Host",
+			expectedValue: "127.0.0.1",
+		},
+		{
+			path:          "RPC.Admin.Port",
+			expectedValue: 8547,
+		},
+		{
+			path:          "RPC.Admin.JWTSecretFile",
+			expectedValue: "",
+		},
 		{
 			path:          "RPC.WebSockets.Enabled",
 			expectedValue: true,
@@ -538,6 +683,10 @@ func Test_Defaults(t *testing.T) {
 			path:          "State.Batch.Constraints.MaxBinaries",
 			expectedValue: uint32(473170),
 		},
+		{
+			path:          "State.Archive.Enabled",
+			expectedValue: false,
+		},
 	}
 	file, err := os.CreateTemp("", "genesisConfig")
 	require.NoError(t, err)
@@ -33,6 +33,8 @@ Outputs = ["stderr"]
 		MaxBinaries = 473170
 		MaxSteps = 7570538
 		MaxSHA256Hashes = 1596
+	[State.Archive]
+		Enabled = false
 
 [Pool]
 IntervalToRefreshBlockedAddresses = "5m"
@@ -44,6 +46,8 @@ MinAllowedGasPriceInterval = "5m"
 PollMinAllowedGasPriceInterval = "15s"
 AccountQueue = 64
 GlobalQueue = 1024
+MaxBundleTxs = 0
+FailedBundlePenalty = "10m"
     [Pool.EffectiveGasPrice]
 	Enabled = false
 	L1GasPriceFactor = 0.25
@@ -91,7 +95,32 @@ BatchRequestsLimit = 20
 MaxLogsCount = 10000
 MaxLogsBlockRange = 10000
 MaxNativeBlockHashBlockRange = 60000
+MaxTracesBlockRange = 100
+MaxL1BlockRange = 1000
+MaxStorageProofKeys = 100
 EnableHttpLog = true
+FilterStorage = "memory"
+FilterTimeout = "5m"
+	[RPC.RateLimit]
+		Enabled = false
+		APIKeyHeader = "X-API-Key"
+		TrustedProxies = []
+	[RPC.GraphQL]
+		Enabled = false
+		MaxBlockRange = 100
+		MaxDepth = 10
+		MaxParallelism = 10
+		MaxBlocks = 1000
+		MaxExecutorCalls = 10
+	[RPC.Cache]
+		Enabled = false
+		Backend = "memory"
+		Size = 10000
+	[RPC.Admin]
+		Enabled = false
+		Host = "127.0.0.1"
+		Port = 8547
+		JWTSecretFile = ""
 	[RPC.WebSockets]
 		Enabled = true
 		Host = "0.0.0.0"
@@ -143,6 +172,17 @@ StateConsistencyCheckInterval = "5s"
 		HaltOnBatchNumber = 0
 		SequentialBatchSanityCheck = false
 		SequentialProcessL2Block = true
+		TxOrderingPolicy = "gasprice"
+	[Sequencer.Finalizer.Throttle]
+		Enabled = false
+		SenderOverrides = []
+		ContractOverrides = []
+	[Sequencer.Finalizer.Throttle.Sender]
+		MaxBatchResourcesPct = 20
+		MaxTxsPerL2Block = 20
+	[Sequencer.Finalizer.Throttle.Contract]
+		MaxBatchResourcesPct = 50
+		MaxTxsPerL2Block = 0
 	[Sequencer.Finalizer.Metrics]
 		Interval = "60m"
 		EnableLog = true
@@ -151,6 +191,14 @@ StateConsistencyCheckInterval = "5s"
 		Filename = ""
 		Version = 0
 		Enabled = false
+	[Sequencer.LeaderElection]
+		Enabled = false
+		NodeID = ""
+		LeaseDuration = "15s"
+		RenewInterval = "5s"
+	[Sequencer.Preconfirmations]
+		Enabled = false
+		PrivateKey = {Path = "/pk/sequencer.keystore", Password = "testonly"}
 
 [SequenceSender]
 WaitPeriodSendSequence = "5s"
@@ -0,0 +1,5 @@
+-- +migrate Up
+CREATE INDEX IF NOT EXISTS idx_transaction_received_at ON pool.transaction (received_at);
+
+-- +migrate Down
+DROP INDEX IF EXISTS pool.idx_transaction_received_at;
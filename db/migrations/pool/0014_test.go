@@ -0,0 +1,35 @@
+package pool_migrations_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// this migration adds an index to query the txs by the time they were received
+type migrationTest0014 struct{}
+
+func (m migrationTest0014) InsertData(db *sql.DB) error {
+	return nil
+}
+
+func (m migrationTest0014) RunAssertsAfterMigrationUp(t *testing.T, db *sql.DB) {
+	const getIndex = `SELECT count(*) FROM pg_indexes WHERE indexname = 'idx_transaction_received_at';`
+	row := db.QueryRow(getIndex)
+	var result int
+	assert.NoError(t, row.Scan(&result))
+	assert.Equal(t, 1, result)
+}
+
+func (m migrationTest0014) RunAssertsAfterMigrationDown(t *testing.T, db *sql.DB) {
+	const getIndex = `SELECT count(*) FROM pg_indexes WHERE indexname = 'idx_transaction_received_at';`
+	row := db.QueryRow(getIndex)
+	var result int
+	assert.NoError(t, row.Scan(&result))
+	assert.Equal(t, 0, result)
+}
+
+func TestMigration0014(t *testing.T) {
+	runMigrationTest(t, 14, migrationTest0014{})
+}
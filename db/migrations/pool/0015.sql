@@ -0,0 +1,19 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS pool.bundle
+(
+    hash          VARCHAR PRIMARY KEY,
+    tx_hashes     VARCHAR[] NOT NULL,
+    encoded_txs   VARCHAR[] NOT NULL,
+    status        VARCHAR NOT NULL,
+    block_num     BIGINT NOT NULL DEFAULT 0,
+    max_timestamp BIGINT NOT NULL DEFAULT 0,
+    received_at   TIMESTAMP WITH TIME ZONE NOT NULL,
+    ip            VARCHAR,
+    failed_reason VARCHAR
+);
+
+CREATE INDEX IF NOT EXISTS idx_bundle_status ON pool.bundle (status);
+
+-- +migrate Down
+DROP INDEX IF EXISTS pool.idx_bundle_status;
+DROP TABLE IF EXISTS pool.bundle;
@@ -0,0 +1,42 @@
+package pool_migrations_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// this migration adds the bundle table
+type migrationTest0015 struct{}
+
+func (m migrationTest0015) InsertData(db *sql.DB) error {
+	return nil
+}
+
+func (m migrationTest0015) RunAssertsAfterMigrationUp(t *testing.T, db *sql.DB) {
+	const insertBundle = `
+		INSERT INTO pool.bundle (hash, tx_hashes, encoded_txs, status, block_num, max_timestamp, received_at, ip)
+		VALUES ('0x0001', '{"0x0011","0x0012"}', '{"0x0021","0x0022"}', 'pending', 10, 0, '2024-01-01', '127.0.0.1')`
+	_, err := db.Exec(insertBundle)
+	require.NoError(t, err)
+
+	const getIndex = `SELECT count(*) FROM pg_indexes WHERE indexname = 'idx_bundle_status';`
+	row := db.QueryRow(getIndex)
+	var result int
+	assert.NoError(t, row.Scan(&result))
+	assert.Equal(t, 1, result)
+}
+
+func (m migrationTest0015) RunAssertsAfterMigrationDown(t *testing.T, db *sql.DB) {
+	const getTable = `SELECT count(*) FROM information_schema.tables WHERE table_schema = 'pool' AND table_name = 'bundle';`
+	row := db.QueryRow(getTable)
+	var result int
+	assert.NoError(t, row.Scan(&result))
+	assert.Equal(t, 0, result)
+}
+
+func TestMigration0015(t *testing.T) {
+	runMigrationTest(t, 15, migrationTest0015{})
+}
@@ -0,0 +1,14 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS pool.preconfirmation
+(
+    id           BIGSERIAL PRIMARY KEY,
+    tx_hash      VARCHAR NOT NULL UNIQUE REFERENCES pool.transaction (hash) ON DELETE CASCADE,
+    l2_block_num BIGINT NOT NULL,
+    position     BIGINT NOT NULL,
+    state_root   VARCHAR NOT NULL,
+    signature    VARCHAR NOT NULL,
+    created_at   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS pool.preconfirmation;
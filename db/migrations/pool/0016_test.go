@@ -0,0 +1,47 @@
+package pool_migrations_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// this migration adds the preconfirmation table
+type migrationTest0016 struct{}
+
+func (m migrationTest0016) InsertData(db *sql.DB) error {
+	const insertTx = `
+		INSERT INTO pool.transaction (hash, ip, received_at, from_address)
+		VALUES ('0x0001', '127.0.0.1', '2024-01-01', '0x0002')`
+	_, err := db.Exec(insertTx)
+	return err
+}
+
+func (m migrationTest0016) RunAssertsAfterMigrationUp(t *testing.T, db *sql.DB) {
+	const insertPreconfirmation = `
+		INSERT INTO pool.preconfirmation (tx_hash, l2_block_num, position, state_root, signature)
+		VALUES ('0x0001', 10, 0, '0x0003', '0x0004')`
+	_, err := db.Exec(insertPreconfirmation)
+	require.NoError(t, err)
+
+	// the preconfirmation is deleted with the tx
+	_, err = db.Exec(`DELETE FROM pool.transaction WHERE hash = '0x0001'`)
+	require.NoError(t, err)
+	var count int
+	require.NoError(t, db.QueryRow(`SELECT count(*) FROM pool.preconfirmation`).Scan(&count))
+	assert.Equal(t, 0, count)
+}
+
+func (m migrationTest0016) RunAssertsAfterMigrationDown(t *testing.T, db *sql.DB) {
+	const getTable = `SELECT count(*) FROM information_schema.tables WHERE table_schema = 'pool' AND table_name = 'preconfirmation';`
+	row := db.QueryRow(getTable)
+	var result int
+	assert.NoError(t, row.Scan(&result))
+	assert.Equal(t, 0, result)
+}
+
+func TestMigration0016(t *testing.T) {
+	runMigrationTest(t, 16, migrationTest0016{})
+}
@@ -0,0 +1,73 @@
+package pool_migrations_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// this migration links the txs of the bundles to their bundle
+type migrationTest0017 struct{}
+
+func (m migrationTest0017) InsertData(db *sql.DB) error {
+	const insertBundle = `
+		INSERT INTO pool.bundle (hash, tx_hashes, encoded_txs, status, block_num, max_timestamp, received_at, ip)
+		VALUES ('0x0001', '{"0x0011"}', '{"0x0021"}', 'pending', 0, 0, '2024-01-01', '127.0.0.1')`
+	if _, err := db.Exec(insertBundle); err != nil {
+		return err
+	}
+
+	const insertTx = `
+		INSERT INTO pool.transaction (hash, ip, received_at, from_address)
+		VALUES ('0x0010', '127.0.0.1', '2024-01-01', '0x0099')`
+	if _, err := db.Exec(insertTx); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m migrationTest0017) RunAssertsAfterMigrationUp(t *testing.T, db *sql.DB) {
+	const getIndex = `SELECT count(*) FROM pg_indexes WHERE indexname = 'idx_transaction_bundle_hash';`
+	row := db.QueryRow(getIndex)
+	var result int
+	assert.NoError(t, row.Scan(&result))
+	assert.Equal(t, 1, result)
+
+	const insertBundleTx = `
+		INSERT INTO pool.transaction (hash, ip, received_at, from_address, bundle_hash)
+		VALUES ('0x0011', '127.0.0.1', '2024-01-01', '0x0099', '0x0001')`
+	_, err := db.Exec(insertBundleTx)
+	assert.NoError(t, err)
+
+	const insertTxOfUnknownBundle = `
+		INSERT INTO pool.transaction (hash, ip, received_at, from_address, bundle_hash)
+		VALUES ('0x0012', '127.0.0.1', '2024-01-01', '0x0099', '0x0002')`
+	_, err = db.Exec(insertTxOfUnknownBundle)
+	assert.Error(t, err)
+
+	// the txs of a bundle are deleted with the bundle
+	_, err = db.Exec(`DELETE FROM pool.bundle WHERE hash = '0x0001'`)
+	assert.NoError(t, err)
+	row = db.QueryRow(`SELECT count(*) FROM pool.transaction WHERE hash = '0x0011'`)
+	assert.NoError(t, row.Scan(&result))
+	assert.Equal(t, 0, result)
+}
+
+func (m migrationTest0017) RunAssertsAfterMigrationDown(t *testing.T, db *sql.DB) {
+	const getIndex = `SELECT count(*) FROM pg_indexes WHERE indexname = 'idx_transaction_bundle_hash';`
+	row := db.QueryRow(getIndex)
+	var result int
+	assert.NoError(t, row.Scan(&result))
+	assert.Equal(t, 0, result)
+
+	const getColumn = `SELECT count(*) FROM information_schema.columns WHERE table_schema = 'pool' AND table_name = 'transaction' AND column_name = 'bundle_hash';`
+	row = db.QueryRow(getColumn)
+	assert.NoError(t, row.Scan(&result))
+	assert.Equal(t, 0, result)
+}
+
+func TestMigration0017(t *testing.T) {
+	runMigrationTest(t, 17, migrationTest0017{})
+}
@@ -0,0 +1,9 @@
+-- +migrate Up
+ALTER TABLE pool.bundle
+    ADD COLUMN failed_at TIMESTAMP WITH TIME ZONE;
+CREATE INDEX IF NOT EXISTS idx_bundle_failed_at ON pool.bundle (failed_at);
+
+-- +migrate Down
+DROP INDEX IF EXISTS pool.idx_bundle_failed_at;
+ALTER TABLE pool.bundle
+    DROP COLUMN failed_at;
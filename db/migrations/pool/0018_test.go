@@ -0,0 +1,52 @@
+package pool_migrations_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// this migration stores the time the bundles failed
+type migrationTest0018 struct{}
+
+func (m migrationTest0018) InsertData(db *sql.DB) error {
+	const insertBundle = `
+		INSERT INTO pool.bundle (hash, tx_hashes, encoded_txs, status, block_num, max_timestamp, received_at, ip)
+		VALUES ('0x0001', '{"0x0011"}', '{"0x0021"}', 'failed', 0, 0, '2024-01-01', '127.0.0.1')`
+	_, err := db.Exec(insertBundle)
+	return err
+}
+
+func (m migrationTest0018) RunAssertsAfterMigrationUp(t *testing.T, db *sql.DB) {
+	const getIndex = `SELECT count(*) FROM pg_indexes WHERE indexname = 'idx_bundle_failed_at';`
+	row := db.QueryRow(getIndex)
+	var result int
+	assert.NoError(t, row.Scan(&result))
+	assert.Equal(t, 1, result)
+
+	// the bundles failed before the migration don't have a failure time
+	row = db.QueryRow(`SELECT count(*) FROM pool.bundle WHERE hash = '0x0001' AND failed_at IS NULL`)
+	assert.NoError(t, row.Scan(&result))
+	assert.Equal(t, 1, result)
+
+	_, err := db.Exec(`UPDATE pool.bundle SET failed_at = '2024-01-02' WHERE hash = '0x0001'`)
+	assert.NoError(t, err)
+}
+
+func (m migrationTest0018) RunAssertsAfterMigrationDown(t *testing.T, db *sql.DB) {
+	const getIndex = `SELECT count(*) FROM pg_indexes WHERE indexname = 'idx_bundle_failed_at';`
+	row := db.QueryRow(getIndex)
+	var result int
+	assert.NoError(t, row.Scan(&result))
+	assert.Equal(t, 0, result)
+
+	const getColumn = `SELECT count(*) FROM information_schema.columns WHERE table_schema = 'pool' AND table_name = 'bundle' AND column_name = 'failed_at';`
+	row = db.QueryRow(getColumn)
+	assert.NoError(t, row.Scan(&result))
+	assert.Equal(t, 0, result)
+}
+
+func TestMigration0018(t *testing.T) {
+	runMigrationTest(t, 18, migrationTest0018{})
+}
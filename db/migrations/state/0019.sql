@@ -0,0 +1,18 @@
+-- +migrate Up
+CREATE SCHEMA IF NOT EXISTS rpc;
+
+CREATE TABLE IF NOT EXISTS rpc.filter
+(
+    id          VARCHAR PRIMARY KEY,
+    filter_type VARCHAR NOT NULL,
+    parameters  JSONB,
+    last_poll   TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS filter_last_poll_idx ON rpc.filter (last_poll);
+
+-- +migrate Down
+DROP TABLE IF EXISTS rpc.filter;
+
+DROP SCHEMA IF EXISTS rpc;
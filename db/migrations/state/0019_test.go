@@ -0,0 +1,30 @@
+package migrations_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type migrationTest0019 struct{}
+
+func (m migrationTest0019) InsertData(db *sql.DB) error {
+	return nil
+}
+
+func (m migrationTest0019) RunAssertsAfterMigrationUp(t *testing.T, db *sql.DB) {
+	assertTableExists(t, db, "rpc", "filter")
+
+	const insertFilter = `INSERT INTO rpc.filter (id, filter_type, parameters, last_poll) VALUES ('0x01', 'log', '{}', now())`
+	_, err := db.Exec(insertFilter)
+	assert.NoError(t, err)
+}
+
+func (m migrationTest0019) RunAssertsAfterMigrationDown(t *testing.T, db *sql.DB) {
+	assertTableNotExists(t, db, "rpc", "filter")
+}
+
+func TestMigration0019(t *testing.T) {
+	runMigrationTest(t, 19, migrationTest0019{})
+}
@@ -0,0 +1,45 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS state.archive_l2block
+(
+    l2_block_num         BIGINT PRIMARY KEY REFERENCES state.l2block (block_num) ON DELETE CASCADE,
+    state_root           VARCHAR NOT NULL,
+    has_code_and_storage BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE INDEX IF NOT EXISTS archive_l2block_state_root_idx ON state.archive_l2block (state_root);
+CREATE INDEX IF NOT EXISTS archive_l2block_no_code_and_storage_idx ON state.archive_l2block (l2_block_num) WHERE NOT has_code_and_storage;
+
+CREATE TABLE IF NOT EXISTS state.archive_account
+(
+    address      VARCHAR NOT NULL,
+    l2_block_num BIGINT  NOT NULL REFERENCES state.archive_l2block (l2_block_num) ON DELETE CASCADE,
+    nonce        DECIMAL(78, 0),
+    balance      DECIMAL(78, 0),
+    PRIMARY KEY (address, l2_block_num)
+);
+
+CREATE TABLE IF NOT EXISTS state.archive_code
+(
+    address      VARCHAR NOT NULL,
+    l2_block_num BIGINT  NOT NULL REFERENCES state.archive_l2block (l2_block_num) ON DELETE CASCADE,
+    code         BYTEA   NOT NULL,
+    PRIMARY KEY (address, l2_block_num)
+);
+
+CREATE TABLE IF NOT EXISTS state.archive_storage
+(
+    address      VARCHAR NOT NULL,
+    position     VARCHAR NOT NULL,
+    l2_block_num BIGINT  NOT NULL REFERENCES state.archive_l2block (l2_block_num) ON DELETE CASCADE,
+    value        VARCHAR NOT NULL,
+    PRIMARY KEY (address, position, l2_block_num)
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS state.archive_storage;
+
+DROP TABLE IF EXISTS state.archive_code;
+
+DROP TABLE IF EXISTS state.archive_account;
+
+DROP TABLE IF EXISTS state.archive_l2block;
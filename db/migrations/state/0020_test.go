@@ -0,0 +1,69 @@
+package migrations_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type migrationTest0020 struct{}
+
+func (m migrationTest0020) InsertData(db *sql.DB) error {
+	const insertBatch = `
+		INSERT INTO state.batch (batch_num, global_exit_root, local_exit_root, acc_input_hash, state_root, timestamp, coinbase, raw_txs_data, forced_batch_num, wip) 
+		VALUES (100, '0x0000', '0x0000', '0x0000', '0x0000', now(), '0x0000', null, null, true)`
+	if _, err := db.Exec(insertBatch); err != nil {
+		return err
+	}
+
+	const insertL2Block = `
+		INSERT INTO state.l2block (block_num, block_hash, header, uncles, parent_hash, state_root, received_at, batch_num, created_at)
+		VALUES (100, '0x0001', '{}', '{}', '0x0000', '0x0002', now(), 100, now())`
+	_, err := db.Exec(insertL2Block)
+	return err
+}
+
+func (m migrationTest0020) RunAssertsAfterMigrationUp(t *testing.T, db *sql.DB) {
+	assertTableExists(t, db, "state", "archive_l2block")
+	assertTableExists(t, db, "state", "archive_account")
+	assertTableExists(t, db, "state", "archive_code")
+	assertTableExists(t, db, "state", "archive_storage")
+
+	const insertArchivedL2Block = `INSERT INTO state.archive_l2block (l2_block_num, state_root, has_code_and_storage) VALUES (100, '0x0002', false)`
+	_, err := db.Exec(insertArchivedL2Block)
+	assert.NoError(t, err)
+
+	const insertArchivedAccount = `INSERT INTO state.archive_account (address, l2_block_num, nonce, balance) VALUES ('0x0003', 100, 1, 115792089237316195423570985008687907853269984665640564039457584007913129639935)`
+	_, err = db.Exec(insertArchivedAccount)
+	assert.NoError(t, err)
+
+	const insertArchivedCode = `INSERT INTO state.archive_code (address, l2_block_num, code) VALUES ('0x0003', 100, '\x6080')`
+	_, err = db.Exec(insertArchivedCode)
+	assert.NoError(t, err)
+
+	const insertArchivedStorage = `INSERT INTO state.archive_storage (address, position, l2_block_num, value) VALUES ('0x0003', '0x0001', 100, '0x0002')`
+	_, err = db.Exec(insertArchivedStorage)
+	assert.NoError(t, err)
+
+	// the archived accounts, code and storage are deleted with the l2 block
+	_, err = db.Exec(`DELETE FROM state.l2block WHERE block_num = 100`)
+	assert.NoError(t, err)
+	for _, table := range []string{"archive_account", "archive_code", "archive_storage"} {
+		var count int
+		err = db.QueryRow(`SELECT COUNT(*) FROM state.` + table).Scan(&count)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, count, table)
+	}
+}
+
+func (m migrationTest0020) RunAssertsAfterMigrationDown(t *testing.T, db *sql.DB) {
+	assertTableNotExists(t, db, "state", "archive_l2block")
+	assertTableNotExists(t, db, "state", "archive_account")
+	assertTableNotExists(t, db, "state", "archive_code")
+	assertTableNotExists(t, db, "state", "archive_storage")
+}
+
+func TestMigration0020(t *testing.T) {
+	runMigrationTest(t, 20, migrationTest0020{})
+}
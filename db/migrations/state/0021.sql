@@ -0,0 +1,11 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS state.sequencer_lease
+(
+    id            SMALLINT PRIMARY KEY CHECK (id = 1),
+    node_id       VARCHAR                  NOT NULL,
+    fencing_token BIGINT                   NOT NULL,
+    expires_at    TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS state.sequencer_lease;
@@ -0,0 +1,35 @@
+package migrations_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type migrationTest0021 struct{}
+
+func (m migrationTest0021) InsertData(db *sql.DB) error {
+	return nil
+}
+
+func (m migrationTest0021) RunAssertsAfterMigrationUp(t *testing.T, db *sql.DB) {
+	assertTableExists(t, db, "state", "sequencer_lease")
+
+	const insertLease = `INSERT INTO state.sequencer_lease (id, node_id, fencing_token, expires_at) VALUES (1, 'node1', 1, now())`
+	_, err := db.Exec(insertLease)
+	assert.NoError(t, err)
+
+	// there can be only one lease
+	const insertSecondLease = `INSERT INTO state.sequencer_lease (id, node_id, fencing_token, expires_at) VALUES (2, 'node2', 1, now())`
+	_, err = db.Exec(insertSecondLease)
+	assert.Error(t, err)
+}
+
+func (m migrationTest0021) RunAssertsAfterMigrationDown(t *testing.T, db *sql.DB) {
+	assertTableNotExists(t, db, "state", "sequencer_lease")
+}
+
+func TestMigration0021(t *testing.T) {
+	runMigrationTest(t, 21, migrationTest0021{})
+}
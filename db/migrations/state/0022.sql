@@ -0,0 +1,11 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS state.reset_epoch
+(
+    id    SMALLINT PRIMARY KEY CHECK (id = 1),
+    epoch BIGINT NOT NULL
+);
+
+INSERT INTO state.reset_epoch (id, epoch) VALUES (1, 0) ON CONFLICT (id) DO NOTHING;
+
+-- +migrate Down
+DROP TABLE IF EXISTS state.reset_epoch;
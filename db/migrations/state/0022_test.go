@@ -0,0 +1,36 @@
+package migrations_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type migrationTest0022 struct{}
+
+func (m migrationTest0022) InsertData(db *sql.DB) error {
+	return nil
+}
+
+func (m migrationTest0022) RunAssertsAfterMigrationUp(t *testing.T, db *sql.DB) {
+	assertTableExists(t, db, "state", "reset_epoch")
+
+	var epoch uint64
+	err := db.QueryRow(`SELECT epoch FROM state.reset_epoch WHERE id = 1`).Scan(&epoch)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), epoch)
+
+	// there can be only one epoch
+	const insertSecondEpoch = `INSERT INTO state.reset_epoch (id, epoch) VALUES (2, 0)`
+	_, err = db.Exec(insertSecondEpoch)
+	assert.Error(t, err)
+}
+
+func (m migrationTest0022) RunAssertsAfterMigrationDown(t *testing.T, db *sql.DB) {
+	assertTableNotExists(t, db, "state", "reset_epoch")
+}
+
+func TestMigration0022(t *testing.T) {
+	runMigrationTest(t, 22, migrationTest0022{})
+}
@@ -34,6 +34,8 @@ const (
 	EventID_FinalizerHalt EventID = "FINALIZER HALT"
 	// EventID_FinalizerRestart is triggered when the finalizer restarts
 	EventID_FinalizerRestart EventID = "FINALIZER RESTART"
+	// EventID_FinalizerPause is triggered when the finalizer is paused by the operator
+	EventID_FinalizerPause EventID = "FINALIZER PAUSE"
 	// EventID_FinalizerBreakEvenGasPriceBigDifference is triggered when the finalizer recalculates the break even gas price and detects a big difference
 	EventID_FinalizerBreakEvenGasPriceBigDifference EventID = "FINALIZER BREAK EVEN GAS PRICE BIG DIFFERENCE"
 	// EventID_SynchronizerRestart is triggered when the Synchonizer restarts
@@ -0,0 +1,100 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/metrics"
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/ethereum/go-ethereum/common/lru"
+)
+
+// CacheBackend is the storage used to cache the responses about consolidated
+// blocks, the responses are stored already encoded to JSON so backends shared
+// by multiple servers can be plugged in
+type CacheBackend interface {
+	Get(key string) ([]byte, bool)
+	Add(key string, value []byte)
+	Purge()
+}
+
+// LRUCacheBackend is a CacheBackend that keeps the responses in memory,
+// evicting the least recently used ones when the max size is reached
+type LRUCacheBackend struct {
+	cache *lru.Cache[string, []byte]
+}
+
+// NewLRUCacheBackend creates a LRUCacheBackend that keeps up to size responses
+func NewLRUCacheBackend(size int) *LRUCacheBackend {
+	return &LRUCacheBackend{cache: lru.NewCache[string, []byte](size)}
+}
+
+// Get returns the response stored with the provided key
+func (b *LRUCacheBackend) Get(key string) ([]byte, bool) {
+	return b.cache.Get(key)
+}
+
+// Add stores the response with the provided key
+func (b *LRUCacheBackend) Add(key string, value []byte) {
+	b.cache.Add(key, value)
+}
+
+// Purge removes all the stored responses
+func (b *LRUCacheBackend) Purge() {
+	b.cache.Purge()
+}
+
+// NewCacheBackend creates the cache backend defined by the config
+func NewCacheBackend(cfg CacheConfig) (CacheBackend, error) {
+	switch cfg.Backend {
+	case "", CacheBackendMemory:
+		return NewLRUCacheBackend(cfg.Size), nil
+	default:
+		return nil, fmt.Errorf("invalid cache backend: %s", cfg.Backend)
+	}
+}
+
+// responseCache caches the responses of the methods about consolidated blocks,
+// a nil responseCache means the cache is disabled
+type responseCache struct {
+	backend CacheBackend
+}
+
+func newResponseCache(backend CacheBackend) *responseCache {
+	return &responseCache{backend: backend}
+}
+
+// get returns the cached response of the method for the provided key
+func (c *responseCache) get(method, key string) (json.RawMessage, bool) {
+	if c == nil {
+		return nil, false
+	}
+	value, found := c.backend.Get(method + ":" + key)
+	if !found {
+		metrics.CacheMiss(method)
+		return nil, false
+	}
+	metrics.CacheHit(method)
+	return value, true
+}
+
+// add caches the response of the method for the provided key
+func (c *responseCache) add(method, key string, response interface{}) {
+	if c == nil {
+		return
+	}
+	value, err := json.Marshal(response)
+	if err != nil {
+		log.Errorf("failed to marshal the %v response to be cached: %v", method, err)
+		return
+	}
+	c.backend.Add(method+":"+key, value)
+}
+
+// purge removes all the cached responses
+func (c *responseCache) purge() {
+	if c == nil {
+		return
+	}
+	c.backend.Purge()
+}
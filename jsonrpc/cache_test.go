@@ -0,0 +1,159 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/mocks"
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLRUCacheBackend(t *testing.T) {
+	backend := NewLRUCacheBackend(2)
+
+	backend.Add("a", []byte("1"))
+	backend.Add("b", []byte("2"))
+	value, found := backend.Get("a")
+	require.True(t, found)
+	assert.Equal(t, []byte("1"), value)
+
+	// b is the least recently used one
+	backend.Add("c", []byte("3"))
+	_, found = backend.Get("b")
+	assert.False(t, found)
+	_, found = backend.Get("a")
+	assert.True(t, found)
+	_, found = backend.Get("c")
+	assert.True(t, found)
+
+	backend.Purge()
+	_, found = backend.Get("a")
+	assert.False(t, found)
+}
+
+func TestCachedResponses(t *testing.T) {
+	pool := mocks.NewPoolMock(t)
+	st := mocks.NewStateMock(t)
+	dbTx := mocks.NewDBTxMock(t)
+	storage := newStorageMock(t)
+
+	var zkEVMEventHandler state.ZKEVMEventHandler
+	st.On("RegisterNewL2BlockEventHandler", mock.Anything).Once()
+	st.On("RegisterZKEVMEventHandler", mock.Anything).Run(func(args mock.Arguments) {
+		zkEVMEventHandler = args.Get(0).(state.ZKEVMEventHandler)
+	}).Once()
+	pool.On("RegisterNewTxsEventHandler", mock.Anything).Once()
+	pool.On("RegisterNewPreconfirmationsEventHandler", mock.Anything).Once()
+
+	cfg := Config{Cache: CacheConfig{Enabled: true, Size: 10}}
+	e := NewEthEndpoints(cfg, chainID, pool, st, nil, storage)
+
+	header := state.NewL2Header(&ethTypes.Header{Number: big.NewInt(1), UncleHash: ethTypes.EmptyUncleHash})
+	consolidatedBlock := state.NewL2BlockWithHeader(header)
+	header = state.NewL2Header(&ethTypes.Header{Number: big.NewInt(2), UncleHash: ethTypes.EmptyUncleHash})
+	trustedBlock := state.NewL2BlockWithHeader(header)
+
+	expectBlockLoaded := func(block *state.L2Block, consolidated bool) {
+		st.On("BeginStateTransaction", context.Background()).Return(dbTx, nil).Once()
+		st.On("GetL2BlockByNumber", context.Background(), block.NumberU64(), dbTx).Return(block, nil).Once()
+		st.On("IsL2BlockConsolidated", context.Background(), block.NumberU64(), dbTx).Return(consolidated, nil).Once()
+		dbTx.On("Commit", context.Background()).Return(nil).Once()
+	}
+
+	getBlock := func(number uint64) types.Block {
+		res, rpcErr := e.GetBlockByNumber(types.BlockNumber(number), false, nil)
+		require.Nil(t, rpcErr)
+		b, err := json.Marshal(res)
+		require.NoError(t, err)
+		var block types.Block
+		require.NoError(t, json.Unmarshal(b, &block))
+		return block
+	}
+
+	// the consolidated block is loaded from the state only once
+	expectBlockLoaded(consolidatedBlock, true)
+	block := getBlock(1)
+	assert.Equal(t, types.ArgUint64(1), block.Number)
+	block = getBlock(1)
+	assert.Equal(t, types.ArgUint64(1), block.Number)
+
+	// the trusted block is not cached
+	expectBlockLoaded(trustedBlock, false)
+	getBlock(2)
+	expectBlockLoaded(trustedBlock, false)
+	getBlock(2)
+
+	// a reset purges the cache
+	require.NotNil(t, zkEVMEventHandler)
+	zkEVMEventHandler(state.ZKEVMEvent{Type: state.ZKEVMEventTypeReset})
+	expectBlockLoaded(consolidatedBlock, true)
+	block = getBlock(1)
+	assert.Equal(t, types.ArgUint64(1), block.Number)
+
+	// receipts of txs in consolidated blocks are cached
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	tx := ethTypes.NewTx(&ethTypes.LegacyTx{Nonce: 1, Gas: 21000, GasPrice: big.NewInt(1), Value: big.NewInt(1)})
+	signedTx, err := ethTypes.SignTx(tx, ethTypes.NewEIP155Signer(big.NewInt(0).SetUint64(chainID)), privateKey)
+	require.NoError(t, err)
+	receipt := &ethTypes.Receipt{
+		TxHash:      signedTx.Hash(),
+		BlockHash:   consolidatedBlock.Hash(),
+		BlockNumber: big.NewInt(1),
+		Logs:        []*ethTypes.Log{},
+	}
+	st.On("BeginStateTransaction", context.Background()).Return(dbTx, nil).Once()
+	st.On("GetTransactionByHash", context.Background(), signedTx.Hash(), dbTx).Return(signedTx, nil).Once()
+	st.On("GetTransactionReceipt", context.Background(), signedTx.Hash(), dbTx).Return(receipt, nil).Once()
+	st.On("IsL2BlockConsolidated", context.Background(), uint64(1), dbTx).Return(true, nil).Once()
+	dbTx.On("Commit", context.Background()).Return(nil).Once()
+	for i := 0; i < 2; i++ {
+		res, rpcErr := e.GetTransactionReceipt(types.ArgHash(signedTx.Hash()))
+		require.Nil(t, rpcErr)
+		b, err := json.Marshal(res)
+		require.NoError(t, err)
+		var r types.Receipt
+		require.NoError(t, json.Unmarshal(b, &r))
+		assert.Equal(t, signedTx.Hash(), r.TxHash)
+	}
+
+	// logs of a range of consolidated blocks are cached
+	from, to := types.BlockNumber(1), types.BlockNumber(1)
+	filter := LogFilter{FromBlock: &from, ToBlock: &to, Addresses: []common.Address{common.HexToAddress("0x1")}}
+	st.On("BeginStateTransaction", context.Background()).Return(dbTx, nil).Once()
+	st.On("GetLogs", context.Background(), uint64(1), uint64(1), filter.Addresses, filter.Topics, filter.BlockHash, filter.Since, dbTx).Return([]*ethTypes.Log{{BlockNumber: 1}}, nil).Once()
+	st.On("GetLastConsolidatedL2BlockNumber", context.Background(), dbTx).Return(uint64(1), nil).Once()
+	dbTx.On("Commit", context.Background()).Return(nil).Once()
+	for i := 0; i < 2; i++ {
+		res, rpcErr := e.GetLogs(filter)
+		require.Nil(t, rpcErr)
+		b, err := json.Marshal(res)
+		require.NoError(t, err)
+		var logs []types.Log
+		require.NoError(t, json.Unmarshal(b, &logs))
+		require.Len(t, logs, 1)
+		assert.Equal(t, types.ArgUint64(1), logs[0].BlockNumber)
+	}
+}
+
+func TestNewCacheBackend(t *testing.T) {
+	backend, err := NewCacheBackend(CacheConfig{Backend: CacheBackendMemory, Size: 1})
+	require.NoError(t, err)
+	assert.IsType(t, &LRUCacheBackend{}, backend)
+
+	backend, err = NewCacheBackend(CacheConfig{Size: 1})
+	require.NoError(t, err)
+	assert.IsType(t, &LRUCacheBackend{}, backend)
+
+	_, err = NewCacheBackend(CacheConfig{Backend: "unknown"})
+	require.EqualError(t, err, "invalid cache backend: unknown")
+}
@@ -97,3 +97,110 @@ func (c *Client) GetLatestGlobalExitRoot(ctx context.Context) (common.Hash, erro
 
 	return common.HexToHash(result), nil
 }
+
+// GetProof returns the sparse merkle tree proofs of the balance, nonce, code hash
+// and the provided storage keys of an account at the provided block number
+func (c *Client) GetProof(ctx context.Context, address common.Address, storageKeys []common.Hash, number *big.Int) (*types.AccountProof, error) {
+	bn := types.LatestBlockNumber
+	if number != nil {
+		bn = types.BlockNumber(number.Int64())
+	}
+	response, err := JSONRPCCall(c.url, "zkevm_getProof", address.String(), storageKeys, bn.StringOrHex())
+	if err != nil {
+		return nil, err
+	}
+
+	if response.Error != nil {
+		return nil, response.Error.RPCError()
+	}
+
+	var result *types.AccountProof
+	err = json.Unmarshal(response.Result, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// BatchProof returns the proof generated by the aggregator that includes the
+// provided batch number and its public inputs, only when the node keeps it
+func (c *Client) BatchProof(ctx context.Context, number *big.Int) (*types.BatchProof, error) {
+	return c.batchProof(ctx, "zkevm_getBatchProof", number)
+}
+
+// VerifiedBatchProof returns the L1 verification of the provided batch number,
+// the public inputs of the proof used to verify it and the proof itself when
+// the node keeps it
+func (c *Client) VerifiedBatchProof(ctx context.Context, number *big.Int) (*types.BatchProof, error) {
+	return c.batchProof(ctx, "zkevm_getVerifiedBatchProof", number)
+}
+
+func (c *Client) batchProof(ctx context.Context, method string, number *big.Int) (*types.BatchProof, error) {
+	bn := types.LatestBatchNumber
+	if number != nil {
+		bn = types.BatchNumber(number.Int64())
+	}
+	response, err := JSONRPCCall(c.url, method, bn.StringOrHex())
+	if err != nil {
+		return nil, err
+	}
+
+	if response.Error != nil {
+		return nil, response.Error.RPCError()
+	}
+
+	var result *types.BatchProof
+	err = json.Unmarshal(response.Result, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// BatchL2Data returns the L2 data of the provided batch number, as sequenced
+// to L1, and its decoded L2 blocks and transactions
+func (c *Client) BatchL2Data(ctx context.Context, number *big.Int) (*types.BatchL2Data, error) {
+	bn := types.LatestBatchNumber
+	if number != nil {
+		bn = types.BatchNumber(number.Int64())
+	}
+	response, err := JSONRPCCall(c.url, "zkevm_getBatchL2Data", bn.StringOrHex())
+	if err != nil {
+		return nil, err
+	}
+
+	if response.Error != nil {
+		return nil, response.Error.RPCError()
+	}
+
+	var result *types.BatchL2Data
+	err = json.Unmarshal(response.Result, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// L1InfoTreeProof returns the leaf of the L1 info tree at the provided index and
+// its merkle proof against the provided L1 info root
+func (c *Client) L1InfoTreeProof(ctx context.Context, index uint32, l1InfoRoot common.Hash) (*types.L1InfoTreeProof, error) {
+	response, err := JSONRPCCall(c.url, "zkevm_getL1InfoTreeProof", hex.EncodeUint64(uint64(index)), l1InfoRoot.String())
+	if err != nil {
+		return nil, err
+	}
+
+	if response.Error != nil {
+		return nil, response.Error.RPCError()
+	}
+
+	var result *types.L1InfoTreeProof
+	err = json.Unmarshal(response.Result, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -59,14 +59,113 @@ type Config struct {
 	// native block hashes in a single call to the state, if zero it means no limit
 	MaxNativeBlockHashBlockRange uint64 `mapstructure:"MaxNativeBlockHashBlockRange"`
 
+	// MaxTracesBlockRange is a configuration to set the max range for block number when filtering
+	// traces with trace_filter, if zero it means no limit
+	MaxTracesBlockRange uint64 `mapstructure:"MaxTracesBlockRange"`
+
+	// MaxL1BlockRange is a configuration to set the max range for L1 block number when querying
+	// the batches sequenced or verified in a range of L1 blocks, if zero it means no limit
+	MaxL1BlockRange uint64 `mapstructure:"MaxL1BlockRange"`
+
+	// MaxStorageProofKeys is a configuration to set the max number of storage keys that can be
+	// requested in a single call to zkevm_getProof, if zero it means no limit
+	MaxStorageProofKeys uint64 `mapstructure:"MaxStorageProofKeys"`
+
 	// EnableHttpLog allows the user to enable or disable the logs related to the HTTP
 	// requests to be captured by the server.
 	EnableHttpLog bool `mapstructure:"EnableHttpLog"`
 
 	// ZKCountersLimits defines the ZK Counter limits
 	ZKCountersLimits ZKCountersLimits
+
+	// FilterStorage defines where the filters are stored, the allowed values are
+	// "memory" to keep them in the memory of the server and "postgres" to store
+	// them in the state db, allowing them to be shared between multiple servers
+	FilterStorage string `mapstructure:"FilterStorage"`
+
+	// FilterTimeout defines the time a filter without a web socket connection
+	// can stay without being polled before it is uninstalled, if zero the filters
+	// are never uninstalled automatically
+	FilterTimeout types.Duration `mapstructure:"FilterTimeout"`
+
+	// RateLimit defines the limits applied per method and per API key
+	RateLimit RateLimitConfig `mapstructure:"RateLimit"`
+
+	// GraphQL configuration
+	GraphQL GraphQLConfig `mapstructure:"GraphQL"`
+
+	// Cache configuration
+	Cache CacheConfig `mapstructure:"Cache"`
+
+	// Admin configuration
+	Admin AdminConfig `mapstructure:"Admin"`
+}
+
+// RateLimitConfig defines the limits applied per method and per API key,
+// on top of the global MaxRequestsPerIPAndSecond limit
+type RateLimitConfig struct {
+	// Enabled defines if the limits are enforced
+	Enabled bool `mapstructure:"Enabled"`
+
+	// APIKeyHeader is the HTTP header used to provide the API key, the key
+	// can also be provided as the URL path, e.g. http://host:port/<key>
+	APIKeyHeader string `mapstructure:"APIKeyHeader"`
+
+	// Methods defines the limits applied to each caller per method, a caller is
+	// identified by its API key or by its IP when no known API key is provided
+	Methods []MethodRateLimit `mapstructure:"Methods"`
+
+	// APIKeys defines the limits applied to each API key across all the methods
+	APIKeys []APIKeyRateLimit `mapstructure:"APIKeys"`
+
+	// TrustedProxies are the IPs or CIDR ranges of the proxies and load balancers in front
+	// of the server. The callers identified by their IP of the requests received from them
+	// are identified by the X-Forwarded-For header, ignoring the trusted proxies in it from
+	// right to left, or by the X-Real-IP header if it's not provided
+	TrustedProxies []string `mapstructure:"TrustedProxies"`
+}
+
+// MethodRateLimit defines the limits of a method, zero means no limit
+type MethodRateLimit struct {
+	// Method is the name of the method, e.g. eth_getLogs, the GraphQL
+	// queries are limited as the graphql method
+	Method string `mapstructure:"Method"`
+
+	// RequestsPerSecond is the max number of requests per second
+	RequestsPerSecond float64 `mapstructure:"RequestsPerSecond"`
+
+	// MaxConcurrentRequests is the max number of requests handled at the same time
+	MaxConcurrentRequests uint64 `mapstructure:"MaxConcurrentRequests"`
+
+	// DailyQuota is the max number of requests per day, reset at midnight UTC
+	DailyQuota uint64 `mapstructure:"DailyQuota"`
+}
+
+// APIKeyRateLimit defines the limits of an API key, zero means no limit
+type APIKeyRateLimit struct {
+	// Key is the API key
+	Key string `mapstructure:"Key"`
+
+	// RequestsPerSecond is the max number of requests per second
+	RequestsPerSecond float64 `mapstructure:"RequestsPerSecond"`
+
+	// MaxConcurrentRequests is the max number of requests handled at the same time
+	MaxConcurrentRequests uint64 `mapstructure:"MaxConcurrentRequests"`
+
+	// DailyQuota is the max number of requests per day, reset at midnight UTC
+	DailyQuota uint64 `mapstructure:"DailyQuota"`
 }
 
+const (
+	// FilterStorageMemory keeps the filters in the memory of the server
+	FilterStorageMemory = "memory"
+	// FilterStoragePostgres stores the filters in the state db
+	FilterStoragePostgres = "postgres"
+
+	// CacheBackendMemory keeps the cached responses in an in-memory LRU cache
+	CacheBackendMemory = "memory"
+)
+
 // ZKCountersLimits defines the ZK Counter limits
 type ZKCountersLimits struct {
 	MaxKeccakHashes     uint32
@@ -93,3 +192,61 @@ type WebSocketsConfig struct {
 	// ReadLimit defines the maximum size of a message read from the client (in bytes)
 	ReadLimit int64 `mapstructure:"ReadLimit"`
 }
+
+// GraphQLConfig has parameters to config the GraphQL API served at the /graphql path
+type GraphQLConfig struct {
+	// Enabled defines if the GraphQL API is enabled or disabled
+	Enabled bool `mapstructure:"Enabled"`
+
+	// MaxBlockRange is the max block range allowed by the blocks query, the
+	// logs query is limited by MaxLogsBlockRange, zero means no limit
+	MaxBlockRange uint64 `mapstructure:"MaxBlockRange"`
+
+	// MaxDepth is the max depth of the selections of a query, zero means no limit
+	MaxDepth int `mapstructure:"MaxDepth"`
+
+	// MaxParallelism is the max number of resolvers executed in parallel
+	// for a query, zero means no limit
+	MaxParallelism int `mapstructure:"MaxParallelism"`
+
+	// MaxBlocks is the max number of blocks loaded by a query across all
+	// its fields, zero means no limit
+	MaxBlocks uint64 `mapstructure:"MaxBlocks"`
+
+	// MaxExecutorCalls is the max number of call and estimateGas fields
+	// executed by a query, zero means no limit
+	MaxExecutorCalls uint64 `mapstructure:"MaxExecutorCalls"`
+}
+
+// CacheConfig has parameters to config the cache of the responses about consolidated
+// blocks, these responses never change unless the state is reset due to a L1 reorg
+type CacheConfig struct {
+	// Enabled defines if the responses of eth_getBlockByNumber, eth_getTransactionReceipt
+	// and eth_getLogs about consolidated blocks are cached
+	Enabled bool `mapstructure:"Enabled"`
+
+	// Backend defines where the responses are cached, the allowed value is
+	// "memory" to keep them in an in-memory LRU cache
+	Backend string `mapstructure:"Backend"`
+
+	// Size is the max number of responses kept in the in-memory LRU cache
+	Size int `mapstructure:"Size"`
+}
+
+// AdminConfig has parameters to config the admin API, served on its own port
+// only to the requests authenticated with a JWT signed with a shared secret
+type AdminConfig struct {
+	// Enabled defines if the admin API is enabled or disabled
+	Enabled bool `mapstructure:"Enabled"`
+
+	// Host defines the network adapter that will be used to serve the admin requests
+	Host string `mapstructure:"Host"`
+
+	// Port defines the port to serve the admin endpoints via HTTP
+	Port int `mapstructure:"Port"`
+
+	// JWTSecretFile is the path of the file containing the hex encoded 32 bytes secret
+	// used to sign the JWTs, the tokens must be sent as a Bearer token in the
+	// Authorization header and must contain an iat claim within 60 seconds of the current time
+	JWTSecretFile string `mapstructure:"JWTSecretFile"`
+}
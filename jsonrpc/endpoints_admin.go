@@ -0,0 +1,156 @@
+package jsonrpc
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// HealthStatusOK is the health status of a component working as expected
+	HealthStatusOK = "ok"
+	// HealthStatusError is the health status of a component failing its health check
+	HealthStatusError = "error"
+
+	finalizerComponent = "finalizer"
+)
+
+// HealthCheck returns an error when the checked component is not healthy
+type HealthCheck func(ctx context.Context) error
+
+// AdminEndpoints contains implementations for the "admin" RPC endpoints, used
+// to operate the node. They are only served by the admin server, so the caller
+// is always authenticated. The sequencer and the pool are nil when the node is
+// not running them
+type AdminEndpoints struct {
+	sequencer    types.SequencerInterface
+	pool         types.PoolInterface
+	healthChecks map[string]HealthCheck
+}
+
+// NewAdminEndpoints returns AdminEndpoints
+func NewAdminEndpoints(sequencer types.SequencerInterface, pool types.PoolInterface, healthChecks map[string]HealthCheck) *AdminEndpoints {
+	return &AdminEndpoints{
+		sequencer:    sequencer,
+		pool:         pool,
+		healthChecks: healthChecks,
+	}
+}
+
+type componentHealth struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// HaltFinalizer stops the finalizer from processing new txs until it's resumed
+func (e *AdminEndpoints) HaltFinalizer() (interface{}, types.Error) {
+	if e.sequencer == nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "sequencer not running in this node", nil, false)
+	}
+	if err := e.sequencer.HaltFinalizer(); err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to halt the finalizer", err, true)
+	}
+	log.Warn("finalizer halt requested through the admin API")
+	return true, nil
+}
+
+// ResumeFinalizer resumes the processing of txs after a halt requested through the admin API
+func (e *AdminEndpoints) ResumeFinalizer() (interface{}, types.Error) {
+	if e.sequencer == nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "sequencer not running in this node", nil, false)
+	}
+	if err := e.sequencer.ResumeFinalizer(); err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to resume the finalizer", err, true)
+	}
+	log.Warn("finalizer resume requested through the admin API")
+	return true, nil
+}
+
+// RegenerateDataStream adds to the data stream the L2 blocks found in the state
+// that are missing in the stream file, the finalizer must be halted. The
+// regeneration is cancelled if the request is cancelled
+func (e *AdminEndpoints) RegenerateDataStream(httpRequest *http.Request) (interface{}, types.Error) {
+	if e.sequencer == nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "sequencer not running in this node", nil, false)
+	}
+	if err := e.sequencer.RegenerateDataStream(httpRequest.Context()); err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to regenerate the data stream", err, true)
+	}
+	return true, nil
+}
+
+// BlockAddress rejects the txs sent by the address
+func (e *AdminEndpoints) BlockAddress(address common.Address, reason *string) (interface{}, types.Error) {
+	if e.pool == nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "pool not available in this node", nil, false)
+	}
+	blockReason := ""
+	if reason != nil {
+		blockReason = *reason
+	}
+	if err := e.pool.BlockAddress(context.Background(), address, blockReason); err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to block address", err, true)
+	}
+	log.Warnf("address %s blocked through the admin API, reason: %s", address.String(), blockReason)
+	return true, nil
+}
+
+// UnblockAddress accepts again the txs sent by the address
+func (e *AdminEndpoints) UnblockAddress(address common.Address) (interface{}, types.Error) {
+	if e.pool == nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "pool not available in this node", nil, false)
+	}
+	if err := e.pool.UnblockAddress(context.Background(), address); err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to unblock address", err, true)
+	}
+	log.Warnf("address %s unblocked through the admin API", address.String())
+	return true, nil
+}
+
+// GetBlockedAddresses returns the addresses whose txs are rejected
+func (e *AdminEndpoints) GetBlockedAddresses() (interface{}, types.Error) {
+	if e.pool == nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "pool not available in this node", nil, false)
+	}
+	addresses, err := e.pool.GetBlockedAddresses(context.Background())
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to get blocked addresses", err, true)
+	}
+	if addresses == nil {
+		addresses = []common.Address{}
+	}
+	return addresses, nil
+}
+
+// SetLogLevel changes the log level of the node, e.g. debug, info, warn or error
+func (e *AdminEndpoints) SetLogLevel(level string) (interface{}, types.Error) {
+	if err := log.SetLevel(level); err != nil {
+		return RPCErrorResponse(types.InvalidParamsErrorCode, err.Error(), nil, false)
+	}
+	return true, nil
+}
+
+// GetLogLevel returns the log level of the node
+func (e *AdminEndpoints) GetLogLevel() (interface{}, types.Error) {
+	return log.GetLevel(), nil
+}
+
+// Health returns the health of the components of the node
+func (e *AdminEndpoints) Health() (interface{}, types.Error) {
+	ctx := context.Background()
+	health := make(map[string]componentHealth, len(e.healthChecks)+1)
+	for component, check := range e.healthChecks {
+		if err := check(ctx); err != nil {
+			health[component] = componentHealth{Status: HealthStatusError, Error: err.Error()}
+		} else {
+			health[component] = componentHealth{Status: HealthStatusOK}
+		}
+	}
+	if e.sequencer != nil {
+		health[finalizerComponent] = componentHealth{Status: e.sequencer.FinalizerStatus()}
+	}
+	return health, nil
+}
@@ -0,0 +1,181 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/mocks"
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newJWT(t *testing.T, secret []byte, issuedAt time.Time) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{IssuedAt: jwt.NewNumericDate(issuedAt)})
+	signed, err := token.SignedString(secret)
+	require.NoError(t, err)
+	return signed
+}
+
+func TestAdminServerAuthentication(t *testing.T) {
+	secret := bytes.Repeat([]byte{0x01}, jwtSecretLength)
+	secretFile := filepath.Join(t.TempDir(), "jwt.hex")
+	require.NoError(t, os.WriteFile(secretFile, []byte(common.Bytes2Hex(secret)), 0600))
+
+	_, err := NewAdminServer(Config{Admin: AdminConfig{Enabled: true}}, nil)
+	require.EqualError(t, err, "missing JWT secret file")
+
+	cfg := Config{
+		MaxRequestsPerIPAndSecond: maxRequestsPerIPAndSecond,
+		Admin: AdminConfig{
+			Enabled:       true,
+			Host:          "127.0.0.1",
+			Port:          9125,
+			JWTSecretFile: secretFile,
+		},
+	}
+	server, err := NewAdminServer(cfg, []Service{{Name: APIAdmin, Service: NewAdminEndpoints(nil, nil, nil)}})
+	require.NoError(t, err)
+	go func() {
+		if err := server.Start(); err != nil {
+			panic(err)
+		}
+	}()
+	defer func() { require.NoError(t, server.Stop()) }()
+
+	serverURL := fmt.Sprintf("http://%s:%d", cfg.Admin.Host, cfg.Admin.Port)
+	call := func(token string) *http.Response {
+		body, err := json.Marshal(types.Request{JSONRPC: "2.0", ID: 1, Method: "admin_getLogLevel"})
+		require.NoError(t, err)
+		req, err := http.NewRequest(http.MethodPost, serverURL, bytes.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", contentType)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		return res
+	}
+	require.Eventually(t, func() bool {
+		_, err := http.Get(serverURL) //nolint:gosec
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	type testCase struct {
+		name               string
+		token              string
+		expectedStatusCode int
+	}
+	testCases := []testCase{
+		{name: "missing token", expectedStatusCode: http.StatusUnauthorized},
+		{name: "invalid secret", token: newJWT(t, bytes.Repeat([]byte{0x02}, jwtSecretLength), time.Now()), expectedStatusCode: http.StatusUnauthorized},
+		{name: "stale token", token: newJWT(t, secret, time.Now().Add(-2*jwtIssuedAtTolerance)), expectedStatusCode: http.StatusUnauthorized},
+		{name: "future token", token: newJWT(t, secret, time.Now().Add(2*jwtIssuedAtTolerance)), expectedStatusCode: http.StatusUnauthorized},
+		{name: "valid token", token: newJWT(t, secret, time.Now()), expectedStatusCode: http.StatusOK},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res := call(tc.token)
+			defer res.Body.Close()
+			assert.Equal(t, tc.expectedStatusCode, res.StatusCode)
+			if tc.expectedStatusCode != http.StatusOK {
+				return
+			}
+			var response types.Response
+			require.NoError(t, json.NewDecoder(res.Body).Decode(&response))
+			require.Nil(t, response.Error)
+			var level string
+			require.NoError(t, json.Unmarshal(response.Result, &level))
+			assert.Equal(t, log.GetLevel(), level)
+		})
+	}
+}
+
+func TestAdminEndpoints(t *testing.T) {
+	sequencer := mocks.NewSequencerMock(t)
+	pool := mocks.NewPoolMock(t)
+	healthChecks := map[string]HealthCheck{
+		"state":    func(ctx context.Context) error { return nil },
+		"executor": func(ctx context.Context) error { return errors.New("connection refused") },
+	}
+	e := NewAdminEndpoints(sequencer, pool, healthChecks)
+	address := common.HexToAddress("0x617b3a3528F9cDd6630fd3301B9c8911F7Bf063D")
+	reason := "spam"
+
+	sequencer.On("HaltFinalizer").Return(nil).Once()
+	res, rpcErr := e.HaltFinalizer()
+	require.Nil(t, rpcErr)
+	assert.Equal(t, true, res)
+
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	sequencer.On("RegenerateDataStream", req.Context()).Return(errors.New("stream server disabled")).Once()
+	_, rpcErr = e.RegenerateDataStream(req)
+	require.NotNil(t, rpcErr)
+	assert.Equal(t, "failed to regenerate the data stream", rpcErr.Error())
+
+	sequencer.On("ResumeFinalizer").Return(nil).Once()
+	res, rpcErr = e.ResumeFinalizer()
+	require.Nil(t, rpcErr)
+	assert.Equal(t, true, res)
+
+	pool.On("BlockAddress", context.Background(), address, reason).Return(nil).Once()
+	res, rpcErr = e.BlockAddress(address, &reason)
+	require.Nil(t, rpcErr)
+	assert.Equal(t, true, res)
+
+	pool.On("GetBlockedAddresses", context.Background()).Return([]common.Address{address}, nil).Once()
+	res, rpcErr = e.GetBlockedAddresses()
+	require.Nil(t, rpcErr)
+	assert.Equal(t, []common.Address{address}, res)
+
+	pool.On("UnblockAddress", context.Background(), address).Return(nil).Once()
+	res, rpcErr = e.UnblockAddress(address)
+	require.Nil(t, rpcErr)
+	assert.Equal(t, true, res)
+
+	sequencer.On("FinalizerStatus").Return("running").Once()
+	res, rpcErr = e.Health()
+	require.Nil(t, rpcErr)
+	assert.Equal(t, map[string]componentHealth{
+		"state":            {Status: HealthStatusOK},
+		"executor":         {Status: HealthStatusError, Error: "connection refused"},
+		finalizerComponent: {Status: "running"},
+	}, res)
+
+	previousLevel := log.GetLevel()
+	defer func() { require.NoError(t, log.SetLevel(previousLevel)) }()
+	res, rpcErr = e.SetLogLevel("warn")
+	require.Nil(t, rpcErr)
+	assert.Equal(t, true, res)
+	res, rpcErr = e.GetLogLevel()
+	require.Nil(t, rpcErr)
+	assert.Equal(t, "warn", res)
+	_, rpcErr = e.SetLogLevel("unknown")
+	require.NotNil(t, rpcErr)
+	assert.Equal(t, types.InvalidParamsErrorCode, rpcErr.ErrorCode())
+
+	// components not running in the node
+	e = NewAdminEndpoints(nil, nil, nil)
+	_, rpcErr = e.HaltFinalizer()
+	require.NotNil(t, rpcErr)
+	assert.Equal(t, "sequencer not running in this node", rpcErr.Error())
+	_, rpcErr = e.BlockAddress(address, nil)
+	require.NotNil(t, rpcErr)
+	assert.Equal(t, "pool not available in this node", rpcErr.Error())
+	res, rpcErr = e.Health()
+	require.Nil(t, rpcErr)
+	assert.Empty(t, res)
+}
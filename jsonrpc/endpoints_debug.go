@@ -52,6 +52,11 @@ type traceConfig struct {
 	TracerConfig     json.RawMessage `json:"tracerConfig"`
 }
 
+type traceCallConfig struct {
+	traceConfig
+	StateOverrides *types.StateOverride `json:"stateOverrides"`
+}
+
 type traceBlockTransactionResponse struct {
 	Result interface{} `json:"result"`
 }
@@ -69,6 +74,53 @@ func (d *DebugEndpoints) TraceTransaction(hash types.ArgHash, cfg *traceConfig)
 	})
 }
 
+// TraceCall creates a response for debug_traceCall request, executing the
+// provided call on top of the state of the requested block without storing it.
+// See https://geth.ethereum.org/docs/interacting-with-geth/rpc/ns-debug#debugtracecall
+func (d *DebugEndpoints) TraceCall(arg *types.TxArgs, blockArg *types.BlockNumberOrHash, cfg *traceCallConfig) (interface{}, types.Error) {
+	return d.txMan.NewDbTxScope(d.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+		if arg == nil {
+			return RPCErrorResponse(types.InvalidParamsErrorCode, "missing value for required argument 0", nil, false)
+		}
+
+		block, rpcErr := d.getBlockByArg(ctx, blockArg, dbTx)
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+
+		// If the caller didn't supply the gas limit in the message, then we set it to maximum possible => block gas limit
+		if arg.Gas == nil || uint64(*arg.Gas) <= 0 {
+			gas := types.ArgUint64(block.GasLimit())
+			arg.Gas = &gas
+		}
+
+		defaultSenderAddress := common.HexToAddress(state.DefaultSenderAddress)
+		sender, tx, err := arg.ToTransaction(ctx, d.state, state.MaxTxGasLimit, block.Root(), defaultSenderAddress, dbTx)
+		if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, "failed to convert arguments into an unsigned transaction", err, false)
+		}
+
+		traceCfg := defaultTraceConfig
+		var stateOverride state.StateOverride
+		if cfg != nil {
+			traceCfg = &cfg.traceConfig
+			stateOverride, err = cfg.StateOverrides.ToStateOverride()
+			if err != nil {
+				return RPCErrorResponse(types.InvalidParamsErrorCode, err.Error(), nil, false)
+			}
+		}
+
+		blockNumber := block.NumberU64()
+		result, err := d.state.DebugCall(ctx, tx, sender, &blockNumber, toStateTraceConfig(traceCfg), stateOverride, dbTx)
+		if err != nil {
+			errorMessage := fmt.Sprintf("failed to get trace: %v", err.Error())
+			return nil, types.NewRPCError(types.DefaultErrorCode, errorMessage)
+		}
+
+		return result.TraceResult, nil
+	})
+}
+
 // TraceBlockByNumber creates a response for debug_traceBlockByNumber request.
 // See https://geth.ethereum.org/docs/interacting-with-geth/rpc/ns-debug#debugtraceblockbynumber
 func (d *DebugEndpoints) TraceBlockByNumber(number types.BlockNumber, cfg *traceConfig) (interface{}, types.Error) {
@@ -276,15 +328,7 @@ func (d *DebugEndpoints) buildTraceTransaction(ctx context.Context, hash common.
 		traceCfg = defaultTraceConfig
 	}
 
-	stateTraceConfig := state.TraceConfig{
-		DisableStack:     traceCfg.DisableStack,
-		DisableStorage:   traceCfg.DisableStorage,
-		EnableMemory:     traceCfg.EnableMemory,
-		EnableReturnData: traceCfg.EnableReturnData,
-		Tracer:           traceCfg.Tracer,
-		TracerConfig:     traceCfg.TracerConfig,
-	}
-	result, err := d.state.DebugTransaction(ctx, hash, stateTraceConfig, dbTx)
+	result, err := d.state.DebugTransaction(ctx, hash, toStateTraceConfig(traceCfg), dbTx)
 	if errors.Is(err, state.ErrNotFound) {
 		return RPCErrorResponse(types.DefaultErrorCode, "transaction not found", nil, false)
 	} else if err != nil {
@@ -295,6 +339,52 @@ func (d *DebugEndpoints) buildTraceTransaction(ctx context.Context, hash common.
 	return result.TraceResult, nil
 }
 
+func (d *DebugEndpoints) getBlockByArg(ctx context.Context, blockArg *types.BlockNumberOrHash, dbTx pgx.Tx) (*state.L2Block, types.Error) {
+	if blockArg == nil {
+		block, err := d.state.GetLastL2Block(ctx, dbTx)
+		if err != nil {
+			_, rpcErr := RPCErrorResponse(types.DefaultErrorCode, "failed to get the last block number from state", err, true)
+			return nil, rpcErr
+		}
+		return block, nil
+	}
+
+	if blockArg.IsHash() {
+		block, err := d.state.GetL2BlockByHash(ctx, blockArg.Hash().Hash(), dbTx)
+		if errors.Is(err, state.ErrNotFound) {
+			return nil, types.NewRPCError(types.DefaultErrorCode, fmt.Sprintf("block %s not found", blockArg.Hash().Hash().String()))
+		} else if err != nil {
+			_, rpcErr := RPCErrorResponse(types.DefaultErrorCode, "failed to get block by hash", err, true)
+			return nil, rpcErr
+		}
+		return block, nil
+	}
+
+	blockNumber, rpcErr := blockArg.Number().GetNumericBlockNumber(ctx, d.state, d.etherman, dbTx)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	block, err := d.state.GetL2BlockByNumber(ctx, blockNumber, dbTx)
+	if errors.Is(err, state.ErrNotFound) {
+		return nil, types.NewRPCError(types.DefaultErrorCode, fmt.Sprintf("block #%d not found", blockNumber))
+	} else if err != nil {
+		_, rpcErr := RPCErrorResponse(types.DefaultErrorCode, "failed to get block by number", err, true)
+		return nil, rpcErr
+	}
+	return block, nil
+}
+
+func toStateTraceConfig(cfg *traceConfig) state.TraceConfig {
+	return state.TraceConfig{
+		DisableStack:     cfg.DisableStack,
+		DisableStorage:   cfg.DisableStorage,
+		EnableMemory:     cfg.EnableMemory,
+		EnableReturnData: cfg.EnableReturnData,
+		Tracer:           cfg.Tracer,
+		TracerConfig:     cfg.TracerConfig,
+	}
+}
+
 // waitTimeout waits for the waitGroup for the specified max timeout.
 // Returns true if waiting timed out.
 func waitTimeout(wg *sync.WaitGroup, timeout time.Duration) bool {
@@ -0,0 +1,130 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/0xPolygonHermez/zkevm-node/state/runtime"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTraceCall(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	type testCase struct {
+		name           string
+		params         []interface{}
+		expectedResult json.RawMessage
+		expectedError  types.Error
+		setupMocks     func(m *mocksWrapper, tc *testCase)
+	}
+
+	from := common.HexToAddress("0x1")
+	to := common.HexToAddress("0x2")
+	blockRoot := common.HexToHash("0x123")
+	block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: blockNumOne, Root: blockRoot, GasLimit: 30000000}))
+	nonce := uint64(7)
+	overriddenBalance := big.NewInt(1000)
+	callTracer := "callTracer"
+
+	txArgs := types.TxArgs{
+		From:  &from,
+		To:    &to,
+		Gas:   types.ArgUint64Ptr(24000),
+		Value: types.ArgBytesPtr(big.NewInt(2).Bytes()),
+		Data:  types.ArgBytesPtr([]byte("data")),
+	}
+	txMatchBy := mock.MatchedBy(func(tx *ethTypes.Transaction) bool {
+		return tx != nil &&
+			tx.To().Hex() == to.Hex() &&
+			tx.Gas() == uint64(*txArgs.Gas) &&
+			tx.Nonce() == nonce &&
+			tx.Value().Uint64() == 2
+	})
+
+	testCases := []testCase{
+		{
+			name:           "trace call with call tracer and state overrides",
+			params:         []interface{}{txArgs, latest, map[string]interface{}{"tracer": callTracer, "stateOverrides": map[string]interface{}{from.Hex(): map[string]interface{}{"balance": "0x3e8"}}}},
+			expectedResult: json.RawMessage(`{"type":"CALL"}`),
+			setupMocks: func(m *mocksWrapper, tc *testCase) {
+				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.On("GetLastL2BlockNumber", context.Background(), m.DbTx).Return(blockNumOneUint64, nil).Once()
+				m.State.On("GetL2BlockByNumber", context.Background(), blockNumOneUint64, m.DbTx).Return(block, nil).Once()
+				m.State.On("GetNonce", context.Background(), from, blockRoot).Return(nonce, nil).Once()
+
+				traceConfigMatchBy := mock.MatchedBy(func(cfg state.TraceConfig) bool {
+					return cfg.Tracer != nil && *cfg.Tracer == callTracer
+				})
+				stateOverrideMatchBy := mock.MatchedBy(func(so state.StateOverride) bool {
+					account, found := so[from]
+					return found && account.Balance != nil && account.Balance.Cmp(overriddenBalance) == 0
+				})
+				m.State.
+					On("DebugCall", context.Background(), txMatchBy, from, &blockNumOneUint64, traceConfigMatchBy, stateOverrideMatchBy, m.DbTx).
+					Return(&runtime.ExecutionResult{TraceResult: tc.expectedResult}, nil).
+					Once()
+			},
+		},
+		{
+			name:          "state and stateDiff overridden at the same time",
+			params:        []interface{}{txArgs, latest, map[string]interface{}{"stateOverrides": map[string]interface{}{from.Hex(): map[string]interface{}{"state": map[string]string{}, "stateDiff": map[string]string{}}}}},
+			expectedError: types.NewRPCError(types.InvalidParamsErrorCode, "account 0x0000000000000000000000000000000000000001 has both 'state' and 'stateDiff'"),
+			setupMocks: func(m *mocksWrapper, tc *testCase) {
+				m.DbTx.On("Rollback", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.On("GetLastL2BlockNumber", context.Background(), m.DbTx).Return(blockNumOneUint64, nil).Once()
+				m.State.On("GetL2BlockByNumber", context.Background(), blockNumOneUint64, m.DbTx).Return(block, nil).Once()
+				m.State.On("GetNonce", context.Background(), from, blockRoot).Return(nonce, nil).Once()
+			},
+		},
+		{
+			name:          "failed to trace call",
+			params:        []interface{}{txArgs, latest},
+			expectedError: types.NewRPCError(types.DefaultErrorCode, "failed to get trace: failed to process unsigned transaction"),
+			setupMocks: func(m *mocksWrapper, tc *testCase) {
+				m.DbTx.On("Rollback", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.On("GetLastL2BlockNumber", context.Background(), m.DbTx).Return(blockNumOneUint64, nil).Once()
+				m.State.On("GetL2BlockByNumber", context.Background(), blockNumOneUint64, m.DbTx).Return(block, nil).Once()
+				m.State.On("GetNonce", context.Background(), from, blockRoot).Return(nonce, nil).Once()
+				m.State.
+					On("DebugCall", context.Background(), txMatchBy, from, &blockNumOneUint64, mock.Anything, state.StateOverride(nil), m.DbTx).
+					Return(nil, errors.New("failed to process unsigned transaction")).
+					Once()
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			tc := testCase
+			tc.setupMocks(m, &tc)
+
+			res, err := s.JSONRPCCall("debug_traceCall", tc.params...)
+			require.NoError(t, err)
+
+			if tc.expectedResult != nil {
+				require.Nil(t, res.Error)
+				assert.JSONEq(t, string(tc.expectedResult), string(res.Result))
+			}
+
+			if tc.expectedError != nil {
+				require.NotNil(t, res.Error)
+				assert.Equal(t, tc.expectedError.ErrorCode(), res.Error.Code)
+				assert.Equal(t, tc.expectedError.Error(), res.Error.Message)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"math/big"
 	"net/http"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -27,6 +28,17 @@ import (
 const (
 	// maxTopics is the max number of topics a log can have
 	maxTopics = 4
+
+	// maxFeeHistoryBlockCount is the max number of blocks a fee history can have
+	maxFeeHistoryBlockCount = 1024
+
+	// maxFeeHistoryRewardPercentiles is the max number of percentiles a fee history can have
+	maxFeeHistoryRewardPercentiles = 100
+
+	// names of the methods whose responses about consolidated blocks are cached
+	getBlockByNumberMethod      = "eth_getBlockByNumber"
+	getTransactionReceiptMethod = "eth_getTransactionReceipt"
+	getLogsMethod               = "eth_getLogs"
 )
 
 // EthEndpoints contains implementations for the "eth" RPC endpoints
@@ -38,12 +50,31 @@ type EthEndpoints struct {
 	etherman types.EthermanInterface
 	storage  storageInterface
 	txMan    DBTxManager
+	cache    *responseCache
+
+	// newTxsMonitorMux serializes the start and stop of the pool monitor of new
+	// txs, that only runs while there are pending txs subscriptions
+	newTxsMonitorMux sync.Mutex
+
+	// newPreconfirmationsMonitorMux serializes the start and stop of the pool monitor
+	// of new preconfirmations, that only runs while there are preconfirmations subscriptions
+	newPreconfirmationsMonitorMux sync.Mutex
 }
 
 // NewEthEndpoints creates an new instance of Eth
 func NewEthEndpoints(cfg Config, chainID uint64, p types.PoolInterface, s types.StateInterface, etherman types.EthermanInterface, storage storageInterface) *EthEndpoints {
 	e := &EthEndpoints{cfg: cfg, chainID: chainID, pool: p, state: s, etherman: etherman, storage: storage}
+	if cfg.Cache.Enabled {
+		backend, err := NewCacheBackend(cfg.Cache)
+		if err != nil {
+			log.Fatalf("failed to create the cache backend: %v", err)
+		}
+		e.cache = newResponseCache(backend)
+	}
 	s.RegisterNewL2BlockEventHandler(e.onNewL2Block)
+	s.RegisterZKEVMEventHandler(e.onZKEVMEvent)
+	p.RegisterNewTxsEventHandler(e.onNewTxs)
+	p.RegisterNewPreconfirmationsEventHandler(e.onNewPreconfirmations)
 
 	return e
 }
@@ -64,7 +95,7 @@ func (e *EthEndpoints) BlockNumber() (interface{}, types.Error) {
 // executed contract and potential error.
 // Note, this function doesn't make any changes in the state/blockchain and is
 // useful to execute view/pure methods and retrieve values.
-func (e *EthEndpoints) Call(arg *types.TxArgs, blockArg *types.BlockNumberOrHash) (interface{}, types.Error) {
+func (e *EthEndpoints) Call(arg *types.TxArgs, blockArg *types.BlockNumberOrHash, stateOverride *types.StateOverride) (interface{}, types.Error) {
 	return e.txMan.NewDbTxScope(e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
 		if arg == nil {
 			return RPCErrorResponse(types.InvalidParamsErrorCode, "missing value for required argument 0", nil, false)
@@ -103,7 +134,12 @@ func (e *EthEndpoints) Call(arg *types.TxArgs, blockArg *types.BlockNumberOrHash
 			return RPCErrorResponse(types.DefaultErrorCode, "failed to convert arguments into an unsigned transaction", err, false)
 		}
 
-		result, err := e.state.ProcessUnsignedTransaction(ctx, tx, sender, blockToProcess, true, dbTx)
+		overrides, err := stateOverride.ToStateOverride()
+		if err != nil {
+			return RPCErrorResponse(types.InvalidParamsErrorCode, err.Error(), nil, false)
+		}
+
+		result, err := e.state.ProcessUnsignedTransaction(ctx, tx, sender, blockToProcess, true, overrides, dbTx)
 		if err != nil {
 			errMsg := fmt.Sprintf("failed to execute the unsigned transaction: %v", err.Error())
 			logError := !executor.IsROMOutOfCountersError(executor.RomErrorCode(err)) && !errors.Is(err, runtime.ErrOutOfGas)
@@ -122,6 +158,53 @@ func (e *EthEndpoints) Call(arg *types.TxArgs, blockArg *types.BlockNumberOrHash
 	})
 }
 
+// CreateAccessList creates an EIP-2930 type AccessList for the given transaction.
+// The block argument can be specified to create the access list on top of a certain state.
+// The gas used includes the cost of the access list, the accesses to the accounts and
+// storage keys of the list are charged as cold, so it's an upper bound.
+func (e *EthEndpoints) CreateAccessList(arg *types.TxArgs, blockArg *types.BlockNumberOrHash) (interface{}, types.Error) {
+	return e.txMan.NewDbTxScope(e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+		if arg == nil {
+			return RPCErrorResponse(types.InvalidParamsErrorCode, "missing value for required argument 0", nil, false)
+		}
+
+		block, respErr := e.getBlockByArg(ctx, blockArg, dbTx)
+		if respErr != nil {
+			return nil, respErr
+		}
+
+		// If the caller didn't supply the gas limit in the message, then we set it to maximum possible => block gas limit
+		if arg.Gas == nil || uint64(*arg.Gas) <= 0 {
+			gas := types.ArgUint64(block.GasLimit())
+			arg.Gas = &gas
+		}
+
+		defaultSenderAddress := common.HexToAddress(state.DefaultSenderAddress)
+		sender, tx, err := arg.ToTransaction(ctx, e.state, state.MaxTxGasLimit, block.Root(), defaultSenderAddress, dbTx)
+		if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, "failed to convert arguments into an unsigned transaction", err, false)
+		}
+
+		blockNumber := block.NumberU64()
+		accessList, result, err := e.state.CreateAccessList(ctx, tx, sender, &blockNumber, dbTx)
+		if err != nil {
+			errMsg := fmt.Sprintf("failed to create access list: %v", err.Error())
+			logError := !executor.IsROMOutOfCountersError(executor.RomErrorCode(err)) && !errors.Is(err, runtime.ErrOutOfGas)
+			return RPCErrorResponse(types.DefaultErrorCode, errMsg, nil, logError)
+		}
+
+		res := types.AccessListResult{
+			AccessList: accessList,
+			GasUsed:    types.ArgUint64(result.GasUsed),
+		}
+		if result.Failed() {
+			res.Error = result.Err.Error()
+		}
+
+		return res, nil
+	})
+}
+
 // ChainId returns the chain id of the client
 func (e *EthEndpoints) ChainId() (interface{}, types.Error) { //nolint:revive
 	return hex.EncodeUint64(e.chainID), nil
@@ -159,7 +242,7 @@ func (e *EthEndpoints) getCoinbaseFromSequencerNode() (interface{}, types.Error)
 // Note that the estimate may be significantly more than the amount of gas actually
 // used by the transaction, for a variety of reasons including EVM mechanics and
 // node performance.
-func (e *EthEndpoints) EstimateGas(arg *types.TxArgs, blockArg *types.BlockNumberOrHash) (interface{}, types.Error) {
+func (e *EthEndpoints) EstimateGas(arg *types.TxArgs, blockArg *types.BlockNumberOrHash, stateOverride *types.StateOverride) (interface{}, types.Error) {
 	return e.txMan.NewDbTxScope(e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
 		if arg == nil {
 			return RPCErrorResponse(types.InvalidParamsErrorCode, "missing value for required argument 0", nil, false)
@@ -187,7 +270,12 @@ func (e *EthEndpoints) EstimateGas(arg *types.TxArgs, blockArg *types.BlockNumbe
 			return RPCErrorResponse(types.DefaultErrorCode, "failed to convert arguments into an unsigned transaction", err, false)
 		}
 
-		gasEstimation, returnValue, err := e.state.EstimateGas(tx, sender, blockToProcess, dbTx)
+		overrides, err := stateOverride.ToStateOverride()
+		if err != nil {
+			return RPCErrorResponse(types.InvalidParamsErrorCode, err.Error(), nil, false)
+		}
+
+		gasEstimation, returnValue, err := e.state.EstimateGas(tx, sender, blockToProcess, overrides, dbTx)
 		if errors.Is(err, runtime.ErrExecutionReverted) {
 			data := make([]byte, len(returnValue))
 			copy(data, returnValue)
@@ -212,6 +300,111 @@ func (e *EthEndpoints) GasPrice() (interface{}, types.Error) {
 	return hex.EncodeUint64(gasPrices.L2GasPrice), nil
 }
 
+// MaxPriorityFeePerGas returns a suggestion for the priority fee of dynamic
+// fee txs. The network has no base fee, so the whole gas price is the tip
+func (e *EthEndpoints) MaxPriorityFeePerGas() (interface{}, types.Error) {
+	return e.GasPrice()
+}
+
+// FeeHistory returns the gas used ratio and the effective gas price paid for the
+// requested percentiles of the txs of a range of blocks ending at newestBlock.
+// The network has no base fee, so the base fee per gas is always zero and the
+// reward is the effective gas price paid by the txs
+func (e *EthEndpoints) FeeHistory(blockCount types.ArgUint64, newestBlock types.BlockNumber, rewardPercentiles *[]float64) (interface{}, types.Error) {
+	var percentiles []float64
+	if rewardPercentiles != nil {
+		percentiles = *rewardPercentiles
+	}
+	if len(percentiles) > maxFeeHistoryRewardPercentiles {
+		return RPCErrorResponse(types.InvalidParamsErrorCode, fmt.Sprintf("reward percentiles are limited to %v values", maxFeeHistoryRewardPercentiles), nil, false)
+	}
+	for i, p := range percentiles {
+		if p < 0 || p > 100 {
+			return RPCErrorResponse(types.InvalidParamsErrorCode, fmt.Sprintf("invalid reward percentile: %v", p), nil, false)
+		}
+		if i > 0 && p <= percentiles[i-1] {
+			return RPCErrorResponse(types.InvalidParamsErrorCode, fmt.Sprintf("invalid reward percentile: #%d:%v >= #%d:%v", i-1, percentiles[i-1], i, p), nil, false)
+		}
+	}
+
+	return e.txMan.NewDbTxScope(e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+		toBlockNumber, rpcErr := newestBlock.GetNumericBlockNumber(ctx, e.state, e.etherman, dbTx)
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+
+		count := uint64(blockCount)
+		if count > maxFeeHistoryBlockCount {
+			count = maxFeeHistoryBlockCount
+		}
+		if count > toBlockNumber+1 {
+			count = toBlockNumber + 1
+		}
+
+		res := types.FeeHistory{
+			OldestBlock:   types.ArgUint64(toBlockNumber + 1 - count),
+			BaseFeePerGas: []types.ArgBig{},
+			GasUsedRatio:  []float64{},
+		}
+		if count == 0 {
+			return res, nil
+		}
+
+		blocks, err := e.state.GetL2BlocksFeeDataInRange(ctx, uint64(res.OldestBlock), toBlockNumber, dbTx)
+		if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, "failed to get fee data of blocks from state", err, true)
+		}
+		if len(blocks) == 0 {
+			return RPCErrorResponse(types.DefaultErrorCode, "blocks not found", nil, false)
+		}
+		res.OldestBlock = types.ArgUint64(blocks[0].BlockNumber)
+
+		// the base fee of the next block is included as well
+		res.BaseFeePerGas = make([]types.ArgBig, len(blocks)+1)
+		res.GasUsedRatio = make([]float64, len(blocks))
+		if len(percentiles) > 0 {
+			res.Reward = make([][]types.ArgBig, len(blocks))
+		}
+		for i, block := range blocks {
+			if block.GasLimit > 0 {
+				res.GasUsedRatio[i] = float64(block.GasUsed) / float64(block.GasLimit)
+			}
+			if len(percentiles) > 0 {
+				res.Reward[i] = feeHistoryRewards(block, percentiles)
+			}
+		}
+
+		return res, nil
+	})
+}
+
+// feeHistoryRewards computes the effective gas price paid for each of the percentiles,
+// weighted by the gas used by the txs, the same way it's done by geth
+func feeHistoryRewards(block state.L2BlockFeeData, percentiles []float64) []types.ArgBig {
+	rewards := make([]types.ArgBig, len(percentiles))
+	if len(block.Txs) == 0 {
+		return rewards
+	}
+
+	txs := make([]state.TxFeeData, len(block.Txs))
+	copy(txs, block.Txs)
+	sort.SliceStable(txs, func(i, j int) bool {
+		return txs[i].EffectiveGasPrice.Cmp(txs[j].EffectiveGasPrice) < 0
+	})
+
+	var txIndex int
+	sumGasUsed := txs[0].GasUsed
+	for i, p := range percentiles {
+		thresholdGasUsed := uint64(float64(block.GasUsed) * p / 100) //nolint:gomnd
+		for sumGasUsed < thresholdGasUsed && txIndex < len(txs)-1 {
+			txIndex++
+			sumGasUsed += txs[txIndex].GasUsed
+		}
+		rewards[i] = types.ArgBig(*txs[txIndex].EffectiveGasPrice)
+	}
+	return rewards
+}
+
 func (e *EthEndpoints) getPriceFromSequencerNode() (interface{}, types.Error) {
 	res, err := client.JSONRPCCall(e.cfg.SequencerNodeURI, "eth_gasPrice")
 	if err != nil {
@@ -333,6 +526,15 @@ func (e *EthEndpoints) GetBlockByHash(hash types.ArgHash, fullTx bool, includeEx
 
 // GetBlockByNumber returns information about a block by block number
 func (e *EthEndpoints) GetBlockByNumber(number types.BlockNumber, fullTx bool, includeExtraInfo *bool) (interface{}, types.Error) {
+	cacheKey := func(blockNumber uint64) string {
+		return fmt.Sprintf("%d:%t:%t", blockNumber, fullTx, includeExtraInfo != nil && *includeExtraInfo)
+	}
+	if number >= 0 {
+		if response, found := e.cache.get(getBlockByNumberMethod, cacheKey(uint64(number))); found {
+			return response, nil
+		}
+	}
+
 	return e.txMan.NewDbTxScope(e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
 		if number == types.PendingBlockNumber {
 			lastBlock, err := e.state.GetLastL2Block(ctx, dbTx)
@@ -387,6 +589,16 @@ func (e *EthEndpoints) GetBlockByNumber(number types.BlockNumber, fullTx bool, i
 			return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't build block response for block by number %v", blockNumber), err, true)
 		}
 
+		if e.cache != nil {
+			consolidated, err := e.state.IsL2BlockConsolidated(ctx, blockNumber, dbTx)
+			if err != nil {
+				return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't check if block %v is consolidated", blockNumber), err, true)
+			}
+			if consolidated {
+				e.cache.add(getBlockByNumberMethod, cacheKey(blockNumber), rpcBlock)
+			}
+		}
+
 		return rpcBlock, nil
 	})
 }
@@ -507,8 +719,32 @@ func (e *EthEndpoints) GetFilterLogs(filterID string) (interface{}, types.Error)
 
 // GetLogs returns a list of logs accordingly to the provided filter
 func (e *EthEndpoints) GetLogs(filter LogFilter) (interface{}, types.Error) {
+	// only the filters with an explicit block range can be cached
+	cacheable := filter.BlockHash == nil && filter.Since == nil &&
+		filter.FromBlock != nil && *filter.FromBlock >= 0 &&
+		filter.ToBlock != nil && *filter.ToBlock >= 0
+	var cacheKey string
+	if cacheable {
+		cacheKey = fmt.Sprintf("%d:%d:%v:%v", *filter.FromBlock, *filter.ToBlock, filter.Addresses, filter.Topics)
+		if response, found := e.cache.get(getLogsMethod, cacheKey); found {
+			return response, nil
+		}
+	}
+
 	return e.txMan.NewDbTxScope(e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
-		return e.internalGetLogs(ctx, dbTx, filter)
+		logs, rpcErr := e.internalGetLogs(ctx, dbTx, filter)
+		if rpcErr != nil || !cacheable || e.cache == nil {
+			return logs, rpcErr
+		}
+
+		lastConsolidatedBlockNumber, err := e.state.GetLastConsolidatedL2BlockNumber(ctx, dbTx)
+		if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, "failed to get last consolidated block number from state", err, true)
+		}
+		if uint64(*filter.ToBlock) <= lastConsolidatedBlockNumber {
+			e.cache.add(getLogsMethod, cacheKey, logs)
+		}
+		return logs, nil
 	})
 }
 
@@ -847,6 +1083,10 @@ func (e *EthEndpoints) getBlockTransactionCountByNumberFromSequencerNode(number
 
 // GetTransactionReceipt returns a transaction receipt by his hash
 func (e *EthEndpoints) GetTransactionReceipt(hash types.ArgHash) (interface{}, types.Error) {
+	if response, found := e.cache.get(getTransactionReceiptMethod, hash.Hash().String()); found {
+		return response, nil
+	}
+
 	return e.txMan.NewDbTxScope(e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
 		tx, err := e.state.GetTransactionByHash(ctx, hash.Hash(), dbTx)
 		if errors.Is(err, state.ErrNotFound) {
@@ -867,10 +1107,61 @@ func (e *EthEndpoints) GetTransactionReceipt(hash types.ArgHash) (interface{}, t
 			return RPCErrorResponse(types.DefaultErrorCode, "failed to build the receipt response", err, true)
 		}
 
+		if e.cache != nil {
+			consolidated, err := e.state.IsL2BlockConsolidated(ctx, r.BlockNumber.Uint64(), dbTx)
+			if err != nil {
+				return RPCErrorResponse(types.DefaultErrorCode, "failed to check if the tx block is consolidated", err, true)
+			}
+			if consolidated {
+				e.cache.add(getTransactionReceiptMethod, hash.Hash().String(), receipt)
+			}
+		}
+
 		return receipt, nil
 	})
 }
 
+// GetBlockReceipts returns the receipts of all the txs in the block
+// identified by the provided block number, tag or hash
+func (e *EthEndpoints) GetBlockReceipts(blockArg types.BlockNumberOrHash) (interface{}, types.Error) {
+	return e.txMan.NewDbTxScope(e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+		var blockNumber uint64
+		if blockArg.IsHash() {
+			header, err := e.state.GetL2BlockHeaderByHash(ctx, blockArg.Hash().Hash(), dbTx)
+			if errors.Is(err, state.ErrNotFound) {
+				return nil, nil
+			} else if err != nil {
+				return RPCErrorResponse(types.DefaultErrorCode, "failed to get block header from state", err, true)
+			}
+			blockNumber = header.Number.Uint64()
+		} else {
+			var rpcErr types.Error
+			blockNumber, rpcErr = blockArg.Number().GetNumericBlockNumber(ctx, e.state, e.etherman, dbTx)
+			if rpcErr != nil {
+				return nil, rpcErr
+			}
+		}
+
+		txs, receipts, err := e.state.GetL2BlockReceipts(ctx, blockNumber, dbTx)
+		if errors.Is(err, state.ErrNotFound) {
+			return nil, nil
+		} else if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, "failed to get block receipts from state", err, true)
+		}
+
+		res := make([]types.Receipt, 0, len(receipts))
+		for i, r := range receipts {
+			receipt, err := types.NewReceipt(*txs[i], r, nil)
+			if err != nil {
+				return RPCErrorResponse(types.DefaultErrorCode, "failed to build the receipt response", err, true)
+			}
+			res = append(res, receipt)
+		}
+
+		return res, nil
+	})
+}
+
 // NewBlockFilter creates a filter in the node, to notify when
 // a new block arrives. To check if the state has changed,
 // call eth_getFilterChanges.
@@ -987,6 +1278,57 @@ func (e *EthEndpoints) tryToAddTxToPool(input, ip string) (interface{}, types.Er
 	return tx.Hash().Hex(), nil
 }
 
+// SendBundle adds an ordered bundle of txs to the pool, the txs of the bundle are
+// included all together and consecutively in the same L2 block or none of them are
+func (e *EthEndpoints) SendBundle(httpRequest *http.Request, args types.SendBundleArgs) (interface{}, types.Error) {
+	if e.cfg.SequencerNodeURI != "" {
+		return e.relayBundleToSequencerNode(args)
+	}
+
+	ip := ""
+	if ips := httpRequest.Header.Get("X-Forwarded-For"); ips != "" {
+		ip = strings.Split(ips, ",")[0]
+	}
+
+	txs := make([]ethTypes.Transaction, 0, len(args.Txs))
+	for i, input := range args.Txs {
+		tx, err := hexToTx(input)
+		if err != nil {
+			return RPCErrorResponse(types.InvalidParamsErrorCode, fmt.Sprintf("invalid bundle tx %d input", i), err, false)
+		}
+		txs = append(txs, *tx)
+	}
+
+	var blockNumber, maxTimestamp uint64
+	if args.BlockNumber != nil {
+		blockNumber = uint64(*args.BlockNumber)
+	}
+	if args.MaxTimestamp != nil {
+		maxTimestamp = uint64(*args.MaxTimestamp)
+	}
+
+	bundleHash, err := e.pool.AddBundle(context.Background(), txs, blockNumber, maxTimestamp, ip)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, err.Error(), nil, false)
+	}
+	log.Infof("bundle %s with %d txs added to the pool", bundleHash.String(), len(txs))
+
+	return types.SendBundleResponse{BundleHash: bundleHash}, nil
+}
+
+func (e *EthEndpoints) relayBundleToSequencerNode(args types.SendBundleArgs) (interface{}, types.Error) {
+	res, err := client.JSONRPCCall(e.cfg.SequencerNodeURI, "eth_sendBundle", args)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to relay bundle to the sequencer node", err, true)
+	}
+
+	if res.Error != nil {
+		return RPCErrorResponse(res.Error.Code, res.Error.Message, nil, false)
+	}
+
+	return res.Result, nil
+}
+
 // UninstallFilter uninstalls a filter with given id.
 func (e *EthEndpoints) UninstallFilter(filterID string) (interface{}, types.Error) {
 	err := e.storage.UninstallFilter(filterID)
@@ -996,6 +1338,9 @@ func (e *EthEndpoints) UninstallFilter(filterID string) (interface{}, types.Erro
 		return RPCErrorResponse(types.DefaultErrorCode, "failed to uninstall filter", err, true)
 	}
 
+	e.stopNewTxsMonitorIfUnused()
+	e.stopNewPreconfirmationsMonitorIfUnused()
+
 	return true, nil
 }
 
@@ -1100,20 +1445,40 @@ func (e *EthEndpoints) updateFilterLastPoll(filterID string) types.Error {
 // The node will return a subscription id.
 // For each event that matches the subscription a notification with relevant
 // data is sent together with the subscription id.
-func (e *EthEndpoints) Subscribe(wsConn *concurrentWsConn, name string, logFilter *LogFilter) (interface{}, types.Error) {
+//
+// Besides the standard subscriptions, the zkevm specific newVirtualizedBatches,
+// newVerifiedBatches, newL1InfoTreeLeaves and newPreconfirmations subscriptions
+// are supported.
+func (e *EthEndpoints) Subscribe(wsConn *concurrentWsConn, name string, params json.RawMessage) (interface{}, types.Error) {
 	switch name {
 	case "newHeads":
 		return e.newBlockFilter(wsConn)
 	case "logs":
-		return e.txMan.NewDbTxScope(e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
-			var lf LogFilter
-			if logFilter != nil {
-				lf = *logFilter
+		var lf LogFilter
+		if hasParams(params) {
+			if err := json.Unmarshal(params, &lf); err != nil {
+				return RPCErrorResponse(types.InvalidParamsErrorCode, "invalid log filter", err, false)
 			}
+		}
+		return e.txMan.NewDbTxScope(e.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
 			return e.newFilter(ctx, wsConn, lf, dbTx)
 		})
 	case "pendingTransactions", "newPendingTransactions":
-		return e.newPendingTransactionFilter(wsConn)
+		var fullTx bool
+		if hasParams(params) {
+			if err := json.Unmarshal(params, &fullTx); err != nil {
+				return RPCErrorResponse(types.InvalidParamsErrorCode, "invalid full transactions flag", err, false)
+			}
+		}
+		return e.newPendingTxsSubscription(wsConn, fullTx)
+	case "newVirtualizedBatches":
+		return e.newSubscriptionFilter(wsConn, FilterTypeVirtualizedBatch, nil)
+	case "newVerifiedBatches":
+		return e.newSubscriptionFilter(wsConn, FilterTypeVerifiedBatch, nil)
+	case "newL1InfoTreeLeaves":
+		return e.newSubscriptionFilter(wsConn, FilterTypeL1InfoTreeLeaf, nil)
+	case "newPreconfirmations":
+		return e.newPreconfirmationsSubscription(wsConn)
 	case "syncing":
 		return nil, types.NewRPCError(types.DefaultErrorCode, "not supported yet")
 	default:
@@ -1121,6 +1486,77 @@ func (e *EthEndpoints) Subscribe(wsConn *concurrentWsConn, name string, logFilte
 	}
 }
 
+// hasParams checks if the optional raw params were provided
+func hasParams(params json.RawMessage) bool {
+	return len(params) > 0 && string(params) != "null"
+}
+
+// newSubscriptionFilter creates a filter that is only available via web sockets
+func (e *EthEndpoints) newSubscriptionFilter(wsConn *concurrentWsConn, filterType FilterType, parameters interface{}) (interface{}, types.Error) {
+	if wsConn == nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "subscription only available via web sockets", nil, false)
+	}
+
+	id, err := e.storage.NewSubscriptionFilter(wsConn, filterType, parameters)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to create new subscription", err, true)
+	}
+
+	return id, nil
+}
+
+// newPendingTxsSubscription creates a pending txs subscription and starts the
+// pool monitor of new txs if it's not running
+func (e *EthEndpoints) newPendingTxsSubscription(wsConn *concurrentWsConn, fullTx bool) (interface{}, types.Error) {
+	e.newTxsMonitorMux.Lock()
+	defer e.newTxsMonitorMux.Unlock()
+
+	id, err := e.newSubscriptionFilter(wsConn, FilterTypePendingTx, PendingTxFilter{FullTx: fullTx})
+	if err != nil {
+		return id, err
+	}
+
+	e.pool.StartToMonitorNewTxs()
+	return id, nil
+}
+
+// stopNewTxsMonitorIfUnused stops the pool monitor of new txs when there are
+// no pending txs subscriptions left
+func (e *EthEndpoints) stopNewTxsMonitorIfUnused() {
+	e.newTxsMonitorMux.Lock()
+	defer e.newTxsMonitorMux.Unlock()
+
+	if len(e.storage.GetAllPendingTxFiltersWithWSConn()) == 0 {
+		e.pool.StopToMonitorNewTxs()
+	}
+}
+
+// newPreconfirmationsSubscription creates a preconfirmations subscription and
+// starts the pool monitor of new preconfirmations if it's not running
+func (e *EthEndpoints) newPreconfirmationsSubscription(wsConn *concurrentWsConn) (interface{}, types.Error) {
+	e.newPreconfirmationsMonitorMux.Lock()
+	defer e.newPreconfirmationsMonitorMux.Unlock()
+
+	id, err := e.newSubscriptionFilter(wsConn, FilterTypePreconfirmation, nil)
+	if err != nil {
+		return id, err
+	}
+
+	e.pool.StartToMonitorNewPreconfirmations()
+	return id, nil
+}
+
+// stopNewPreconfirmationsMonitorIfUnused stops the pool monitor of new
+// preconfirmations when there are no preconfirmations subscriptions left
+func (e *EthEndpoints) stopNewPreconfirmationsMonitorIfUnused() {
+	e.newPreconfirmationsMonitorMux.Lock()
+	defer e.newPreconfirmationsMonitorMux.Unlock()
+
+	if len(e.storage.GetAllZKEVMFiltersWithWSConn(FilterTypePreconfirmation)) == 0 {
+		e.pool.StopToMonitorNewPreconfirmations()
+	}
+}
+
 // Unsubscribe uninstalls the filter based on the provided filterID
 func (e *EthEndpoints) Unsubscribe(wsConn *concurrentWsConn, filterID string) (interface{}, types.Error) {
 	return e.UninstallFilter(filterID)
@@ -1129,7 +1565,14 @@ func (e *EthEndpoints) Unsubscribe(wsConn *concurrentWsConn, filterID string) (i
 // uninstallFilterByWSConn uninstalls the filters connected to the
 // provided web socket connection
 func (e *EthEndpoints) uninstallFilterByWSConn(wsConn *concurrentWsConn) error {
-	return e.storage.UninstallFilterByWSConn(wsConn)
+	err := e.storage.UninstallFilterByWSConn(wsConn)
+	if err != nil {
+		return err
+	}
+
+	e.stopNewTxsMonitorIfUnused()
+	e.stopNewPreconfirmationsMonitorIfUnused()
+	return nil
 }
 
 // onNewL2Block is triggered when the state triggers the event for a new l2 block
@@ -1216,6 +1659,130 @@ func (e *EthEndpoints) notifyNewLogs(wg *sync.WaitGroup, event state.NewL2BlockE
 	log.Debugf("[notifyNewLogs] new l2 block event for block %v took %v to send all the messages for log filters", event.Block.NumberU64(), time.Since(start))
 }
 
+// onNewTxs is triggered when the pool triggers the event for new txs
+func (e *EthEndpoints) onNewTxs(event pool.NewTxsEvent) {
+	start := time.Now()
+
+	filters := e.storage.GetAllPendingTxFiltersWithWSConn()
+	if len(filters) == 0 {
+		// the subscriptions were removed without uninstalling them
+		e.stopNewTxsMonitorIfUnused()
+		return
+	}
+
+	hashes := make([][]byte, 0, len(event.Txs))
+	txs := make([][]byte, 0, len(event.Txs))
+	for _, tx := range event.Txs {
+		hash, err := json.Marshal(tx.Hash())
+		if err != nil {
+			log.Errorf("failed to marshal tx hash response to subscription: %v", err)
+			continue
+		}
+		rpcTx, err := types.NewTransaction(tx.Transaction, nil, false, nil)
+		if err != nil {
+			log.Errorf("failed to build tx response to subscription: %v", err)
+			continue
+		}
+		fullTx, err := json.Marshal(rpcTx)
+		if err != nil {
+			log.Errorf("failed to marshal tx response to subscription: %v", err)
+			continue
+		}
+		hashes = append(hashes, hash)
+		txs = append(txs, fullTx)
+	}
+
+	const maxWorkers = 32
+	parallelize(maxWorkers, filters, func(worker int, filters []*Filter) {
+		for _, filter := range filters {
+			data := hashes
+			if parameters, ok := filter.Parameters.(PendingTxFilter); ok && parameters.FullTx {
+				data = txs
+			}
+			for _, d := range data {
+				filter.EnqueueSubscriptionDataToBeSent(d)
+			}
+		}
+	})
+
+	log.Debugf("[onNewTxs] %v new txs took %v to send the messages to all ws connections", len(event.Txs), time.Since(start))
+}
+
+// onNewPreconfirmations is triggered when the pool triggers the event for new preconfirmations
+func (e *EthEndpoints) onNewPreconfirmations(event pool.NewPreconfirmationsEvent) {
+	start := time.Now()
+
+	filters := e.storage.GetAllZKEVMFiltersWithWSConn(FilterTypePreconfirmation)
+	if len(filters) == 0 {
+		// the subscriptions were removed without uninstalling them
+		e.stopNewPreconfirmationsMonitorIfUnused()
+		return
+	}
+
+	for _, p := range event.Preconfirmations {
+		res, err := types.NewPreconfirmation(p, e.chainID)
+		if err != nil {
+			log.Errorf("failed to build preconfirmation response to subscription: %v", err)
+			continue
+		}
+		data, err := json.Marshal(res)
+		if err != nil {
+			log.Errorf("failed to marshal preconfirmation response to subscription: %v", err)
+			continue
+		}
+		for _, filter := range filters {
+			filter.EnqueueSubscriptionDataToBeSent(data)
+		}
+	}
+
+	log.Debugf("[onNewPreconfirmations] %v new preconfirmations took %v to send the messages to all ws connections", len(event.Preconfirmations), time.Since(start))
+}
+
+// onZKEVMEvent is triggered when the state triggers a zkevm event
+func (e *EthEndpoints) onZKEVMEvent(event state.ZKEVMEvent) {
+	start := time.Now()
+
+	var (
+		filterType FilterType
+		res        interface{}
+	)
+	switch event.Type {
+	case state.ZKEVMEventTypeReset:
+		// the consolidated blocks may have changed
+		e.cache.purge()
+		return
+	case state.ZKEVMEventTypeVirtualizedBatch:
+		filterType = FilterTypeVirtualizedBatch
+		res = types.NewVirtualizedBatch(*event.VirtualBatch)
+	case state.ZKEVMEventTypeVerifiedBatch:
+		filterType = FilterTypeVerifiedBatch
+		res = types.NewVerifiedBatch(*event.VerifiedBatch)
+	case state.ZKEVMEventTypeL1InfoTreeLeaf:
+		filterType = FilterTypeL1InfoTreeLeaf
+		res = types.NewL1InfoTreeLeaf(*event.L1InfoTreeLeaf)
+	default:
+		log.Errorf("unknown zkevm event type: %v", event.Type)
+		return
+	}
+
+	filters := e.storage.GetAllZKEVMFiltersWithWSConn(filterType)
+	if len(filters) == 0 {
+		return
+	}
+
+	data, err := json.Marshal(res)
+	if err != nil {
+		log.Errorf("failed to marshal %v response to subscription: %v", event.Type, err)
+		return
+	}
+
+	for _, filter := range filters {
+		filter.EnqueueSubscriptionDataToBeSent(data)
+	}
+
+	log.Debugf("[onZKEVMEvent] %v event took %v to send the messages to all ws connections", event.Type, time.Since(start))
+}
+
 // shouldSkipLogFilter checks if the log filter can be skipped while notifying new logs.
 // it checks the log filter information against the block in the event to decide if the
 // information in the event is required by the filter or can be ignored to save resources.
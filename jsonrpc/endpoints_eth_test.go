@@ -13,6 +13,7 @@ import (
 	"github.com/0xPolygonHermez/zkevm-node/encoding"
 	"github.com/0xPolygonHermez/zkevm-node/hex"
 	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/client"
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/mocks"
 	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
 	"github.com/0xPolygonHermez/zkevm-node/pool"
 	"github.com/0xPolygonHermez/zkevm-node/state"
@@ -174,7 +175,7 @@ func TestCall(t *testing.T) {
 				m.State.On("GetL2BlockByNumber", context.Background(), blockNumOneUint64, m.DbTx).Return(block, nil).Once()
 				m.State.On("GetNonce", context.Background(), *txArgs.From, blockRoot).Return(nonce, nil).Once()
 				m.State.
-					On("ProcessUnsignedTransaction", context.Background(), txMatchBy, *txArgs.From, &blockNumOneUint64, true, m.DbTx).
+					On("ProcessUnsignedTransaction", context.Background(), txMatchBy, *txArgs.From, &blockNumOneUint64, true, state.StateOverride(nil), m.DbTx).
 					Return(&runtime.ExecutionResult{ReturnValue: testCase.expectedResult}, nil).
 					Once()
 			},
@@ -218,7 +219,7 @@ func TestCall(t *testing.T) {
 				})
 				m.State.On("GetNonce", context.Background(), *txArgs.From, blockRoot).Return(nonce, nil).Once()
 				m.State.
-					On("ProcessUnsignedTransaction", context.Background(), txMatchBy, *txArgs.From, &blockNumOneUint64, true, m.DbTx).
+					On("ProcessUnsignedTransaction", context.Background(), txMatchBy, *txArgs.From, &blockNumOneUint64, true, state.StateOverride(nil), m.DbTx).
 					Return(&runtime.ExecutionResult{ReturnValue: testCase.expectedResult}, nil).
 					Once()
 			},
@@ -260,7 +261,7 @@ func TestCall(t *testing.T) {
 				m.State.On("GetL2BlockByNumber", context.Background(), blockNumOneUint64, m.DbTx).Return(block, nil).Once()
 				m.State.On("GetNonce", context.Background(), *txArgs.From, blockRoot).Return(nonce, nil).Once()
 				m.State.
-					On("ProcessUnsignedTransaction", context.Background(), txMatchBy, *txArgs.From, nilUint64, true, m.DbTx).
+					On("ProcessUnsignedTransaction", context.Background(), txMatchBy, *txArgs.From, nilUint64, true, state.StateOverride(nil), m.DbTx).
 					Return(&runtime.ExecutionResult{ReturnValue: testCase.expectedResult}, nil).
 					Once()
 			},
@@ -302,7 +303,7 @@ func TestCall(t *testing.T) {
 				})
 				m.State.On("GetNonce", context.Background(), *txArgs.From, blockRoot).Return(nonce, nil).Once()
 				m.State.
-					On("ProcessUnsignedTransaction", context.Background(), txMatchBy, *txArgs.From, &blockNumTenUint64, true, m.DbTx).
+					On("ProcessUnsignedTransaction", context.Background(), txMatchBy, *txArgs.From, &blockNumTenUint64, true, state.StateOverride(nil), m.DbTx).
 					Return(&runtime.ExecutionResult{ReturnValue: testCase.expectedResult}, nil).
 					Once()
 			},
@@ -342,7 +343,7 @@ func TestCall(t *testing.T) {
 				m.State.On("GetL2BlockByNumber", context.Background(), blockNumTenUint64, m.DbTx).Return(block, nil).Once()
 				m.State.On("GetNonce", context.Background(), *txArgs.From, blockRoot).Return(nonce, nil).Once()
 				m.State.
-					On("ProcessUnsignedTransaction", context.Background(), txMatchBy, *txArgs.From, &blockNumTenUint64, true, m.DbTx).
+					On("ProcessUnsignedTransaction", context.Background(), txMatchBy, *txArgs.From, &blockNumTenUint64, true, state.StateOverride(nil), m.DbTx).
 					Return(&runtime.ExecutionResult{ReturnValue: testCase.expectedResult}, nil).
 					Once()
 			},
@@ -381,7 +382,7 @@ func TestCall(t *testing.T) {
 				block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: blockNumOne, Root: blockRoot}))
 				m.State.On("GetL2BlockByNumber", context.Background(), blockNumOneUint64, m.DbTx).Return(block, nil).Once()
 				m.State.
-					On("ProcessUnsignedTransaction", context.Background(), txMatchBy, common.HexToAddress(state.DefaultSenderAddress), nilUint64, true, m.DbTx).
+					On("ProcessUnsignedTransaction", context.Background(), txMatchBy, common.HexToAddress(state.DefaultSenderAddress), nilUint64, true, state.StateOverride(nil), m.DbTx).
 					Return(&runtime.ExecutionResult{ReturnValue: testCase.expectedResult}, nil).
 					Once()
 			},
@@ -420,7 +421,7 @@ func TestCall(t *testing.T) {
 				block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: blockNumOne, Root: blockRoot}))
 				m.State.On("GetL2BlockByNumber", context.Background(), blockNumOneUint64, m.DbTx).Return(block, nil).Once()
 				m.State.
-					On("ProcessUnsignedTransaction", context.Background(), txMatchBy, common.HexToAddress(state.DefaultSenderAddress), nilUint64, true, m.DbTx).
+					On("ProcessUnsignedTransaction", context.Background(), txMatchBy, common.HexToAddress(state.DefaultSenderAddress), nilUint64, true, state.StateOverride(nil), m.DbTx).
 					Return(&runtime.ExecutionResult{ReturnValue: testCase.expectedResult}, nil).
 					Once()
 			},
@@ -484,7 +485,7 @@ func TestCall(t *testing.T) {
 				m.State.On("GetL2BlockByNumber", context.Background(), blockNumOneUint64, m.DbTx).Return(block, nil).Once()
 				m.State.On("GetNonce", context.Background(), *txArgs.From, blockRoot).Return(nonce, nil).Once()
 				m.State.
-					On("ProcessUnsignedTransaction", context.Background(), txMatchBy, *txArgs.From, nilUint64, true, m.DbTx).
+					On("ProcessUnsignedTransaction", context.Background(), txMatchBy, *txArgs.From, nilUint64, true, state.StateOverride(nil), m.DbTx).
 					Return(&runtime.ExecutionResult{Err: errors.New("failed to process unsigned transaction")}, nil).
 					Once()
 			},
@@ -526,11 +527,76 @@ func TestCall(t *testing.T) {
 				m.State.On("GetL2BlockByNumber", context.Background(), blockNumOneUint64, m.DbTx).Return(block, nil).Once()
 				m.State.On("GetNonce", context.Background(), *txArgs.From, blockRoot).Return(nonce, nil).Once()
 				m.State.
-					On("ProcessUnsignedTransaction", context.Background(), txMatchBy, *txArgs.From, nilUint64, true, m.DbTx).
+					On("ProcessUnsignedTransaction", context.Background(), txMatchBy, *txArgs.From, nilUint64, true, state.StateOverride(nil), m.DbTx).
 					Return(&runtime.ExecutionResult{Err: runtime.ErrExecutionReverted}, nil).
 					Once()
 			},
 		},
+		{
+			name: "Transaction with state override",
+			params: []interface{}{
+				types.TxArgs{
+					From:     state.HexToAddressPtr("0x1"),
+					To:       state.HexToAddressPtr("0x2"),
+					Gas:      types.ArgUint64Ptr(24000),
+					GasPrice: types.ArgBytesPtr(big.NewInt(1).Bytes()),
+					Value:    types.ArgBytesPtr(big.NewInt(2).Bytes()),
+					Data:     types.ArgBytesPtr([]byte("data")),
+				},
+				latest,
+				map[string]interface{}{
+					"0x0000000000000000000000000000000000000001": map[string]interface{}{"balance": "0x3e8", "nonce": "0x9"},
+					"0x0000000000000000000000000000000000000002": map[string]interface{}{"code": "0x6001", "stateDiff": map[string]string{common.HexToHash("0x1").Hex(): common.HexToHash("0x2").Hex()}},
+				},
+			},
+			expectedResult: []byte("hello world"),
+			expectedError:  nil,
+			setupMocks: func(c Config, m *mocksWrapper, testCase *testCase) {
+				nonce := uint64(7)
+				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.On("GetLastL2BlockNumber", context.Background(), m.DbTx).Return(blockNumOne.Uint64(), nil).Once()
+				txArgs := testCase.params[0].(types.TxArgs)
+				block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: blockNumOne, Root: blockRoot}))
+				m.State.On("GetL2BlockByNumber", context.Background(), blockNumOneUint64, m.DbTx).Return(block, nil).Once()
+				m.State.On("GetNonce", context.Background(), *txArgs.From, blockRoot).Return(nonce, nil).Once()
+				stateOverride := state.StateOverride{
+					common.HexToAddress("0x1"): state.OverrideAccount{Balance: big.NewInt(1000), Nonce: state.Ptr(uint64(9))},
+					common.HexToAddress("0x2"): state.OverrideAccount{Code: []byte{0x60, 0x01}, StateDiff: map[common.Hash]common.Hash{common.HexToHash("0x1"): common.HexToHash("0x2")}},
+				}
+				m.State.
+					On("ProcessUnsignedTransaction", context.Background(), mock.Anything, *txArgs.From, nilUint64, true, stateOverride, m.DbTx).
+					Return(&runtime.ExecutionResult{ReturnValue: testCase.expectedResult}, nil).
+					Once()
+			},
+		},
+		{
+			name: "Transaction with state and stateDiff overridden at the same time",
+			params: []interface{}{
+				types.TxArgs{
+					From: state.HexToAddressPtr("0x1"),
+					To:   state.HexToAddressPtr("0x2"),
+					Gas:  types.ArgUint64Ptr(24000),
+					Data: types.ArgBytesPtr([]byte("data")),
+				},
+				latest,
+				map[string]interface{}{
+					"0x0000000000000000000000000000000000000002": map[string]interface{}{"state": map[string]string{}, "stateDiff": map[string]string{}},
+				},
+			},
+			expectedResult: nil,
+			expectedError:  types.NewRPCError(types.InvalidParamsErrorCode, "account 0x0000000000000000000000000000000000000002 has both 'state' and 'stateDiff'"),
+			setupMocks: func(c Config, m *mocksWrapper, testCase *testCase) {
+				nonce := uint64(7)
+				m.DbTx.On("Rollback", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.On("GetLastL2BlockNumber", context.Background(), m.DbTx).Return(blockNumOne.Uint64(), nil).Once()
+				txArgs := testCase.params[0].(types.TxArgs)
+				block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: blockNumOne, Root: blockRoot}))
+				m.State.On("GetL2BlockByNumber", context.Background(), blockNumOneUint64, m.DbTx).Return(block, nil).Once()
+				m.State.On("GetNonce", context.Background(), *txArgs.From, blockRoot).Return(nonce, nil).Once()
+			},
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -688,7 +754,7 @@ func TestEstimateGas(t *testing.T) {
 					Return(nonce, nil).
 					Once()
 				m.State.
-					On("EstimateGas", txMatchBy, *txArgs.From, nilUint64, m.DbTx).
+					On("EstimateGas", txMatchBy, *txArgs.From, nilUint64, state.StateOverride(nil), m.DbTx).
 					Return(*testCase.expectedResult, nil, nil).
 					Once()
 			},
@@ -729,7 +795,47 @@ func TestEstimateGas(t *testing.T) {
 				m.State.On("GetLastL2Block", context.Background(), m.DbTx).Return(block, nil).Once()
 
 				m.State.
-					On("EstimateGas", txMatchBy, common.HexToAddress(state.DefaultSenderAddress), nilUint64, m.DbTx).
+					On("EstimateGas", txMatchBy, common.HexToAddress(state.DefaultSenderAddress), nilUint64, state.StateOverride(nil), m.DbTx).
+					Return(*testCase.expectedResult, nil, nil).
+					Once()
+			},
+		},
+		{
+			name: "Transaction with state override",
+			params: []interface{}{
+				types.TxArgs{
+					From:     state.HexToAddressPtr("0x1"),
+					To:       state.HexToAddressPtr("0x2"),
+					GasPrice: types.ArgBytesPtr(big.NewInt(1).Bytes()),
+					Value:    types.ArgBytesPtr(big.NewInt(2).Bytes()),
+					Data:     types.ArgBytesPtr([]byte("data")),
+				},
+				latest,
+				map[string]interface{}{
+					"0x0000000000000000000000000000000000000001": map[string]interface{}{"balance": "0x3e8"},
+				},
+			},
+			expectedResult: state.Ptr(uint64(100)),
+			setupMocks: func(c Config, m *mocksWrapper, testCase *testCase) {
+				nonce := uint64(7)
+				txArgs := testCase.params[0].(types.TxArgs)
+
+				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.On("GetLastL2BlockNumber", context.Background(), m.DbTx).Return(blockNumTen.Uint64(), nil).Once()
+
+				block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: blockNumTen, Root: blockRoot}))
+				m.State.On("GetL2BlockByNumber", context.Background(), blockNumTenUint64, m.DbTx).Return(block, nil).Once()
+
+				m.State.
+					On("GetNonce", context.Background(), *txArgs.From, blockRoot).
+					Return(nonce, nil).
+					Once()
+				stateOverride := state.StateOverride{
+					common.HexToAddress("0x1"): state.OverrideAccount{Balance: big.NewInt(1000)},
+				}
+				m.State.
+					On("EstimateGas", mock.Anything, *txArgs.From, nilUint64, stateOverride, m.DbTx).
 					Return(*testCase.expectedResult, nil, nil).
 					Once()
 			},
@@ -766,6 +872,127 @@ func TestEstimateGas(t *testing.T) {
 	}
 }
 
+func TestCreateAccessList(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	type testCase struct {
+		name           string
+		params         []interface{}
+		expectedResult *types.AccessListResult
+		expectedError  interface{}
+		setupMocks     func(*mocksWrapper, *testCase)
+	}
+
+	from := common.HexToAddress("0x1")
+	to := common.HexToAddress("0x2")
+	block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: blockNumOne, Root: blockRoot, GasLimit: 30000000}))
+	nonce := uint64(7)
+	accessList := ethTypes.AccessList{
+		{Address: common.HexToAddress("0x3"), StorageKeys: []common.Hash{common.HexToHash("0x1"), common.HexToHash("0x2")}},
+		{Address: common.HexToAddress("0x4"), StorageKeys: []common.Hash{}},
+	}
+	txArgs := types.TxArgs{
+		From: &from,
+		To:   &to,
+		Data: types.ArgBytesPtr([]byte("data")),
+	}
+	txMatchBy := mock.MatchedBy(func(tx *ethTypes.Transaction) bool {
+		return tx != nil &&
+			tx.To().Hex() == to.Hex() &&
+			tx.Gas() == block.GasLimit() &&
+			tx.Nonce() == nonce
+	})
+
+	testCases := []testCase{
+		{
+			name:   "create access list successfully",
+			params: []interface{}{txArgs, latest},
+			expectedResult: &types.AccessListResult{
+				AccessList: accessList,
+				GasUsed:    types.ArgUint64(50000),
+			},
+			setupMocks: func(m *mocksWrapper, tc *testCase) {
+				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.On("GetLastL2BlockNumber", context.Background(), m.DbTx).Return(blockNumOneUint64, nil).Once()
+				m.State.On("GetL2BlockByNumber", context.Background(), blockNumOneUint64, m.DbTx).Return(block, nil).Once()
+				m.State.On("GetNonce", context.Background(), from, blockRoot).Return(nonce, nil).Once()
+				m.State.
+					On("CreateAccessList", context.Background(), txMatchBy, from, &blockNumOneUint64, m.DbTx).
+					Return(accessList, &runtime.ExecutionResult{GasUsed: 50000}, nil).
+					Once()
+			},
+		},
+		{
+			name:   "create access list of a reverted tx",
+			params: []interface{}{txArgs, latest},
+			expectedResult: &types.AccessListResult{
+				AccessList: accessList,
+				Error:      runtime.ErrExecutionReverted.Error(),
+				GasUsed:    types.ArgUint64(21000),
+			},
+			setupMocks: func(m *mocksWrapper, tc *testCase) {
+				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.On("GetLastL2BlockNumber", context.Background(), m.DbTx).Return(blockNumOneUint64, nil).Once()
+				m.State.On("GetL2BlockByNumber", context.Background(), blockNumOneUint64, m.DbTx).Return(block, nil).Once()
+				m.State.On("GetNonce", context.Background(), from, blockRoot).Return(nonce, nil).Once()
+				m.State.
+					On("CreateAccessList", context.Background(), txMatchBy, from, &blockNumOneUint64, m.DbTx).
+					Return(accessList, &runtime.ExecutionResult{GasUsed: 21000, Err: runtime.ErrExecutionReverted}, nil).
+					Once()
+			},
+		},
+		{
+			name:          "failed to create access list",
+			params:        []interface{}{txArgs, latest},
+			expectedError: types.NewRPCError(types.DefaultErrorCode, "failed to create access list: failed to process unsigned transaction"),
+			setupMocks: func(m *mocksWrapper, tc *testCase) {
+				m.DbTx.On("Rollback", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.On("GetLastL2BlockNumber", context.Background(), m.DbTx).Return(blockNumOneUint64, nil).Once()
+				m.State.On("GetL2BlockByNumber", context.Background(), blockNumOneUint64, m.DbTx).Return(block, nil).Once()
+				m.State.On("GetNonce", context.Background(), from, blockRoot).Return(nonce, nil).Once()
+				m.State.
+					On("CreateAccessList", context.Background(), txMatchBy, from, &blockNumOneUint64, m.DbTx).
+					Return(nil, nil, errors.New("failed to process unsigned transaction")).
+					Once()
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			tc := testCase
+			tc.setupMocks(m, &tc)
+
+			res, err := s.JSONRPCCall("eth_createAccessList", tc.params...)
+			require.NoError(t, err)
+
+			if tc.expectedResult != nil {
+				require.NotNil(t, res.Result)
+				require.Nil(t, res.Error)
+
+				var result types.AccessListResult
+				err = json.Unmarshal(res.Result, &result)
+				require.NoError(t, err)
+
+				assert.ElementsMatch(t, tc.expectedResult.AccessList, result.AccessList)
+				assert.Equal(t, tc.expectedResult.Error, result.Error)
+				assert.Equal(t, tc.expectedResult.GasUsed, result.GasUsed)
+			}
+
+			if tc.expectedError != nil {
+				expectedErr := tc.expectedError.(*types.RPCError)
+				require.NotNil(t, res.Error)
+				assert.Equal(t, expectedErr.ErrorCode(), res.Error.Code)
+				assert.Equal(t, expectedErr.Error(), res.Error.Message)
+			}
+		})
+	}
+}
+
 func TestGasPrice(t *testing.T) {
 	s, m, c := newSequencerMockedServer(t)
 	defer s.Stop()
@@ -799,6 +1026,148 @@ func TestGasPrice(t *testing.T) {
 	}
 }
 
+func TestMaxPriorityFeePerGas(t *testing.T) {
+	s, m, c := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	m.Pool.
+		On("GetGasPrices", context.Background()).
+		Return(pool.GasPrices{L2GasPrice: 50, L1GasPrice: 100}, nil).
+		Once()
+
+	tip, err := c.SuggestGasTipCap(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(50), tip.Uint64())
+}
+
+func TestFeeHistory(t *testing.T) {
+	s, m, c := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	type testCase struct {
+		name               string
+		blockCount         uint64
+		lastBlock          *big.Int
+		percentiles        []float64
+		expectedFeeHistory *ethereum.FeeHistory
+		expectedError      *types.RPCError
+		setupMocks         func(m *mocksWrapper, tc testCase)
+	}
+
+	blocksFeeData := []state.L2BlockFeeData{
+		{
+			BlockNumber: 9,
+			GasUsed:     0,
+			GasLimit:    100,
+		},
+		{
+			BlockNumber: 10,
+			GasUsed:     100,
+			GasLimit:    200,
+			Txs: []state.TxFeeData{
+				{GasUsed: 60, EffectiveGasPrice: big.NewInt(30)},
+				{GasUsed: 10, EffectiveGasPrice: big.NewInt(10)},
+				{GasUsed: 30, EffectiveGasPrice: big.NewInt(20)},
+			},
+		},
+	}
+
+	testCases := []testCase{
+		{
+			name:        "fee history of the last two blocks",
+			blockCount:  2,
+			lastBlock:   nil,
+			percentiles: []float64{0, 10, 40, 50, 100},
+			expectedFeeHistory: &ethereum.FeeHistory{
+				OldestBlock:  big.NewInt(9),
+				Reward:       [][]*big.Int{{big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0)}, {big.NewInt(10), big.NewInt(10), big.NewInt(20), big.NewInt(30), big.NewInt(30)}},
+				BaseFee:      []*big.Int{big.NewInt(0), big.NewInt(0), big.NewInt(0)},
+				GasUsedRatio: []float64{0, 0.5},
+			},
+			setupMocks: func(m *mocksWrapper, tc testCase) {
+				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.On("GetLastL2BlockNumber", context.Background(), m.DbTx).Return(blockNumTenUint64, nil).Once()
+				m.State.On("GetL2BlocksFeeDataInRange", context.Background(), uint64(9), blockNumTenUint64, m.DbTx).Return(blocksFeeData, nil).Once()
+			},
+		},
+		{
+			name:       "fee history without percentiles limited by the genesis block",
+			blockCount: 5,
+			lastBlock:  blockNumOne,
+			expectedFeeHistory: &ethereum.FeeHistory{
+				OldestBlock:  big.NewInt(0),
+				Reward:       [][]*big.Int{},
+				BaseFee:      []*big.Int{big.NewInt(0), big.NewInt(0), big.NewInt(0)},
+				GasUsedRatio: []float64{0, 0.5},
+			},
+			setupMocks: func(m *mocksWrapper, tc testCase) {
+				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.
+					On("GetL2BlocksFeeDataInRange", context.Background(), uint64(0), blockNumOneUint64, m.DbTx).
+					Return([]state.L2BlockFeeData{
+						{BlockNumber: 0, GasUsed: 0, GasLimit: 100},
+						{BlockNumber: 1, GasUsed: 50, GasLimit: 100},
+					}, nil).
+					Once()
+			},
+		},
+		{
+			name:          "invalid percentiles",
+			blockCount:    2,
+			lastBlock:     nil,
+			percentiles:   []float64{50, 10},
+			expectedError: types.NewRPCError(types.InvalidParamsErrorCode, "invalid reward percentile: #0:50 >= #1:10"),
+			setupMocks:    func(m *mocksWrapper, tc testCase) {},
+		},
+		{
+			name:          "failed to get fee data of blocks",
+			blockCount:    2,
+			lastBlock:     nil,
+			percentiles:   []float64{50},
+			expectedError: types.NewRPCError(types.DefaultErrorCode, "failed to get fee data of blocks from state"),
+			setupMocks: func(m *mocksWrapper, tc testCase) {
+				m.DbTx.On("Rollback", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.On("GetLastL2BlockNumber", context.Background(), m.DbTx).Return(blockNumTenUint64, nil).Once()
+				m.State.On("GetL2BlocksFeeDataInRange", context.Background(), uint64(9), blockNumTenUint64, m.DbTx).Return(nil, errors.New("failed to get fee data")).Once()
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			tc := testCase
+			tc.setupMocks(m, tc)
+
+			feeHistory, err := c.FeeHistory(context.Background(), tc.blockCount, tc.lastBlock, tc.percentiles)
+			if tc.expectedError != nil {
+				require.Error(t, err)
+				rpcErr := err.(rpc.Error)
+				assert.Equal(t, tc.expectedError.ErrorCode(), rpcErr.ErrorCode())
+				assert.Equal(t, tc.expectedError.Error(), rpcErr.Error())
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedFeeHistory.OldestBlock.Uint64(), feeHistory.OldestBlock.Uint64())
+			assert.Equal(t, tc.expectedFeeHistory.GasUsedRatio, feeHistory.GasUsedRatio)
+			require.Equal(t, len(tc.expectedFeeHistory.BaseFee), len(feeHistory.BaseFee))
+			for i, baseFee := range feeHistory.BaseFee {
+				assert.Equal(t, tc.expectedFeeHistory.BaseFee[i].Uint64(), baseFee.Uint64())
+			}
+			require.Equal(t, len(tc.expectedFeeHistory.Reward), len(feeHistory.Reward))
+			for i, rewards := range feeHistory.Reward {
+				require.Equal(t, len(tc.expectedFeeHistory.Reward[i]), len(rewards))
+				for j, reward := range rewards {
+					assert.Equal(t, tc.expectedFeeHistory.Reward[i][j].Uint64(), reward.Uint64())
+				}
+			}
+		})
+	}
+}
+
 func TestGetBalance(t *testing.T) {
 	s, m, _ := newSequencerMockedServer(t)
 	defer s.Stop()
@@ -3538,6 +3907,146 @@ func TestGetTransactionReceipt(t *testing.T) {
 	}
 }
 
+func TestGetBlockReceipts(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	type testCase struct {
+		Name             string
+		Params           []interface{}
+		ExpectedReceipts []*ethTypes.Receipt
+		ExpectedError    *types.RPCError
+		SetupMocks       func(m *mocksWrapper, tc testCase)
+	}
+
+	chainID := big.NewInt(1)
+
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	auth, err := bind.NewKeyedTransactorWithChainID(privateKey, chainID)
+	require.NoError(t, err)
+
+	signedTxs := make([]*ethTypes.Transaction, 0, 2)
+	receipts := make([]*ethTypes.Receipt, 0, 2)
+	for i := 0; i < 2; i++ {
+		tx := ethTypes.NewTransaction(uint64(i), common.HexToAddress("0x111"), big.NewInt(2), 3, big.NewInt(4), []byte{5, 6, 7, 8})
+		signedTx, err := auth.Signer(auth.From, tx)
+		require.NoError(t, err)
+		signedTxs = append(signedTxs, signedTx)
+
+		receipt := &ethTypes.Receipt{
+			Type:              signedTx.Type(),
+			CumulativeGasUsed: uint64(i + 1),
+			BlockNumber:       blockNumTen,
+			GasUsed:           1,
+			TxHash:            signedTx.Hash(),
+			TransactionIndex:  uint(i),
+			Logs:              []*ethTypes.Log{{Topics: []common.Hash{common.HexToHash("0x1")}, Data: []byte{}, TxHash: signedTx.Hash(), TxIndex: uint(i)}},
+			Status:            ethTypes.ReceiptStatusSuccessful,
+			EffectiveGasPrice: big.NewInt(5),
+			BlockHash:         blockHash,
+		}
+		receipt.Bloom = ethTypes.CreateBloom(ethTypes.Receipts{receipt})
+		receipts = append(receipts, receipt)
+	}
+
+	testCases := []testCase{
+		{
+			Name:             "get receipts by block number successfully",
+			Params:           []interface{}{hex.EncodeBig(blockNumTen)},
+			ExpectedReceipts: receipts,
+			SetupMocks: func(m *mocksWrapper, tc testCase) {
+				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.On("GetL2BlockReceipts", context.Background(), blockNumTenUint64, m.DbTx).Return(signedTxs, receipts, nil).Once()
+			},
+		},
+		{
+			Name:             "get receipts by block hash successfully",
+			Params:           []interface{}{blockHash.String()},
+			ExpectedReceipts: receipts,
+			SetupMocks: func(m *mocksWrapper, tc testCase) {
+				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.On("GetL2BlockHeaderByHash", context.Background(), blockHash, m.DbTx).Return(state.NewL2Header(&ethTypes.Header{Number: blockNumTen}), nil).Once()
+				m.State.On("GetL2BlockReceipts", context.Background(), blockNumTenUint64, m.DbTx).Return(signedTxs, receipts, nil).Once()
+			},
+		},
+		{
+			Name:             "get receipts of the latest block without txs",
+			Params:           []interface{}{latest},
+			ExpectedReceipts: []*ethTypes.Receipt{},
+			SetupMocks: func(m *mocksWrapper, tc testCase) {
+				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.On("GetLastL2BlockNumber", context.Background(), m.DbTx).Return(blockNumTenUint64, nil).Once()
+				m.State.On("GetL2BlockReceipts", context.Background(), blockNumTenUint64, m.DbTx).Return([]*ethTypes.Transaction{}, []*ethTypes.Receipt{}, nil).Once()
+			},
+		},
+		{
+			Name:   "block not found",
+			Params: []interface{}{hex.EncodeBig(blockNumTen)},
+			SetupMocks: func(m *mocksWrapper, tc testCase) {
+				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.On("GetL2BlockReceipts", context.Background(), blockNumTenUint64, m.DbTx).Return(nil, nil, state.ErrNotFound).Once()
+			},
+		},
+		{
+			Name:          "failed to get block receipts",
+			Params:        []interface{}{hex.EncodeBig(blockNumTen)},
+			ExpectedError: types.NewRPCError(types.DefaultErrorCode, "failed to get block receipts from state"),
+			SetupMocks: func(m *mocksWrapper, tc testCase) {
+				m.DbTx.On("Rollback", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.On("GetL2BlockReceipts", context.Background(), blockNumTenUint64, m.DbTx).Return(nil, nil, errors.New("failed to get block receipts")).Once()
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			tc := testCase
+			tc.SetupMocks(m, tc)
+
+			res, err := s.JSONRPCCall("eth_getBlockReceipts", tc.Params...)
+			require.NoError(t, err)
+
+			if tc.ExpectedError != nil {
+				require.NotNil(t, res.Error)
+				assert.Equal(t, tc.ExpectedError.ErrorCode(), res.Error.Code)
+				assert.Equal(t, tc.ExpectedError.Error(), res.Error.Message)
+				return
+			}
+
+			require.Nil(t, res.Error)
+			if tc.ExpectedReceipts == nil {
+				assert.Equal(t, "null", string(res.Result))
+				return
+			}
+
+			var result []types.Receipt
+			err = json.Unmarshal(res.Result, &result)
+			require.NoError(t, err)
+			require.Equal(t, len(tc.ExpectedReceipts), len(result))
+			for i, receipt := range tc.ExpectedReceipts {
+				assert.Equal(t, receipt.TxHash, result[i].TxHash)
+				assert.Equal(t, types.ArgUint64(receipt.TransactionIndex), result[i].TxIndex)
+				assert.Equal(t, types.ArgUint64(receipt.CumulativeGasUsed), result[i].CumulativeGasUsed)
+				assert.Equal(t, receipt.BlockHash, result[i].BlockHash)
+				assert.Equal(t, types.ArgUint64(receipt.BlockNumber.Uint64()), result[i].BlockNumber)
+				assert.Equal(t, receipt.Bloom, result[i].LogsBloom)
+				assert.Equal(t, auth.From, result[i].FromAddr)
+				assert.Equal(t, signedTxs[i].To(), result[i].ToAddr)
+				assert.Equal(t, receipt.EffectiveGasPrice.Uint64(), (*big.Int)(result[i].EffectiveGasPrice).Uint64())
+				require.Equal(t, len(receipt.Logs), len(result[i].Logs))
+				assert.Equal(t, receipt.Logs[0].TxHash, result[i].Logs[0].TxHash)
+				assert.Equal(t, receipt.Logs[0].Topics, result[i].Logs[0].Topics)
+			}
+		})
+	}
+}
+
 func TestSendRawTransactionViaGeth(t *testing.T) {
 	s, m, c := newSequencerMockedServer(t)
 	defer s.Stop()
@@ -3701,6 +4210,86 @@ func TestSendRawTransactionJSONRPCCall(t *testing.T) {
 	}
 }
 
+func TestSendBundleJSONRPCCall(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	tx1 := ethTypes.NewTransaction(1, common.HexToAddress("0x1"), big.NewInt(1), uint64(1), big.NewInt(1), []byte{})
+	tx2 := ethTypes.NewTransaction(2, common.HexToAddress("0x1"), big.NewInt(1), uint64(1), big.NewInt(1), []byte{})
+	rawTxs := []string{}
+	for _, tx := range []*ethTypes.Transaction{tx1, tx2} {
+		txBinary, err := tx.MarshalBinary()
+		require.NoError(t, err)
+		rawTxs = append(rawTxs, hex.EncodeToHex(txBinary))
+	}
+	bundleHash := common.HexToHash("0xb")
+
+	type testCase struct {
+		Name           string
+		Args           types.SendBundleArgs
+		ExpectedResult *common.Hash
+		ExpectedError  types.Error
+		SetupMocks     func(t *testing.T, m *mocksWrapper, tc testCase)
+	}
+
+	testCases := []testCase{
+		{
+			Name:           "Send bundle successfully",
+			Args:           types.SendBundleArgs{Txs: rawTxs, BlockNumber: types.ArgUint64Ptr(10), MaxTimestamp: types.ArgUint64Ptr(1700000000)},
+			ExpectedResult: &bundleHash,
+			SetupMocks: func(t *testing.T, m *mocksWrapper, tc testCase) {
+				m.Pool.
+					On("AddBundle", context.Background(), mock.MatchedBy(func(txs []ethTypes.Transaction) bool {
+						return len(txs) == 2 && txs[0].Hash() == tx1.Hash() && txs[1].Hash() == tx2.Hash()
+					}), uint64(10), uint64(1700000000), "").
+					Return(bundleHash, nil).
+					Once()
+			},
+		},
+		{
+			Name:          "Send bundle failed to add to the pool",
+			Args:          types.SendBundleArgs{Txs: rawTxs},
+			ExpectedError: types.NewRPCError(types.DefaultErrorCode, pool.ErrBundleTooLarge.Error()),
+			SetupMocks: func(t *testing.T, m *mocksWrapper, tc testCase) {
+				m.Pool.
+					On("AddBundle", context.Background(), mock.IsType([]ethTypes.Transaction{}), uint64(0), uint64(0), "").
+					Return(common.Hash{}, pool.ErrBundleTooLarge).
+					Once()
+			},
+		},
+		{
+			Name:          "Send bundle with invalid tx input",
+			Args:          types.SendBundleArgs{Txs: []string{rawTxs[0], "0x1234"}},
+			ExpectedError: types.NewRPCError(types.InvalidParamsErrorCode, "invalid bundle tx 1 input"),
+			SetupMocks:    func(t *testing.T, m *mocksWrapper, tc testCase) {},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			tc := testCase
+			tc.SetupMocks(t, m, tc)
+
+			res, err := s.JSONRPCCall("eth_sendBundle", tc.Args)
+			require.NoError(t, err)
+
+			assert.Equal(t, float64(1), res.ID)
+			assert.Equal(t, "2.0", res.JSONRPC)
+
+			if res.Result != nil || tc.ExpectedResult != nil {
+				var result types.SendBundleResponse
+				err = json.Unmarshal(res.Result, &result)
+				require.NoError(t, err)
+				assert.Equal(t, *tc.ExpectedResult, result.BundleHash)
+			}
+			if res.Error != nil || tc.ExpectedError != nil {
+				assert.Equal(t, tc.ExpectedError.ErrorCode(), res.Error.Code)
+				assert.Equal(t, tc.ExpectedError.Error(), res.Error.Message)
+			}
+		})
+	}
+}
+
 func TestSendRawTransactionViaGethForNonSequencerNode(t *testing.T) {
 	sequencerServer, sequencerMocks, _ := newSequencerMockedServer(t)
 	defer sequencerServer.Stop()
@@ -4136,6 +4725,24 @@ func TestUninstallFilter(t *testing.T) {
 					On("UninstallFilter", tc.FilterID).
 					Return(nil).
 					Once()
+
+				m.Storage.
+					On("GetAllPendingTxFiltersWithWSConn").
+					Return([]*Filter{}).
+					Once()
+
+				m.Pool.
+					On("StopToMonitorNewTxs").
+					Once()
+
+				m.Storage.
+					On("GetAllZKEVMFiltersWithWSConn", FilterType(FilterTypePreconfirmation)).
+					Return([]*Filter{}).
+					Once()
+
+				m.Pool.
+					On("StopToMonitorNewPreconfirmations").
+					Once()
 			},
 		},
 		{
@@ -5290,6 +5897,273 @@ func TestSubscribeNewLogs(t *testing.T) {
 	}
 }
 
+func TestSubscribeZKEVMAndPendingTransactions(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	type testCase struct {
+		Name          string
+		Args          []interface{}
+		ExpectedError interface{}
+		SetupMocks    func(m *mocksWrapper, tc testCase)
+	}
+
+	testCases := []testCase{
+		{
+			Name: "Subscribe to new pending transaction hashes successfully",
+			Args: []interface{}{"newPendingTransactions"},
+			SetupMocks: func(m *mocksWrapper, tc testCase) {
+				m.Storage.
+					On("NewSubscriptionFilter", mock.IsType(&concurrentWsConn{}), FilterType(FilterTypePendingTx), PendingTxFilter{FullTx: false}).
+					Return("0x1", nil).
+					Once()
+
+				m.Pool.
+					On("StartToMonitorNewTxs").
+					Once()
+			},
+		},
+		{
+			Name: "Subscribe to new full pending transactions successfully",
+			Args: []interface{}{"newPendingTransactions", true},
+			SetupMocks: func(m *mocksWrapper, tc testCase) {
+				m.Storage.
+					On("NewSubscriptionFilter", mock.IsType(&concurrentWsConn{}), FilterType(FilterTypePendingTx), PendingTxFilter{FullTx: true}).
+					Return("0x1", nil).
+					Once()
+
+				m.Pool.
+					On("StartToMonitorNewTxs").
+					Once()
+			},
+		},
+		{
+			Name: "Subscribe to new virtualized batches successfully",
+			Args: []interface{}{"newVirtualizedBatches"},
+			SetupMocks: func(m *mocksWrapper, tc testCase) {
+				m.Storage.
+					On("NewSubscriptionFilter", mock.IsType(&concurrentWsConn{}), FilterType(FilterTypeVirtualizedBatch), nil).
+					Return("0x1", nil).
+					Once()
+			},
+		},
+		{
+			Name: "Subscribe to new verified batches successfully",
+			Args: []interface{}{"newVerifiedBatches"},
+			SetupMocks: func(m *mocksWrapper, tc testCase) {
+				m.Storage.
+					On("NewSubscriptionFilter", mock.IsType(&concurrentWsConn{}), FilterType(FilterTypeVerifiedBatch), nil).
+					Return("0x1", nil).
+					Once()
+			},
+		},
+		{
+			Name: "Subscribe to new l1 info tree leaves successfully",
+			Args: []interface{}{"newL1InfoTreeLeaves"},
+			SetupMocks: func(m *mocksWrapper, tc testCase) {
+				m.Storage.
+					On("NewSubscriptionFilter", mock.IsType(&concurrentWsConn{}), FilterType(FilterTypeL1InfoTreeLeaf), nil).
+					Return("0x1", nil).
+					Once()
+			},
+		},
+		{
+			Name: "Subscribe to new preconfirmations successfully",
+			Args: []interface{}{"newPreconfirmations"},
+			SetupMocks: func(m *mocksWrapper, tc testCase) {
+				m.Storage.
+					On("NewSubscriptionFilter", mock.IsType(&concurrentWsConn{}), FilterType(FilterTypePreconfirmation), nil).
+					Return("0x1", nil).
+					Once()
+
+				m.Pool.
+					On("StartToMonitorNewPreconfirmations").
+					Once()
+			},
+		},
+		{
+			Name:          "Subscribe to new pending transactions with invalid full transactions flag",
+			Args:          []interface{}{"newPendingTransactions", "yes"},
+			ExpectedError: types.NewRPCError(types.InvalidParamsErrorCode, "invalid full transactions flag"),
+			SetupMocks:    func(m *mocksWrapper, tc testCase) {},
+		},
+		{
+			Name:          "Subscribe fails to add filter to storage",
+			Args:          []interface{}{"newVirtualizedBatches"},
+			ExpectedError: types.NewRPCError(types.DefaultErrorCode, "failed to create new subscription"),
+			SetupMocks: func(m *mocksWrapper, tc testCase) {
+				m.Storage.
+					On("NewSubscriptionFilter", mock.IsType(&concurrentWsConn{}), FilterType(FilterTypeVirtualizedBatch), nil).
+					Return("", fmt.Errorf("failed to add filter to storage")).
+					Once()
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			tc := testCase
+			tc.SetupMocks(m, tc)
+
+			c := s.GetWSClient()
+
+			ctx := context.Background()
+			ch := make(chan json.RawMessage, 100)
+			sub, err := c.Client().EthSubscribe(ctx, ch, tc.Args...)
+
+			if tc.ExpectedError == nil {
+				require.NoError(t, err)
+				assert.NotNil(t, sub)
+			} else {
+				require.Error(t, err)
+				expectedErr := tc.ExpectedError.(*types.RPCError)
+				rpcErr := err.(rpc.Error)
+				assert.Equal(t, expectedErr.ErrorCode(), rpcErr.ErrorCode())
+				assert.Equal(t, expectedErr.Error(), rpcErr.Error())
+			}
+		})
+	}
+}
+
+func newTestSubscriptionFilter(filterType FilterType, parameters interface{}) *Filter {
+	return &Filter{
+		ID:            "0x1",
+		Type:          filterType,
+		Parameters:    parameters,
+		wsQueue:       state.NewQueue[[]byte](),
+		wsQueueSignal: sync.NewCond(&sync.Mutex{}),
+	}
+}
+
+func popSubscriptionData(t *testing.T, f *Filter) []string {
+	data := []string{}
+	for {
+		d, err := f.wsQueue.Pop()
+		if errors.Is(err, state.ErrQueueEmpty) {
+			return data
+		}
+		require.NoError(t, err)
+		data = append(data, string(d))
+	}
+}
+
+func TestOnNewTxs(t *testing.T) {
+	storage := newStorageMock(t)
+	poolMock := mocks.NewPoolMock(t)
+	e := &EthEndpoints{storage: storage, pool: poolMock}
+
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	auth, err := bind.NewKeyedTransactorWithChainID(privateKey, big.NewInt(0).SetUint64(chainID))
+	require.NoError(t, err)
+	to := common.HexToAddress("0x2")
+	tx, err := auth.Signer(auth.From, ethTypes.NewTransaction(1, to, big.NewInt(2), 21000, big.NewInt(3), nil))
+	require.NoError(t, err)
+
+	hashesFilter := newTestSubscriptionFilter(FilterTypePendingTx, PendingTxFilter{FullTx: false})
+	fullTxsFilter := newTestSubscriptionFilter(FilterTypePendingTx, PendingTxFilter{FullTx: true})
+	storage.On("GetAllPendingTxFiltersWithWSConn").Return([]*Filter{hashesFilter, fullTxsFilter}).Once()
+
+	e.onNewTxs(pool.NewTxsEvent{Txs: []pool.Transaction{{Transaction: *tx}}})
+
+	assert.Equal(t, []string{fmt.Sprintf("%q", tx.Hash().String())}, popSubscriptionData(t, hashesFilter))
+
+	fullTxs := popSubscriptionData(t, fullTxsFilter)
+	require.Len(t, fullTxs, 1)
+	var rpcTx types.Transaction
+	require.NoError(t, json.Unmarshal([]byte(fullTxs[0]), &rpcTx))
+	assert.Equal(t, tx.Hash(), rpcTx.Hash)
+	assert.Equal(t, auth.From, rpcTx.From)
+	assert.Equal(t, to, *rpcTx.To)
+	assert.Nil(t, rpcTx.BlockNumber)
+
+	// the pool monitor is stopped when there are no subscriptions left
+	storage.On("GetAllPendingTxFiltersWithWSConn").Return([]*Filter{}).Twice()
+	poolMock.On("StopToMonitorNewTxs").Once()
+	e.onNewTxs(pool.NewTxsEvent{Txs: []pool.Transaction{{Transaction: *tx}}})
+}
+
+func TestOnNewPreconfirmationsWithoutSubscriptions(t *testing.T) {
+	storage := newStorageMock(t)
+	poolMock := mocks.NewPoolMock(t)
+	e := &EthEndpoints{storage: storage, pool: poolMock}
+
+	// the pool monitor is stopped when there are no subscriptions left
+	storage.On("GetAllZKEVMFiltersWithWSConn", FilterType(FilterTypePreconfirmation)).Return([]*Filter{}).Twice()
+	poolMock.On("StopToMonitorNewPreconfirmations").Once()
+	e.onNewPreconfirmations(pool.NewPreconfirmationsEvent{Preconfirmations: []pool.Preconfirmation{{ID: 1}}})
+}
+
+func TestOnZKEVMEvent(t *testing.T) {
+	l1InfoRoot := common.HexToHash("0x3")
+	timestamp := time.Unix(1700000000, 0)
+
+	testCases := []struct {
+		name         string
+		event        state.ZKEVMEvent
+		filterType   FilterType
+		expectedData string
+	}{
+		{
+			name: "virtualized batch",
+			event: state.ZKEVMEvent{
+				Type:         state.ZKEVMEventTypeVirtualizedBatch,
+				VirtualBatch: &state.VirtualBatch{BatchNumber: 10, TxHash: common.HexToHash("0x1"), BlockNumber: 100, Coinbase: common.HexToAddress("0x2"), L1InfoRoot: &l1InfoRoot},
+			},
+			filterType:   FilterTypeVirtualizedBatch,
+			expectedData: `{"number":"0xa","sendSequencesTxHash":"0x0000000000000000000000000000000000000000000000000000000000000001","l1BlockNumber":"0x64","coinbase":"0x0000000000000000000000000000000000000002","l1InfoRoot":"0x0000000000000000000000000000000000000000000000000000000000000003"}`,
+		},
+		{
+			name: "verified batch",
+			event: state.ZKEVMEvent{
+				Type:          state.ZKEVMEventTypeVerifiedBatch,
+				VerifiedBatch: &state.VerifiedBatch{BatchNumber: 9, TxHash: common.HexToHash("0x1"), BlockNumber: 101, Aggregator: common.HexToAddress("0x2")},
+			},
+			filterType:   FilterTypeVerifiedBatch,
+			expectedData: `{"number":"0x9","verifyBatchTxHash":"0x0000000000000000000000000000000000000000000000000000000000000001","l1BlockNumber":"0x65","aggregator":"0x0000000000000000000000000000000000000002"}`,
+		},
+		{
+			name: "l1 info tree leaf",
+			event: state.ZKEVMEvent{
+				Type: state.ZKEVMEventTypeL1InfoTreeLeaf,
+				L1InfoTreeLeaf: &state.L1InfoTreeExitRootStorageEntry{
+					L1InfoTreeLeaf: state.L1InfoTreeLeaf{
+						GlobalExitRoot: state.GlobalExitRoot{
+							BlockNumber:     102,
+							Timestamp:       timestamp,
+							MainnetExitRoot: common.HexToHash("0x4"),
+							RollupExitRoot:  common.HexToHash("0x5"),
+							GlobalExitRoot:  common.HexToHash("0x6"),
+						},
+						PreviousBlockHash: common.HexToHash("0x7"),
+					},
+					L1InfoTreeRoot:  l1InfoRoot,
+					L1InfoTreeIndex: 5,
+				},
+			},
+			filterType:   FilterTypeL1InfoTreeLeaf,
+			expectedData: `{"index":"0x5","l1InfoTreeRoot":"0x0000000000000000000000000000000000000000000000000000000000000003","globalExitRoot":"0x0000000000000000000000000000000000000000000000000000000000000006","mainnetExitRoot":"0x0000000000000000000000000000000000000000000000000000000000000004","rollupExitRoot":"0x0000000000000000000000000000000000000000000000000000000000000005","previousBlockHash":"0x0000000000000000000000000000000000000000000000000000000000000007","l1BlockNumber":"0x66","timestamp":"0x6553f100"}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			tc := testCase
+			storage := newStorageMock(t)
+			e := &EthEndpoints{storage: storage}
+
+			filter := newTestSubscriptionFilter(tc.filterType, nil)
+			storage.On("GetAllZKEVMFiltersWithWSConn", tc.filterType).Return([]*Filter{filter}).Once()
+
+			e.onZKEVMEvent(tc.event)
+
+			data := popSubscriptionData(t, filter)
+			require.Len(t, data, 1)
+			assert.JSONEq(t, tc.expectedData, data[0])
+		})
+	}
+}
+
 func TestFilterLogs(t *testing.T) {
 	logs := []*ethTypes.Log{{
 		Address: common.HexToAddress("0x1"),
@@ -0,0 +1,283 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/0xPolygonHermez/zkevm-node/state/runtime"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jackc/pgx/v4"
+)
+
+const (
+	// flatCallTracer is the native tracer used to produce the parity style traces
+	flatCallTracer = "flatCallTracer"
+
+	// traceTypeTrace is the only trace type supported by trace_replayBlockTransactions
+	traceTypeTrace = "trace"
+)
+
+// flatCallTracerConfig makes the flat call tracer report the errors the way parity does
+var flatCallTracerConfig = json.RawMessage(`{"convertParityErrors":true}`)
+
+// TraceEndpoints is the trace jsonrpc endpoint, compatible with the
+// parity/openethereum trace namespace
+type TraceEndpoints struct {
+	cfg      Config
+	state    types.StateInterface
+	etherman types.EthermanInterface
+	txMan    DBTxManager
+}
+
+// NewTraceEndpoints returns TraceEndpoints
+func NewTraceEndpoints(cfg Config, state types.StateInterface, etherman types.EthermanInterface) *TraceEndpoints {
+	return &TraceEndpoints{
+		cfg:      cfg,
+		state:    state,
+		etherman: etherman,
+	}
+}
+
+// traceFilter is the filter used by trace_filter to select the traces
+// to be returned, the traces must match all the provided criteria
+type traceFilter struct {
+	FromBlock   *types.BlockNumber `json:"fromBlock"`
+	ToBlock     *types.BlockNumber `json:"toBlock"`
+	FromAddress []common.Address   `json:"fromAddress"`
+	ToAddress   []common.Address   `json:"toAddress"`
+	After       *uint64            `json:"after"`
+	Count       *uint64            `json:"count"`
+}
+
+// flatTraceAddresses contains the fields of a flat trace used to filter it
+type flatTraceAddresses struct {
+	Action struct {
+		From *common.Address `json:"from"`
+		To   *common.Address `json:"to"`
+	} `json:"action"`
+	Result *struct {
+		Address *common.Address `json:"address"`
+	} `json:"result"`
+}
+
+type traceReplayTransactionResponse struct {
+	Output          types.ArgBytes    `json:"output"`
+	StateDiff       interface{}       `json:"stateDiff"`
+	Trace           []json.RawMessage `json:"trace"`
+	VMTrace         interface{}       `json:"vmTrace"`
+	TransactionHash common.Hash       `json:"transactionHash"`
+}
+
+// Transaction creates a response for trace_transaction request.
+// See https://openethereum.github.io/JSONRPC-trace-module#trace_transaction
+func (t *TraceEndpoints) Transaction(hash types.ArgHash) (interface{}, types.Error) {
+	return t.txMan.NewDbTxScope(t.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+		_, traces, err := t.traceTransaction(ctx, hash.Hash(), dbTx)
+		if errors.Is(err, state.ErrNotFound) {
+			return nil, nil
+		} else if err != nil {
+			return nil, newTraceTransactionError(hash.Hash(), err)
+		}
+
+		return traces, nil
+	})
+}
+
+// Block creates a response for trace_block request.
+// See https://openethereum.github.io/JSONRPC-trace-module#trace_block
+func (t *TraceEndpoints) Block(number types.BlockNumber) (interface{}, types.Error) {
+	return t.txMan.NewDbTxScope(t.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+		blockNumber, rpcErr := number.GetNumericBlockNumber(ctx, t.state, t.etherman, dbTx)
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+
+		block, err := t.state.GetL2BlockByNumber(ctx, blockNumber, dbTx)
+		if errors.Is(err, state.ErrNotFound) {
+			return nil, nil
+		} else if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, "failed to get block by number", err, true)
+		}
+
+		traces := []json.RawMessage{}
+		for _, tx := range block.Transactions() {
+			_, txTraces, err := t.traceTransaction(ctx, tx.Hash(), dbTx)
+			if err != nil {
+				return nil, newTraceTransactionError(tx.Hash(), err)
+			}
+			traces = append(traces, txTraces...)
+		}
+
+		return traces, nil
+	})
+}
+
+// Filter creates a response for trace_filter request, the block range
+// is limited by the same limit used to filter logs.
+// See https://openethereum.github.io/JSONRPC-trace-module#trace_filter
+func (t *TraceEndpoints) Filter(filter traceFilter) (interface{}, types.Error) {
+	return t.txMan.NewDbTxScope(t.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+		fromBlock, toBlock, rpcErr := getNumericBlockNumbers(ctx, t.state, t.etherman, filter.FromBlock, filter.ToBlock, t.cfg.MaxTracesBlockRange, state.ErrMaxTracesBlockRangeLimitExceeded, dbTx)
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+
+		var after uint64
+		if filter.After != nil {
+			after = *filter.After
+		}
+
+		traces := []json.RawMessage{}
+		for blockNumber := fromBlock; blockNumber <= toBlock; blockNumber++ {
+			block, err := t.state.GetL2BlockByNumber(ctx, blockNumber, dbTx)
+			if errors.Is(err, state.ErrNotFound) {
+				break
+			} else if err != nil {
+				return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("failed to get block #%d", blockNumber), err, true)
+			}
+
+			for _, tx := range block.Transactions() {
+				_, txTraces, err := t.traceTransaction(ctx, tx.Hash(), dbTx)
+				if err != nil {
+					return nil, newTraceTransactionError(tx.Hash(), err)
+				}
+
+				for _, trace := range txTraces {
+					match, err := filter.match(trace)
+					if err != nil {
+						return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("failed to filter traces of transaction %v", tx.Hash().String()), err, true)
+					}
+					if !match {
+						continue
+					}
+					if after > 0 {
+						after--
+						continue
+					}
+					traces = append(traces, trace)
+					if filter.Count != nil && uint64(len(traces)) >= *filter.Count {
+						return traces, nil
+					}
+				}
+			}
+		}
+
+		return traces, nil
+	})
+}
+
+// ReplayBlockTransactions creates a response for trace_replayBlockTransactions request,
+// only the "trace" trace type is supported.
+// See https://openethereum.github.io/JSONRPC-trace-module#trace_replayblocktransactions
+func (t *TraceEndpoints) ReplayBlockTransactions(number types.BlockNumber, traceTypes []string) (interface{}, types.Error) {
+	return t.txMan.NewDbTxScope(t.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+		includeTrace := false
+		for _, traceType := range traceTypes {
+			if traceType != traceTypeTrace {
+				return RPCErrorResponse(types.InvalidParamsErrorCode, fmt.Sprintf("trace type %v is not supported", traceType), nil, false)
+			}
+			includeTrace = true
+		}
+
+		blockNumber, rpcErr := number.GetNumericBlockNumber(ctx, t.state, t.etherman, dbTx)
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+
+		block, err := t.state.GetL2BlockByNumber(ctx, blockNumber, dbTx)
+		if errors.Is(err, state.ErrNotFound) {
+			return nil, types.NewRPCError(types.DefaultErrorCode, fmt.Sprintf("block #%d not found", blockNumber))
+		} else if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, "failed to get block by number", err, true)
+		}
+
+		responses := make([]traceReplayTransactionResponse, 0, len(block.Transactions()))
+		for _, tx := range block.Transactions() {
+			result, txTraces, err := t.traceTransaction(ctx, tx.Hash(), dbTx)
+			if err != nil {
+				return nil, newTraceTransactionError(tx.Hash(), err)
+			}
+
+			response := traceReplayTransactionResponse{
+				Output:          result.ReturnValue,
+				TransactionHash: tx.Hash(),
+			}
+			if includeTrace {
+				response.Trace = txTraces
+			}
+			responses = append(responses, response)
+		}
+
+		return responses, nil
+	})
+}
+
+// traceTransaction executes the flat call tracer for the provided tx and
+// returns the execution result and the flat traces
+func (t *TraceEndpoints) traceTransaction(ctx context.Context, hash common.Hash, dbTx pgx.Tx) (*runtime.ExecutionResult, []json.RawMessage, error) {
+	tracer := flatCallTracer
+	traceCfg := state.TraceConfig{
+		Tracer:       &tracer,
+		TracerConfig: flatCallTracerConfig,
+	}
+
+	result, err := t.state.DebugTransaction(ctx, hash, traceCfg, dbTx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var traces []json.RawMessage
+	if err := json.Unmarshal(result.TraceResult, &traces); err != nil {
+		return nil, nil, fmt.Errorf("failed to read flat call traces: %w", err)
+	}
+
+	return result, traces, nil
+}
+
+func newTraceTransactionError(hash common.Hash, err error) types.Error {
+	if errors.Is(err, state.ErrNotFound) {
+		return types.NewRPCError(types.DefaultErrorCode, fmt.Sprintf("transaction %v not found", hash.String()))
+	}
+	return types.NewRPCError(types.DefaultErrorCode, fmt.Sprintf("failed to get trace for transaction %v: %v", hash.String(), err.Error()))
+}
+
+// match checks if the provided flat trace matches the filter addresses
+func (f *traceFilter) match(trace json.RawMessage) (bool, error) {
+	if len(f.FromAddress) == 0 && len(f.ToAddress) == 0 {
+		return true, nil
+	}
+
+	var addresses flatTraceAddresses
+	if err := json.Unmarshal(trace, &addresses); err != nil {
+		return false, err
+	}
+
+	to := addresses.Action.To
+	if to == nil && addresses.Result != nil {
+		// contract creations don't have a "to" address in the action,
+		// the created contract address is in the result
+		to = addresses.Result.Address
+	}
+
+	return containsAddress(f.FromAddress, addresses.Action.From) && containsAddress(f.ToAddress, to), nil
+}
+
+// containsAddress returns true if the list is empty or contains the address
+func containsAddress(list []common.Address, address *common.Address) bool {
+	if len(list) == 0 {
+		return true
+	}
+	if address == nil {
+		return false
+	}
+	for _, a := range list {
+		if a == *address {
+			return true
+		}
+	}
+	return false
+}
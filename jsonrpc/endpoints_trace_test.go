@@ -0,0 +1,186 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/0xPolygonHermez/zkevm-node/state/runtime"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTraceEndpoints(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	type testCase struct {
+		name           string
+		method         string
+		params         []interface{}
+		expectedResult string
+		expectedError  types.Error
+		setupMocks     func(m *mocksWrapper)
+	}
+
+	a := common.HexToAddress("0xa")
+	b := common.HexToAddress("0xb")
+	c := common.HexToAddress("0xc")
+	d := common.HexToAddress("0xd")
+
+	callTx := ethTypes.NewTransaction(1, b, big.NewInt(1), 100000, big.NewInt(1), nil)
+	createTx := ethTypes.NewContractCreation(2, big.NewInt(0), 100000, big.NewInt(1), []byte{0x60})
+	block := state.NewL2Block(state.NewL2Header(&ethTypes.Header{Number: blockNumOne}), []*ethTypes.Transaction{callTx, createTx}, nil, []*ethTypes.Receipt{{}, {}}, trie.NewStackTrie(nil))
+
+	callTrace := fmt.Sprintf(`{"action":{"callType":"call","from":"%v","to":"%v"},"type":"call","traceAddress":[]}`, a.Hex(), b.Hex())
+	subCallTrace := fmt.Sprintf(`{"action":{"callType":"call","from":"%v","to":"%v"},"type":"call","traceAddress":[0]}`, b.Hex(), c.Hex())
+	createTrace := fmt.Sprintf(`{"action":{"from":"%v"},"result":{"address":"%v"},"type":"create","traceAddress":[]}`, a.Hex(), d.Hex())
+
+	flatCallTracerMatchBy := mock.MatchedBy(func(cfg state.TraceConfig) bool {
+		return cfg.Tracer != nil && *cfg.Tracer == flatCallTracer && string(cfg.TracerConfig) == `{"convertParityErrors":true}`
+	})
+
+	setupBlockMocks := func(m *mocksWrapper) {
+		m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+		m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+		m.State.On("GetL2BlockByNumber", context.Background(), blockNumOneUint64, m.DbTx).Return(block, nil).Once()
+		m.State.
+			On("DebugTransaction", context.Background(), callTx.Hash(), flatCallTracerMatchBy, m.DbTx).
+			Return(&runtime.ExecutionResult{ReturnValue: []byte{0x1}, TraceResult: json.RawMessage("[" + callTrace + "," + subCallTrace + "]")}, nil).
+			Once()
+		m.State.
+			On("DebugTransaction", context.Background(), createTx.Hash(), flatCallTracerMatchBy, m.DbTx).
+			Return(&runtime.ExecutionResult{TraceResult: json.RawMessage("[" + createTrace + "]")}, nil).
+			Once()
+	}
+
+	testCases := []testCase{
+		{
+			name:           "trace_transaction",
+			method:         "trace_transaction",
+			params:         []interface{}{callTx.Hash()},
+			expectedResult: "[" + callTrace + "," + subCallTrace + "]",
+			setupMocks: func(m *mocksWrapper) {
+				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.
+					On("DebugTransaction", context.Background(), callTx.Hash(), flatCallTracerMatchBy, m.DbTx).
+					Return(&runtime.ExecutionResult{TraceResult: json.RawMessage("[" + callTrace + "," + subCallTrace + "]")}, nil).
+					Once()
+			},
+		},
+		{
+			name:           "trace_transaction tx not found",
+			method:         "trace_transaction",
+			params:         []interface{}{callTx.Hash()},
+			expectedResult: "null",
+			setupMocks: func(m *mocksWrapper) {
+				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.
+					On("DebugTransaction", context.Background(), callTx.Hash(), flatCallTracerMatchBy, m.DbTx).
+					Return(nil, state.ErrNotFound).
+					Once()
+			},
+		},
+		{
+			name:          "trace_transaction fails to trace",
+			method:        "trace_transaction",
+			params:        []interface{}{callTx.Hash()},
+			expectedError: types.NewRPCError(types.DefaultErrorCode, fmt.Sprintf("failed to get trace for transaction %v: executor failed", callTx.Hash().String())),
+			setupMocks: func(m *mocksWrapper) {
+				m.DbTx.On("Rollback", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.
+					On("DebugTransaction", context.Background(), callTx.Hash(), flatCallTracerMatchBy, m.DbTx).
+					Return(nil, errors.New("executor failed")).
+					Once()
+			},
+		},
+		{
+			name:           "trace_block",
+			method:         "trace_block",
+			params:         []interface{}{"0x1"},
+			expectedResult: "[" + callTrace + "," + subCallTrace + "," + createTrace + "]",
+			setupMocks:     setupBlockMocks,
+		},
+		{
+			name:           "trace_filter by from and to address",
+			method:         "trace_filter",
+			params:         []interface{}{map[string]interface{}{"fromBlock": "0x1", "toBlock": "0x1", "fromAddress": []common.Address{a}, "toAddress": []common.Address{b, d}}},
+			expectedResult: "[" + callTrace + "," + createTrace + "]",
+			setupMocks:     setupBlockMocks,
+		},
+		{
+			name:           "trace_filter with after and count",
+			method:         "trace_filter",
+			params:         []interface{}{map[string]interface{}{"fromBlock": "0x1", "toBlock": "0x1", "after": 1, "count": 1}},
+			expectedResult: "[" + subCallTrace + "]",
+			setupMocks: func(m *mocksWrapper) {
+				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.On("GetL2BlockByNumber", context.Background(), blockNumOneUint64, m.DbTx).Return(block, nil).Once()
+				m.State.
+					On("DebugTransaction", context.Background(), callTx.Hash(), flatCallTracerMatchBy, m.DbTx).
+					Return(&runtime.ExecutionResult{TraceResult: json.RawMessage("[" + callTrace + "," + subCallTrace + "]")}, nil).
+					Once()
+			},
+		},
+		{
+			name:          "trace_filter block range bigger than the limit",
+			method:        "trace_filter",
+			params:        []interface{}{map[string]interface{}{"fromBlock": "0x1", "toBlock": "0x66"}},
+			expectedError: types.NewRPCError(types.InvalidParamsErrorCode, "traces are limited to a 100 block range"),
+			setupMocks: func(m *mocksWrapper) {
+				m.DbTx.On("Rollback", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+			},
+		},
+		{
+			name:   "trace_replayBlockTransactions",
+			method: "trace_replayBlockTransactions",
+			params: []interface{}{"0x1", []string{"trace"}},
+			expectedResult: fmt.Sprintf(`[{"output":"0x01","stateDiff":null,"trace":[%v,%v],"vmTrace":null,"transactionHash":"%v"},{"output":"0x","stateDiff":null,"trace":[%v],"vmTrace":null,"transactionHash":"%v"}]`,
+				callTrace, subCallTrace, callTx.Hash().String(), createTrace, createTx.Hash().String()),
+			setupMocks: setupBlockMocks,
+		},
+		{
+			name:          "trace_replayBlockTransactions unsupported trace type",
+			method:        "trace_replayBlockTransactions",
+			params:        []interface{}{"0x1", []string{"trace", "vmTrace"}},
+			expectedError: types.NewRPCError(types.InvalidParamsErrorCode, "trace type vmTrace is not supported"),
+			setupMocks: func(m *mocksWrapper) {
+				m.DbTx.On("Rollback", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			tc := testCase
+			tc.setupMocks(m)
+
+			res, err := s.JSONRPCCall(tc.method, tc.params...)
+			require.NoError(t, err)
+
+			if tc.expectedError == nil {
+				require.Nil(t, res.Error)
+				assert.JSONEq(t, tc.expectedResult, string(res.Result))
+			} else {
+				require.NotNil(t, res.Error)
+				assert.Equal(t, tc.expectedError.ErrorCode(), res.Error.Code)
+				assert.Equal(t, tc.expectedError.Error(), res.Error.Message)
+			}
+		})
+	}
+}
@@ -1,18 +1,54 @@
 package jsonrpc
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/0xPolygonHermez/zkevm-node/state"
 	"github.com/ethereum/go-ethereum/common"
 )
 
+const (
+	// defaultTxPoolSendersLimit is the number of senders returned by
+	// txpool_content and txpool_inspect when the limit is not provided
+	defaultTxPoolSendersLimit = 100
+	// maxTxPoolSendersLimit is the max number of senders that can be
+	// requested to txpool_content and txpool_inspect in a single call
+	maxTxPoolSendersLimit = 1000
+)
+
 // TxPoolEndpoints is the txpool jsonrpc endpoint
-type TxPoolEndpoints struct{}
+type TxPoolEndpoints struct {
+	pool types.PoolInterface
+}
+
+// NewTxPoolEndpoints returns TxPoolEndpoints
+func NewTxPoolEndpoints(p types.PoolInterface) *TxPoolEndpoints {
+	return &TxPoolEndpoints{pool: p}
+}
 
 type contentResponse struct {
 	Pending map[common.Address]map[uint64]*txPoolTransaction `json:"pending"`
 	Queued  map[common.Address]map[uint64]*txPoolTransaction `json:"queued"`
 }
 
+type contentFromResponse struct {
+	Pending map[uint64]*txPoolTransaction `json:"pending"`
+	Queued  map[uint64]*txPoolTransaction `json:"queued"`
+}
+
+type statusResponse struct {
+	Pending types.ArgUint64 `json:"pending"`
+	Queued  types.ArgUint64 `json:"queued"`
+}
+
+type inspectResponse struct {
+	Pending map[common.Address]map[uint64]string `json:"pending"`
+	Queued  map[common.Address]map[uint64]string `json:"queued"`
+}
+
 type txPoolTransaction struct {
 	Nonce       types.ArgUint64 `json:"nonce"`
 	GasPrice    types.ArgBig    `json:"gasPrice"`
@@ -27,13 +63,140 @@ type txPoolTransaction struct {
 	TxIndex     interface{}     `json:"transactionIndex"`
 }
 
+func newTxPoolTransaction(tx pool.Transaction, from common.Address) *txPoolTransaction {
+	return &txPoolTransaction{
+		Nonce:    types.ArgUint64(tx.Nonce()),
+		GasPrice: types.ArgBig(*state.TxGasPrice(&tx.Transaction)),
+		Gas:      types.ArgUint64(tx.Gas()),
+		To:       tx.To(),
+		Value:    types.ArgBig(*tx.Value()),
+		Input:    tx.Data(),
+		Hash:     tx.Hash(),
+		From:     from,
+	}
+}
+
 // Content creates a response for txpool_content request.
 // See https://geth.ethereum.org/docs/rpc/ns-txpool#txpool_content.
-func (e *TxPoolEndpoints) Content() (interface{}, types.Error) {
+// The optional offset and limit params allow to paginate the response
+// by sender, when the limit is not provided the first 100 senders are returned.
+func (e *TxPoolEndpoints) Content(offset, limit *types.ArgUint64) (interface{}, types.Error) {
+	content, rpcErr := e.getContent(offset, limit)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
 	resp := contentResponse{
 		Pending: make(map[common.Address]map[uint64]*txPoolTransaction),
 		Queued:  make(map[common.Address]map[uint64]*txPoolTransaction),
 	}
+	for from, txs := range content.Pending {
+		resp.Pending[from] = make(map[uint64]*txPoolTransaction, len(txs))
+		for _, tx := range txs {
+			resp.Pending[from][tx.Nonce()] = newTxPoolTransaction(tx, from)
+		}
+	}
+	for from, txs := range content.Queued {
+		resp.Queued[from] = make(map[uint64]*txPoolTransaction, len(txs))
+		for _, tx := range txs {
+			resp.Queued[from][tx.Nonce()] = newTxPoolTransaction(tx, from)
+		}
+	}
 
 	return resp, nil
 }
+
+// ContentFrom creates a response for txpool_contentFrom request.
+// See https://geth.ethereum.org/docs/rpc/ns-txpool#txpool_contentfrom.
+func (e *TxPoolEndpoints) ContentFrom(address types.ArgAddress) (interface{}, types.Error) {
+	from := address.Address()
+	content, err := e.pool.GetContentFrom(context.Background(), from)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to get pool content", err, true)
+	}
+
+	resp := contentFromResponse{
+		Pending: make(map[uint64]*txPoolTransaction),
+		Queued:  make(map[uint64]*txPoolTransaction),
+	}
+	for _, tx := range content.Pending[from] {
+		resp.Pending[tx.Nonce()] = newTxPoolTransaction(tx, from)
+	}
+	for _, tx := range content.Queued[from] {
+		resp.Queued[tx.Nonce()] = newTxPoolTransaction(tx, from)
+	}
+
+	return resp, nil
+}
+
+// Status creates a response for txpool_status request.
+// See https://geth.ethereum.org/docs/rpc/ns-txpool#txpool_status.
+func (e *TxPoolEndpoints) Status() (interface{}, types.Error) {
+	status, err := e.pool.GetStatus(context.Background())
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to get pool status", err, true)
+	}
+
+	return statusResponse{
+		Pending: types.ArgUint64(status.Pending),
+		Queued:  types.ArgUint64(status.Queued),
+	}, nil
+}
+
+// Inspect creates a response for txpool_inspect request.
+// See https://geth.ethereum.org/docs/rpc/ns-txpool#txpool_inspect.
+// The optional offset and limit params allow to paginate the response
+// by sender, when the limit is not provided the first 100 senders are returned.
+func (e *TxPoolEndpoints) Inspect(offset, limit *types.ArgUint64) (interface{}, types.Error) {
+	content, rpcErr := e.getContent(offset, limit)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	resp := inspectResponse{
+		Pending: make(map[common.Address]map[uint64]string),
+		Queued:  make(map[common.Address]map[uint64]string),
+	}
+	for from, txs := range content.Pending {
+		resp.Pending[from] = make(map[uint64]string, len(txs))
+		for _, tx := range txs {
+			resp.Pending[from][tx.Nonce()] = inspectTx(tx)
+		}
+	}
+	for from, txs := range content.Queued {
+		resp.Queued[from] = make(map[uint64]string, len(txs))
+		for _, tx := range txs {
+			resp.Queued[from][tx.Nonce()] = inspectTx(tx)
+		}
+	}
+
+	return resp, nil
+}
+
+func (e *TxPoolEndpoints) getContent(offset, limit *types.ArgUint64) (*pool.Content, types.Error) {
+	senderOffset, senderLimit := uint64(0), uint64(defaultTxPoolSendersLimit)
+	if offset != nil {
+		senderOffset = uint64(*offset)
+	}
+	if limit != nil {
+		senderLimit = uint64(*limit)
+	}
+	if senderLimit == 0 || senderLimit > maxTxPoolSendersLimit {
+		return nil, types.NewRPCError(types.InvalidParamsErrorCode, fmt.Sprintf("limit must be between 1 and %v", maxTxPoolSendersLimit))
+	}
+
+	content, err := e.pool.GetContent(context.Background(), senderOffset, senderLimit)
+	if err != nil {
+		_, rpcErr := RPCErrorResponse(types.DefaultErrorCode, "failed to get pool content", err, true)
+		return nil, rpcErr
+	}
+	return content, nil
+}
+
+// inspectTx returns the textual summary of a tx in the same format used by geth
+func inspectTx(tx pool.Transaction) string {
+	if to := tx.To(); to != nil {
+		return fmt.Sprintf("%s: %v wei + %v gas × %v wei", to.Hex(), tx.Value(), tx.Gas(), state.TxGasPrice(&tx.Transaction))
+	}
+	return fmt.Sprintf("contract creation: %v wei + %v gas × %v wei", tx.Value(), tx.Gas(), state.TxGasPrice(&tx.Transaction))
+}
@@ -0,0 +1,229 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxPoolContent(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	from := common.HexToAddress("0x1")
+	to := common.HexToAddress("0x2")
+	pendingTx := pool.Transaction{Transaction: *ethTypes.NewTransaction(1, to, big.NewInt(10), 21000, big.NewInt(1), []byte{})}
+	queuedTx := pool.Transaction{Transaction: *ethTypes.NewTransaction(3, to, big.NewInt(20), 21000, big.NewInt(2), []byte{})}
+
+	type testCase struct {
+		Name          string
+		Params        []interface{}
+		ExpectedError types.Error
+		SetupMocks    func(m *mocksWrapper)
+	}
+
+	testCases := []testCase{
+		{
+			Name:   "get content successfully",
+			Params: []interface{}{},
+			SetupMocks: func(m *mocksWrapper) {
+				m.Pool.
+					On("GetContent", context.Background(), uint64(0), uint64(defaultTxPoolSendersLimit)).
+					Return(&pool.Content{
+						Pending: map[common.Address][]pool.Transaction{from: {pendingTx}},
+						Queued:  map[common.Address][]pool.Transaction{from: {queuedTx}},
+					}, nil).
+					Once()
+			},
+		},
+		{
+			Name:   "get paginated content successfully",
+			Params: []interface{}{"0x1", "0xa"},
+			SetupMocks: func(m *mocksWrapper) {
+				m.Pool.
+					On("GetContent", context.Background(), uint64(1), uint64(10)).
+					Return(&pool.Content{
+						Pending: map[common.Address][]pool.Transaction{from: {pendingTx}},
+						Queued:  map[common.Address][]pool.Transaction{from: {queuedTx}},
+					}, nil).
+					Once()
+			},
+		},
+		{
+			Name:          "limit above the max",
+			Params:        []interface{}{"0x0", "0x3e9"},
+			ExpectedError: types.NewRPCError(types.InvalidParamsErrorCode, "limit must be between 1 and 1000"),
+			SetupMocks:    func(m *mocksWrapper) {},
+		},
+		{
+			Name:          "zero limit",
+			Params:        []interface{}{"0x0", "0x0"},
+			ExpectedError: types.NewRPCError(types.InvalidParamsErrorCode, "limit must be between 1 and 1000"),
+			SetupMocks:    func(m *mocksWrapper) {},
+		},
+		{
+			Name:          "failed to get content",
+			Params:        []interface{}{},
+			ExpectedError: types.NewRPCError(types.DefaultErrorCode, "failed to get pool content"),
+			SetupMocks: func(m *mocksWrapper) {
+				m.Pool.
+					On("GetContent", context.Background(), uint64(0), uint64(defaultTxPoolSendersLimit)).
+					Return(nil, errors.New("failed to get content")).
+					Once()
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			tc := testCase
+			tc.SetupMocks(m)
+
+			res, err := s.JSONRPCCall("txpool_content", tc.Params...)
+			require.NoError(t, err)
+
+			if tc.ExpectedError != nil {
+				require.NotNil(t, res.Error)
+				assert.Equal(t, tc.ExpectedError.ErrorCode(), res.Error.Code)
+				assert.Equal(t, tc.ExpectedError.Error(), res.Error.Message)
+				return
+			}
+			require.Nil(t, res.Error)
+
+			var result contentResponse
+			err = json.Unmarshal(res.Result, &result)
+			require.NoError(t, err)
+
+			require.Len(t, result.Pending, 1)
+			require.Len(t, result.Queued, 1)
+			assert.Equal(t, pendingTx.Hash(), result.Pending[from][1].Hash)
+			assert.Equal(t, from, result.Pending[from][1].From)
+			assert.Equal(t, queuedTx.Hash(), result.Queued[from][3].Hash)
+			assert.Equal(t, uint64(3), uint64(result.Queued[from][3].Nonce))
+		})
+	}
+}
+
+func TestTxPoolContentFrom(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	from := common.HexToAddress("0x1")
+	to := common.HexToAddress("0x2")
+	pendingTx := pool.Transaction{Transaction: *ethTypes.NewTransaction(1, to, big.NewInt(10), 21000, big.NewInt(1), []byte{})}
+
+	m.Pool.
+		On("GetContentFrom", context.Background(), from).
+		Return(&pool.Content{
+			Pending: map[common.Address][]pool.Transaction{from: {pendingTx}},
+			Queued:  map[common.Address][]pool.Transaction{},
+		}, nil).
+		Once()
+
+	res, err := s.JSONRPCCall("txpool_contentFrom", from.String())
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+
+	var result contentFromResponse
+	err = json.Unmarshal(res.Result, &result)
+	require.NoError(t, err)
+
+	require.Len(t, result.Pending, 1)
+	assert.Len(t, result.Queued, 0)
+	assert.Equal(t, pendingTx.Hash(), result.Pending[1].Hash)
+}
+
+func TestTxPoolStatus(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	type testCase struct {
+		Name           string
+		ExpectedResult *statusResponse
+		ExpectedError  types.Error
+		SetupMocks     func(m *mocksWrapper)
+	}
+
+	testCases := []testCase{
+		{
+			Name:           "get status successfully",
+			ExpectedResult: &statusResponse{Pending: 5, Queued: 2},
+			SetupMocks: func(m *mocksWrapper) {
+				m.Pool.
+					On("GetStatus", context.Background()).
+					Return(&pool.Status{Pending: 5, Queued: 2}, nil).
+					Once()
+			},
+		},
+		{
+			Name:          "failed to get status",
+			ExpectedError: types.NewRPCError(types.DefaultErrorCode, "failed to get pool status"),
+			SetupMocks: func(m *mocksWrapper) {
+				m.Pool.
+					On("GetStatus", context.Background()).
+					Return(nil, errors.New("failed to get status")).
+					Once()
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			tc := testCase
+			tc.SetupMocks(m)
+
+			res, err := s.JSONRPCCall("txpool_status")
+			require.NoError(t, err)
+
+			if res.Result != nil {
+				var result statusResponse
+				err = json.Unmarshal(res.Result, &result)
+				require.NoError(t, err)
+				assert.Equal(t, *tc.ExpectedResult, result)
+			}
+
+			if res.Error != nil || tc.ExpectedError != nil {
+				assert.Equal(t, tc.ExpectedError.ErrorCode(), res.Error.Code)
+				assert.Equal(t, tc.ExpectedError.Error(), res.Error.Message)
+			}
+		})
+	}
+}
+
+func TestTxPoolInspect(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	from := common.HexToAddress("0x1")
+	to := common.HexToAddress("0x2")
+	pendingTx := pool.Transaction{Transaction: *ethTypes.NewTransaction(1, to, big.NewInt(10), 21000, big.NewInt(1), []byte{})}
+	deployTx := pool.Transaction{Transaction: *ethTypes.NewContractCreation(3, big.NewInt(0), 100000, big.NewInt(2), []byte{0x1})}
+
+	m.Pool.
+		On("GetContent", context.Background(), uint64(0), uint64(defaultTxPoolSendersLimit)).
+		Return(&pool.Content{
+			Pending: map[common.Address][]pool.Transaction{from: {pendingTx}},
+			Queued:  map[common.Address][]pool.Transaction{from: {deployTx}},
+		}, nil).
+		Once()
+
+	res, err := s.JSONRPCCall("txpool_inspect")
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+
+	var result inspectResponse
+	err = json.Unmarshal(res.Result, &result)
+	require.NoError(t, err)
+
+	assert.Equal(t, "0x0000000000000000000000000000000000000002: 10 wei + 21000 gas × 1 wei", result.Pending[from][1])
+	assert.Equal(t, "contract creation: 0 wei + 100000 gas × 2 wei", result.Queued[from][3])
+}
@@ -5,12 +5,14 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"math/big"
 	"time"
 
 	"github.com/0xPolygonHermez/zkevm-node/hex"
 	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/client"
 	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/l1infotree"
 	"github.com/0xPolygonHermez/zkevm-node/log"
 	"github.com/0xPolygonHermez/zkevm-node/pool"
 	"github.com/0xPolygonHermez/zkevm-node/state"
@@ -24,6 +26,7 @@ import (
 // ZKEVMEndpoints contains implementations for the "zkevm" RPC endpoints
 type ZKEVMEndpoints struct {
 	cfg      Config
+	chainID  uint64
 	pool     types.PoolInterface
 	state    types.StateInterface
 	etherman types.EthermanInterface
@@ -31,9 +34,10 @@ type ZKEVMEndpoints struct {
 }
 
 // NewZKEVMEndpoints returns ZKEVMEndpoints
-func NewZKEVMEndpoints(cfg Config, pool types.PoolInterface, state types.StateInterface, etherman types.EthermanInterface) *ZKEVMEndpoints {
+func NewZKEVMEndpoints(cfg Config, chainID uint64, pool types.PoolInterface, state types.StateInterface, etherman types.EthermanInterface) *ZKEVMEndpoints {
 	return &ZKEVMEndpoints{
 		cfg:      cfg,
+		chainID:  chainID,
 		pool:     pool,
 		state:    state,
 		etherman: etherman,
@@ -204,6 +208,40 @@ func (z *ZKEVMEndpoints) GetBatchByNumber(batchNumber types.BatchNumber, fullTx
 	})
 }
 
+// GetBatchL2Data returns the exact L2 data of a batch, as sequenced to L1, and
+// its decoded representation with the L2 blocks and their transactions
+func (z *ZKEVMEndpoints) GetBatchL2Data(batchNumber types.BatchNumber) (interface{}, types.Error) {
+	return z.txMan.NewDbTxScope(z.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+		batchNumber, rpcErr := batchNumber.GetNumericBatchNumber(ctx, z.state, z.etherman, dbTx)
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+
+		batch, err := z.state.GetBatchByNumber(ctx, batchNumber, dbTx)
+		if errors.Is(err, state.ErrNotFound) {
+			return nil, nil
+		} else if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't load batch from state by number %v", batchNumber), err, true)
+		}
+
+		var forcedBatch *state.ForcedBatch
+		if batch.ForcedBatchNum != nil {
+			forcedBatch, err = z.state.GetForcedBatch(ctx, *batch.ForcedBatchNum, dbTx)
+			if err != nil {
+				return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't load forced batch from state by number %v", *batch.ForcedBatchNum), err, true)
+			}
+		}
+
+		forkID := z.state.GetForkIDByBatchNumber(batchNumber)
+		batchL2Data, err := types.NewBatchL2Data(*batch, forkID, forcedBatch)
+		if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't decode the L2 data of the batch %v", batchNumber), err, true)
+		}
+
+		return batchL2Data, nil
+	})
+}
+
 // GetFullBlockByNumber returns information about a block by block number
 func (z *ZKEVMEndpoints) GetFullBlockByNumber(number types.BlockNumber, fullTx bool) (interface{}, types.Error) {
 	return z.txMan.NewDbTxScope(z.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
@@ -397,6 +435,41 @@ func (z *ZKEVMEndpoints) GetTransactionReceiptByL2Hash(hash types.ArgHash) (inte
 	})
 }
 
+// GetPreconfirmation returns the preconfirmation signed by the sequencer for a tx,
+// it returns nil if the sequencer has not preconfirmed the tx
+func (z *ZKEVMEndpoints) GetPreconfirmation(hash types.ArgHash) (interface{}, types.Error) {
+	if z.cfg.SequencerNodeURI != "" {
+		return z.getPreconfirmationFromSequencerNode(hash.Hash())
+	}
+
+	preconfirmation, err := z.pool.GetPreconfirmation(context.Background(), hash.Hash())
+	if errors.Is(err, pool.ErrNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to load preconfirmation from pool", err, true)
+	}
+
+	res, err := types.NewPreconfirmation(*preconfirmation, z.chainID)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to build preconfirmation response", err, true)
+	}
+
+	return res, nil
+}
+
+func (z *ZKEVMEndpoints) getPreconfirmationFromSequencerNode(hash common.Hash) (interface{}, types.Error) {
+	res, err := client.JSONRPCCall(z.cfg.SequencerNodeURI, "zkevm_getPreconfirmation", hash.String())
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to get preconfirmation from sequencer node", err, true)
+	}
+
+	if res.Error != nil {
+		return RPCErrorResponse(res.Error.Code, res.Error.Message, nil, false)
+	}
+
+	return res.Result, nil
+}
+
 func (z *ZKEVMEndpoints) getTransactionByL2HashFromSequencerNode(hash common.Hash) (interface{}, types.Error) {
 	res, err := client.JSONRPCCall(z.cfg.SequencerNodeURI, "zkevm_getTransactionByL2Hash", hash.String())
 	if err != nil {
@@ -434,6 +507,256 @@ func (z *ZKEVMEndpoints) GetExitRootsByGER(globalExitRoot common.Hash) (interfac
 	})
 }
 
+// GetBlocksByBatch returns the range of L2 blocks of the provided batch number
+func (z *ZKEVMEndpoints) GetBlocksByBatch(batchNumber types.BatchNumber) (interface{}, types.Error) {
+	return z.txMan.NewDbTxScope(z.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+		batchNumber, rpcErr := batchNumber.GetNumericBatchNumber(ctx, z.state, z.etherman, dbTx)
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+
+		_, err := z.state.GetBatchByNumber(ctx, batchNumber, dbTx)
+		if errors.Is(err, state.ErrNotFound) {
+			return nil, nil
+		} else if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't load batch from state by number %v", batchNumber), err, true)
+		}
+
+		res := types.BatchBlockRange{BatchNumber: types.ArgUint64(batchNumber)}
+		fromBlockNumber, toBlockNumber, err := z.state.GetL2BlockRangeByBatchNumber(ctx, batchNumber, dbTx)
+		if errors.Is(err, state.ErrNotFound) {
+			return res, nil
+		} else if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't load the l2 blocks of the batch %v", batchNumber), err, true)
+		}
+
+		res.FromBlock = types.ArgUint64Ptr(types.ArgUint64(fromBlockNumber))
+		res.ToBlock = types.ArgUint64Ptr(types.ArgUint64(toBlockNumber))
+		return res, nil
+	})
+}
+
+// GetBatchesByL1Block returns the batches sequenced and verified in the provided range of L1 blocks
+func (z *ZKEVMEndpoints) GetBatchesByL1Block(filter L1BlockRangeFilter) (interface{}, types.Error) {
+	return z.txMan.NewDbTxScope(z.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+		if rpcErr := filter.Validate(z.cfg); rpcErr != nil {
+			return nil, rpcErr
+		}
+
+		virtualBatches, err := z.state.GetVirtualBatchesByL1BlockRange(ctx, uint64(filter.FromBlock), uint64(filter.ToBlock), dbTx)
+		if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, "couldn't load virtual batches by l1 block range", err, true)
+		}
+
+		verifiedBatches, err := z.state.GetVerifiedBatchesByL1BlockRange(ctx, uint64(filter.FromBlock), uint64(filter.ToBlock), dbTx)
+		if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, "couldn't load verified batches by l1 block range", err, true)
+		}
+
+		res := types.BatchesByL1Block{
+			VirtualizedBatches: make([]types.VirtualizedBatch, 0, len(virtualBatches)),
+			VerifiedBatches:    make([]types.VerifiedBatch, 0, len(verifiedBatches)),
+		}
+		for _, virtualBatch := range virtualBatches {
+			res.VirtualizedBatches = append(res.VirtualizedBatches, types.NewVirtualizedBatch(virtualBatch))
+		}
+		for _, verifiedBatch := range verifiedBatches {
+			res.VerifiedBatches = append(res.VerifiedBatches, types.NewVerifiedBatch(verifiedBatch))
+		}
+
+		return res, nil
+	})
+}
+
+// GetL1InfoTreeLeafByIndex returns the leaf of the L1 info tree at the provided index
+func (z *ZKEVMEndpoints) GetL1InfoTreeLeafByIndex(index types.ArgUint64) (interface{}, types.Error) {
+	return z.txMan.NewDbTxScope(z.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+		lastIndex, err := z.state.GetLatestIndex(ctx, dbTx)
+		if errors.Is(err, state.ErrNotFound) {
+			return nil, nil
+		} else if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, "couldn't load the last l1 info tree index", err, true)
+		}
+		if uint64(index) > uint64(lastIndex) {
+			return nil, nil
+		}
+
+		leaf, err := z.state.GetL1InfoRootLeafByIndex(ctx, uint32(index), dbTx)
+		if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't load l1 info tree leaf by index %v", uint64(index)), err, true)
+		}
+
+		return types.NewL1InfoTreeLeaf(leaf), nil
+	})
+}
+
+// GetL1InfoTreeLeafByGER returns the latest leaf of the L1 info tree that contains
+// the provided global exit root
+func (z *ZKEVMEndpoints) GetL1InfoTreeLeafByGER(globalExitRoot common.Hash) (interface{}, types.Error) {
+	return z.txMan.NewDbTxScope(z.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+		leaf, err := z.state.GetL1InfoTreeLeafByGlobalExitRoot(ctx, globalExitRoot, dbTx)
+		if errors.Is(err, state.ErrNotFound) {
+			return nil, nil
+		} else if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, "couldn't load l1 info tree leaf by global exit root", err, true)
+		}
+
+		return types.NewL1InfoTreeLeaf(leaf), nil
+	})
+}
+
+// GetL1InfoTreeRoot returns the current root of the L1 info tree and its number of leaves
+func (z *ZKEVMEndpoints) GetL1InfoTreeRoot() (interface{}, types.Error) {
+	return z.txMan.NewDbTxScope(z.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+		lastIndex, err := z.state.GetLatestIndex(ctx, dbTx)
+		if errors.Is(err, state.ErrNotFound) {
+			mt, err := l1infotree.NewL1InfoTree(uint8(32), [][32]byte{}) //nolint:gomnd
+			if err != nil {
+				return RPCErrorResponse(types.DefaultErrorCode, "couldn't build the empty l1 info tree", err, true)
+			}
+			root, _, _ := mt.GetCurrentRootCountAndSiblings()
+			return types.L1InfoTreeRoot{L1InfoTreeRoot: root}, nil
+		} else if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, "couldn't load the last l1 info tree index", err, true)
+		}
+
+		leaf, err := z.state.GetL1InfoRootLeafByIndex(ctx, lastIndex, dbTx)
+		if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't load l1 info tree leaf by index %v", lastIndex), err, true)
+		}
+
+		return types.L1InfoTreeRoot{
+			L1InfoTreeRoot: leaf.L1InfoTreeRoot,
+			LeafCount:      types.ArgUint64(lastIndex) + 1,
+		}, nil
+	})
+}
+
+// GetL1InfoTreeProof returns the leaf of the L1 info tree at the provided index
+// and its merkle proof against the provided L1 info root
+func (z *ZKEVMEndpoints) GetL1InfoTreeProof(index types.ArgUint64, l1InfoRoot common.Hash) (interface{}, types.Error) {
+	return z.txMan.NewDbTxScope(z.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+		if uint64(index) > math.MaxUint32 {
+			return RPCErrorResponse(types.InvalidParamsErrorCode, fmt.Sprintf("invalid l1 info tree index %v", uint64(index)), nil, false)
+		}
+
+		leaf, proof, err := z.state.GetL1InfoTreeMerkleProof(ctx, uint32(index), l1InfoRoot, dbTx)
+		if errors.Is(err, state.ErrNotFound) {
+			return nil, nil
+		} else if errors.Is(err, state.ErrL1InfoTreeLeafNotIncluded) {
+			return RPCErrorResponse(types.InvalidParamsErrorCode, fmt.Sprintf("l1 info tree leaf %v is not included in the l1 info root %v", uint64(index), l1InfoRoot.String()), nil, false)
+		} else if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't compute the merkle proof of the l1 info tree leaf %v", uint64(index)), err, true)
+		}
+
+		res := types.L1InfoTreeProof{
+			L1InfoTreeRoot: l1InfoRoot,
+			Leaf:           types.NewL1InfoTreeLeaf(leaf),
+			Proof:          make([]common.Hash, 0, len(proof)),
+		}
+		for _, sibling := range proof {
+			res.Proof = append(res.Proof, common.Hash(sibling))
+		}
+
+		return res, nil
+	})
+}
+
+// GetBatchProof returns the proof generated by the aggregator that includes the
+// provided batch number and its public inputs, only when the node keeps it
+func (z *ZKEVMEndpoints) GetBatchProof(batchNumber types.BatchNumber) (interface{}, types.Error) {
+	return z.txMan.NewDbTxScope(z.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+		batchNumber, rpcErr := batchNumber.GetNumericBatchNumber(ctx, z.state, z.etherman, dbTx)
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+
+		proof, err := z.state.GetBatchProof(ctx, batchNumber, dbTx)
+		if errors.Is(err, state.ErrNotFound) {
+			return nil, nil
+		} else if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't load proof from state by batch number %v", batchNumber), err, true)
+		}
+
+		batchProof, rpcErr := z.newBatchProof(ctx, batchNumber, proof.BatchNumber, proof.BatchNumberFinal, dbTx)
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+		batchProof.Proof = &proof.Proof
+
+		return batchProof, nil
+	})
+}
+
+// GetVerifiedBatchProof returns the L1 verification of the provided batch number,
+// the public inputs of the proof used to verify it and the proof itself when the
+// node keeps it. A single verification can verify a range of batches, the range
+// is also returned.
+func (z *ZKEVMEndpoints) GetVerifiedBatchProof(batchNumber types.BatchNumber) (interface{}, types.Error) {
+	return z.txMan.NewDbTxScope(z.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+		batchNumber, rpcErr := batchNumber.GetNumericBatchNumber(ctx, z.state, z.etherman, dbTx)
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+
+		verifiedBatch, previousVerifiedBatchNumber, err := z.state.GetVerifiedBatchCoveringBatch(ctx, batchNumber, dbTx)
+		if errors.Is(err, state.ErrNotFound) {
+			return nil, nil
+		} else if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't load verified batch from state by batch number %v", batchNumber), err, true)
+		}
+
+		fromBatchNumber := previousVerifiedBatchNumber + 1
+		batchProof, rpcErr := z.newBatchProof(ctx, batchNumber, fromBatchNumber, verifiedBatch.BatchNumber, dbTx)
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+
+		l1BlockNumber := types.ArgUint64(verifiedBatch.BlockNumber)
+		batchProof.VerifyBatchTxHash = &verifiedBatch.TxHash
+		batchProof.L1BlockNumber = &l1BlockNumber
+		batchProof.Aggregator = &verifiedBatch.Aggregator
+
+		// the aggregator cleans up the proofs once they are verified,
+		// so the proof is only returned if it is still stored
+		proof, err := z.state.GetBatchProof(ctx, verifiedBatch.BatchNumber, dbTx)
+		if err != nil && !errors.Is(err, state.ErrNotFound) {
+			return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't load proof from state by batch number %v", verifiedBatch.BatchNumber), err, true)
+		} else if err == nil && proof.BatchNumber == fromBatchNumber && proof.BatchNumberFinal == verifiedBatch.BatchNumber {
+			batchProof.Proof = &proof.Proof
+		}
+
+		return batchProof, nil
+	})
+}
+
+// newBatchProof builds a batch proof response with the public inputs of the
+// proof that verifies the range of batches from fromBatchNumber to toBatchNumber
+func (z *ZKEVMEndpoints) newBatchProof(ctx context.Context, batchNumber, fromBatchNumber, toBatchNumber uint64, dbTx pgx.Tx) (*types.BatchProof, types.Error) {
+	oldBatch, err := z.state.GetBatchByNumber(ctx, fromBatchNumber-1, dbTx)
+	if err != nil {
+		_, rpcErr := RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't load batch from state by number %v", fromBatchNumber-1), err, true)
+		return nil, rpcErr
+	}
+
+	newBatch, err := z.state.GetBatchByNumber(ctx, toBatchNumber, dbTx)
+	if err != nil {
+		_, rpcErr := RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't load batch from state by number %v", toBatchNumber), err, true)
+		return nil, rpcErr
+	}
+
+	return &types.BatchProof{
+		BatchNumber:      types.ArgUint64(batchNumber),
+		FromBatchNumber:  types.ArgUint64(fromBatchNumber),
+		ToBatchNumber:    types.ArgUint64(toBatchNumber),
+		OldStateRoot:     oldBatch.StateRoot,
+		NewStateRoot:     newBatch.StateRoot,
+		NewLocalExitRoot: newBatch.LocalExitRoot,
+		OldAccInputHash:  oldBatch.AccInputHash,
+		NewAccInputHash:  newBatch.AccInputHash,
+	}, nil
+}
+
 // EstimateGasPrice returns an estimate gas price for the transaction.
 func (z *ZKEVMEndpoints) EstimateGasPrice(arg *types.TxArgs, blockArg *types.BlockNumberOrHash) (interface{}, types.Error) {
 	return z.txMan.NewDbTxScope(z.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
@@ -484,7 +807,7 @@ func (z *ZKEVMEndpoints) internalEstimateGasPriceAndFee(ctx context.Context, arg
 		return nil, nil, types.NewRPCError(types.DefaultErrorCode, "failed to convert arguments into an unsigned transaction")
 	}
 
-	gasEstimation, returnValue, err := z.state.EstimateGas(tx, sender, blockToProcess, dbTx)
+	gasEstimation, returnValue, err := z.state.EstimateGas(tx, sender, blockToProcess, nil, dbTx)
 	if errors.Is(err, runtime.ErrExecutionReverted) {
 		data := make([]byte, len(returnValue))
 		copy(data, returnValue)
@@ -608,6 +931,34 @@ func (z *ZKEVMEndpoints) EstimateCounters(arg *types.TxArgs, blockArg *types.Blo
 	})
 }
 
+// GetProof returns the sparse merkle tree proofs of the balance, nonce, code hash
+// and the provided storage keys of an account at the state root of the provided block
+func (z *ZKEVMEndpoints) GetProof(address types.ArgAddress, storageKeys []types.ArgHash, blockArg *types.BlockNumberOrHash) (interface{}, types.Error) {
+	if z.cfg.MaxStorageProofKeys > 0 && uint64(len(storageKeys)) > z.cfg.MaxStorageProofKeys {
+		errMsg := fmt.Sprintf("storage keys are limited to %v", z.cfg.MaxStorageProofKeys)
+		return RPCErrorResponse(types.InvalidParamsErrorCode, errMsg, nil, false)
+	}
+
+	return z.txMan.NewDbTxScope(z.state, func(ctx context.Context, dbTx pgx.Tx) (interface{}, types.Error) {
+		block, rpcErr := z.getBlockByArg(ctx, blockArg, dbTx)
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+
+		positions := make([]*big.Int, 0, len(storageKeys))
+		for _, storageKey := range storageKeys {
+			positions = append(positions, storageKey.Hash().Big())
+		}
+
+		accountProof, err := z.state.GetAccountProof(ctx, address.Address(), positions, block.Root())
+		if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, "failed to get account proof from state", err, true)
+		}
+
+		return types.NewAccountProof(address.Address(), block.Root(), accountProof), nil
+	})
+}
+
 func (z *ZKEVMEndpoints) getBlockByArg(ctx context.Context, blockArg *types.BlockNumberOrHash, dbTx pgx.Tx) (*state.L2Block, types.Error) {
 	// If no block argument is provided, return the latest block
 	if blockArg == nil {
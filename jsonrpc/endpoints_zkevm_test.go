@@ -13,6 +13,7 @@ import (
 	"github.com/0xPolygonHermez/zkevm-node/hex"
 	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/client"
 	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/merkletree"
 	"github.com/0xPolygonHermez/zkevm-node/pool"
 	"github.com/0xPolygonHermez/zkevm-node/state"
 	"github.com/0xPolygonHermez/zkevm-node/test/operations"
@@ -2514,6 +2515,110 @@ func signTx(tx *ethTypes.Transaction, chainID uint64) *ethTypes.Transaction {
 	return signedTx
 }
 
+func TestGetPreconfirmation(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	type testCase struct {
+		Name           string
+		Hash           common.Hash
+		ExpectedResult *types.Preconfirmation
+		ExpectedError  *types.RPCError
+		SetupMocks     func(m *mocksWrapper, tc testCase)
+	}
+
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	preconfirmation := pool.Preconfirmation{
+		TxHash:        common.HexToHash("0x123"),
+		L2BlockNumber: 10,
+		Position:      2,
+		StateRoot:     common.HexToHash("0x456"),
+	}
+	preconfirmation.Signature, err = crypto.Sign(preconfirmation.Hash(chainID).Bytes(), privateKey)
+	require.NoError(t, err)
+
+	expectedPreconfirmation := types.Preconfirmation{
+		TxHash:      preconfirmation.TxHash,
+		BlockNumber: types.ArgUint64(preconfirmation.L2BlockNumber),
+		Position:    types.ArgUint64(preconfirmation.Position),
+		StateRoot:   preconfirmation.StateRoot,
+		Hash:        preconfirmation.Hash(chainID),
+		Signature:   preconfirmation.Signature,
+		Signer:      crypto.PubkeyToAddress(privateKey.PublicKey),
+	}
+
+	testCases := []testCase{
+		{
+			Name:           "Get preconfirmation successfully",
+			Hash:           common.HexToHash("0x123"),
+			ExpectedResult: &expectedPreconfirmation,
+			ExpectedError:  nil,
+			SetupMocks: func(m *mocksWrapper, tc testCase) {
+				m.Pool.
+					On("GetPreconfirmation", context.Background(), tc.Hash).
+					Return(&preconfirmation, nil).
+					Once()
+			},
+		},
+		{
+			Name:           "Preconfirmation not found",
+			Hash:           common.HexToHash("0x123"),
+			ExpectedResult: nil,
+			ExpectedError:  nil,
+			SetupMocks: func(m *mocksWrapper, tc testCase) {
+				m.Pool.
+					On("GetPreconfirmation", context.Background(), tc.Hash).
+					Return(nil, pool.ErrNotFound).
+					Once()
+			},
+		},
+		{
+			Name:           "Preconfirmation failed to load from the pool",
+			Hash:           common.HexToHash("0x123"),
+			ExpectedResult: nil,
+			ExpectedError:  types.NewRPCError(types.DefaultErrorCode, "failed to load preconfirmation from pool"),
+			SetupMocks: func(m *mocksWrapper, tc testCase) {
+				m.Pool.
+					On("GetPreconfirmation", context.Background(), tc.Hash).
+					Return(nil, errors.New("failed to load preconfirmation from pool")).
+					Once()
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			tc := testCase
+			tc.SetupMocks(m, tc)
+
+			res, err := s.JSONRPCCall("zkevm_getPreconfirmation", tc.Hash.String())
+			require.NoError(t, err)
+
+			if tc.ExpectedResult != nil {
+				require.NotNil(t, res.Result)
+				require.Nil(t, res.Error)
+
+				var result types.Preconfirmation
+				err = json.Unmarshal(res.Result, &result)
+				require.NoError(t, err)
+
+				assert.Equal(t, *tc.ExpectedResult, result)
+			} else if tc.ExpectedError == nil {
+				require.Nil(t, res.Error)
+				assert.Equal(t, "null", string(res.Result))
+			}
+
+			if res.Error != nil || tc.ExpectedError != nil {
+				rpcErr := res.Error.RPCError()
+				assert.Equal(t, tc.ExpectedError.ErrorCode(), rpcErr.ErrorCode())
+				assert.Equal(t, tc.ExpectedError.Error(), rpcErr.Error())
+			}
+		})
+	}
+}
+
 func TestGetExitRootsByGER(t *testing.T) {
 	type testCase struct {
 		Name           string
@@ -2705,3 +2810,844 @@ func TestGetLatestGlobalExitRoot(t *testing.T) {
 		})
 	}
 }
+
+func TestGetProof(t *testing.T) {
+	type testCase struct {
+		Name           string
+		Address        common.Address
+		StorageKeys    []common.Hash
+		BlockNumber    *big.Int
+		ExpectedResult *types.AccountProof
+		ExpectedError  types.Error
+		SetupMocks     func(*mocksWrapper, *testCase)
+	}
+
+	root := common.HexToHash("0x1234")
+	block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: big.NewInt(1), Root: root}))
+	address := common.HexToAddress("0x123")
+	storageKey := common.HexToHash("0x1")
+
+	smtProof := &merkletree.Proof{
+		Root:     []uint64{1, 2, 3, 4},
+		Key:      []uint64{5, 6, 7, 8},
+		Value:    []uint64{9, 0, 0, 0, 0, 0, 0, 0},
+		Siblings: [][]uint64{{10, 11, 12, 13}, {14, 15, 16, 17}},
+	}
+	accountProof := &merkletree.AccountProof{
+		Balance:       big.NewInt(9),
+		BalanceProof:  smtProof,
+		Nonce:         big.NewInt(1),
+		NonceProof:    smtProof,
+		CodeHash:      common.HexToHash("0x5678").Bytes(),
+		CodeHashProof: smtProof,
+		StorageProofs: []*merkletree.StorageProof{{Position: storageKey.Big(), Value: big.NewInt(9), Proof: smtProof}},
+	}
+	expectedProof := types.NewAccountProof(address, root, accountProof)
+
+	testCases := []testCase{
+		{
+			Name:           "get proof successfully",
+			Address:        address,
+			StorageKeys:    []common.Hash{storageKey},
+			BlockNumber:    big.NewInt(1),
+			ExpectedResult: &expectedProof,
+			SetupMocks: func(m *mocksWrapper, tc *testCase) {
+				m.DbTx.
+					On("Commit", context.Background()).
+					Return(nil).
+					Once()
+
+				m.State.
+					On("BeginStateTransaction", context.Background()).
+					Return(m.DbTx, nil).
+					Once()
+
+				m.State.
+					On("GetL2BlockByNumber", context.Background(), tc.BlockNumber.Uint64(), m.DbTx).
+					Return(block, nil).
+					Once()
+
+				m.State.
+					On("GetAccountProof", context.Background(), tc.Address, []*big.Int{storageKey.Big()}, root).
+					Return(accountProof, nil).
+					Once()
+			},
+		},
+		{
+			Name:          "failed to get account proof",
+			Address:       address,
+			StorageKeys:   []common.Hash{},
+			BlockNumber:   big.NewInt(1),
+			ExpectedError: types.NewRPCError(types.DefaultErrorCode, "failed to get account proof from state"),
+			SetupMocks: func(m *mocksWrapper, tc *testCase) {
+				m.DbTx.
+					On("Rollback", context.Background()).
+					Return(nil).
+					Once()
+
+				m.State.
+					On("BeginStateTransaction", context.Background()).
+					Return(m.DbTx, nil).
+					Once()
+
+				m.State.
+					On("GetL2BlockByNumber", context.Background(), tc.BlockNumber.Uint64(), m.DbTx).
+					Return(block, nil).
+					Once()
+
+				m.State.
+					On("GetAccountProof", context.Background(), tc.Address, []*big.Int{}, root).
+					Return(nil, errors.New("failed to get proof")).
+					Once()
+			},
+		},
+		{
+			Name:          "too many storage keys",
+			Address:       address,
+			StorageKeys:   []common.Hash{storageKey, storageKey, storageKey},
+			BlockNumber:   big.NewInt(1),
+			ExpectedError: types.NewRPCError(types.InvalidParamsErrorCode, "storage keys are limited to 2"),
+			SetupMocks:    func(m *mocksWrapper, tc *testCase) {},
+		},
+	}
+
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	zkEVMClient := client.NewClient(s.ServerURL)
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			tc := testCase
+			testCase.SetupMocks(m, &tc)
+
+			proof, err := zkEVMClient.GetProof(context.Background(), tc.Address, tc.StorageKeys, tc.BlockNumber)
+
+			if tc.ExpectedResult != nil {
+				require.NoError(t, err)
+				assert.Equal(t, tc.ExpectedResult.Address, proof.Address)
+				assert.Equal(t, tc.ExpectedResult.StateRoot, proof.StateRoot)
+				assert.Equal(t, tc.ExpectedResult.Balance.Hex(), proof.Balance.Hex())
+				assert.Equal(t, tc.ExpectedResult.Nonce, proof.Nonce)
+				assert.Equal(t, tc.ExpectedResult.CodeHash, proof.CodeHash)
+				assert.Equal(t, tc.ExpectedResult.BalanceProof, proof.BalanceProof)
+				assert.Equal(t, tc.ExpectedResult.NonceProof, proof.NonceProof)
+				assert.Equal(t, tc.ExpectedResult.CodeHashProof, proof.CodeHashProof)
+				require.Equal(t, len(tc.ExpectedResult.StorageProof), len(proof.StorageProof))
+				for i, storageProof := range tc.ExpectedResult.StorageProof {
+					assert.Equal(t, storageProof.Key, proof.StorageProof[i].Key)
+					assert.Equal(t, storageProof.Value.Hex(), proof.StorageProof[i].Value.Hex())
+					assert.Equal(t, storageProof.Proof, proof.StorageProof[i].Proof)
+				}
+			}
+
+			if err != nil || tc.ExpectedError != nil {
+				rpcErr := err.(types.RPCError)
+				assert.Equal(t, tc.ExpectedError.ErrorCode(), rpcErr.ErrorCode())
+				assert.Equal(t, tc.ExpectedError.Error(), rpcErr.Error())
+			}
+		})
+	}
+}
+
+func TestGetBatchProof(t *testing.T) {
+	type testCase struct {
+		Name           string
+		BatchNumber    *big.Int
+		ExpectedResult *types.BatchProof
+		ExpectedError  types.Error
+		SetupMocks     func(*mocksWrapper, *testCase)
+	}
+
+	oldBatch := &state.Batch{BatchNumber: 3, StateRoot: common.HexToHash("0x1"), AccInputHash: common.HexToHash("0x2")}
+	newBatch := &state.Batch{BatchNumber: 6, StateRoot: common.HexToHash("0x3"), LocalExitRoot: common.HexToHash("0x4"), AccInputHash: common.HexToHash("0x5")}
+	proof := &state.Proof{BatchNumber: 4, BatchNumberFinal: 6, Proof: "proof"}
+
+	testCases := []testCase{
+		{
+			Name:        "proof found",
+			BatchNumber: big.NewInt(5),
+			ExpectedResult: &types.BatchProof{
+				BatchNumber:      5,
+				FromBatchNumber:  4,
+				ToBatchNumber:    6,
+				OldStateRoot:     oldBatch.StateRoot,
+				NewStateRoot:     newBatch.StateRoot,
+				NewLocalExitRoot: newBatch.LocalExitRoot,
+				OldAccInputHash:  oldBatch.AccInputHash,
+				NewAccInputHash:  newBatch.AccInputHash,
+				Proof:            &proof.Proof,
+			},
+			SetupMocks: func(m *mocksWrapper, tc *testCase) {
+				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.On("GetBatchProof", context.Background(), uint64(5), m.DbTx).Return(proof, nil).Once()
+				m.State.On("GetBatchByNumber", context.Background(), uint64(3), m.DbTx).Return(oldBatch, nil).Once()
+				m.State.On("GetBatchByNumber", context.Background(), uint64(6), m.DbTx).Return(newBatch, nil).Once()
+			},
+		},
+		{
+			Name:        "proof not kept by the node",
+			BatchNumber: big.NewInt(5),
+			SetupMocks: func(m *mocksWrapper, tc *testCase) {
+				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.On("GetBatchProof", context.Background(), uint64(5), m.DbTx).Return(nil, state.ErrNotFound).Once()
+			},
+		},
+		{
+			Name:          "failed to load proof",
+			BatchNumber:   big.NewInt(5),
+			ExpectedError: types.NewRPCError(types.DefaultErrorCode, "couldn't load proof from state by batch number 5"),
+			SetupMocks: func(m *mocksWrapper, tc *testCase) {
+				m.DbTx.On("Rollback", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.On("GetBatchProof", context.Background(), uint64(5), m.DbTx).Return(nil, fmt.Errorf("failed to load proof")).Once()
+			},
+		},
+	}
+
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	zkEVMClient := client.NewClient(s.ServerURL)
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			tc := testCase
+			testCase.SetupMocks(m, &tc)
+
+			batchProof, err := zkEVMClient.BatchProof(context.Background(), tc.BatchNumber)
+
+			if tc.ExpectedError == nil {
+				require.NoError(t, err)
+				assert.Equal(t, tc.ExpectedResult, batchProof)
+			} else {
+				rpcErr := err.(types.RPCError)
+				assert.Equal(t, tc.ExpectedError.ErrorCode(), rpcErr.ErrorCode())
+				assert.Equal(t, tc.ExpectedError.Error(), rpcErr.Error())
+			}
+		})
+	}
+}
+
+func TestGetVerifiedBatchProof(t *testing.T) {
+	type testCase struct {
+		Name           string
+		BatchNumber    *big.Int
+		ExpectedResult *types.BatchProof
+		ExpectedError  types.Error
+		SetupMocks     func(*mocksWrapper, *testCase)
+	}
+
+	oldBatch := &state.Batch{BatchNumber: 3, StateRoot: common.HexToHash("0x1"), AccInputHash: common.HexToHash("0x2")}
+	newBatch := &state.Batch{BatchNumber: 6, StateRoot: common.HexToHash("0x3"), LocalExitRoot: common.HexToHash("0x4"), AccInputHash: common.HexToHash("0x5")}
+	verifiedBatch := &state.VerifiedBatch{BatchNumber: 6, BlockNumber: 100, TxHash: common.HexToHash("0x6"), Aggregator: common.HexToAddress("0x7"), StateRoot: newBatch.StateRoot}
+	l1BlockNumber := types.ArgUint64(verifiedBatch.BlockNumber)
+	proof := &state.Proof{BatchNumber: 4, BatchNumberFinal: 6, Proof: "proof"}
+
+	newExpectedResult := func(proof *string) *types.BatchProof {
+		return &types.BatchProof{
+			BatchNumber:       5,
+			FromBatchNumber:   4,
+			ToBatchNumber:     6,
+			OldStateRoot:      oldBatch.StateRoot,
+			NewStateRoot:      newBatch.StateRoot,
+			NewLocalExitRoot:  newBatch.LocalExitRoot,
+			OldAccInputHash:   oldBatch.AccInputHash,
+			NewAccInputHash:   newBatch.AccInputHash,
+			Proof:             proof,
+			VerifyBatchTxHash: &verifiedBatch.TxHash,
+			L1BlockNumber:     &l1BlockNumber,
+			Aggregator:        &verifiedBatch.Aggregator,
+		}
+	}
+
+	setupVerifiedBatchMocks := func(m *mocksWrapper) {
+		m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+		m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+		m.State.On("GetVerifiedBatchCoveringBatch", context.Background(), uint64(5), m.DbTx).Return(verifiedBatch, uint64(3), nil).Once()
+		m.State.On("GetBatchByNumber", context.Background(), uint64(3), m.DbTx).Return(oldBatch, nil).Once()
+		m.State.On("GetBatchByNumber", context.Background(), uint64(6), m.DbTx).Return(newBatch, nil).Once()
+	}
+
+	testCases := []testCase{
+		{
+			Name:           "verified batch with proof kept by the node",
+			BatchNumber:    big.NewInt(5),
+			ExpectedResult: newExpectedResult(&proof.Proof),
+			SetupMocks: func(m *mocksWrapper, tc *testCase) {
+				setupVerifiedBatchMocks(m)
+				m.State.On("GetBatchProof", context.Background(), uint64(6), m.DbTx).Return(proof, nil).Once()
+			},
+		},
+		{
+			Name:           "verified batch with a proof for a different range",
+			BatchNumber:    big.NewInt(5),
+			ExpectedResult: newExpectedResult(nil),
+			SetupMocks: func(m *mocksWrapper, tc *testCase) {
+				setupVerifiedBatchMocks(m)
+				m.State.On("GetBatchProof", context.Background(), uint64(6), m.DbTx).Return(&state.Proof{BatchNumber: 5, BatchNumberFinal: 6, Proof: "other"}, nil).Once()
+			},
+		},
+		{
+			Name:           "verified batch without proof",
+			BatchNumber:    big.NewInt(5),
+			ExpectedResult: newExpectedResult(nil),
+			SetupMocks: func(m *mocksWrapper, tc *testCase) {
+				setupVerifiedBatchMocks(m)
+				m.State.On("GetBatchProof", context.Background(), uint64(6), m.DbTx).Return(nil, state.ErrNotFound).Once()
+			},
+		},
+		{
+			Name:        "batch not verified",
+			BatchNumber: big.NewInt(5),
+			SetupMocks: func(m *mocksWrapper, tc *testCase) {
+				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.On("GetVerifiedBatchCoveringBatch", context.Background(), uint64(5), m.DbTx).Return(nil, uint64(0), state.ErrNotFound).Once()
+			},
+		},
+		{
+			Name:          "failed to load batch",
+			BatchNumber:   big.NewInt(5),
+			ExpectedError: types.NewRPCError(types.DefaultErrorCode, "couldn't load batch from state by number 3"),
+			SetupMocks: func(m *mocksWrapper, tc *testCase) {
+				m.DbTx.On("Rollback", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.On("GetVerifiedBatchCoveringBatch", context.Background(), uint64(5), m.DbTx).Return(verifiedBatch, uint64(3), nil).Once()
+				m.State.On("GetBatchByNumber", context.Background(), uint64(3), m.DbTx).Return(nil, fmt.Errorf("failed to load batch")).Once()
+			},
+		},
+	}
+
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	zkEVMClient := client.NewClient(s.ServerURL)
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			tc := testCase
+			testCase.SetupMocks(m, &tc)
+
+			batchProof, err := zkEVMClient.VerifiedBatchProof(context.Background(), tc.BatchNumber)
+
+			if tc.ExpectedError == nil {
+				require.NoError(t, err)
+				assert.Equal(t, tc.ExpectedResult, batchProof)
+			} else {
+				rpcErr := err.(types.RPCError)
+				assert.Equal(t, tc.ExpectedError.ErrorCode(), rpcErr.ErrorCode())
+				assert.Equal(t, tc.ExpectedError.Error(), rpcErr.Error())
+			}
+		})
+	}
+}
+
+func TestGetBatchL2Data(t *testing.T) {
+	type expectedBlock struct {
+		DeltaTimestamp  uint64
+		IndexL1InfoTree uint64
+		TxHashes        []common.Hash
+	}
+
+	type testCase struct {
+		Name                string
+		BatchNumber         *big.Int
+		ExpectedResult      bool
+		ExpectedBlocks      []expectedBlock
+		ExpectedTxHashes    []common.Hash
+		ExpectedForcedBatch *types.ForcedBatch
+		ExpectedBatchL2Data []byte
+		ExpectedError       types.Error
+		SetupMocks          func(*mocksWrapper, *testCase)
+	}
+
+	tx1 := signTx(ethTypes.NewTransaction(1, common.HexToAddress("0x1"), big.NewInt(1), 21000, big.NewInt(1), nil), chainID)
+	tx2 := signTx(ethTypes.NewTransaction(2, common.HexToAddress("0x2"), big.NewInt(2), 21000, big.NewInt(1), nil), chainID)
+	tx3 := signTx(ethTypes.NewTransaction(3, common.HexToAddress("0x3"), big.NewInt(3), 21000, big.NewInt(1), nil), chainID)
+
+	etrogBatchL2Data, err := state.EncodeBatchV2(&state.BatchRawV2{
+		Blocks: []state.L2BlockRaw{
+			{
+				ChangeL2BlockHeader: state.ChangeL2BlockHeader{DeltaTimestamp: 3, IndexL1InfoTree: 7},
+				Transactions: []state.L2TxRaw{
+					{EfficiencyPercentage: state.MaxEffectivePercentage, Tx: *tx1},
+					{EfficiencyPercentage: state.MaxEffectivePercentage, Tx: *tx2},
+				},
+			},
+			{
+				ChangeL2BlockHeader: state.ChangeL2BlockHeader{DeltaTimestamp: 2, IndexL1InfoTree: 0},
+				Transactions:        []state.L2TxRaw{{EfficiencyPercentage: state.MaxEffectivePercentage, Tx: *tx3}},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	forcedBatchL2Data, err := state.EncodeTransactions([]ethTypes.Transaction{*tx1}, []uint8{state.MaxEffectivePercentage}, state.FORKID_ETROG)
+	require.NoError(t, err)
+
+	preEtrogBatchL2Data, err := state.EncodeTransactions([]ethTypes.Transaction{*tx1, *tx2}, []uint8{state.MaxEffectivePercentage, state.MaxEffectivePercentage}, state.FORKID_DRAGONFRUIT)
+	require.NoError(t, err)
+
+	forcedBatchNum := uint64(4)
+	forcedBatch := &state.ForcedBatch{
+		BlockNumber:       100,
+		ForcedBatchNumber: forcedBatchNum,
+		Sequencer:         common.HexToAddress("0x4"),
+		GlobalExitRoot:    common.HexToHash("0x5"),
+		RawTxsData:        forcedBatchL2Data,
+		ForcedAt:          time.Unix(1000, 0),
+	}
+
+	testCases := []testCase{
+		{
+			Name:                "etrog batch",
+			BatchNumber:         big.NewInt(5),
+			ExpectedResult:      true,
+			ExpectedBatchL2Data: etrogBatchL2Data,
+			ExpectedBlocks: []expectedBlock{
+				{DeltaTimestamp: 3, IndexL1InfoTree: 7, TxHashes: []common.Hash{tx1.Hash(), tx2.Hash()}},
+				{DeltaTimestamp: 2, IndexL1InfoTree: 0, TxHashes: []common.Hash{tx3.Hash()}},
+			},
+			SetupMocks: func(m *mocksWrapper, tc *testCase) {
+				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.On("GetBatchByNumber", context.Background(), uint64(5), m.DbTx).Return(&state.Batch{BatchNumber: 5, BatchL2Data: etrogBatchL2Data}, nil).Once()
+				m.State.On("GetForkIDByBatchNumber", uint64(5)).Return(uint64(state.FORKID_ETROG)).Once()
+			},
+		},
+		{
+			Name:                "forced batch",
+			BatchNumber:         big.NewInt(5),
+			ExpectedResult:      true,
+			ExpectedBatchL2Data: forcedBatchL2Data,
+			ExpectedTxHashes:    []common.Hash{tx1.Hash()},
+			ExpectedForcedBatch: &types.ForcedBatch{
+				ForcedBatchNumber: types.ArgUint64(forcedBatchNum),
+				L1BlockNumber:     100,
+				Sequencer:         forcedBatch.Sequencer,
+				GlobalExitRoot:    forcedBatch.GlobalExitRoot,
+				RawTxsData:        forcedBatchL2Data,
+				ForcedAt:          1000,
+			},
+			SetupMocks: func(m *mocksWrapper, tc *testCase) {
+				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.On("GetBatchByNumber", context.Background(), uint64(5), m.DbTx).Return(&state.Batch{BatchNumber: 5, BatchL2Data: forcedBatchL2Data, ForcedBatchNum: &forcedBatchNum}, nil).Once()
+				m.State.On("GetForcedBatch", context.Background(), forcedBatchNum, m.DbTx).Return(forcedBatch, nil).Once()
+				m.State.On("GetForkIDByBatchNumber", uint64(5)).Return(uint64(state.FORKID_ETROG)).Once()
+			},
+		},
+		{
+			Name:                "forced batch with malformed l2 data",
+			BatchNumber:         big.NewInt(5),
+			ExpectedResult:      true,
+			ExpectedBatchL2Data: []byte{0x1},
+			ExpectedForcedBatch: &types.ForcedBatch{
+				ForcedBatchNumber: types.ArgUint64(forcedBatchNum),
+				L1BlockNumber:     100,
+				Sequencer:         forcedBatch.Sequencer,
+				GlobalExitRoot:    forcedBatch.GlobalExitRoot,
+				RawTxsData:        []byte{0x1},
+				ForcedAt:          1000,
+			},
+			SetupMocks: func(m *mocksWrapper, tc *testCase) {
+				malformedForcedBatch := *forcedBatch
+				malformedForcedBatch.RawTxsData = []byte{0x1}
+				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.On("GetBatchByNumber", context.Background(), uint64(5), m.DbTx).Return(&state.Batch{BatchNumber: 5, BatchL2Data: []byte{0x1}, ForcedBatchNum: &forcedBatchNum}, nil).Once()
+				m.State.On("GetForcedBatch", context.Background(), forcedBatchNum, m.DbTx).Return(&malformedForcedBatch, nil).Once()
+				m.State.On("GetForkIDByBatchNumber", uint64(5)).Return(uint64(state.FORKID_ETROG)).Once()
+			},
+		},
+		{
+			Name:                "pre etrog batch",
+			BatchNumber:         big.NewInt(5),
+			ExpectedResult:      true,
+			ExpectedBatchL2Data: preEtrogBatchL2Data,
+			ExpectedTxHashes:    []common.Hash{tx1.Hash(), tx2.Hash()},
+			SetupMocks: func(m *mocksWrapper, tc *testCase) {
+				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.On("GetBatchByNumber", context.Background(), uint64(5), m.DbTx).Return(&state.Batch{BatchNumber: 5, BatchL2Data: preEtrogBatchL2Data}, nil).Once()
+				m.State.On("GetForkIDByBatchNumber", uint64(5)).Return(uint64(state.FORKID_DRAGONFRUIT)).Once()
+			},
+		},
+		{
+			Name:        "batch not found",
+			BatchNumber: big.NewInt(5),
+			SetupMocks: func(m *mocksWrapper, tc *testCase) {
+				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.On("GetBatchByNumber", context.Background(), uint64(5), m.DbTx).Return(nil, state.ErrNotFound).Once()
+			},
+		},
+		{
+			Name:          "malformed batch l2 data",
+			BatchNumber:   big.NewInt(5),
+			ExpectedError: types.NewRPCError(types.DefaultErrorCode, "couldn't decode the L2 data of the batch 5"),
+			SetupMocks: func(m *mocksWrapper, tc *testCase) {
+				m.DbTx.On("Rollback", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.On("GetBatchByNumber", context.Background(), uint64(5), m.DbTx).Return(&state.Batch{BatchNumber: 5, BatchL2Data: []byte{0x1}}, nil).Once()
+				m.State.On("GetForkIDByBatchNumber", uint64(5)).Return(uint64(state.FORKID_ETROG)).Once()
+			},
+		},
+	}
+
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	zkEVMClient := client.NewClient(s.ServerURL)
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			tc := testCase
+			testCase.SetupMocks(m, &tc)
+
+			batchL2Data, err := zkEVMClient.BatchL2Data(context.Background(), tc.BatchNumber)
+
+			if tc.ExpectedError != nil {
+				rpcErr := err.(types.RPCError)
+				assert.Equal(t, tc.ExpectedError.ErrorCode(), rpcErr.ErrorCode())
+				assert.Equal(t, tc.ExpectedError.Error(), rpcErr.Error())
+				return
+			}
+
+			require.NoError(t, err)
+			if !tc.ExpectedResult {
+				assert.Nil(t, batchL2Data)
+				return
+			}
+
+			require.NotNil(t, batchL2Data)
+			assert.Equal(t, tc.BatchNumber.Uint64(), uint64(batchL2Data.Number))
+			assert.Equal(t, tc.ExpectedBatchL2Data, []byte(batchL2Data.BatchL2Data))
+			assert.Equal(t, tc.ExpectedForcedBatch, batchL2Data.ForcedBatch)
+
+			require.Equal(t, len(tc.ExpectedBlocks), len(batchL2Data.Blocks))
+			for i, block := range batchL2Data.Blocks {
+				assert.Equal(t, tc.ExpectedBlocks[i].DeltaTimestamp, uint64(block.DeltaTimestamp))
+				assert.Equal(t, tc.ExpectedBlocks[i].IndexL1InfoTree, uint64(block.IndexL1InfoTree))
+				require.Equal(t, len(tc.ExpectedBlocks[i].TxHashes), len(block.Transactions))
+				for j, tx := range block.Transactions {
+					assert.Equal(t, tc.ExpectedBlocks[i].TxHashes[j], tx.Transaction.Hash)
+					assert.Equal(t, uint64(state.MaxEffectivePercentage), uint64(tx.EffectivePercentage))
+				}
+			}
+
+			require.Equal(t, len(tc.ExpectedTxHashes), len(batchL2Data.Transactions))
+			for i, tx := range batchL2Data.Transactions {
+				assert.Equal(t, tc.ExpectedTxHashes[i], tx.Transaction.Hash)
+				assert.Equal(t, uint64(state.MaxEffectivePercentage), uint64(tx.EffectivePercentage))
+			}
+		})
+	}
+}
+
+func TestL1InfoTreeEndpoints(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	type testCase struct {
+		Name           string
+		Method         string
+		Params         []interface{}
+		ExpectedResult string
+		ExpectedError  types.Error
+		SetupMocks     func(m *mocksWrapper)
+	}
+
+	ger := common.HexToHash("0x1")
+	l1InfoRoot := common.HexToHash("0x2")
+	leaf := state.L1InfoTreeExitRootStorageEntry{
+		L1InfoTreeLeaf: state.L1InfoTreeLeaf{
+			GlobalExitRoot: state.GlobalExitRoot{
+				BlockNumber:     10,
+				Timestamp:       time.Unix(1000, 0),
+				MainnetExitRoot: common.HexToHash("0x3"),
+				RollupExitRoot:  common.HexToHash("0x4"),
+				GlobalExitRoot:  ger,
+			},
+			PreviousBlockHash: common.HexToHash("0x5"),
+		},
+		L1InfoTreeRoot:  l1InfoRoot,
+		L1InfoTreeIndex: 7,
+	}
+	leafJSON := fmt.Sprintf(`{"index":"0x7","l1InfoTreeRoot":"%v","globalExitRoot":"%v","mainnetExitRoot":"%v","rollupExitRoot":"%v","previousBlockHash":"%v","l1BlockNumber":"0xa","timestamp":"0x3e8"}`,
+		l1InfoRoot.String(), ger.String(), leaf.MainnetExitRoot.String(), leaf.RollupExitRoot.String(), leaf.PreviousBlockHash.String())
+
+	testCases := []testCase{
+		{
+			Name:           "leaf by index",
+			Method:         "zkevm_getL1InfoTreeLeafByIndex",
+			Params:         []interface{}{"0x7"},
+			ExpectedResult: leafJSON,
+			SetupMocks: func(m *mocksWrapper) {
+				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.On("GetLatestIndex", context.Background(), m.DbTx).Return(uint32(8), nil).Once()
+				m.State.On("GetL1InfoRootLeafByIndex", context.Background(), uint32(7), m.DbTx).Return(leaf, nil).Once()
+			},
+		},
+		{
+			Name:           "leaf by index not added yet",
+			Method:         "zkevm_getL1InfoTreeLeafByIndex",
+			Params:         []interface{}{"0x9"},
+			ExpectedResult: "null",
+			SetupMocks: func(m *mocksWrapper) {
+				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.On("GetLatestIndex", context.Background(), m.DbTx).Return(uint32(8), nil).Once()
+			},
+		},
+		{
+			Name:           "leaf by index empty tree",
+			Method:         "zkevm_getL1InfoTreeLeafByIndex",
+			Params:         []interface{}{"0x0"},
+			ExpectedResult: "null",
+			SetupMocks: func(m *mocksWrapper) {
+				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.On("GetLatestIndex", context.Background(), m.DbTx).Return(uint32(0), state.ErrNotFound).Once()
+			},
+		},
+		{
+			Name:           "leaf by GER",
+			Method:         "zkevm_getL1InfoTreeLeafByGER",
+			Params:         []interface{}{ger.String()},
+			ExpectedResult: leafJSON,
+			SetupMocks: func(m *mocksWrapper) {
+				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.On("GetL1InfoTreeLeafByGlobalExitRoot", context.Background(), ger, m.DbTx).Return(leaf, nil).Once()
+			},
+		},
+		{
+			Name:           "leaf by GER not found",
+			Method:         "zkevm_getL1InfoTreeLeafByGER",
+			Params:         []interface{}{ger.String()},
+			ExpectedResult: "null",
+			SetupMocks: func(m *mocksWrapper) {
+				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.On("GetL1InfoTreeLeafByGlobalExitRoot", context.Background(), ger, m.DbTx).Return(state.L1InfoTreeExitRootStorageEntry{}, state.ErrNotFound).Once()
+			},
+		},
+		{
+			Name:           "root",
+			Method:         "zkevm_getL1InfoTreeRoot",
+			ExpectedResult: fmt.Sprintf(`{"l1InfoTreeRoot":"%v","leafCount":"0x8"}`, l1InfoRoot.String()),
+			SetupMocks: func(m *mocksWrapper) {
+				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.On("GetLatestIndex", context.Background(), m.DbTx).Return(uint32(7), nil).Once()
+				m.State.On("GetL1InfoRootLeafByIndex", context.Background(), uint32(7), m.DbTx).Return(leaf, nil).Once()
+			},
+		},
+		{
+			Name:           "root of the empty tree",
+			Method:         "zkevm_getL1InfoTreeRoot",
+			ExpectedResult: `{"l1InfoTreeRoot":"0x27ae5ba08d7291c96c8cbddcc148bf48a6d68c7974b94356f53754ef6171d757","leafCount":"0x0"}`,
+			SetupMocks: func(m *mocksWrapper) {
+				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.On("GetLatestIndex", context.Background(), m.DbTx).Return(uint32(0), state.ErrNotFound).Once()
+			},
+		},
+		{
+			Name:   "proof",
+			Method: "zkevm_getL1InfoTreeProof",
+			Params: []interface{}{"0x7", l1InfoRoot.String()},
+			ExpectedResult: fmt.Sprintf(`{"l1InfoTreeRoot":"%v","leaf":%v,"proof":["%v","%v"]}`,
+				l1InfoRoot.String(), leafJSON, common.HexToHash("0x6").String(), common.HexToHash("0x7").String()),
+			SetupMocks: func(m *mocksWrapper) {
+				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.
+					On("GetL1InfoTreeMerkleProof", context.Background(), uint32(7), l1InfoRoot, m.DbTx).
+					Return(leaf, [][32]byte{common.HexToHash("0x6"), common.HexToHash("0x7")}, nil).
+					Once()
+			},
+		},
+		{
+			Name:           "proof for an unknown l1 info root",
+			Method:         "zkevm_getL1InfoTreeProof",
+			Params:         []interface{}{"0x7", l1InfoRoot.String()},
+			ExpectedResult: "null",
+			SetupMocks: func(m *mocksWrapper) {
+				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.
+					On("GetL1InfoTreeMerkleProof", context.Background(), uint32(7), l1InfoRoot, m.DbTx).
+					Return(state.L1InfoTreeExitRootStorageEntry{}, nil, state.ErrNotFound).
+					Once()
+			},
+		},
+		{
+			Name:          "proof for a leaf not included in the l1 info root",
+			Method:        "zkevm_getL1InfoTreeProof",
+			Params:        []interface{}{"0x7", l1InfoRoot.String()},
+			ExpectedError: types.NewRPCError(types.InvalidParamsErrorCode, fmt.Sprintf("l1 info tree leaf 7 is not included in the l1 info root %v", l1InfoRoot.String())),
+			SetupMocks: func(m *mocksWrapper) {
+				m.DbTx.On("Rollback", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.
+					On("GetL1InfoTreeMerkleProof", context.Background(), uint32(7), l1InfoRoot, m.DbTx).
+					Return(state.L1InfoTreeExitRootStorageEntry{}, nil, state.ErrL1InfoTreeLeafNotIncluded).
+					Once()
+			},
+		},
+		{
+			Name:          "proof for an invalid index",
+			Method:        "zkevm_getL1InfoTreeProof",
+			Params:        []interface{}{"0x100000000", l1InfoRoot.String()},
+			ExpectedError: types.NewRPCError(types.InvalidParamsErrorCode, "invalid l1 info tree index 4294967296"),
+			SetupMocks: func(m *mocksWrapper) {
+				m.DbTx.On("Rollback", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			tc := testCase
+			tc.SetupMocks(m)
+
+			res, err := s.JSONRPCCall(tc.Method, tc.Params...)
+			require.NoError(t, err)
+
+			if tc.ExpectedError == nil {
+				require.Nil(t, res.Error)
+				assert.JSONEq(t, tc.ExpectedResult, string(res.Result))
+			} else {
+				require.NotNil(t, res.Error)
+				assert.Equal(t, tc.ExpectedError.ErrorCode(), res.Error.Code)
+				assert.Equal(t, tc.ExpectedError.Error(), res.Error.Message)
+			}
+		})
+	}
+}
+
+func TestBatchBlockRangeEndpoints(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	type testCase struct {
+		Name           string
+		Method         string
+		Params         []interface{}
+		ExpectedResult string
+		ExpectedError  types.Error
+		SetupMocks     func(m *mocksWrapper)
+	}
+
+	virtualBatch := state.VirtualBatch{BatchNumber: 3, BlockNumber: 10, TxHash: common.HexToHash("0x1"), Coinbase: common.HexToAddress("0x2")}
+	verifiedBatch := state.VerifiedBatch{BatchNumber: 2, BlockNumber: 11, TxHash: common.HexToHash("0x3"), Aggregator: common.HexToAddress("0x4")}
+
+	testCases := []testCase{
+		{
+			Name:           "blocks by batch",
+			Method:         "zkevm_getBlocksByBatch",
+			Params:         []interface{}{"0x3"},
+			ExpectedResult: `{"batchNumber":"0x3","fromBlock":"0x5","toBlock":"0x7"}`,
+			SetupMocks: func(m *mocksWrapper) {
+				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.On("GetBatchByNumber", context.Background(), uint64(3), m.DbTx).Return(&state.Batch{BatchNumber: 3}, nil).Once()
+				m.State.On("GetL2BlockRangeByBatchNumber", context.Background(), uint64(3), m.DbTx).Return(uint64(5), uint64(7), nil).Once()
+			},
+		},
+		{
+			Name:           "blocks by batch without blocks",
+			Method:         "zkevm_getBlocksByBatch",
+			Params:         []interface{}{"0x3"},
+			ExpectedResult: `{"batchNumber":"0x3","fromBlock":null,"toBlock":null}`,
+			SetupMocks: func(m *mocksWrapper) {
+				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.On("GetBatchByNumber", context.Background(), uint64(3), m.DbTx).Return(&state.Batch{BatchNumber: 3}, nil).Once()
+				m.State.On("GetL2BlockRangeByBatchNumber", context.Background(), uint64(3), m.DbTx).Return(uint64(0), uint64(0), state.ErrNotFound).Once()
+			},
+		},
+		{
+			Name:           "blocks by batch not found",
+			Method:         "zkevm_getBlocksByBatch",
+			Params:         []interface{}{"0x3"},
+			ExpectedResult: "null",
+			SetupMocks: func(m *mocksWrapper) {
+				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.On("GetBatchByNumber", context.Background(), uint64(3), m.DbTx).Return(nil, state.ErrNotFound).Once()
+			},
+		},
+		{
+			Name:   "batches by l1 block",
+			Method: "zkevm_getBatchesByL1Block",
+			Params: []interface{}{map[string]interface{}{"fromBlock": "0xa", "toBlock": "0xb"}},
+			ExpectedResult: fmt.Sprintf(`{"virtualizedBatches":[{"number":"0x3","sendSequencesTxHash":"%v","l1BlockNumber":"0xa","coinbase":"%v"}],"verifiedBatches":[{"number":"0x2","verifyBatchTxHash":"%v","l1BlockNumber":"0xb","aggregator":"%v"}]}`,
+				virtualBatch.TxHash.String(), virtualBatch.Coinbase.String(), verifiedBatch.TxHash.String(), verifiedBatch.Aggregator.String()),
+			SetupMocks: func(m *mocksWrapper) {
+				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.On("GetVirtualBatchesByL1BlockRange", context.Background(), uint64(10), uint64(11), m.DbTx).Return([]state.VirtualBatch{virtualBatch}, nil).Once()
+				m.State.On("GetVerifiedBatchesByL1BlockRange", context.Background(), uint64(10), uint64(11), m.DbTx).Return([]state.VerifiedBatch{verifiedBatch}, nil).Once()
+			},
+		},
+		{
+			Name:           "batches by l1 block without batches",
+			Method:         "zkevm_getBatchesByL1Block",
+			Params:         []interface{}{map[string]interface{}{"fromBlock": "0xa", "toBlock": "0xa"}},
+			ExpectedResult: `{"virtualizedBatches":[],"verifiedBatches":[]}`,
+			SetupMocks: func(m *mocksWrapper) {
+				m.DbTx.On("Commit", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+				m.State.On("GetVirtualBatchesByL1BlockRange", context.Background(), uint64(10), uint64(10), m.DbTx).Return([]state.VirtualBatch{}, nil).Once()
+				m.State.On("GetVerifiedBatchesByL1BlockRange", context.Background(), uint64(10), uint64(10), m.DbTx).Return([]state.VerifiedBatch{}, nil).Once()
+			},
+		},
+		{
+			Name:          "batches by l1 block invalid range",
+			Method:        "zkevm_getBatchesByL1Block",
+			Params:        []interface{}{map[string]interface{}{"fromBlock": "0xb", "toBlock": "0xa"}},
+			ExpectedError: types.NewRPCError(types.InvalidParamsErrorCode, state.ErrInvalidBlockRange.Error()),
+			SetupMocks: func(m *mocksWrapper) {
+				m.DbTx.On("Rollback", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+			},
+		},
+		{
+			Name:          "batches by l1 block range bigger than the limit",
+			Method:        "zkevm_getBatchesByL1Block",
+			Params:        []interface{}{map[string]interface{}{"fromBlock": "0x1", "toBlock": "0x3ea"}},
+			ExpectedError: types.NewRPCError(types.InvalidParamsErrorCode, "l1 blocks are limited to a 1000 block range"),
+			SetupMocks: func(m *mocksWrapper) {
+				m.DbTx.On("Rollback", context.Background()).Return(nil).Once()
+				m.State.On("BeginStateTransaction", context.Background()).Return(m.DbTx, nil).Once()
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			tc := testCase
+			tc.SetupMocks(m)
+
+			res, err := s.JSONRPCCall(tc.Method, tc.Params...)
+			require.NoError(t, err)
+
+			if tc.ExpectedError == nil {
+				require.Nil(t, res.Error)
+				assert.JSONEq(t, tc.ExpectedResult, string(res.Result))
+			} else {
+				require.NotNil(t, res.Error)
+				assert.Equal(t, tc.ExpectedError.ErrorCode(), res.Error.Code)
+				assert.Equal(t, tc.ExpectedError.Error(), res.Error.Message)
+			}
+		})
+	}
+}
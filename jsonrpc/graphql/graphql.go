@@ -0,0 +1,1402 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+var (
+	errBlockInvariant    = errors.New("block objects must be instantiated with at least one of number or hash")
+	errInvalidBlockRange = errors.New("invalid from and to block combination: from > to")
+	errNegativeValue     = errors.New("negative values are not allowed")
+
+	errMaxBlockRangeLimitExceeded    = errors.New("blocks are limited to a %v block range")
+	errMaxBlocksLimitExceeded        = errors.New("queries are limited to load %v blocks")
+	errMaxExecutorCallsLimitExceeded = errors.New("queries are limited to %v call and estimateGas executions")
+)
+
+// Long is a 64 bit integer provided in the GraphQL queries, it accepts
+// decimal and hexadecimal representations
+type Long int64
+
+// ImplementsGraphQLType returns true if Long implements the provided GraphQL type.
+func (b Long) ImplementsGraphQLType(name string) bool { return name == "Long" }
+
+// UnmarshalGraphQL unmarshals the provided GraphQL query data.
+func (b *Long) UnmarshalGraphQL(input interface{}) error {
+	var err error
+	switch input := input.(type) {
+	case string:
+		if strings.HasPrefix(input, "0x") {
+			var value uint64
+			value, err = hexutil.DecodeUint64(input)
+			*b = Long(value)
+		} else {
+			var value int64
+			value, err = strconv.ParseInt(input, 10, 64) //nolint:gomnd
+			*b = Long(value)
+		}
+	case int32:
+		*b = Long(input)
+	case int64:
+		*b = Long(input)
+	case float64:
+		*b = Long(input)
+	default:
+		err = fmt.Errorf("unexpected type %T for Long", input)
+	}
+	return err
+}
+
+func (b *Long) toUint64() (*uint64, error) {
+	if b == nil {
+		return nil, nil
+	}
+	if *b < 0 {
+		return nil, errNegativeValue
+	}
+	n := uint64(*b)
+	return &n, nil
+}
+
+// Resolver is the root resolver of the GraphQL queries
+type Resolver struct {
+	chainID           uint64
+	maxBlockRange     uint64
+	maxLogsBlockRange uint64
+	maxBlocks         uint64
+	maxExecutorCalls  uint64
+	state             types.StateInterface
+	pool              types.PoolInterface
+}
+
+// queryCost counts the blocks loaded and the executor calls done by the
+// resolvers of a query, which may be executed in parallel
+type queryCost struct {
+	blocks        uint64
+	executorCalls uint64
+}
+
+type queryCostKey struct{}
+
+// withQueryCost returns a context to count the cost of a query
+func withQueryCost(ctx context.Context) context.Context {
+	return context.WithValue(ctx, queryCostKey{}, &queryCost{})
+}
+
+// addBlocks adds the loaded blocks to the cost of the query, failing
+// when the query loads more blocks than allowed
+func (r *Resolver) addBlocks(ctx context.Context, blocks uint64) error {
+	cost, ok := ctx.Value(queryCostKey{}).(*queryCost)
+	if !ok || r.maxBlocks == 0 {
+		return nil
+	}
+	if atomic.AddUint64(&cost.blocks, blocks) > r.maxBlocks {
+		return fmt.Errorf(errMaxBlocksLimitExceeded.Error(), r.maxBlocks)
+	}
+	return nil
+}
+
+// addExecutorCall adds an executor call to the cost of the query, failing
+// when the query does more executor calls than allowed
+func (r *Resolver) addExecutorCall(ctx context.Context) error {
+	cost, ok := ctx.Value(queryCostKey{}).(*queryCost)
+	if !ok || r.maxExecutorCalls == 0 {
+		return nil
+	}
+	if atomic.AddUint64(&cost.executorCalls, 1) > r.maxExecutorCalls {
+		return fmt.Errorf(errMaxExecutorCallsLimitExceeded.Error(), r.maxExecutorCalls)
+	}
+	return nil
+}
+
+// Account represents an account at a particular L2 block, the latest
+// L2 block is used when the block number is not provided
+type Account struct {
+	r           *Resolver
+	address     common.Address
+	blockNumber *uint64
+}
+
+func (a *Account) root(ctx context.Context) (common.Hash, error) {
+	if a.blockNumber == nil {
+		block, err := a.r.state.GetLastL2Block(ctx, nil)
+		if err != nil {
+			return common.Hash{}, err
+		}
+		return block.Root(), nil
+	}
+	header, err := a.r.state.GetL2BlockHeaderByNumber(ctx, *a.blockNumber, nil)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return header.Root, nil
+}
+
+// Address returns the address of the account
+func (a *Account) Address(ctx context.Context) (common.Address, error) {
+	return a.address, nil
+}
+
+// Balance returns the balance of the account
+func (a *Account) Balance(ctx context.Context) (hexutil.Big, error) {
+	root, err := a.root(ctx)
+	if err != nil {
+		return hexutil.Big{}, err
+	}
+	balance, err := a.r.state.GetBalance(ctx, a.address, root)
+	if errors.Is(err, state.ErrNotFound) {
+		return hexutil.Big{}, nil
+	} else if err != nil {
+		return hexutil.Big{}, err
+	}
+	return hexutil.Big(*balance), nil
+}
+
+// TransactionCount returns the nonce of the account
+func (a *Account) TransactionCount(ctx context.Context) (hexutil.Uint64, error) {
+	root, err := a.root(ctx)
+	if err != nil {
+		return 0, err
+	}
+	nonce, err := a.r.state.GetNonce(ctx, a.address, root)
+	if errors.Is(err, state.ErrNotFound) {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+	return hexutil.Uint64(nonce), nil
+}
+
+// Code returns the code of the account
+func (a *Account) Code(ctx context.Context) (hexutil.Bytes, error) {
+	root, err := a.root(ctx)
+	if err != nil {
+		return nil, err
+	}
+	code, err := a.r.state.GetCode(ctx, a.address, root)
+	if errors.Is(err, state.ErrNotFound) {
+		return hexutil.Bytes{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(code), nil
+}
+
+// Storage returns the value stored in the provided storage slot of the account
+func (a *Account) Storage(ctx context.Context, args struct{ Slot common.Hash }) (common.Hash, error) {
+	root, err := a.root(ctx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	value, err := a.r.state.GetStorageAt(ctx, a.address, args.Slot.Big(), root)
+	if errors.Is(err, state.ErrNotFound) {
+		return common.Hash{}, nil
+	} else if err != nil {
+		return common.Hash{}, err
+	}
+	return common.BigToHash(value), nil
+}
+
+// Log represents a log emitted by a transaction
+type Log struct {
+	r   *Resolver
+	log *ethTypes.Log
+}
+
+// Index returns the index of the log in the block
+func (l *Log) Index(ctx context.Context) hexutil.Uint64 {
+	return hexutil.Uint64(l.log.Index)
+}
+
+// Account returns the account that emitted the log
+func (l *Log) Account(ctx context.Context, args BlockNumberArgs) (*Account, error) {
+	blockNumber, err := args.Block.toUint64()
+	if err != nil {
+		return nil, err
+	}
+	return &Account{r: l.r, address: l.log.Address, blockNumber: blockNumber}, nil
+}
+
+// Topics returns the topics of the log
+func (l *Log) Topics(ctx context.Context) []common.Hash {
+	return l.log.Topics
+}
+
+// Data returns the data of the log
+func (l *Log) Data(ctx context.Context) hexutil.Bytes {
+	return l.log.Data
+}
+
+// Transaction returns the transaction that emitted the log
+func (l *Log) Transaction(ctx context.Context) *Transaction {
+	return &Transaction{r: l.r, hash: l.log.TxHash}
+}
+
+// BlockNumberArgs are the arguments of the fields that return an account at
+// a particular L2 block
+type BlockNumberArgs struct {
+	Block *Long
+}
+
+// Transaction represents a L2 transaction, it is loaded lazily by hash
+type Transaction struct {
+	r    *Resolver
+	hash common.Hash
+
+	mu sync.Mutex
+	tx *types.Transaction
+}
+
+// resolve loads the transaction and its receipt, returning nil if the
+// transaction is not found
+func (t *Transaction) resolve(ctx context.Context) (*types.Transaction, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.tx != nil {
+		return t.tx, nil
+	}
+	tx, err := t.r.state.GetTransactionByHash(ctx, t.hash, nil)
+	if errors.Is(err, state.ErrNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	receipt, err := t.r.state.GetTransactionReceipt(ctx, t.hash, nil)
+	if err != nil {
+		return nil, err
+	}
+	t.tx, err = types.NewTransaction(*tx, receipt, true, nil)
+	if err != nil {
+		return nil, err
+	}
+	return t.tx, nil
+}
+
+func (t *Transaction) resolveReceipt(ctx context.Context) (*types.Receipt, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return nil, err
+	}
+	return tx.Receipt, nil
+}
+
+// Hash returns the hash of the transaction
+func (t *Transaction) Hash(ctx context.Context) common.Hash {
+	return t.hash
+}
+
+// L2Hash returns the L2 hash of the transaction
+func (t *Transaction) L2Hash(ctx context.Context) (*common.Hash, error) {
+	l2Hash, err := t.r.state.GetL2TxHashByTxHash(ctx, t.hash, nil)
+	if errors.Is(err, state.ErrNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return l2Hash, nil
+}
+
+// Nonce returns the nonce of the transaction
+func (t *Transaction) Nonce(ctx context.Context) (hexutil.Uint64, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return 0, err
+	}
+	return hexutil.Uint64(tx.Nonce), nil
+}
+
+// Index returns the index of the transaction in the block
+func (t *Transaction) Index(ctx context.Context) (*hexutil.Uint64, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil || tx.TxIndex == nil {
+		return nil, err
+	}
+	index := hexutil.Uint64(*tx.TxIndex)
+	return &index, nil
+}
+
+// From returns the account that sent the transaction
+func (t *Transaction) From(ctx context.Context, args BlockNumberArgs) (*Account, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return nil, err
+	}
+	blockNumber, err := args.Block.toUint64()
+	if err != nil {
+		return nil, err
+	}
+	return &Account{r: t.r, address: tx.From, blockNumber: blockNumber}, nil
+}
+
+// To returns the account the transaction was sent to, nil for contract creations
+func (t *Transaction) To(ctx context.Context, args BlockNumberArgs) (*Account, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil || tx.To == nil {
+		return nil, err
+	}
+	blockNumber, err := args.Block.toUint64()
+	if err != nil {
+		return nil, err
+	}
+	return &Account{r: t.r, address: *tx.To, blockNumber: blockNumber}, nil
+}
+
+// Value returns the value transferred by the transaction
+func (t *Transaction) Value(ctx context.Context) (hexutil.Big, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return hexutil.Big{}, err
+	}
+	return hexutil.Big(tx.Value), nil
+}
+
+// GasPrice returns the gas price of the transaction
+func (t *Transaction) GasPrice(ctx context.Context) (hexutil.Big, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return hexutil.Big{}, err
+	}
+	return hexutil.Big(tx.GasPrice), nil
+}
+
+// MaxFeePerGas returns the fee cap of a dynamic fee transaction
+func (t *Transaction) MaxFeePerGas(ctx context.Context) (*hexutil.Big, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil || tx.MaxFeePerGas == nil {
+		return nil, err
+	}
+	maxFeePerGas := hexutil.Big(*tx.MaxFeePerGas)
+	return &maxFeePerGas, nil
+}
+
+// MaxPriorityFeePerGas returns the tip of a dynamic fee transaction
+func (t *Transaction) MaxPriorityFeePerGas(ctx context.Context) (*hexutil.Big, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil || tx.MaxPriorityFeePerGas == nil {
+		return nil, err
+	}
+	maxPriorityFeePerGas := hexutil.Big(*tx.MaxPriorityFeePerGas)
+	return &maxPriorityFeePerGas, nil
+}
+
+// Gas returns the gas limit of the transaction
+func (t *Transaction) Gas(ctx context.Context) (hexutil.Uint64, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return 0, err
+	}
+	return hexutil.Uint64(tx.Gas), nil
+}
+
+// InputData returns the data of the transaction
+func (t *Transaction) InputData(ctx context.Context) (hexutil.Bytes, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return hexutil.Bytes{}, err
+	}
+	return hexutil.Bytes(tx.Input), nil
+}
+
+// Block returns the L2 block that includes the transaction
+func (t *Transaction) Block(ctx context.Context) (*Block, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil || tx.BlockNumber == nil {
+		return nil, err
+	}
+	blockNumber := uint64(*tx.BlockNumber)
+	return &Block{r: t.r, number: &blockNumber}, nil
+}
+
+// Status returns the status of the transaction execution
+func (t *Transaction) Status(ctx context.Context) (*hexutil.Uint64, error) {
+	receipt, err := t.resolveReceipt(ctx)
+	if err != nil || receipt == nil {
+		return nil, err
+	}
+	status := hexutil.Uint64(receipt.Status)
+	return &status, nil
+}
+
+// GasUsed returns the gas used by the transaction
+func (t *Transaction) GasUsed(ctx context.Context) (*hexutil.Uint64, error) {
+	receipt, err := t.resolveReceipt(ctx)
+	if err != nil || receipt == nil {
+		return nil, err
+	}
+	gasUsed := hexutil.Uint64(receipt.GasUsed)
+	return &gasUsed, nil
+}
+
+// CumulativeGasUsed returns the gas used by the block up to the transaction
+func (t *Transaction) CumulativeGasUsed(ctx context.Context) (*hexutil.Uint64, error) {
+	receipt, err := t.resolveReceipt(ctx)
+	if err != nil || receipt == nil {
+		return nil, err
+	}
+	cumulativeGasUsed := hexutil.Uint64(receipt.CumulativeGasUsed)
+	return &cumulativeGasUsed, nil
+}
+
+// EffectiveGasPrice returns the effective gas price paid by the transaction
+func (t *Transaction) EffectiveGasPrice(ctx context.Context) (*hexutil.Big, error) {
+	receipt, err := t.resolveReceipt(ctx)
+	if err != nil || receipt == nil || receipt.EffectiveGasPrice == nil {
+		return nil, err
+	}
+	effectiveGasPrice := hexutil.Big(*receipt.EffectiveGasPrice)
+	return &effectiveGasPrice, nil
+}
+
+// CreatedContract returns the contract created by the transaction, if any
+func (t *Transaction) CreatedContract(ctx context.Context, args BlockNumberArgs) (*Account, error) {
+	receipt, err := t.resolveReceipt(ctx)
+	if err != nil || receipt == nil || receipt.ContractAddress == nil {
+		return nil, err
+	}
+	blockNumber, err := args.Block.toUint64()
+	if err != nil {
+		return nil, err
+	}
+	return &Account{r: t.r, address: *receipt.ContractAddress, blockNumber: blockNumber}, nil
+}
+
+// Logs returns the logs emitted by the transaction
+func (t *Transaction) Logs(ctx context.Context) (*[]*Log, error) {
+	receipt, err := t.resolveReceipt(ctx)
+	if err != nil || receipt == nil {
+		return nil, err
+	}
+	logs := make([]*Log, 0, len(receipt.Logs))
+	for _, l := range receipt.Logs {
+		logs = append(logs, &Log{r: t.r, log: l})
+	}
+	return &logs, nil
+}
+
+// R returns the R value of the transaction signature
+func (t *Transaction) R(ctx context.Context) (hexutil.Big, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return hexutil.Big{}, err
+	}
+	return hexutil.Big(tx.R), nil
+}
+
+// S returns the S value of the transaction signature
+func (t *Transaction) S(ctx context.Context) (hexutil.Big, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return hexutil.Big{}, err
+	}
+	return hexutil.Big(tx.S), nil
+}
+
+// V returns the V value of the transaction signature
+func (t *Transaction) V(ctx context.Context) (hexutil.Big, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return hexutil.Big{}, err
+	}
+	return hexutil.Big(tx.V), nil
+}
+
+// Type returns the type of the transaction
+func (t *Transaction) Type(ctx context.Context) (*hexutil.Uint64, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return nil, err
+	}
+	txType := hexutil.Uint64(tx.Type)
+	return &txType, nil
+}
+
+// BlockFilterCriteria filters the logs of a block
+type BlockFilterCriteria struct {
+	Addresses *[]common.Address
+	Topics    *[][]common.Hash
+}
+
+// FilterCriteria filters the logs of a range of L2 blocks
+type FilterCriteria struct {
+	FromBlock *Long
+	ToBlock   *Long
+	Addresses *[]common.Address
+	Topics    *[][]common.Hash
+}
+
+// CallData are the arguments of the unsigned transactions executed
+// by call and estimateGas
+type CallData struct {
+	From     *common.Address
+	To       *common.Address
+	Gas      *Long
+	GasPrice *hexutil.Big
+	Value    *hexutil.Big
+	Data     *hexutil.Bytes
+}
+
+func (c CallData) toTxArgs() (*types.TxArgs, error) {
+	args := &types.TxArgs{
+		From: c.From,
+		To:   c.To,
+	}
+	if c.Gas != nil {
+		gas, err := c.Gas.toUint64()
+		if err != nil {
+			return nil, err
+		}
+		args.Gas = types.ArgUint64Ptr(types.ArgUint64(*gas))
+	}
+	if c.GasPrice != nil {
+		args.GasPrice = types.ArgBytesPtr(c.GasPrice.ToInt().Bytes())
+	}
+	if c.Value != nil {
+		args.Value = types.ArgBytesPtr(c.Value.ToInt().Bytes())
+	}
+	if c.Data != nil {
+		args.Data = types.ArgBytesPtr(*c.Data)
+	}
+	return args, nil
+}
+
+// CallResult is the result of the execution of an unsigned transaction
+type CallResult struct {
+	data    hexutil.Bytes
+	gasUsed hexutil.Uint64
+	status  hexutil.Uint64
+}
+
+// Data returns the value returned by the execution
+func (c *CallResult) Data() hexutil.Bytes {
+	return c.data
+}
+
+// GasUsed returns the gas used by the execution
+func (c *CallResult) GasUsed() hexutil.Uint64 {
+	return c.gasUsed
+}
+
+// Status returns 1 if the execution succeeded and 0 otherwise
+func (c *CallResult) Status() hexutil.Uint64 {
+	return c.status
+}
+
+// Block represents a L2 block, it is loaded lazily by number or hash
+type Block struct {
+	r      *Resolver
+	number *uint64
+	hash   *common.Hash
+
+	mu    sync.Mutex
+	block *state.L2Block
+	txs   []*Transaction
+}
+
+// resolve loads the block, returning nil if the block is not found
+func (b *Block) resolve(ctx context.Context) (*state.L2Block, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.block != nil {
+		return b.block, nil
+	}
+	if err := b.r.addBlocks(ctx, 1); err != nil {
+		return nil, err
+	}
+
+	var block *state.L2Block
+	var err error
+	if b.number != nil {
+		block, err = b.r.state.GetL2BlockByNumber(ctx, *b.number, nil)
+	} else if b.hash != nil {
+		block, err = b.r.state.GetL2BlockByHash(ctx, *b.hash, nil)
+	} else {
+		return nil, errBlockInvariant
+	}
+	if errors.Is(err, state.ErrNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	b.setBlock(block)
+	return b.block, nil
+}
+
+func (b *Block) setBlock(block *state.L2Block) {
+	number, hash := block.NumberU64(), block.Hash()
+	b.block, b.number, b.hash = block, &number, &hash
+}
+
+// Number returns the number of the block
+func (b *Block) Number(ctx context.Context) (hexutil.Uint64, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return 0, err
+	}
+	return hexutil.Uint64(block.NumberU64()), nil
+}
+
+// Hash returns the hash of the block
+func (b *Block) Hash(ctx context.Context) (common.Hash, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return common.Hash{}, err
+	}
+	return block.Hash(), nil
+}
+
+// Parent returns the parent of the block, nil for the genesis block
+func (b *Block) Parent(ctx context.Context) (*Block, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil || block.NumberU64() == 0 {
+		return nil, err
+	}
+	parentHash := block.ParentHash()
+	return &Block{r: b.r, hash: &parentHash}, nil
+}
+
+// Nonce returns the nonce of the block
+func (b *Block) Nonce(ctx context.Context) (hexutil.Bytes, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return hexutil.Bytes{}, err
+	}
+	nonce := ethTypes.EncodeNonce(block.Nonce())
+	return hexutil.Bytes(nonce[:]), nil
+}
+
+// TransactionsRoot returns the root of the transactions trie of the block
+func (b *Block) TransactionsRoot(ctx context.Context) (common.Hash, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return common.Hash{}, err
+	}
+	return block.TxHash(), nil
+}
+
+// TransactionCount returns the number of transactions in the block
+func (b *Block) TransactionCount(ctx context.Context) (*hexutil.Uint64, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return nil, err
+	}
+	count := hexutil.Uint64(len(block.Transactions()))
+	return &count, nil
+}
+
+// StateRoot returns the state root after the execution of the block
+func (b *Block) StateRoot(ctx context.Context) (common.Hash, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return common.Hash{}, err
+	}
+	return block.Root(), nil
+}
+
+// ReceiptsRoot returns the root of the receipts trie of the block
+func (b *Block) ReceiptsRoot(ctx context.Context) (common.Hash, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return common.Hash{}, err
+	}
+	return block.ReceiptHash(), nil
+}
+
+// Miner returns the coinbase account of the block
+func (b *Block) Miner(ctx context.Context, args BlockNumberArgs) (*Account, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return nil, err
+	}
+	blockNumber, err := args.Block.toUint64()
+	if err != nil {
+		return nil, err
+	}
+	return &Account{r: b.r, address: block.Coinbase(), blockNumber: blockNumber}, nil
+}
+
+// ExtraData returns the extra data of the block
+func (b *Block) ExtraData(ctx context.Context) (hexutil.Bytes, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return hexutil.Bytes{}, err
+	}
+	return hexutil.Bytes(block.Extra()), nil
+}
+
+// GasLimit returns the gas limit of the block
+func (b *Block) GasLimit(ctx context.Context) (hexutil.Uint64, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return 0, err
+	}
+	return hexutil.Uint64(block.GasLimit()), nil
+}
+
+// GasUsed returns the gas used by the transactions of the block
+func (b *Block) GasUsed(ctx context.Context) (hexutil.Uint64, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return 0, err
+	}
+	return hexutil.Uint64(block.GasUsed()), nil
+}
+
+// Timestamp returns the timestamp of the block
+func (b *Block) Timestamp(ctx context.Context) (hexutil.Uint64, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return 0, err
+	}
+	return hexutil.Uint64(block.Time()), nil
+}
+
+// LogsBloom returns the bloom filter of the logs of the block
+func (b *Block) LogsBloom(ctx context.Context) (hexutil.Bytes, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return hexutil.Bytes{}, err
+	}
+	return hexutil.Bytes(block.Bloom().Bytes()), nil
+}
+
+// MixHash returns the mix hash of the block
+func (b *Block) MixHash(ctx context.Context) (common.Hash, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return common.Hash{}, err
+	}
+	return block.MixDigest(), nil
+}
+
+// Difficulty returns the difficulty of the block
+func (b *Block) Difficulty(ctx context.Context) (hexutil.Big, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return hexutil.Big{}, err
+	}
+	return hexutil.Big(*block.Difficulty()), nil
+}
+
+// TotalDifficulty returns the total difficulty of the chain up to the block,
+// which is always zero in the L2
+func (b *Block) TotalDifficulty(ctx context.Context) (hexutil.Big, error) {
+	return hexutil.Big{}, nil
+}
+
+// OmmerCount returns the number of uncles of the block, which is always zero in the L2
+func (b *Block) OmmerCount(ctx context.Context) (*hexutil.Uint64, error) {
+	count := hexutil.Uint64(0)
+	return &count, nil
+}
+
+// Ommers returns the uncles of the block, which is always empty in the L2
+func (b *Block) Ommers(ctx context.Context) (*[]*Block, error) {
+	ommers := []*Block{}
+	return &ommers, nil
+}
+
+// OmmerAt returns the uncle of the block at the provided index, which is always nil in the L2
+func (b *Block) OmmerAt(ctx context.Context, args struct{ Index Long }) (*Block, error) {
+	return nil, nil
+}
+
+// OmmerHash returns the hash of the uncles of the block
+func (b *Block) OmmerHash(ctx context.Context) (common.Hash, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return common.Hash{}, err
+	}
+	return block.UncleHash(), nil
+}
+
+// Transactions returns the transactions of the block
+func (b *Block) Transactions(ctx context.Context) (*[]*Transaction, error) {
+	txs, err := b.resolveTransactions(ctx)
+	if err != nil || txs == nil {
+		return nil, err
+	}
+	return &txs, nil
+}
+
+// TransactionAt returns the transaction of the block at the provided index
+func (b *Block) TransactionAt(ctx context.Context, args struct{ Index Long }) (*Transaction, error) {
+	txs, err := b.resolveTransactions(ctx)
+	if err != nil || txs == nil {
+		return nil, err
+	}
+	if args.Index < 0 || int(args.Index) >= len(txs) {
+		return nil, nil
+	}
+	return txs[args.Index], nil
+}
+
+func (b *Block) resolveTransactions(ctx context.Context) ([]*Transaction, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return nil, err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.txs != nil {
+		return b.txs, nil
+	}
+
+	txs, receipts, err := b.r.state.GetL2BlockReceipts(ctx, block.NumberU64(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(txs) != len(receipts) {
+		return nil, fmt.Errorf("the block %v has %v txs and %v receipts", block.NumberU64(), len(txs), len(receipts))
+	}
+	res := make([]*Transaction, 0, len(txs))
+	for i, tx := range txs {
+		rpcTx, err := types.NewTransaction(*tx, receipts[i], true, nil)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, &Transaction{r: b.r, hash: tx.Hash(), tx: rpcTx})
+	}
+	b.txs = res
+	return b.txs, nil
+}
+
+// Logs returns the logs of the block that match the provided filter
+func (b *Block) Logs(ctx context.Context, args struct{ Filter BlockFilterCriteria }) ([]*Log, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return nil, err
+	}
+	return b.r.getLogs(ctx, block.NumberU64(), block.NumberU64(), args.Filter.Addresses, args.Filter.Topics)
+}
+
+// Account returns the provided account at this block
+func (b *Block) Account(ctx context.Context, args struct{ Address common.Address }) (*Account, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return nil, err
+	}
+	blockNumber := block.NumberU64()
+	return &Account{r: b.r, address: args.Address, blockNumber: &blockNumber}, nil
+}
+
+// Call executes an unsigned transaction on top of the state of this block
+func (b *Block) Call(ctx context.Context, args struct{ Data CallData }) (*CallResult, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return nil, err
+	}
+	if err := b.r.addExecutorCall(ctx); err != nil {
+		return nil, err
+	}
+	blockNumber := block.NumberU64()
+	sender, tx, err := b.r.toTransaction(ctx, args.Data, block)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := b.r.state.ProcessUnsignedTransaction(ctx, tx, sender, &blockNumber, true, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	status := hexutil.Uint64(1)
+	if result.Failed() {
+		status = 0
+	}
+	return &CallResult{
+		data:    hexutil.Bytes(result.ReturnValue),
+		gasUsed: hexutil.Uint64(result.GasUsed),
+		status:  status,
+	}, nil
+}
+
+// EstimateGas estimates the gas needed to execute an unsigned transaction on top of the state of this block
+func (b *Block) EstimateGas(ctx context.Context, args struct{ Data CallData }) (hexutil.Uint64, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return 0, err
+	}
+	if err := b.r.addExecutorCall(ctx); err != nil {
+		return 0, err
+	}
+	blockNumber := block.NumberU64()
+	sender, tx, err := b.r.toTransaction(ctx, args.Data, block)
+	if err != nil {
+		return 0, err
+	}
+
+	gas, _, err := b.r.state.EstimateGas(tx, sender, &blockNumber, nil, nil)
+	if err != nil {
+		return 0, err
+	}
+	return hexutil.Uint64(gas), nil
+}
+
+// GlobalExitRoot returns the global exit root used by the block
+func (b *Block) GlobalExitRoot(ctx context.Context) (common.Hash, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return common.Hash{}, err
+	}
+	return block.GlobalExitRoot(), nil
+}
+
+// BlockInfoRoot returns the block info root of the block
+func (b *Block) BlockInfoRoot(ctx context.Context) (common.Hash, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return common.Hash{}, err
+	}
+	return block.BlockInfoRoot(), nil
+}
+
+// Batch returns the batch that includes the block
+func (b *Block) Batch(ctx context.Context) (*Batch, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return nil, err
+	}
+	batchNumber, err := b.r.state.BatchNumberByL2BlockNumber(ctx, block.NumberU64(), nil)
+	if err != nil {
+		return nil, err
+	}
+	return &Batch{r: b.r, number: batchNumber}, nil
+}
+
+// Batch represents a zkEVM batch, it is loaded lazily by number
+type Batch struct {
+	r      *Resolver
+	number uint64
+
+	mu    sync.Mutex
+	batch *state.Batch
+}
+
+// resolve loads the batch, returning nil if the batch is not found
+func (b *Batch) resolve(ctx context.Context) (*state.Batch, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.batch != nil {
+		return b.batch, nil
+	}
+
+	batch, err := b.r.state.GetBatchByNumber(ctx, b.number, nil)
+	if errors.Is(err, state.ErrNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	b.batch = batch
+	return b.batch, nil
+}
+
+// Number returns the number of the batch
+func (b *Batch) Number(ctx context.Context) hexutil.Uint64 {
+	return hexutil.Uint64(b.number)
+}
+
+// Timestamp returns the timestamp of the batch
+func (b *Batch) Timestamp(ctx context.Context) (hexutil.Uint64, error) {
+	timestamp, err := b.r.state.GetBatchTimestamp(ctx, b.number, nil, nil)
+	if err != nil || timestamp == nil {
+		return 0, err
+	}
+	return hexutil.Uint64(timestamp.Unix()), nil
+}
+
+// Coinbase returns the address that receives the fees of the batch
+func (b *Batch) Coinbase(ctx context.Context) (common.Address, error) {
+	batch, err := b.resolve(ctx)
+	if err != nil || batch == nil {
+		return common.Address{}, err
+	}
+	return batch.Coinbase, nil
+}
+
+// StateRoot returns the state root after the execution of the batch
+func (b *Batch) StateRoot(ctx context.Context) (common.Hash, error) {
+	batch, err := b.resolve(ctx)
+	if err != nil || batch == nil {
+		return common.Hash{}, err
+	}
+	return batch.StateRoot, nil
+}
+
+// LocalExitRoot returns the local exit root after the execution of the batch
+func (b *Batch) LocalExitRoot(ctx context.Context) (common.Hash, error) {
+	batch, err := b.resolve(ctx)
+	if err != nil || batch == nil {
+		return common.Hash{}, err
+	}
+	return batch.LocalExitRoot, nil
+}
+
+// AccInputHash returns the accumulated input hash of the batch
+func (b *Batch) AccInputHash(ctx context.Context) (common.Hash, error) {
+	batch, err := b.resolve(ctx)
+	if err != nil || batch == nil {
+		return common.Hash{}, err
+	}
+	return batch.AccInputHash, nil
+}
+
+// GlobalExitRoot returns the global exit root of the batch
+func (b *Batch) GlobalExitRoot(ctx context.Context) (common.Hash, error) {
+	batch, err := b.resolve(ctx)
+	if err != nil || batch == nil {
+		return common.Hash{}, err
+	}
+	return batch.GlobalExitRoot, nil
+}
+
+// ForcedBatchNumber returns the forced batch number, nil if the batch is not forced
+func (b *Batch) ForcedBatchNumber(ctx context.Context) (*hexutil.Uint64, error) {
+	batch, err := b.resolve(ctx)
+	if err != nil || batch == nil || batch.ForcedBatchNum == nil {
+		return nil, err
+	}
+	forcedBatchNumber := hexutil.Uint64(*batch.ForcedBatchNum)
+	return &forcedBatchNumber, nil
+}
+
+// Closed returns true if the batch is closed
+func (b *Batch) Closed(ctx context.Context) (bool, error) {
+	batch, err := b.resolve(ctx)
+	if err != nil || batch == nil {
+		return false, err
+	}
+	return !batch.WIP, nil
+}
+
+// Blocks returns the L2 blocks of the batch
+func (b *Batch) Blocks(ctx context.Context) ([]*Block, error) {
+	blocks, err := b.r.state.GetL2BlocksByBatchNumber(ctx, b.number, nil)
+	if errors.Is(err, state.ErrNotFound) {
+		return []*Block{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err := b.r.addBlocks(ctx, uint64(len(blocks))); err != nil {
+		return nil, err
+	}
+	res := make([]*Block, 0, len(blocks))
+	for i := range blocks {
+		block := &Block{r: b.r}
+		block.setBlock(&blocks[i])
+		res = append(res, block)
+	}
+	return res, nil
+}
+
+// SendSequencesTxHash returns the hash of the L1 transaction that sequenced the
+// batch, nil if the batch is not virtualized yet
+func (b *Batch) SendSequencesTxHash(ctx context.Context) (*common.Hash, error) {
+	virtualBatch, err := b.resolveVirtualBatch(ctx)
+	if err != nil || virtualBatch == nil {
+		return nil, err
+	}
+	return &virtualBatch.TxHash, nil
+}
+
+// L1InfoRoot returns the L1 info root used to sequence the batch, nil if the
+// batch is not virtualized yet or it was sequenced before etrog
+func (b *Batch) L1InfoRoot(ctx context.Context) (*common.Hash, error) {
+	virtualBatch, err := b.resolveVirtualBatch(ctx)
+	if err != nil || virtualBatch == nil {
+		return nil, err
+	}
+	return virtualBatch.L1InfoRoot, nil
+}
+
+func (b *Batch) resolveVirtualBatch(ctx context.Context) (*state.VirtualBatch, error) {
+	virtualBatch, err := b.r.state.GetVirtualBatch(ctx, b.number, nil)
+	if errors.Is(err, state.ErrNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return virtualBatch, nil
+}
+
+// VerifiedBatch returns the L1 verification that covers the batch, nil if the
+// batch is not verified yet
+func (b *Batch) VerifiedBatch(ctx context.Context) (*VerifiedBatch, error) {
+	verifiedBatch, _, err := b.r.state.GetVerifiedBatchCoveringBatch(ctx, b.number, nil)
+	if errors.Is(err, state.ErrNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return &VerifiedBatch{verifiedBatch: verifiedBatch}, nil
+}
+
+// VerifiedBatch represents the L1 verification of a range of batches
+type VerifiedBatch struct {
+	verifiedBatch *state.VerifiedBatch
+}
+
+// BatchNumber returns the last batch number verified
+func (v *VerifiedBatch) BatchNumber() hexutil.Uint64 {
+	return hexutil.Uint64(v.verifiedBatch.BatchNumber)
+}
+
+// L1BlockNumber returns the number of the L1 block that includes the verification
+func (v *VerifiedBatch) L1BlockNumber() hexutil.Uint64 {
+	return hexutil.Uint64(v.verifiedBatch.BlockNumber)
+}
+
+// TxHash returns the hash of the L1 transaction that verified the batches
+func (v *VerifiedBatch) TxHash() common.Hash {
+	return v.verifiedBatch.TxHash
+}
+
+// Aggregator returns the address of the aggregator that verified the batches
+func (v *VerifiedBatch) Aggregator() common.Address {
+	return v.verifiedBatch.Aggregator
+}
+
+// StateRoot returns the state root verified
+func (v *VerifiedBatch) StateRoot() common.Hash {
+	return v.verifiedBatch.StateRoot
+}
+
+// SyncState represents the syncing status of the node
+type SyncState struct {
+	info state.SyncingInfo
+}
+
+// StartingBlock returns the L2 block where the sync started
+func (s *SyncState) StartingBlock() hexutil.Uint64 {
+	return hexutil.Uint64(s.info.InitialSyncingBlock)
+}
+
+// CurrentBlock returns the last L2 block synced
+func (s *SyncState) CurrentBlock() hexutil.Uint64 {
+	return hexutil.Uint64(s.info.CurrentBlockNumber)
+}
+
+// HighestBlock returns the estimated highest L2 block
+func (s *SyncState) HighestBlock() hexutil.Uint64 {
+	return hexutil.Uint64(s.info.EstimatedHighestBlock)
+}
+
+// Block returns the L2 block by number or hash, the latest L2 block is
+// returned when none of them is provided
+func (r *Resolver) Block(ctx context.Context, args struct {
+	Number *Long
+	Hash   *common.Hash
+}) (*Block, error) {
+	if args.Number != nil && args.Hash != nil {
+		return nil, errors.New("only one of number or hash must be specified")
+	}
+
+	block := &Block{r: r, hash: args.Hash}
+	if args.Hash == nil {
+		number, err := args.Number.toUint64()
+		if err != nil {
+			return nil, err
+		}
+		if number == nil {
+			lastBlockNumber, err := r.state.GetLastL2BlockNumber(ctx, nil)
+			if err != nil {
+				return nil, err
+			}
+			number = &lastBlockNumber
+		}
+		block.number = number
+	}
+
+	l2Block, err := block.resolve(ctx)
+	if err != nil || l2Block == nil {
+		return nil, err
+	}
+	return block, nil
+}
+
+// Blocks returns the existing L2 blocks in the provided range, the latest L2
+// block is used when any of the ends of the range is not provided
+func (r *Resolver) Blocks(ctx context.Context, args struct {
+	From *Long
+	To   *Long
+}) ([]*Block, error) {
+	from, to, err := r.getBlockRange(ctx, args.From, args.To)
+	if err != nil {
+		return nil, err
+	}
+	if r.maxBlockRange > 0 && to-from > r.maxBlockRange {
+		return nil, fmt.Errorf(errMaxBlockRangeLimitExceeded.Error(), r.maxBlockRange)
+	}
+
+	blocks := make([]*Block, 0, to-from+1)
+	for n := from; n <= to; n++ {
+		number := n
+		block := &Block{r: r, number: &number}
+		l2Block, err := block.resolve(ctx)
+		if err != nil {
+			return nil, err
+		} else if l2Block == nil {
+			break
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks, nil
+}
+
+// Batch returns the batch by number, the latest batch is returned when the
+// number is not provided
+func (r *Resolver) Batch(ctx context.Context, args struct{ Number *Long }) (*Batch, error) {
+	number, err := args.Number.toUint64()
+	if err != nil {
+		return nil, err
+	}
+	if number == nil {
+		lastBatchNumber, err := r.state.GetLastBatchNumber(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		number = &lastBatchNumber
+	}
+
+	batch := &Batch{r: r, number: *number}
+	stateBatch, err := batch.resolve(ctx)
+	if err != nil || stateBatch == nil {
+		return nil, err
+	}
+	return batch, nil
+}
+
+// Transaction returns the L2 transaction by hash
+func (r *Resolver) Transaction(ctx context.Context, args struct{ Hash common.Hash }) (*Transaction, error) {
+	tx := &Transaction{r: r, hash: args.Hash}
+	rpcTx, err := tx.resolve(ctx)
+	if err != nil || rpcTx == nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// Logs returns the logs that match the provided filter
+func (r *Resolver) Logs(ctx context.Context, args struct{ Filter FilterCriteria }) ([]*Log, error) {
+	from, to, err := r.getBlockRange(ctx, args.Filter.FromBlock, args.Filter.ToBlock)
+	if err != nil {
+		return nil, err
+	}
+	if r.maxLogsBlockRange > 0 && to-from > r.maxLogsBlockRange {
+		return nil, fmt.Errorf(state.ErrMaxLogsBlockRangeLimitExceeded.Error(), r.maxLogsBlockRange)
+	}
+	return r.getLogs(ctx, from, to, args.Filter.Addresses, args.Filter.Topics)
+}
+
+// GasPrice returns the L2 gas price suggested by the node
+func (r *Resolver) GasPrice(ctx context.Context) (hexutil.Big, error) {
+	gasPrices, err := r.pool.GetGasPrices(ctx)
+	if err != nil {
+		return hexutil.Big{}, err
+	}
+	return hexutil.Big(*new(big.Int).SetUint64(gasPrices.L2GasPrice)), nil
+}
+
+// MaxPriorityFeePerGas returns the same value as GasPrice, since there is no base fee
+func (r *Resolver) MaxPriorityFeePerGas(ctx context.Context) (hexutil.Big, error) {
+	return r.GasPrice(ctx)
+}
+
+// Syncing returns the syncing status of the node, nil if the node is synced
+func (r *Resolver) Syncing(ctx context.Context) (*SyncState, error) {
+	info, err := r.state.GetSyncingInfo(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsSynchronizing {
+		return nil, nil
+	}
+	return &SyncState{info: info}, nil
+}
+
+// ChainID returns the L2 chain id
+func (r *Resolver) ChainID(ctx context.Context) hexutil.Big {
+	return hexutil.Big(*new(big.Int).SetUint64(r.chainID))
+}
+
+// getBlockRange returns the numeric block range, using the latest L2 block
+// when any of the ends is not provided
+func (r *Resolver) getBlockRange(ctx context.Context, fromArg, toArg *Long) (uint64, uint64, error) {
+	from, err := fromArg.toUint64()
+	if err != nil {
+		return 0, 0, err
+	}
+	to, err := toArg.toUint64()
+	if err != nil {
+		return 0, 0, err
+	}
+	if from == nil || to == nil {
+		lastBlockNumber, err := r.state.GetLastL2BlockNumber(ctx, nil)
+		if err != nil {
+			return 0, 0, err
+		}
+		if from == nil {
+			from = &lastBlockNumber
+		}
+		if to == nil {
+			to = &lastBlockNumber
+		}
+	}
+
+	if *from > *to {
+		return 0, 0, errInvalidBlockRange
+	}
+	return *from, *to, nil
+}
+
+func (r *Resolver) getLogs(ctx context.Context, from, to uint64, addressesArg *[]common.Address, topicsArg *[][]common.Hash) ([]*Log, error) {
+	var addresses []common.Address
+	if addressesArg != nil {
+		addresses = *addressesArg
+	}
+	var topics [][]common.Hash
+	if topicsArg != nil {
+		topics = *topicsArg
+	}
+
+	logs, err := r.state.GetLogs(ctx, from, to, addresses, topics, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*Log, 0, len(logs))
+	for _, l := range logs {
+		res = append(res, &Log{r: r, log: l})
+	}
+	return res, nil
+}
+
+// toTransaction converts the call data into an unsigned transaction executed
+// on top of the state of the provided block
+func (r *Resolver) toTransaction(ctx context.Context, data CallData, block *state.L2Block) (common.Address, *ethTypes.Transaction, error) {
+	txArgs, err := data.toTxArgs()
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+
+	// If the caller didn't supply the gas limit in the message, then we set it to maximum possible => block gas limit
+	if txArgs.Gas == nil || uint64(*txArgs.Gas) == 0 {
+		txArgs.Gas = types.ArgUint64Ptr(types.ArgUint64(block.GasLimit()))
+	}
+
+	defaultSenderAddress := common.HexToAddress(state.DefaultSenderAddress)
+	return txArgs.ToTransaction(ctx, r.state, state.MaxTxGasLimit, block.Root(), defaultSenderAddress, nil)
+}
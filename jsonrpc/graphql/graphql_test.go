@@ -0,0 +1,311 @@
+package graphql
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/mocks"
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/0xPolygonHermez/zkevm-node/state/runtime"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	chainID           = uint64(1000)
+	maxBlockRange     = uint64(5)
+	maxLogsBlockRange = uint64(10)
+	maxBlocks         = uint64(8)
+	maxExecutorCalls  = uint64(2)
+)
+
+var limits = Limits{
+	MaxBlockRange:           maxBlockRange,
+	MaxLogsBlockRange:       maxLogsBlockRange,
+	MaxBlocks:               maxBlocks,
+	MaxExecutorCalls:        maxExecutorCalls,
+	MaxDepth:                6,
+	MaxParallelism:          10,
+	MaxRequestContentLength: 1024,
+}
+
+type mocksWrapper struct {
+	State *mocks.StateMock
+	Pool  *mocks.PoolMock
+}
+
+func TestGraphQL(t *testing.T) {
+	type testCase struct {
+		Name               string
+		Query              string
+		ExpectedStatusCode int
+		ExpectedResult     string
+		ExpectedError      string
+		SetupMocks         func(m *mocksWrapper)
+	}
+
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	auth, err := bind.NewKeyedTransactorWithChainID(privateKey, new(big.Int).SetUint64(chainID))
+	require.NoError(t, err)
+	to := common.HexToAddress("0x111")
+	signedTx, err := auth.Signer(auth.From, ethTypes.NewTransaction(1, to, big.NewInt(2), 21000, big.NewInt(4), []byte{5, 6, 7, 8}))
+	require.NoError(t, err)
+
+	header := &ethTypes.Header{
+		Number:     big.NewInt(1),
+		ParentHash: common.HexToHash("0x1"),
+		Root:       common.HexToHash("0x2"),
+		Coinbase:   common.HexToAddress("0x3"),
+		GasLimit:   1000000,
+		GasUsed:    21000,
+		Time:       1000,
+	}
+	receipt := &ethTypes.Receipt{
+		Status:            ethTypes.ReceiptStatusSuccessful,
+		CumulativeGasUsed: 21000,
+		GasUsed:           21000,
+		TxHash:            signedTx.Hash(),
+		BlockNumber:       big.NewInt(1),
+		EffectiveGasPrice: big.NewInt(4),
+		Logs: []*ethTypes.Log{{
+			Address: to,
+			Topics:  []common.Hash{common.HexToHash("0x4")},
+			Data:    []byte{9},
+			TxHash:  signedTx.Hash(),
+		}},
+	}
+	l2Block := state.NewL2Block(state.NewL2Header(header), []*ethTypes.Transaction{signedTx}, nil, []*ethTypes.Receipt{receipt}, trie.NewStackTrie(nil))
+	receipt.BlockHash = l2Block.Hash()
+	receipt.Logs[0].BlockHash = l2Block.Hash()
+	receipt.Logs[0].BlockNumber = 1
+
+	batch := &state.Batch{BatchNumber: 2, StateRoot: common.HexToHash("0x5"), Coinbase: common.HexToAddress("0x6")}
+	verifiedBatch := &state.VerifiedBatch{BatchNumber: 3, BlockNumber: 100, TxHash: common.HexToHash("0x7"), Aggregator: common.HexToAddress("0x8"), StateRoot: common.HexToHash("0x9")}
+
+	testCases := []testCase{
+		{
+			Name:               "block with transactions and batch",
+			Query:              `{ block(number: 1) { number hash transactionCount miner { address } transactions { hash from { address } status gasUsed effectiveGasPrice } batch { number stateRoot closed verifiedBatch { batchNumber l1BlockNumber txHash } } } }`,
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedResult: fmt.Sprintf(`{"block":{"number":"0x1","hash":"%v","transactionCount":"0x1","miner":{"address":"%v"},"transactions":[{"hash":"%v","from":{"address":"%v"},"status":"0x1","gasUsed":"0x5208","effectiveGasPrice":"0x4"}],"batch":{"number":"0x2","stateRoot":"%v","closed":true,"verifiedBatch":{"batchNumber":"0x3","l1BlockNumber":"0x64","txHash":"%v"}}}}`,
+				l2Block.Hash().String(), strings.ToLower(header.Coinbase.String()), signedTx.Hash().String(), strings.ToLower(auth.From.String()), batch.StateRoot.String(), verifiedBatch.TxHash.String()),
+			SetupMocks: func(m *mocksWrapper) {
+				m.State.On("GetL2BlockByNumber", mock.Anything, uint64(1), nil).Return(l2Block, nil).Once()
+				m.State.On("GetL2BlockReceipts", mock.Anything, uint64(1), nil).Return([]*ethTypes.Transaction{signedTx}, []*ethTypes.Receipt{receipt}, nil).Once()
+				m.State.On("BatchNumberByL2BlockNumber", mock.Anything, uint64(1), nil).Return(uint64(2), nil).Once()
+				m.State.On("GetBatchByNumber", mock.Anything, uint64(2), nil).Return(batch, nil).Once()
+				m.State.On("GetVerifiedBatchCoveringBatch", mock.Anything, uint64(2), nil).Return(verifiedBatch, uint64(1), nil).Once()
+			},
+		},
+		{
+			Name:               "block not found",
+			Query:              `{ block(number: 2) { number } }`,
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedResult:     `{"block":null}`,
+			SetupMocks: func(m *mocksWrapper) {
+				m.State.On("GetL2BlockByNumber", mock.Anything, uint64(2), nil).Return(nil, state.ErrNotFound).Once()
+			},
+		},
+		{
+			Name:               "transaction with logs",
+			Query:              fmt.Sprintf(`{ transaction(hash: "%v") { nonce index block { number } logs { index account { address } topics data } } }`, signedTx.Hash().String()),
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedResult:     fmt.Sprintf(`{"transaction":{"nonce":"0x1","index":"0x0","block":{"number":"0x1"},"logs":[{"index":"0x0","account":{"address":"%v"},"topics":["%v"],"data":"0x09"}]}}`, strings.ToLower(to.String()), common.HexToHash("0x4").String()),
+			SetupMocks: func(m *mocksWrapper) {
+				m.State.On("GetTransactionByHash", mock.Anything, signedTx.Hash(), nil).Return(signedTx, nil).Once()
+				m.State.On("GetTransactionReceipt", mock.Anything, signedTx.Hash(), nil).Return(receipt, nil).Once()
+				m.State.On("GetL2BlockByNumber", mock.Anything, uint64(1), nil).Return(l2Block, nil).Once()
+			},
+		},
+		{
+			Name:               "transaction not found",
+			Query:              fmt.Sprintf(`{ transaction(hash: "%v") { nonce } }`, signedTx.Hash().String()),
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedResult:     `{"transaction":null}`,
+			SetupMocks: func(m *mocksWrapper) {
+				m.State.On("GetTransactionByHash", mock.Anything, signedTx.Hash(), nil).Return(nil, state.ErrNotFound).Once()
+			},
+		},
+		{
+			Name:               "account at latest block",
+			Query:              fmt.Sprintf(`{ block { account(address: "%v") { balance transactionCount } } }`, auth.From.String()),
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedResult:     `{"block":{"account":{"balance":"0x3e8","transactionCount":"0x2"}}}`,
+			SetupMocks: func(m *mocksWrapper) {
+				m.State.On("GetLastL2BlockNumber", mock.Anything, nil).Return(uint64(1), nil).Once()
+				m.State.On("GetL2BlockByNumber", mock.Anything, uint64(1), nil).Return(l2Block, nil).Once()
+				m.State.On("GetL2BlockHeaderByNumber", mock.Anything, uint64(1), nil).Return(state.NewL2Header(header), nil).Twice()
+				m.State.On("GetBalance", mock.Anything, auth.From, header.Root).Return(big.NewInt(1000), nil).Once()
+				m.State.On("GetNonce", mock.Anything, auth.From, header.Root).Return(uint64(2), nil).Once()
+			},
+		},
+		{
+			Name:               "call",
+			Query:              fmt.Sprintf(`{ block(number: 1) { call(data: {to: "%v", data: "0x01"}) { data gasUsed status } } }`, to.String()),
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedResult:     `{"block":{"call":{"data":"0x0102","gasUsed":"0x5208","status":"0x1"}}}`,
+			SetupMocks: func(m *mocksWrapper) {
+				m.State.On("GetL2BlockByNumber", mock.Anything, uint64(1), nil).Return(l2Block, nil).Once()
+				m.State.
+					On("ProcessUnsignedTransaction", mock.Anything, mock.MatchedBy(func(tx *ethTypes.Transaction) bool {
+						return tx.To() != nil && *tx.To() == to && tx.Gas() == header.GasLimit && bytes.Equal(tx.Data(), []byte{1})
+					}), common.HexToAddress(state.DefaultSenderAddress), mock.Anything, true, state.StateOverride(nil), nil).
+					Return(&runtime.ExecutionResult{ReturnValue: []byte{1, 2}, GasUsed: 21000}, nil).
+					Once()
+			},
+		},
+		{
+			Name:               "gas price, chain id and syncing",
+			Query:              `{ gasPrice chainID syncing { currentBlock } }`,
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedResult:     `{"gasPrice":"0x3e8","chainID":"0x3e8","syncing":null}`,
+			SetupMocks: func(m *mocksWrapper) {
+				m.Pool.On("GetGasPrices", mock.Anything).Return(pool.GasPrices{L2GasPrice: 1000}, nil).Once()
+				m.State.On("GetSyncingInfo", mock.Anything, nil).Return(state.SyncingInfo{IsSynchronizing: false}, nil).Once()
+			},
+		},
+		{
+			Name:               "logs exceeding the max block range",
+			Query:              `{ logs(filter: {fromBlock: 1, toBlock: 20}) { index } }`,
+			ExpectedStatusCode: http.StatusBadRequest,
+			ExpectedError:      fmt.Sprintf(state.ErrMaxLogsBlockRangeLimitExceeded.Error(), maxLogsBlockRange),
+			SetupMocks:         func(m *mocksWrapper) {},
+		},
+		{
+			Name:               "blocks exceeding the max block range",
+			Query:              `{ blocks(from: 1, to: 10) { number } }`,
+			ExpectedStatusCode: http.StatusBadRequest,
+			ExpectedError:      fmt.Sprintf(errMaxBlockRangeLimitExceeded.Error(), maxBlockRange),
+			SetupMocks:         func(m *mocksWrapper) {},
+		},
+		{
+			Name:               "blocks exceeding the max blocks of a query",
+			Query:              `{ a: blocks(from: 1, to: 5) { number } b: blocks(from: 1, to: 5) { number } }`,
+			ExpectedStatusCode: http.StatusBadRequest,
+			ExpectedError:      fmt.Sprintf(errMaxBlocksLimitExceeded.Error(), maxBlocks),
+			SetupMocks: func(m *mocksWrapper) {
+				m.State.On("GetL2BlockByNumber", mock.Anything, mock.Anything, nil).Return(l2Block, nil).Times(int(maxBlocks))
+			},
+		},
+		{
+			Name:               "calls exceeding the max executor calls of a query",
+			Query:              fmt.Sprintf(`{ block(number: 1) { a: call(data: {to: "%[1]v"}) { status } b: call(data: {to: "%[1]v"}) { status } c: estimateGas(data: {to: "%[1]v"}) } }`, to.String()),
+			ExpectedStatusCode: http.StatusBadRequest,
+			ExpectedError:      fmt.Sprintf(errMaxExecutorCallsLimitExceeded.Error(), maxExecutorCalls),
+			SetupMocks: func(m *mocksWrapper) {
+				m.State.On("GetL2BlockByNumber", mock.Anything, uint64(1), nil).Return(l2Block, nil).Once()
+				m.State.
+					On("ProcessUnsignedTransaction", mock.Anything, mock.Anything, mock.Anything, mock.Anything, true, state.StateOverride(nil), nil).
+					Return(&runtime.ExecutionResult{GasUsed: 21000}, nil).
+					Maybe()
+				m.State.
+					On("EstimateGas", mock.Anything, mock.Anything, mock.Anything, state.StateOverride(nil), nil).
+					Return(uint64(21000), []byte{}, nil).
+					Maybe()
+			},
+		},
+		{
+			Name:               "query exceeding the max depth",
+			Query:              `{ block { transactions { block { transactions { block { transactions { hash } } } } } } }`,
+			ExpectedStatusCode: http.StatusBadRequest,
+			ExpectedError:      `Field "hash" has depth 7 that exceeds max depth 6`,
+			SetupMocks:         func(m *mocksWrapper) {},
+		},
+		{
+			Name:               "unknown field",
+			Query:              `{ block { unknown } }`,
+			ExpectedStatusCode: http.StatusBadRequest,
+			ExpectedError:      `Cannot query field "unknown" on type "Block".`,
+			SetupMocks:         func(m *mocksWrapper) {},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			tc := testCase
+			m := &mocksWrapper{
+				State: mocks.NewStateMock(t),
+				Pool:  mocks.NewPoolMock(t),
+			}
+			tc.SetupMocks(m)
+
+			handler, err := NewHandler(chainID, limits, m.Pool, m.State)
+			require.NoError(t, err)
+			server := httptest.NewServer(handler)
+			defer server.Close()
+
+			body, err := json.Marshal(map[string]interface{}{"query": tc.Query})
+			require.NoError(t, err)
+			res, err := http.Post(server.URL, "application/json", bytes.NewReader(body))
+			require.NoError(t, err)
+			defer res.Body.Close()
+			assert.Equal(t, tc.ExpectedStatusCode, res.StatusCode)
+
+			var response struct {
+				Data   json.RawMessage `json:"data"`
+				Errors []struct {
+					Message string `json:"message"`
+				} `json:"errors"`
+			}
+			require.NoError(t, json.NewDecoder(res.Body).Decode(&response))
+
+			if tc.ExpectedError != "" {
+				require.NotEmpty(t, response.Errors)
+				assert.Equal(t, tc.ExpectedError, response.Errors[0].Message)
+			} else {
+				require.Empty(t, response.Errors)
+				assert.JSONEq(t, tc.ExpectedResult, string(response.Data))
+			}
+		})
+	}
+}
+
+func TestGraphQLBadRequest(t *testing.T) {
+	handler, err := NewHandler(chainID, limits, mocks.NewPoolMock(t), mocks.NewStateMock(t))
+	require.NoError(t, err)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	res, err := http.Post(server.URL, "application/json", strings.NewReader("not json"))
+	require.NoError(t, err)
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	res, err = http.Get(server.URL)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, res.StatusCode)
+
+	query := `{"query": "` + strings.Repeat(" ", int(limits.MaxRequestContentLength)) + `{ chainID }"}`
+	res, err = http.Post(server.URL, "application/json", strings.NewReader(query))
+	require.NoError(t, err)
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusRequestEntityTooLarge, res.StatusCode)
+
+	// chunked request without content length
+	req, err := http.NewRequest(http.MethodPost, server.URL, io.NopCloser(strings.NewReader(query)))
+	require.NoError(t, err)
+	req.ContentLength = -1
+	res, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusRequestEntityTooLarge, res.StatusCode)
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "content length too large (1025>1024)\n", string(body))
+}
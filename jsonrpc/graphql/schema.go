@@ -0,0 +1,160 @@
+package graphql
+
+// schema is the read only subset of the Ethereum GraphQL schema defined
+// by EIP-1767, extended with the zkEVM Batch and VerifiedBatch types that
+// link the L2 blocks to the batches they belong to and their L1 verification
+const schema string = `
+    scalar Bytes32
+    scalar Address
+    scalar Bytes
+    scalar BigInt
+    scalar Long
+
+    schema {
+        query: Query
+    }
+
+    type Account {
+        address: Address!
+        balance: BigInt!
+        transactionCount: Long!
+        code: Bytes!
+        storage(slot: Bytes32!): Bytes32!
+    }
+
+    type Log {
+        index: Long!
+        account(block: Long): Account!
+        topics: [Bytes32!]!
+        data: Bytes!
+        transaction: Transaction!
+    }
+
+    type Transaction {
+        hash: Bytes32!
+        l2Hash: Bytes32
+        nonce: Long!
+        index: Long
+        from(block: Long): Account!
+        to(block: Long): Account
+        value: BigInt!
+        gasPrice: BigInt!
+        maxFeePerGas: BigInt
+        maxPriorityFeePerGas: BigInt
+        gas: Long!
+        inputData: Bytes!
+        block: Block
+        status: Long
+        gasUsed: Long
+        cumulativeGasUsed: Long
+        effectiveGasPrice: BigInt
+        createdContract(block: Long): Account
+        logs: [Log!]
+        r: BigInt!
+        s: BigInt!
+        v: BigInt!
+        type: Long
+    }
+
+    input BlockFilterCriteria {
+        addresses: [Address!]
+        topics: [[Bytes32!]!]
+    }
+
+    type Block {
+        number: Long!
+        hash: Bytes32!
+        parent: Block
+        nonce: Bytes!
+        transactionsRoot: Bytes32!
+        transactionCount: Long
+        stateRoot: Bytes32!
+        receiptsRoot: Bytes32!
+        miner(block: Long): Account!
+        extraData: Bytes!
+        gasLimit: Long!
+        gasUsed: Long!
+        timestamp: Long!
+        logsBloom: Bytes!
+        mixHash: Bytes32!
+        difficulty: BigInt!
+        totalDifficulty: BigInt!
+        ommerCount: Long
+        ommers: [Block]
+        ommerAt(index: Long!): Block
+        ommerHash: Bytes32!
+        transactions: [Transaction!]
+        transactionAt(index: Long!): Transaction
+        logs(filter: BlockFilterCriteria!): [Log!]!
+        account(address: Address!): Account!
+        call(data: CallData!): CallResult
+        estimateGas(data: CallData!): Long!
+        globalExitRoot: Bytes32!
+        blockInfoRoot: Bytes32!
+        batch: Batch!
+    }
+
+    input CallData {
+        from: Address
+        to: Address
+        gas: Long
+        gasPrice: BigInt
+        value: BigInt
+        data: Bytes
+    }
+
+    type CallResult {
+        data: Bytes!
+        gasUsed: Long!
+        status: Long!
+    }
+
+    input FilterCriteria {
+        fromBlock: Long
+        toBlock: Long
+        addresses: [Address!]
+        topics: [[Bytes32!]!]
+    }
+
+    type SyncState {
+        startingBlock: Long!
+        currentBlock: Long!
+        highestBlock: Long!
+    }
+
+    type Batch {
+        number: Long!
+        timestamp: Long!
+        coinbase: Address!
+        stateRoot: Bytes32!
+        localExitRoot: Bytes32!
+        accInputHash: Bytes32!
+        globalExitRoot: Bytes32!
+        forcedBatchNumber: Long
+        closed: Boolean!
+        blocks: [Block!]!
+        sendSequencesTxHash: Bytes32
+        l1InfoRoot: Bytes32
+        verifiedBatch: VerifiedBatch
+    }
+
+    type VerifiedBatch {
+        batchNumber: Long!
+        l1BlockNumber: Long!
+        txHash: Bytes32!
+        aggregator: Address!
+        stateRoot: Bytes32!
+    }
+
+    type Query {
+        block(number: Long, hash: Bytes32): Block
+        blocks(from: Long, to: Long): [Block!]!
+        batch(number: Long): Batch
+        transaction(hash: Bytes32!): Transaction
+        logs(filter: FilterCriteria!): [Log!]!
+        gasPrice: BigInt!
+        maxPriorityFeePerGas: BigInt!
+        syncing: SyncState
+        chainID: BigInt!
+    }
+`
@@ -0,0 +1,120 @@
+package graphql
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/graph-gophers/graphql-go"
+)
+
+// Handler serves the GraphQL queries sent via HTTP POST
+type Handler struct {
+	schema                  *graphql.Schema
+	maxRequestContentLength int64
+}
+
+// Limits bounds the cost of the GraphQL queries, zero means no limit
+type Limits struct {
+	// MaxBlockRange is the max block range of the blocks query
+	MaxBlockRange uint64
+	// MaxLogsBlockRange is the max block range of the logs query
+	MaxLogsBlockRange uint64
+	// MaxBlocks is the max number of blocks loaded by a query
+	MaxBlocks uint64
+	// MaxExecutorCalls is the max number of call and estimateGas fields executed by a query
+	MaxExecutorCalls uint64
+	// MaxDepth is the max depth of the selections of a query
+	MaxDepth int
+	// MaxParallelism is the max number of resolvers executed in parallel
+	MaxParallelism int
+	// MaxRequestContentLength is the max size in bytes of the request body
+	MaxRequestContentLength int64
+}
+
+// NewHandler creates a GraphQL handler for the L2 state that rejects the
+// queries exceeding the provided limits
+func NewHandler(chainID uint64, limits Limits, p types.PoolInterface, s types.StateInterface) (*Handler, error) {
+	r := &Resolver{
+		chainID:           chainID,
+		maxBlockRange:     limits.MaxBlockRange,
+		maxLogsBlockRange: limits.MaxLogsBlockRange,
+		maxBlocks:         limits.MaxBlocks,
+		maxExecutorCalls:  limits.MaxExecutorCalls,
+		state:             s,
+		pool:              p,
+	}
+
+	opts := []graphql.SchemaOpt{}
+	if limits.MaxDepth > 0 {
+		opts = append(opts, graphql.MaxDepth(limits.MaxDepth))
+	}
+	if limits.MaxParallelism > 0 {
+		opts = append(opts, graphql.MaxParallelism(limits.MaxParallelism))
+	}
+
+	parsedSchema, err := graphql.ParseSchema(schema, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Handler{schema: parsedSchema, maxRequestContentLength: limits.MaxRequestContentLength}, nil
+}
+
+// ServeHTTP executes the GraphQL query provided in the request body
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method "+r.Method+" not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if h.maxRequestContentLength > 0 {
+		if r.ContentLength > h.maxRequestContentLength {
+			h.contentLengthTooLarge(w, r.ContentLength)
+			return
+		}
+		r.Body = http.MaxBytesReader(w, r.Body, h.maxRequestContentLength)
+	}
+
+	var params struct {
+		Query         string                 `json:"query"`
+		OperationName string                 `json:"operationName"`
+		Variables     map[string]interface{} `json:"variables"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			// the content length is unknown when the body is sent in chunks,
+			// but at least one byte over the limit has been read
+			h.contentLengthTooLarge(w, maxBytesErr.Limit+1)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response := h.schema.Exec(withQueryCost(r.Context()), params.Query, params.OperationName, params.Variables)
+	responseJSON, err := json.Marshal(response)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if len(response.Errors) > 0 {
+		w.WriteHeader(http.StatusBadRequest)
+	}
+	if _, err := w.Write(responseJSON); err != nil {
+		log.Errorf("failed to write the GraphQL response: %v", err)
+	}
+}
+
+// contentLengthTooLarge responds with the same error returned by the JSON-RPC
+// server when the request body exceeds the max request content length
+func (h *Handler) contentLengthTooLarge(w http.ResponseWriter, contentLength int64) {
+	err := fmt.Errorf("content length too large (%d>%d)", contentLength, h.maxRequestContentLength)
+	http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+}
@@ -62,6 +62,7 @@ type handleRequest struct {
 // check the `eth.go` file for more example on how the methods are implemented
 type Handler struct {
 	serviceMap map[string]*serviceData
+	limiter    *rateLimiter
 }
 
 func newJSONRpcHandler() *Handler {
@@ -82,6 +83,15 @@ func (h *Handler) Handle(req handleRequest) types.Response {
 		return types.NewResponse(req.Request, nil, err)
 	}
 
+	if h.limiter != nil {
+		release, err := h.limiter.acquire(req.Method, req.HttpRequest)
+		if err != nil {
+			log.Debugf("request rejected: %v", err.Error())
+			return types.NewResponse(req.Request, nil, err)
+		}
+		defer release()
+	}
+
 	inArgsOffset := 0
 	inArgs := make([]reflect.Value, fd.inNum)
 	inArgs[0] = service.sv
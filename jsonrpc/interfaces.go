@@ -1,14 +1,20 @@
 package jsonrpc
 
+import "time"
+
 // storageInterface json rpc internal storage to persist data
 type storageInterface interface {
 	GetAllBlockFiltersWithWSConn() []*Filter
 	GetAllLogFiltersWithWSConn() []*Filter
+	GetAllPendingTxFiltersWithWSConn() []*Filter
+	GetAllZKEVMFiltersWithWSConn(filterType FilterType) []*Filter
 	GetFilter(filterID string) (*Filter, error)
 	NewBlockFilter(wsConn *concurrentWsConn) (string, error)
 	NewLogFilter(wsConn *concurrentWsConn, filter LogFilter) (string, error)
 	NewPendingTransactionFilter(wsConn *concurrentWsConn) (string, error)
+	NewSubscriptionFilter(wsConn *concurrentWsConn, filterType FilterType, parameters interface{}) (string, error)
 	UninstallFilter(filterID string) error
 	UninstallFilterByWSConn(wsConn *concurrentWsConn) error
+	UninstallFiltersNotPolledSince(since time.Time) error
 	UpdateFilterLastPoll(filterID string) error
 }
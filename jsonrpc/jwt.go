@@ -0,0 +1,91 @@
+package jsonrpc
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const (
+	// jwtSecretLength is the length in bytes of the secret used to sign the JWTs
+	jwtSecretLength = 32
+	// jwtIssuedAtTolerance is the max difference allowed between the iat claim and the current time
+	jwtIssuedAtTolerance = 60 * time.Second
+)
+
+// readJWTSecret reads the hex encoded secret used to sign the JWTs from the provided file
+func readJWTSecret(fileName string) ([]byte, error) {
+	if fileName == "" {
+		return nil, fmt.Errorf("missing JWT secret file")
+	}
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JWT secret file %s: %w", fileName, err)
+	}
+	secret := common.FromHex(strings.TrimSpace(string(data)))
+	if len(secret) != jwtSecretLength {
+		return nil, fmt.Errorf("invalid JWT secret in file %s, it must be %d hex encoded bytes", fileName, jwtSecretLength)
+	}
+	return secret, nil
+}
+
+// jwtHandler only forwards to the next handler the requests containing a valid JWT
+// in the Authorization header, signed with HS256 and issued around the current time
+type jwtHandler struct {
+	secret []byte
+	next   http.Handler
+	now    func() time.Time
+}
+
+func newJWTHandler(secret []byte, next http.Handler) *jwtHandler {
+	return &jwtHandler{secret: secret, next: next, now: time.Now}
+}
+
+// ServeHTTP implements http.Handler
+func (h *jwtHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if err := h.authenticate(req); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	h.next.ServeHTTP(w, req)
+}
+
+// authenticate returns an error if the request doesn't contain a valid JWT
+func (h *jwtHandler) authenticate(req *http.Request) error {
+	auth := req.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "Bearer ") {
+		return fmt.Errorf("missing token")
+	}
+
+	var claims jwt.RegisteredClaims
+	parser := jwt.NewParser(jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithoutClaimsValidation())
+	token, err := parser.ParseWithClaims(strings.TrimPrefix(auth, "Bearer "), &claims, func(token *jwt.Token) (interface{}, error) {
+		return h.secret, nil
+	})
+	if err != nil {
+		return err
+	}
+	if !token.Valid {
+		return fmt.Errorf("invalid token")
+	}
+
+	now := h.now()
+	if !claims.VerifyExpiresAt(now, false) {
+		return fmt.Errorf("token is expired")
+	}
+	if claims.IssuedAt == nil {
+		return fmt.Errorf("missing issued-at")
+	}
+	if now.Sub(claims.IssuedAt.Time) > jwtIssuedAtTolerance {
+		return fmt.Errorf("stale token")
+	}
+	if claims.IssuedAt.Time.Sub(now) > jwtIssuedAtTolerance {
+		return fmt.Errorf("future token")
+	}
+	return nil
+}
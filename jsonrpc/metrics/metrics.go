@@ -13,8 +13,18 @@ const (
 	requestsHandledName = requestPrefix + "handled"
 	requestDurationName = requestPrefix + "duration"
 	connName            = requestPrefix + "connection"
+	requestRejectedName = requestPrefix + "rejected"
 
-	requestHandledTypeLabelName = "type"
+	requestRejectedByMethodName = requestRejectedName + "_by_method"
+
+	cachePrefix     = prefix + "cache_"
+	cacheHitsName   = cachePrefix + "hits"
+	cacheMissesName = cachePrefix + "misses"
+
+	requestHandledTypeLabelName    = "type"
+	requestRejectedReasonLabelName = "reason"
+	requestRejectedMethodLabelName = "method"
+	cacheMethodLabelName           = "method"
 )
 
 // RequestHandledLabel represents the possible values for the
@@ -25,6 +35,10 @@ type RequestHandledLabel string
 // `jsonrpc_request_connection` metric `type` label.
 type ConnLabel string
 
+// RequestRejectedLabel represents the possible values for the
+// `jsonrpc_request_rejected` metric `reason` label.
+type RequestRejectedLabel string
+
 const (
 	// RequestHandledLabelInvalid represents an request of type invalid
 	RequestHandledLabelInvalid RequestHandledLabel = "invalid"
@@ -39,6 +53,13 @@ const (
 	HTTPConnLabel ConnLabel = "HTTP"
 	// WSConnLabel represents a WS connection
 	WSConnLabel ConnLabel = "WS"
+
+	// RequestRejectedLabelRateLimit represents a request rejected by the requests per second limit
+	RequestRejectedLabelRateLimit RequestRejectedLabel = "rate_limit"
+	// RequestRejectedLabelConcurrency represents a request rejected by the concurrent requests limit
+	RequestRejectedLabelConcurrency RequestRejectedLabel = "concurrency"
+	// RequestRejectedLabelDailyQuota represents a request rejected by the daily quota
+	RequestRejectedLabelDailyQuota RequestRejectedLabel = "daily_quota"
 )
 
 // Register the metrics for the jsonrpc package.
@@ -56,6 +77,34 @@ func Register() {
 			},
 			Labels: []string{requestHandledTypeLabelName},
 		},
+		{
+			CounterOpts: prometheus.CounterOpts{
+				Name: requestRejectedName,
+				Help: "[JSONRPC] number of requests rejected by the rate limits",
+			},
+			Labels: []string{requestRejectedReasonLabelName},
+		},
+		{
+			CounterOpts: prometheus.CounterOpts{
+				Name: requestRejectedByMethodName,
+				Help: "[JSONRPC] number of requests rejected by the rate limits per method",
+			},
+			Labels: []string{requestRejectedMethodLabelName},
+		},
+		{
+			CounterOpts: prometheus.CounterOpts{
+				Name: cacheHitsName,
+				Help: "[JSONRPC] number of responses served from the cache per method",
+			},
+			Labels: []string{cacheMethodLabelName},
+		},
+		{
+			CounterOpts: prometheus.CounterOpts{
+				Name: cacheMissesName,
+				Help: "[JSONRPC] number of responses not found in the cache per method",
+			},
+			Labels: []string{cacheMethodLabelName},
+		},
 	}
 
 	start := 0.1
@@ -90,3 +139,20 @@ func RequestHandled(label RequestHandledLabel) {
 func RequestDuration(start time.Time) {
 	metrics.HistogramObserve(requestDurationName, time.Since(start).Seconds())
 }
+
+// RequestRejected increments the requests rejected counter vectors by one for
+// the given reason and method.
+func RequestRejected(reason RequestRejectedLabel, method string) {
+	metrics.CounterVecInc(requestRejectedName, string(reason))
+	metrics.CounterVecInc(requestRejectedByMethodName, method)
+}
+
+// CacheHit increments the cache hits counter vector by one for the given method.
+func CacheHit(method string) {
+	metrics.CounterVecInc(cacheHitsName, method)
+}
+
+// CacheMiss increments the cache misses counter vector by one for the given method.
+func CacheMiss(method string) {
+	metrics.CounterVecInc(cacheMissesName, method)
+}
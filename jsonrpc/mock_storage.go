@@ -2,7 +2,11 @@
 
 package jsonrpc
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
 
 // storageMock is an autogenerated mock type for the storageInterface type
 type storageMock struct {
@@ -49,6 +53,46 @@ func (_m *storageMock) GetAllLogFiltersWithWSConn() []*Filter {
 	return r0
 }
 
+// GetAllPendingTxFiltersWithWSConn provides a mock function with given fields:
+func (_m *storageMock) GetAllPendingTxFiltersWithWSConn() []*Filter {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllPendingTxFiltersWithWSConn")
+	}
+
+	var r0 []*Filter
+	if rf, ok := ret.Get(0).(func() []*Filter); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*Filter)
+		}
+	}
+
+	return r0
+}
+
+// GetAllZKEVMFiltersWithWSConn provides a mock function with given fields: filterType
+func (_m *storageMock) GetAllZKEVMFiltersWithWSConn(filterType FilterType) []*Filter {
+	ret := _m.Called(filterType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllZKEVMFiltersWithWSConn")
+	}
+
+	var r0 []*Filter
+	if rf, ok := ret.Get(0).(func(FilterType) []*Filter); ok {
+		r0 = rf(filterType)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*Filter)
+		}
+	}
+
+	return r0
+}
+
 // GetFilter provides a mock function with given fields: filterID
 func (_m *storageMock) GetFilter(filterID string) (*Filter, error) {
 	ret := _m.Called(filterID)
@@ -163,6 +207,34 @@ func (_m *storageMock) NewPendingTransactionFilter(wsConn *concurrentWsConn) (st
 	return r0, r1
 }
 
+// NewSubscriptionFilter provides a mock function with given fields: wsConn, filterType, parameters
+func (_m *storageMock) NewSubscriptionFilter(wsConn *concurrentWsConn, filterType FilterType, parameters interface{}) (string, error) {
+	ret := _m.Called(wsConn, filterType, parameters)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NewSubscriptionFilter")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*concurrentWsConn, FilterType, interface{}) (string, error)); ok {
+		return rf(wsConn, filterType, parameters)
+	}
+	if rf, ok := ret.Get(0).(func(*concurrentWsConn, FilterType, interface{}) string); ok {
+		r0 = rf(wsConn, filterType, parameters)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(*concurrentWsConn, FilterType, interface{}) error); ok {
+		r1 = rf(wsConn, filterType, parameters)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UninstallFilter provides a mock function with given fields: filterID
 func (_m *storageMock) UninstallFilter(filterID string) error {
 	ret := _m.Called(filterID)
@@ -199,6 +271,24 @@ func (_m *storageMock) UninstallFilterByWSConn(wsConn *concurrentWsConn) error {
 	return r0
 }
 
+// UninstallFiltersNotPolledSince provides a mock function with given fields: since
+func (_m *storageMock) UninstallFiltersNotPolledSince(since time.Time) error {
+	ret := _m.Called(since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UninstallFiltersNotPolledSince")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(time.Time) error); ok {
+		r0 = rf(since)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateFilterLastPoll provides a mock function with given fields: filterID
 func (_m *storageMock) UpdateFilterLastPoll(filterID string) error {
 	ret := _m.Called(filterID)
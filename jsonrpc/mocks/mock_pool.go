@@ -22,6 +22,36 @@ type PoolMock struct {
 	mock.Mock
 }
 
+// AddBundle provides a mock function with given fields: ctx, txs, blockNumber, maxTimestamp, ip
+func (_m *PoolMock) AddBundle(ctx context.Context, txs []types.Transaction, blockNumber uint64, maxTimestamp uint64, ip string) (common.Hash, error) {
+	ret := _m.Called(ctx, txs, blockNumber, maxTimestamp, ip)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddBundle")
+	}
+
+	var r0 common.Hash
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []types.Transaction, uint64, uint64, string) (common.Hash, error)); ok {
+		return rf(ctx, txs, blockNumber, maxTimestamp, ip)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []types.Transaction, uint64, uint64, string) common.Hash); ok {
+		r0 = rf(ctx, txs, blockNumber, maxTimestamp, ip)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(common.Hash)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []types.Transaction, uint64, uint64, string) error); ok {
+		r1 = rf(ctx, txs, blockNumber, maxTimestamp, ip)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // AddTx provides a mock function with given fields: ctx, tx, ip
 func (_m *PoolMock) AddTx(ctx context.Context, tx types.Transaction, ip string) error {
 	ret := _m.Called(ctx, tx, ip)
@@ -40,6 +70,24 @@ func (_m *PoolMock) AddTx(ctx context.Context, tx types.Transaction, ip string)
 	return r0
 }
 
+// BlockAddress provides a mock function with given fields: ctx, address, reason
+func (_m *PoolMock) BlockAddress(ctx context.Context, address common.Address, reason string) error {
+	ret := _m.Called(ctx, address, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BlockAddress")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address, string) error); ok {
+		r0 = rf(ctx, address, reason)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CalculateEffectiveGasPrice provides a mock function with given fields: rawTx, txGasPrice, txGasUsed, l1GasPrice, l2GasPrice
 func (_m *PoolMock) CalculateEffectiveGasPrice(rawTx []byte, txGasPrice *big.Int, txGasUsed uint64, l1GasPrice uint64, l2GasPrice uint64) (*big.Int, error) {
 	ret := _m.Called(rawTx, txGasPrice, txGasUsed, l1GasPrice, l2GasPrice)
@@ -144,6 +192,96 @@ func (_m *PoolMock) EffectiveGasPriceEnabled() bool {
 	return r0
 }
 
+// GetBlockedAddresses provides a mock function with given fields: ctx
+func (_m *PoolMock) GetBlockedAddresses(ctx context.Context) ([]common.Address, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBlockedAddresses")
+	}
+
+	var r0 []common.Address
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]common.Address, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []common.Address); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]common.Address)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetContent provides a mock function with given fields: ctx, senderOffset, senderLimit
+func (_m *PoolMock) GetContent(ctx context.Context, senderOffset uint64, senderLimit uint64) (*pool.Content, error) {
+	ret := _m.Called(ctx, senderOffset, senderLimit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetContent")
+	}
+
+	var r0 *pool.Content
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) (*pool.Content, error)); ok {
+		return rf(ctx, senderOffset, senderLimit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) *pool.Content); ok {
+		r0 = rf(ctx, senderOffset, senderLimit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pool.Content)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64) error); ok {
+		r1 = rf(ctx, senderOffset, senderLimit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetContentFrom provides a mock function with given fields: ctx, from
+func (_m *PoolMock) GetContentFrom(ctx context.Context, from common.Address) (*pool.Content, error) {
+	ret := _m.Called(ctx, from)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetContentFrom")
+	}
+
+	var r0 *pool.Content
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address) (*pool.Content, error)); ok {
+		return rf(ctx, from)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address) *pool.Content); ok {
+		r0 = rf(ctx, from)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pool.Content)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Address) error); ok {
+		r1 = rf(ctx, from)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetGasPrices provides a mock function with given fields: ctx
 func (_m *PoolMock) GetGasPrices(ctx context.Context) (pool.GasPrices, error) {
 	ret := _m.Called(ctx)
@@ -260,6 +398,66 @@ func (_m *PoolMock) GetPendingTxs(ctx context.Context, limit uint64) ([]pool.Tra
 	return r0, r1
 }
 
+// GetPreconfirmation provides a mock function with given fields: ctx, txHash
+func (_m *PoolMock) GetPreconfirmation(ctx context.Context, txHash common.Hash) (*pool.Preconfirmation, error) {
+	ret := _m.Called(ctx, txHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPreconfirmation")
+	}
+
+	var r0 *pool.Preconfirmation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) (*pool.Preconfirmation, error)); ok {
+		return rf(ctx, txHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) *pool.Preconfirmation); ok {
+		r0 = rf(ctx, txHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pool.Preconfirmation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Hash) error); ok {
+		r1 = rf(ctx, txHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStatus provides a mock function with given fields: ctx
+func (_m *PoolMock) GetStatus(ctx context.Context) (*pool.Status, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStatus")
+	}
+
+	var r0 *pool.Status
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*pool.Status, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *pool.Status); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pool.Status)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTransactionByHash provides a mock function with given fields: ctx, hash
 func (_m *PoolMock) GetTransactionByHash(ctx context.Context, hash common.Hash) (*pool.Transaction, error) {
 	ret := _m.Called(ctx, hash)
@@ -320,6 +518,54 @@ func (_m *PoolMock) GetTransactionByL2Hash(ctx context.Context, hash common.Hash
 	return r0, r1
 }
 
+// RegisterNewPreconfirmationsEventHandler provides a mock function with given fields: h
+func (_m *PoolMock) RegisterNewPreconfirmationsEventHandler(h pool.NewPreconfirmationsEventHandler) {
+	_m.Called(h)
+}
+
+// RegisterNewTxsEventHandler provides a mock function with given fields: h
+func (_m *PoolMock) RegisterNewTxsEventHandler(h pool.NewTxsEventHandler) {
+	_m.Called(h)
+}
+
+// StartToMonitorNewPreconfirmations provides a mock function with given fields:
+func (_m *PoolMock) StartToMonitorNewPreconfirmations() {
+	_m.Called()
+}
+
+// StartToMonitorNewTxs provides a mock function with given fields:
+func (_m *PoolMock) StartToMonitorNewTxs() {
+	_m.Called()
+}
+
+// StopToMonitorNewPreconfirmations provides a mock function with given fields:
+func (_m *PoolMock) StopToMonitorNewPreconfirmations() {
+	_m.Called()
+}
+
+// StopToMonitorNewTxs provides a mock function with given fields:
+func (_m *PoolMock) StopToMonitorNewTxs() {
+	_m.Called()
+}
+
+// UnblockAddress provides a mock function with given fields: ctx, address
+func (_m *PoolMock) UnblockAddress(ctx context.Context, address common.Address) error {
+	ret := _m.Called(ctx, address)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnblockAddress")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address) error); ok {
+		r0 = rf(ctx, address)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewPoolMock creates a new instance of PoolMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewPoolMock(t interface {
@@ -10,6 +10,8 @@ import (
 
 	coretypes "github.com/ethereum/go-ethereum/core/types"
 
+	merkletree "github.com/0xPolygonHermez/zkevm-node/merkletree"
+
 	mock "github.com/stretchr/testify/mock"
 
 	pgx "github.com/jackc/pgx/v4"
@@ -84,6 +86,75 @@ func (_m *StateMock) BeginStateTransaction(ctx context.Context) (pgx.Tx, error)
 	return r0, r1
 }
 
+// CreateAccessList provides a mock function with given fields: ctx, tx, senderAddress, l2BlockNumber, dbTx
+func (_m *StateMock) CreateAccessList(ctx context.Context, tx *coretypes.Transaction, senderAddress common.Address, l2BlockNumber *uint64, dbTx pgx.Tx) (coretypes.AccessList, *runtime.ExecutionResult, error) {
+	ret := _m.Called(ctx, tx, senderAddress, l2BlockNumber, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateAccessList")
+	}
+
+	var r0 coretypes.AccessList
+	var r1 *runtime.ExecutionResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *coretypes.Transaction, common.Address, *uint64, pgx.Tx) (coretypes.AccessList, *runtime.ExecutionResult, error)); ok {
+		return rf(ctx, tx, senderAddress, l2BlockNumber, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *coretypes.Transaction, common.Address, *uint64, pgx.Tx) coretypes.AccessList); ok {
+		r0 = rf(ctx, tx, senderAddress, l2BlockNumber, dbTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(coretypes.AccessList)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *coretypes.Transaction, common.Address, *uint64, pgx.Tx) *runtime.ExecutionResult); ok {
+		r1 = rf(ctx, tx, senderAddress, l2BlockNumber, dbTx)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*runtime.ExecutionResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *coretypes.Transaction, common.Address, *uint64, pgx.Tx) error); ok {
+		r2 = rf(ctx, tx, senderAddress, l2BlockNumber, dbTx)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// DebugCall provides a mock function with given fields: ctx, tx, senderAddress, l2BlockNumber, traceConfig, stateOverride, dbTx
+func (_m *StateMock) DebugCall(ctx context.Context, tx *coretypes.Transaction, senderAddress common.Address, l2BlockNumber *uint64, traceConfig state.TraceConfig, stateOverride state.StateOverride, dbTx pgx.Tx) (*runtime.ExecutionResult, error) {
+	ret := _m.Called(ctx, tx, senderAddress, l2BlockNumber, traceConfig, stateOverride, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DebugCall")
+	}
+
+	var r0 *runtime.ExecutionResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *coretypes.Transaction, common.Address, *uint64, state.TraceConfig, state.StateOverride, pgx.Tx) (*runtime.ExecutionResult, error)); ok {
+		return rf(ctx, tx, senderAddress, l2BlockNumber, traceConfig, stateOverride, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *coretypes.Transaction, common.Address, *uint64, state.TraceConfig, state.StateOverride, pgx.Tx) *runtime.ExecutionResult); ok {
+		r0 = rf(ctx, tx, senderAddress, l2BlockNumber, traceConfig, stateOverride, dbTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*runtime.ExecutionResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *coretypes.Transaction, common.Address, *uint64, state.TraceConfig, state.StateOverride, pgx.Tx) error); ok {
+		r1 = rf(ctx, tx, senderAddress, l2BlockNumber, traceConfig, stateOverride, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DebugTransaction provides a mock function with given fields: ctx, transactionHash, traceConfig, dbTx
 func (_m *StateMock) DebugTransaction(ctx context.Context, transactionHash common.Hash, traceConfig state.TraceConfig, dbTx pgx.Tx) (*runtime.ExecutionResult, error) {
 	ret := _m.Called(ctx, transactionHash, traceConfig, dbTx)
@@ -114,9 +185,9 @@ func (_m *StateMock) DebugTransaction(ctx context.Context, transactionHash commo
 	return r0, r1
 }
 
-// EstimateGas provides a mock function with given fields: transaction, senderAddress, l2BlockNumber, dbTx
-func (_m *StateMock) EstimateGas(transaction *coretypes.Transaction, senderAddress common.Address, l2BlockNumber *uint64, dbTx pgx.Tx) (uint64, []byte, error) {
-	ret := _m.Called(transaction, senderAddress, l2BlockNumber, dbTx)
+// EstimateGas provides a mock function with given fields: transaction, senderAddress, l2BlockNumber, stateOverride, dbTx
+func (_m *StateMock) EstimateGas(transaction *coretypes.Transaction, senderAddress common.Address, l2BlockNumber *uint64, stateOverride state.StateOverride, dbTx pgx.Tx) (uint64, []byte, error) {
+	ret := _m.Called(transaction, senderAddress, l2BlockNumber, stateOverride, dbTx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for EstimateGas")
@@ -125,25 +196,25 @@ func (_m *StateMock) EstimateGas(transaction *coretypes.Transaction, senderAddre
 	var r0 uint64
 	var r1 []byte
 	var r2 error
-	if rf, ok := ret.Get(0).(func(*coretypes.Transaction, common.Address, *uint64, pgx.Tx) (uint64, []byte, error)); ok {
-		return rf(transaction, senderAddress, l2BlockNumber, dbTx)
+	if rf, ok := ret.Get(0).(func(*coretypes.Transaction, common.Address, *uint64, state.StateOverride, pgx.Tx) (uint64, []byte, error)); ok {
+		return rf(transaction, senderAddress, l2BlockNumber, stateOverride, dbTx)
 	}
-	if rf, ok := ret.Get(0).(func(*coretypes.Transaction, common.Address, *uint64, pgx.Tx) uint64); ok {
-		r0 = rf(transaction, senderAddress, l2BlockNumber, dbTx)
+	if rf, ok := ret.Get(0).(func(*coretypes.Transaction, common.Address, *uint64, state.StateOverride, pgx.Tx) uint64); ok {
+		r0 = rf(transaction, senderAddress, l2BlockNumber, stateOverride, dbTx)
 	} else {
 		r0 = ret.Get(0).(uint64)
 	}
 
-	if rf, ok := ret.Get(1).(func(*coretypes.Transaction, common.Address, *uint64, pgx.Tx) []byte); ok {
-		r1 = rf(transaction, senderAddress, l2BlockNumber, dbTx)
+	if rf, ok := ret.Get(1).(func(*coretypes.Transaction, common.Address, *uint64, state.StateOverride, pgx.Tx) []byte); ok {
+		r1 = rf(transaction, senderAddress, l2BlockNumber, stateOverride, dbTx)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).([]byte)
 		}
 	}
 
-	if rf, ok := ret.Get(2).(func(*coretypes.Transaction, common.Address, *uint64, pgx.Tx) error); ok {
-		r2 = rf(transaction, senderAddress, l2BlockNumber, dbTx)
+	if rf, ok := ret.Get(2).(func(*coretypes.Transaction, common.Address, *uint64, state.StateOverride, pgx.Tx) error); ok {
+		r2 = rf(transaction, senderAddress, l2BlockNumber, stateOverride, dbTx)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -151,6 +222,36 @@ func (_m *StateMock) EstimateGas(transaction *coretypes.Transaction, senderAddre
 	return r0, r1, r2
 }
 
+// GetAccountProof provides a mock function with given fields: ctx, address, positions, root
+func (_m *StateMock) GetAccountProof(ctx context.Context, address common.Address, positions []*big.Int, root common.Hash) (*merkletree.AccountProof, error) {
+	ret := _m.Called(ctx, address, positions, root)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccountProof")
+	}
+
+	var r0 *merkletree.AccountProof
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address, []*big.Int, common.Hash) (*merkletree.AccountProof, error)); ok {
+		return rf(ctx, address, positions, root)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address, []*big.Int, common.Hash) *merkletree.AccountProof); ok {
+		r0 = rf(ctx, address, positions, root)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*merkletree.AccountProof)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Address, []*big.Int, common.Hash) error); ok {
+		r1 = rf(ctx, address, positions, root)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetBalance provides a mock function with given fields: ctx, address, root
 func (_m *StateMock) GetBalance(ctx context.Context, address common.Address, root common.Hash) (*big.Int, error) {
 	ret := _m.Called(ctx, address, root)
@@ -211,6 +312,36 @@ func (_m *StateMock) GetBatchByNumber(ctx context.Context, batchNumber uint64, d
 	return r0, r1
 }
 
+// GetBatchProof provides a mock function with given fields: ctx, batchNumber, dbTx
+func (_m *StateMock) GetBatchProof(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (*state.Proof, error) {
+	ret := _m.Called(ctx, batchNumber, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBatchProof")
+	}
+
+	var r0 *state.Proof
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) (*state.Proof, error)); ok {
+		return rf(ctx, batchNumber, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) *state.Proof); ok {
+		r0 = rf(ctx, batchNumber, dbTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*state.Proof)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, pgx.Tx) error); ok {
+		r1 = rf(ctx, batchNumber, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetBatchTimestamp provides a mock function with given fields: ctx, batchNumber, forcedForkId, dbTx
 func (_m *StateMock) GetBatchTimestamp(ctx context.Context, batchNumber uint64, forcedForkId *uint64, dbTx pgx.Tx) (*time.Time, error) {
 	ret := _m.Called(ctx, batchNumber, forcedForkId, dbTx)
@@ -301,6 +432,147 @@ func (_m *StateMock) GetExitRootByGlobalExitRoot(ctx context.Context, ger common
 	return r0, r1
 }
 
+// GetForcedBatch provides a mock function with given fields: ctx, forcedBatchNumber, dbTx
+func (_m *StateMock) GetForcedBatch(ctx context.Context, forcedBatchNumber uint64, dbTx pgx.Tx) (*state.ForcedBatch, error) {
+	ret := _m.Called(ctx, forcedBatchNumber, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetForcedBatch")
+	}
+
+	var r0 *state.ForcedBatch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) (*state.ForcedBatch, error)); ok {
+		return rf(ctx, forcedBatchNumber, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) *state.ForcedBatch); ok {
+		r0 = rf(ctx, forcedBatchNumber, dbTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*state.ForcedBatch)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, pgx.Tx) error); ok {
+		r1 = rf(ctx, forcedBatchNumber, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetForkIDByBatchNumber provides a mock function with given fields: batchNumber
+func (_m *StateMock) GetForkIDByBatchNumber(batchNumber uint64) uint64 {
+	ret := _m.Called(batchNumber)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetForkIDByBatchNumber")
+	}
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func(uint64) uint64); ok {
+		r0 = rf(batchNumber)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	return r0
+}
+
+// GetL1InfoRootLeafByIndex provides a mock function with given fields: ctx, l1InfoTreeIndex, dbTx
+func (_m *StateMock) GetL1InfoRootLeafByIndex(ctx context.Context, l1InfoTreeIndex uint32, dbTx pgx.Tx) (state.L1InfoTreeExitRootStorageEntry, error) {
+	ret := _m.Called(ctx, l1InfoTreeIndex, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetL1InfoRootLeafByIndex")
+	}
+
+	var r0 state.L1InfoTreeExitRootStorageEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint32, pgx.Tx) (state.L1InfoTreeExitRootStorageEntry, error)); ok {
+		return rf(ctx, l1InfoTreeIndex, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint32, pgx.Tx) state.L1InfoTreeExitRootStorageEntry); ok {
+		r0 = rf(ctx, l1InfoTreeIndex, dbTx)
+	} else {
+		r0 = ret.Get(0).(state.L1InfoTreeExitRootStorageEntry)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint32, pgx.Tx) error); ok {
+		r1 = rf(ctx, l1InfoTreeIndex, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetL1InfoTreeLeafByGlobalExitRoot provides a mock function with given fields: ctx, ger, dbTx
+func (_m *StateMock) GetL1InfoTreeLeafByGlobalExitRoot(ctx context.Context, ger common.Hash, dbTx pgx.Tx) (state.L1InfoTreeExitRootStorageEntry, error) {
+	ret := _m.Called(ctx, ger, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetL1InfoTreeLeafByGlobalExitRoot")
+	}
+
+	var r0 state.L1InfoTreeExitRootStorageEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash, pgx.Tx) (state.L1InfoTreeExitRootStorageEntry, error)); ok {
+		return rf(ctx, ger, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash, pgx.Tx) state.L1InfoTreeExitRootStorageEntry); ok {
+		r0 = rf(ctx, ger, dbTx)
+	} else {
+		r0 = ret.Get(0).(state.L1InfoTreeExitRootStorageEntry)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Hash, pgx.Tx) error); ok {
+		r1 = rf(ctx, ger, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetL1InfoTreeMerkleProof provides a mock function with given fields: ctx, index, l1InfoRoot, dbTx
+func (_m *StateMock) GetL1InfoTreeMerkleProof(ctx context.Context, index uint32, l1InfoRoot common.Hash, dbTx pgx.Tx) (state.L1InfoTreeExitRootStorageEntry, [][32]byte, error) {
+	ret := _m.Called(ctx, index, l1InfoRoot, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetL1InfoTreeMerkleProof")
+	}
+
+	var r0 state.L1InfoTreeExitRootStorageEntry
+	var r1 [][32]byte
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint32, common.Hash, pgx.Tx) (state.L1InfoTreeExitRootStorageEntry, [][32]byte, error)); ok {
+		return rf(ctx, index, l1InfoRoot, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint32, common.Hash, pgx.Tx) state.L1InfoTreeExitRootStorageEntry); ok {
+		r0 = rf(ctx, index, l1InfoRoot, dbTx)
+	} else {
+		r0 = ret.Get(0).(state.L1InfoTreeExitRootStorageEntry)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint32, common.Hash, pgx.Tx) [][32]byte); ok {
+		r1 = rf(ctx, index, l1InfoRoot, dbTx)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([][32]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, uint32, common.Hash, pgx.Tx) error); ok {
+		r2 = rf(ctx, index, l1InfoRoot, dbTx)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetL2BlockByHash provides a mock function with given fields: ctx, hash, dbTx
 func (_m *StateMock) GetL2BlockByHash(ctx context.Context, hash common.Hash, dbTx pgx.Tx) (*state.L2Block, error) {
 	ret := _m.Called(ctx, hash, dbTx)
@@ -391,6 +663,36 @@ func (_m *StateMock) GetL2BlockHashesSince(ctx context.Context, since time.Time,
 	return r0, r1
 }
 
+// GetL2BlockHeaderByHash provides a mock function with given fields: ctx, hash, dbTx
+func (_m *StateMock) GetL2BlockHeaderByHash(ctx context.Context, hash common.Hash, dbTx pgx.Tx) (*state.L2Header, error) {
+	ret := _m.Called(ctx, hash, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetL2BlockHeaderByHash")
+	}
+
+	var r0 *state.L2Header
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash, pgx.Tx) (*state.L2Header, error)); ok {
+		return rf(ctx, hash, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash, pgx.Tx) *state.L2Header); ok {
+		r0 = rf(ctx, hash, dbTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*state.L2Header)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Hash, pgx.Tx) error); ok {
+		r1 = rf(ctx, hash, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetL2BlockHeaderByNumber provides a mock function with given fields: ctx, blockNumber, dbTx
 func (_m *StateMock) GetL2BlockHeaderByNumber(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) (*state.L2Header, error) {
 	ret := _m.Called(ctx, blockNumber, dbTx)
@@ -421,6 +723,80 @@ func (_m *StateMock) GetL2BlockHeaderByNumber(ctx context.Context, blockNumber u
 	return r0, r1
 }
 
+// GetL2BlockRangeByBatchNumber provides a mock function with given fields: ctx, batchNumber, dbTx
+func (_m *StateMock) GetL2BlockRangeByBatchNumber(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (uint64, uint64, error) {
+	ret := _m.Called(ctx, batchNumber, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetL2BlockRangeByBatchNumber")
+	}
+
+	var r0 uint64
+	var r1 uint64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) (uint64, uint64, error)); ok {
+		return rf(ctx, batchNumber, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) uint64); ok {
+		r0 = rf(ctx, batchNumber, dbTx)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, pgx.Tx) uint64); ok {
+		r1 = rf(ctx, batchNumber, dbTx)
+	} else {
+		r1 = ret.Get(1).(uint64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, uint64, pgx.Tx) error); ok {
+		r2 = rf(ctx, batchNumber, dbTx)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetL2BlockReceipts provides a mock function with given fields: ctx, blockNumber, dbTx
+func (_m *StateMock) GetL2BlockReceipts(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) ([]*coretypes.Transaction, []*coretypes.Receipt, error) {
+	ret := _m.Called(ctx, blockNumber, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetL2BlockReceipts")
+	}
+
+	var r0 []*coretypes.Transaction
+	var r1 []*coretypes.Receipt
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) ([]*coretypes.Transaction, []*coretypes.Receipt, error)); ok {
+		return rf(ctx, blockNumber, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) []*coretypes.Transaction); ok {
+		r0 = rf(ctx, blockNumber, dbTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*coretypes.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, pgx.Tx) []*coretypes.Receipt); ok {
+		r1 = rf(ctx, blockNumber, dbTx)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]*coretypes.Receipt)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, uint64, pgx.Tx) error); ok {
+		r2 = rf(ctx, blockNumber, dbTx)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetL2BlockTransactionCountByHash provides a mock function with given fields: ctx, hash, dbTx
 func (_m *StateMock) GetL2BlockTransactionCountByHash(ctx context.Context, hash common.Hash, dbTx pgx.Tx) (uint64, error) {
 	ret := _m.Called(ctx, hash, dbTx)
@@ -507,6 +883,36 @@ func (_m *StateMock) GetL2BlocksByBatchNumber(ctx context.Context, batchNumber u
 	return r0, r1
 }
 
+// GetL2BlocksFeeDataInRange provides a mock function with given fields: ctx, fromBlockNumber, toBlockNumber, dbTx
+func (_m *StateMock) GetL2BlocksFeeDataInRange(ctx context.Context, fromBlockNumber uint64, toBlockNumber uint64, dbTx pgx.Tx) ([]state.L2BlockFeeData, error) {
+	ret := _m.Called(ctx, fromBlockNumber, toBlockNumber, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetL2BlocksFeeDataInRange")
+	}
+
+	var r0 []state.L2BlockFeeData
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, pgx.Tx) ([]state.L2BlockFeeData, error)); ok {
+		return rf(ctx, fromBlockNumber, toBlockNumber, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, pgx.Tx) []state.L2BlockFeeData); ok {
+		r0 = rf(ctx, fromBlockNumber, toBlockNumber, dbTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]state.L2BlockFeeData)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64, pgx.Tx) error); ok {
+		r1 = rf(ctx, fromBlockNumber, toBlockNumber, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetL2TxHashByTxHash provides a mock function with given fields: ctx, hash, dbTx
 func (_m *StateMock) GetL2TxHashByTxHash(ctx context.Context, hash common.Hash, dbTx pgx.Tx) (*common.Hash, error) {
 	ret := _m.Called(ctx, hash, dbTx)
@@ -851,6 +1257,34 @@ func (_m *StateMock) GetLatestBatchGlobalExitRoot(ctx context.Context, dbTx pgx.
 	return r0, r1
 }
 
+// GetLatestIndex provides a mock function with given fields: ctx, dbTx
+func (_m *StateMock) GetLatestIndex(ctx context.Context, dbTx pgx.Tx) (uint32, error) {
+	ret := _m.Called(ctx, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLatestIndex")
+	}
+
+	var r0 uint32
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx) (uint32, error)); ok {
+		return rf(ctx, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx) uint32); ok {
+		r0 = rf(ctx, dbTx)
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, pgx.Tx) error); ok {
+		r1 = rf(ctx, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetLogs provides a mock function with given fields: ctx, fromBlock, toBlock, addresses, topics, blockHash, since, dbTx
 func (_m *StateMock) GetLogs(ctx context.Context, fromBlock uint64, toBlock uint64, addresses []common.Address, topics [][]common.Hash, blockHash *common.Hash, since *time.Time, dbTx pgx.Tx) ([]*coretypes.Log, error) {
 	ret := _m.Called(ctx, fromBlock, toBlock, addresses, topics, blockHash, since, dbTx)
@@ -1216,6 +1650,73 @@ func (_m *StateMock) GetVerifiedBatch(ctx context.Context, batchNumber uint64, d
 	return r0, r1
 }
 
+// GetVerifiedBatchCoveringBatch provides a mock function with given fields: ctx, batchNumber, dbTx
+func (_m *StateMock) GetVerifiedBatchCoveringBatch(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (*state.VerifiedBatch, uint64, error) {
+	ret := _m.Called(ctx, batchNumber, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetVerifiedBatchCoveringBatch")
+	}
+
+	var r0 *state.VerifiedBatch
+	var r1 uint64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) (*state.VerifiedBatch, uint64, error)); ok {
+		return rf(ctx, batchNumber, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) *state.VerifiedBatch); ok {
+		r0 = rf(ctx, batchNumber, dbTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*state.VerifiedBatch)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, pgx.Tx) uint64); ok {
+		r1 = rf(ctx, batchNumber, dbTx)
+	} else {
+		r1 = ret.Get(1).(uint64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, uint64, pgx.Tx) error); ok {
+		r2 = rf(ctx, batchNumber, dbTx)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetVerifiedBatchesByL1BlockRange provides a mock function with given fields: ctx, fromL1BlockNumber, toL1BlockNumber, dbTx
+func (_m *StateMock) GetVerifiedBatchesByL1BlockRange(ctx context.Context, fromL1BlockNumber uint64, toL1BlockNumber uint64, dbTx pgx.Tx) ([]state.VerifiedBatch, error) {
+	ret := _m.Called(ctx, fromL1BlockNumber, toL1BlockNumber, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetVerifiedBatchesByL1BlockRange")
+	}
+
+	var r0 []state.VerifiedBatch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, pgx.Tx) ([]state.VerifiedBatch, error)); ok {
+		return rf(ctx, fromL1BlockNumber, toL1BlockNumber, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, pgx.Tx) []state.VerifiedBatch); ok {
+		r0 = rf(ctx, fromL1BlockNumber, toL1BlockNumber, dbTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]state.VerifiedBatch)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64, pgx.Tx) error); ok {
+		r1 = rf(ctx, fromL1BlockNumber, toL1BlockNumber, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetVirtualBatch provides a mock function with given fields: ctx, batchNumber, dbTx
 func (_m *StateMock) GetVirtualBatch(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (*state.VirtualBatch, error) {
 	ret := _m.Called(ctx, batchNumber, dbTx)
@@ -1246,6 +1747,36 @@ func (_m *StateMock) GetVirtualBatch(ctx context.Context, batchNumber uint64, db
 	return r0, r1
 }
 
+// GetVirtualBatchesByL1BlockRange provides a mock function with given fields: ctx, fromL1BlockNumber, toL1BlockNumber, dbTx
+func (_m *StateMock) GetVirtualBatchesByL1BlockRange(ctx context.Context, fromL1BlockNumber uint64, toL1BlockNumber uint64, dbTx pgx.Tx) ([]state.VirtualBatch, error) {
+	ret := _m.Called(ctx, fromL1BlockNumber, toL1BlockNumber, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetVirtualBatchesByL1BlockRange")
+	}
+
+	var r0 []state.VirtualBatch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, pgx.Tx) ([]state.VirtualBatch, error)); ok {
+		return rf(ctx, fromL1BlockNumber, toL1BlockNumber, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, pgx.Tx) []state.VirtualBatch); ok {
+		r0 = rf(ctx, fromL1BlockNumber, toL1BlockNumber, dbTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]state.VirtualBatch)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64, pgx.Tx) error); ok {
+		r1 = rf(ctx, fromL1BlockNumber, toL1BlockNumber, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // IsL2BlockConsolidated provides a mock function with given fields: ctx, blockNumber, dbTx
 func (_m *StateMock) IsL2BlockConsolidated(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) (bool, error) {
 	ret := _m.Called(ctx, blockNumber, dbTx)
@@ -1332,9 +1863,9 @@ func (_m *StateMock) PreProcessUnsignedTransaction(ctx context.Context, tx *core
 	return r0, r1
 }
 
-// ProcessUnsignedTransaction provides a mock function with given fields: ctx, tx, senderAddress, l2BlockNumber, noZKEVMCounters, dbTx
-func (_m *StateMock) ProcessUnsignedTransaction(ctx context.Context, tx *coretypes.Transaction, senderAddress common.Address, l2BlockNumber *uint64, noZKEVMCounters bool, dbTx pgx.Tx) (*runtime.ExecutionResult, error) {
-	ret := _m.Called(ctx, tx, senderAddress, l2BlockNumber, noZKEVMCounters, dbTx)
+// ProcessUnsignedTransaction provides a mock function with given fields: ctx, tx, senderAddress, l2BlockNumber, noZKEVMCounters, stateOverride, dbTx
+func (_m *StateMock) ProcessUnsignedTransaction(ctx context.Context, tx *coretypes.Transaction, senderAddress common.Address, l2BlockNumber *uint64, noZKEVMCounters bool, stateOverride state.StateOverride, dbTx pgx.Tx) (*runtime.ExecutionResult, error) {
+	ret := _m.Called(ctx, tx, senderAddress, l2BlockNumber, noZKEVMCounters, stateOverride, dbTx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ProcessUnsignedTransaction")
@@ -1342,19 +1873,19 @@ func (_m *StateMock) ProcessUnsignedTransaction(ctx context.Context, tx *coretyp
 
 	var r0 *runtime.ExecutionResult
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *coretypes.Transaction, common.Address, *uint64, bool, pgx.Tx) (*runtime.ExecutionResult, error)); ok {
-		return rf(ctx, tx, senderAddress, l2BlockNumber, noZKEVMCounters, dbTx)
+	if rf, ok := ret.Get(0).(func(context.Context, *coretypes.Transaction, common.Address, *uint64, bool, state.StateOverride, pgx.Tx) (*runtime.ExecutionResult, error)); ok {
+		return rf(ctx, tx, senderAddress, l2BlockNumber, noZKEVMCounters, stateOverride, dbTx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *coretypes.Transaction, common.Address, *uint64, bool, pgx.Tx) *runtime.ExecutionResult); ok {
-		r0 = rf(ctx, tx, senderAddress, l2BlockNumber, noZKEVMCounters, dbTx)
+	if rf, ok := ret.Get(0).(func(context.Context, *coretypes.Transaction, common.Address, *uint64, bool, state.StateOverride, pgx.Tx) *runtime.ExecutionResult); ok {
+		r0 = rf(ctx, tx, senderAddress, l2BlockNumber, noZKEVMCounters, stateOverride, dbTx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*runtime.ExecutionResult)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *coretypes.Transaction, common.Address, *uint64, bool, pgx.Tx) error); ok {
-		r1 = rf(ctx, tx, senderAddress, l2BlockNumber, noZKEVMCounters, dbTx)
+	if rf, ok := ret.Get(1).(func(context.Context, *coretypes.Transaction, common.Address, *uint64, bool, state.StateOverride, pgx.Tx) error); ok {
+		r1 = rf(ctx, tx, senderAddress, l2BlockNumber, noZKEVMCounters, stateOverride, dbTx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1367,11 +1898,21 @@ func (_m *StateMock) RegisterNewL2BlockEventHandler(h state.NewL2BlockEventHandl
 	_m.Called(h)
 }
 
+// RegisterZKEVMEventHandler provides a mock function with given fields: h
+func (_m *StateMock) RegisterZKEVMEventHandler(h state.ZKEVMEventHandler) {
+	_m.Called(h)
+}
+
 // StartToMonitorNewL2Blocks provides a mock function with given fields:
 func (_m *StateMock) StartToMonitorNewL2Blocks() {
 	_m.Called()
 }
 
+// StartToMonitorZKEVMEvents provides a mock function with given fields:
+func (_m *StateMock) StartToMonitorZKEVMEvents() {
+	_m.Called()
+}
+
 // NewStateMock creates a new instance of StateMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewStateMock(t interface {
@@ -0,0 +1,250 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/db"
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// PostgresStorage uses the state db to store the filters without a web socket
+// connection, so they survive restarts and are shared between all the json rpc
+// servers connected to the same db. The filters with a web socket connection
+// are bound to the server holding the connection, so they are kept in memory
+type PostgresStorage struct {
+	*pgxpool.Pool
+	wsFilters *Storage
+}
+
+// NewPostgresStorage creates and initializes an instance of PostgresStorage
+func NewPostgresStorage(dbCfg db.Config) (*PostgresStorage, error) {
+	db, err := db.NewSQLDB(dbCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PostgresStorage{
+		Pool:      db,
+		wsFilters: NewStorage(),
+	}, nil
+}
+
+// storedLogFilter is the representation of a log filter stored in the db
+type storedLogFilter struct {
+	BlockHash *common.Hash     `json:"blockHash,omitempty"`
+	FromBlock *int64           `json:"fromBlock,omitempty"`
+	ToBlock   *int64           `json:"toBlock,omitempty"`
+	Addresses []common.Address `json:"addresses,omitempty"`
+	Topics    [][]common.Hash  `json:"topics,omitempty"`
+}
+
+func newStoredLogFilter(filter LogFilter) storedLogFilter {
+	stored := storedLogFilter{
+		BlockHash: filter.BlockHash,
+		Addresses: filter.Addresses,
+		Topics:    filter.Topics,
+	}
+	if filter.FromBlock != nil {
+		fromBlock := int64(*filter.FromBlock)
+		stored.FromBlock = &fromBlock
+	}
+	if filter.ToBlock != nil {
+		toBlock := int64(*filter.ToBlock)
+		stored.ToBlock = &toBlock
+	}
+	return stored
+}
+
+func (f storedLogFilter) logFilter() LogFilter {
+	filter := LogFilter{
+		BlockHash: f.BlockHash,
+		Addresses: f.Addresses,
+		Topics:    f.Topics,
+	}
+	if f.FromBlock != nil {
+		fromBlock := types.BlockNumber(*f.FromBlock)
+		filter.FromBlock = &fromBlock
+	}
+	if f.ToBlock != nil {
+		toBlock := types.BlockNumber(*f.ToBlock)
+		filter.ToBlock = &toBlock
+	}
+	return filter
+}
+
+// NewLogFilter persists a new log filter
+func (p *PostgresStorage) NewLogFilter(wsConn *concurrentWsConn, filter LogFilter) (string, error) {
+	if wsConn != nil {
+		return p.wsFilters.NewLogFilter(wsConn, filter)
+	}
+
+	if err := filter.Validate(); err != nil {
+		return "", err
+	}
+
+	parameters, err := json.Marshal(newStoredLogFilter(filter))
+	if err != nil {
+		return "", err
+	}
+
+	return p.createFilter(FilterTypeLog, parameters)
+}
+
+// NewBlockFilter persists a new block log filter
+func (p *PostgresStorage) NewBlockFilter(wsConn *concurrentWsConn) (string, error) {
+	if wsConn != nil {
+		return p.wsFilters.NewBlockFilter(wsConn)
+	}
+	return p.createFilter(FilterTypeBlock, nil)
+}
+
+// NewPendingTransactionFilter persists a new pending transaction filter
+func (p *PostgresStorage) NewPendingTransactionFilter(wsConn *concurrentWsConn) (string, error) {
+	if wsConn != nil {
+		return p.wsFilters.NewPendingTransactionFilter(wsConn)
+	}
+	return p.createFilter(FilterTypePendingTx, nil)
+}
+
+// NewSubscriptionFilter persists a new filter of the provided type bound to
+// the web socket connection
+func (p *PostgresStorage) NewSubscriptionFilter(wsConn *concurrentWsConn, filterType FilterType, parameters interface{}) (string, error) {
+	return p.wsFilters.NewSubscriptionFilter(wsConn, filterType, parameters)
+}
+
+// createFilter persists the filter to the db and provides the filter id
+func (p *PostgresStorage) createFilter(t FilterType, parameters []byte) (string, error) {
+	const insertFilterSQL = "INSERT INTO rpc.filter (id, filter_type, parameters, last_poll) VALUES ($1, $2, $3, $4)"
+
+	id, err := generateFilterID()
+	if err != nil {
+		return "", err
+	}
+
+	lastPoll := time.Now().UTC().Round(time.Microsecond)
+	if _, err := p.Exec(context.Background(), insertFilterSQL, id, string(t), parameters, lastPoll); err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+// GetAllBlockFiltersWithWSConn returns an array with all filter that have
+// a web socket connection and are filtering by new blocks
+func (p *PostgresStorage) GetAllBlockFiltersWithWSConn() []*Filter {
+	return p.wsFilters.GetAllBlockFiltersWithWSConn()
+}
+
+// GetAllLogFiltersWithWSConn returns an array with all filter that have
+// a web socket connection and are filtering by new logs
+func (p *PostgresStorage) GetAllLogFiltersWithWSConn() []*Filter {
+	return p.wsFilters.GetAllLogFiltersWithWSConn()
+}
+
+// GetAllPendingTxFiltersWithWSConn returns an array with all filter that have
+// a web socket connection and are filtering by new pending transactions
+func (p *PostgresStorage) GetAllPendingTxFiltersWithWSConn() []*Filter {
+	return p.wsFilters.GetAllPendingTxFiltersWithWSConn()
+}
+
+// GetAllZKEVMFiltersWithWSConn returns an array with all filter that have
+// a web socket connection and are filtering by the provided zkevm event type
+func (p *PostgresStorage) GetAllZKEVMFiltersWithWSConn(filterType FilterType) []*Filter {
+	return p.wsFilters.GetAllZKEVMFiltersWithWSConn(filterType)
+}
+
+// GetFilter gets a filter by its id
+func (p *PostgresStorage) GetFilter(filterID string) (*Filter, error) {
+	const getFilterSQL = "SELECT id, filter_type, parameters, last_poll FROM rpc.filter WHERE id = $1"
+
+	filter, err := p.wsFilters.GetFilter(filterID)
+	if !errors.Is(err, ErrNotFound) {
+		return filter, err
+	}
+
+	var (
+		id, filterType string
+		parameters     []byte
+		lastPoll       time.Time
+	)
+	err = p.QueryRow(context.Background(), getFilterSQL, filterID).Scan(&id, &filterType, &parameters, &lastPoll)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	filter = &Filter{
+		ID:       id,
+		Type:     FilterType(filterType),
+		LastPoll: lastPoll.UTC(),
+	}
+	if filter.Type == FilterTypeLog {
+		var stored storedLogFilter
+		if err := json.Unmarshal(parameters, &stored); err != nil {
+			return nil, err
+		}
+		filter.Parameters = stored.logFilter()
+	}
+
+	return filter, nil
+}
+
+// UpdateFilterLastPoll updates the last poll to now
+func (p *PostgresStorage) UpdateFilterLastPoll(filterID string) error {
+	const updateFilterLastPollSQL = "UPDATE rpc.filter SET last_poll = $2 WHERE id = $1"
+
+	err := p.wsFilters.UpdateFilterLastPoll(filterID)
+	if !errors.Is(err, ErrNotFound) {
+		return err
+	}
+
+	lastPoll := time.Now().UTC().Round(time.Microsecond)
+	commandTag, err := p.Exec(context.Background(), updateFilterLastPollSQL, filterID, lastPoll)
+	if err != nil {
+		return err
+	}
+	if commandTag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// UninstallFilter deletes a filter by its id
+func (p *PostgresStorage) UninstallFilter(filterID string) error {
+	const deleteFilterSQL = "DELETE FROM rpc.filter WHERE id = $1"
+
+	err := p.wsFilters.UninstallFilter(filterID)
+	if !errors.Is(err, ErrNotFound) {
+		return err
+	}
+
+	commandTag, err := p.Exec(context.Background(), deleteFilterSQL, filterID)
+	if err != nil {
+		return err
+	}
+	if commandTag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// UninstallFiltersNotPolledSince deletes the filters without a web socket
+// connection that haven't been polled since the provided time
+func (p *PostgresStorage) UninstallFiltersNotPolledSince(since time.Time) error {
+	const deleteFiltersSQL = "DELETE FROM rpc.filter WHERE last_poll < $1"
+
+	_, err := p.Exec(context.Background(), deleteFiltersSQL, since.UTC())
+	return err
+}
+
+// UninstallFilterByWSConn deletes all filters connected to the provided web socket connection
+func (p *PostgresStorage) UninstallFilterByWSConn(wsConn *concurrentWsConn) error {
+	return p.wsFilters.UninstallFilterByWSConn(wsConn)
+}
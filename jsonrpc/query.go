@@ -23,8 +23,26 @@ const (
 	FilterTypeBlock = "block"
 	// FilterTypePendingTx represent a filter of type pending Tx.
 	FilterTypePendingTx = "pendingTx"
+	// FilterTypeVirtualizedBatch represents a filter of type virtualized batch.
+	FilterTypeVirtualizedBatch = "virtualizedBatch"
+	// FilterTypeVerifiedBatch represents a filter of type verified batch.
+	FilterTypeVerifiedBatch = "verifiedBatch"
+	// FilterTypeL1InfoTreeLeaf represents a filter of type L1 info tree leaf.
+	FilterTypeL1InfoTreeLeaf = "l1InfoTreeLeaf"
+	// FilterTypePreconfirmation represents a filter of type preconfirmation.
+	FilterTypePreconfirmation = "preconfirmation"
 )
 
+// isZKEVMFilterType checks if the filter type is one of the zkevm specific ones
+func isZKEVMFilterType(t FilterType) bool {
+	return t == FilterTypeVirtualizedBatch || t == FilterTypeVerifiedBatch || t == FilterTypeL1InfoTreeLeaf || t == FilterTypePreconfirmation
+}
+
+// PendingTxFilter represents the parameters of a pending tx subscription
+type PendingTxFilter struct {
+	FullTx bool
+}
+
 // Filter represents a filter.
 type Filter struct {
 	ID         string
@@ -370,6 +388,29 @@ func (f *NativeBlockHashBlockRangeFilter) GetNumericBlockNumbers(ctx context.Con
 	return getNumericBlockNumbers(ctx, s, e, &f.FromBlock, &f.ToBlock, cfg.MaxNativeBlockHashBlockRange, state.ErrMaxNativeBlockHashBlockRangeLimitExceeded, dbTx)
 }
 
+// L1BlockRangeFilter is a filter to filter the batches sequenced or verified in a range of L1 blocks
+type L1BlockRangeFilter struct {
+	FromBlock types.ArgUint64 `json:"fromBlock"`
+	ToBlock   types.ArgUint64 `json:"toBlock"`
+}
+
+// Validate checks the L1 block range is valid and not bigger than the configured limit
+func (f *L1BlockRangeFilter) Validate(cfg Config) types.Error {
+	if f.ToBlock < f.FromBlock {
+		_, rpcErr := RPCErrorResponse(types.InvalidParamsErrorCode, state.ErrInvalidBlockRange.Error(), nil, false)
+		return rpcErr
+	}
+
+	blockRange := uint64(f.ToBlock - f.FromBlock)
+	if cfg.MaxL1BlockRange > 0 && blockRange > cfg.MaxL1BlockRange {
+		errMsg := fmt.Sprintf(state.ErrMaxL1BlockRangeLimitExceeded.Error(), cfg.MaxL1BlockRange)
+		_, rpcErr := RPCErrorResponse(types.InvalidParamsErrorCode, errMsg, nil, false)
+		return rpcErr
+	}
+
+	return nil
+}
+
 // getNumericBlockNumbers load the numeric block numbers from state accordingly
 // to the provided from and to block number
 func getNumericBlockNumbers(ctx context.Context, s types.StateInterface, e types.EthermanInterface, fromBlock, toBlock *types.BlockNumber, maxBlockRange uint64, maxBlockRangeErr error, dbTx pgx.Tx) (uint64, uint64, types.Error) {
@@ -0,0 +1,382 @@
+package jsonrpc
+
+import (
+	"container/list"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/metrics"
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"golang.org/x/time/rate"
+)
+
+const (
+	secondsPerDay = 24 * 60 * 60
+
+	// usagesSweepInterval is the time between the sweeps of the expired usages
+	usagesSweepInterval = time.Minute
+	// maxUsages is the max number of usages kept in memory, when it's reached
+	// the least recently used usage without requests in progress is evicted
+	maxUsages = 100000
+
+	// graphQLMethod is the method name used to limit the GraphQL queries
+	graphQLMethod = "graphql"
+)
+
+// limits defines the limits applied to a caller, zero means no limit
+type limits struct {
+	requestsPerSecond     float64
+	maxConcurrentRequests uint64
+	dailyQuota            uint64
+}
+
+// limitUsage keeps track of the usage of the limits by a single caller
+type limitUsage struct {
+	id         string
+	limits     limits
+	limiter    *rate.Limiter
+	concurrent uint64
+	quotaUsed  uint64
+	element    *list.Element // position in the least recently used list
+}
+
+// expired returns if the usage doesn't hold any state that must be kept, this
+// is, there are no requests in progress, the daily quota has not been used and
+// the rate limiter bucket has been refilled
+func (u *limitUsage) expired(now time.Time) bool {
+	if u.concurrent > 0 || u.quotaUsed > 0 && u.limits.dailyQuota > 0 {
+		return false
+	}
+	return u.limiter == nil || u.limiter.TokensAt(now) >= float64(u.limiter.Burst())
+}
+
+// rateLimiter enforces the limits configured per method and per API key
+type rateLimiter struct {
+	apiKeyHeader   string
+	methods        map[string]limits
+	apiKeys        map[string]limits
+	trustedProxies []*net.IPNet
+
+	usages    map[string]*limitUsage
+	lru       *list.List // usages sorted from the most to the least recently used
+	maxUsages int
+	lastSweep time.Time
+	day       int64
+	mutex     *sync.Mutex
+
+	now func() time.Time
+}
+
+// newRateLimiter creates a rate limiter with the provided config, it
+// returns nil if the limits are disabled
+func newRateLimiter(cfg RateLimitConfig) (*rateLimiter, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	trustedProxies, err := parseTrustedProxies(cfg.TrustedProxies)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &rateLimiter{
+		trustedProxies: trustedProxies,
+		apiKeyHeader:   cfg.APIKeyHeader,
+		methods:        make(map[string]limits, len(cfg.Methods)),
+		apiKeys:        make(map[string]limits, len(cfg.APIKeys)),
+		usages:         make(map[string]*limitUsage),
+		lru:            list.New(),
+		maxUsages:      maxUsages,
+		mutex:          &sync.Mutex{},
+		now:            time.Now,
+	}
+	for _, m := range cfg.Methods {
+		l.methods[m.Method] = limits{
+			requestsPerSecond:     m.RequestsPerSecond,
+			maxConcurrentRequests: m.MaxConcurrentRequests,
+			dailyQuota:            m.DailyQuota,
+		}
+	}
+	for _, k := range cfg.APIKeys {
+		l.apiKeys[k.Key] = limits{
+			requestsPerSecond:     k.RequestsPerSecond,
+			maxConcurrentRequests: k.MaxConcurrentRequests,
+			dailyQuota:            k.DailyQuota,
+		}
+	}
+	return l, nil
+}
+
+// parseTrustedProxies parses the IPs and CIDR ranges of the trusted proxies
+func parseTrustedProxies(trustedProxies []string) ([]*net.IPNet, error) {
+	nets := make([]*net.IPNet, 0, len(trustedProxies))
+	for _, proxy := range trustedProxies {
+		if !strings.Contains(proxy, "/") {
+			ip := net.ParseIP(proxy)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid trusted proxy %q", proxy)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", proxy, err)
+		}
+		nets = append(nets, ipNet)
+	}
+	return nets, nil
+}
+
+// acquire checks the limits of the method and of the API key provided in the
+// http request, when the request is allowed the usage is registered and a
+// function to release the concurrency slots once the request is handled is
+// returned, otherwise an error is returned
+func (l *rateLimiter) acquire(method string, httpRequest *http.Request) (func(), types.Error) {
+	apiKey := l.apiKey(httpRequest)
+	caller := apiKey
+	if caller == "" {
+		caller = l.clientIP(httpRequest)
+	}
+
+	type check struct {
+		subject string
+		usage   *limitUsage
+	}
+	var checks []check
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := l.now()
+	l.resetDailyUsage(now)
+	l.sweepExpiredUsages(now)
+
+	if apiKey != "" {
+		checks = append(checks, check{subject: "api key", usage: l.usage("key:"+apiKey, l.apiKeys[apiKey])})
+	}
+	if methodLimits, found := l.methods[method]; found {
+		checks = append(checks, check{subject: method, usage: l.usage("method:"+method+":"+caller, methodLimits)})
+	}
+
+	for _, c := range checks {
+		if c.usage.limits.maxConcurrentRequests > 0 && c.usage.concurrent >= c.usage.limits.maxConcurrentRequests {
+			metrics.RequestRejected(metrics.RequestRejectedLabelConcurrency, method)
+			return nil, types.NewRPCError(types.LimitExceededErrorCode, "too many concurrent requests for %s", c.subject)
+		}
+		if c.usage.limits.dailyQuota > 0 && c.usage.quotaUsed >= c.usage.limits.dailyQuota {
+			metrics.RequestRejected(metrics.RequestRejectedLabelDailyQuota, method)
+			return nil, types.NewRPCError(types.LimitExceededErrorCode, "daily quota exceeded for %s", c.subject)
+		}
+	}
+
+	reservations := make([]*rate.Reservation, 0, len(checks))
+	for _, c := range checks {
+		if c.usage.limiter == nil {
+			continue
+		}
+		r := c.usage.limiter.ReserveN(now, 1)
+		if !r.OK() || r.DelayFrom(now) > 0 {
+			r.CancelAt(now)
+			for _, reservation := range reservations {
+				reservation.CancelAt(now)
+			}
+			metrics.RequestRejected(metrics.RequestRejectedLabelRateLimit, method)
+			return nil, types.NewRPCError(types.LimitExceededErrorCode, "rate limit exceeded for %s", c.subject)
+		}
+		reservations = append(reservations, r)
+	}
+
+	for _, c := range checks {
+		c.usage.concurrent++
+		c.usage.quotaUsed++
+	}
+
+	return func() {
+		l.mutex.Lock()
+		defer l.mutex.Unlock()
+		for _, c := range checks {
+			c.usage.concurrent--
+		}
+	}, nil
+}
+
+// usage returns the usage registered for the provided id, creating it if needed
+func (l *rateLimiter) usage(id string, lim limits) *limitUsage {
+	u, found := l.usages[id]
+	if found {
+		l.lru.MoveToFront(u.element)
+		return u
+	}
+
+	if len(l.usages) >= l.maxUsages {
+		l.evictLeastRecentlyUsed()
+	}
+
+	u = &limitUsage{id: id, limits: lim}
+	if lim.requestsPerSecond > 0 {
+		burst := int(lim.requestsPerSecond)
+		if burst < 1 {
+			burst = 1
+		}
+		u.limiter = rate.NewLimiter(rate.Limit(lim.requestsPerSecond), burst)
+	}
+	u.element = l.lru.PushFront(u)
+	l.usages[id] = u
+	return u
+}
+
+// deleteUsage removes the usage from memory
+func (l *rateLimiter) deleteUsage(u *limitUsage) {
+	l.lru.Remove(u.element)
+	delete(l.usages, u.id)
+}
+
+// evictLeastRecentlyUsed removes the least recently used usage without
+// requests in progress, it's used to bound the memory when there are more
+// callers than maxUsages
+func (l *rateLimiter) evictLeastRecentlyUsed() {
+	for e := l.lru.Back(); e != nil; e = e.Prev() {
+		u := e.Value.(*limitUsage)
+		if u.concurrent == 0 {
+			l.deleteUsage(u)
+			return
+		}
+	}
+}
+
+// sweepExpiredUsages removes the usages that have expired, so the callers
+// don't pile up in memory between the daily resets
+func (l *rateLimiter) sweepExpiredUsages(now time.Time) {
+	if now.Sub(l.lastSweep) < usagesSweepInterval {
+		return
+	}
+	l.lastSweep = now
+
+	for e := l.lru.Back(); e != nil; {
+		prev := e.Prev()
+		if u := e.Value.(*limitUsage); u.expired(now) {
+			l.deleteUsage(u)
+		}
+		e = prev
+	}
+}
+
+// resetDailyUsage resets the daily quotas when the day changes and drops the
+// usages without requests in progress, so the callers don't pile up in memory
+func (l *rateLimiter) resetDailyUsage(now time.Time) {
+	day := now.UTC().Unix() / secondsPerDay
+	if day == l.day {
+		return
+	}
+	l.day = day
+
+	for _, u := range l.usages {
+		if u.concurrent == 0 {
+			l.deleteUsage(u)
+		} else {
+			u.quotaUsed = 0
+		}
+	}
+}
+
+// apiKey returns the API key provided in the http request via header or URL
+// path, if the key is not configured an empty string is returned
+func (l *rateLimiter) apiKey(httpRequest *http.Request) string {
+	if httpRequest == nil {
+		return ""
+	}
+
+	key := httpRequest.Header.Get(l.apiKeyHeader)
+	if key == "" && httpRequest.URL != nil {
+		key = strings.Trim(httpRequest.URL.Path, "/")
+	}
+
+	if _, found := l.apiKeys[key]; !found {
+		return ""
+	}
+	return key
+}
+
+// limitHandler wraps the http handler to apply the limits of the method and
+// of the API key to its requests, the handler is returned as is when the
+// limits are disabled
+func (l *rateLimiter) limitHandler(method string, next http.Handler) http.Handler {
+	if l == nil {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		release, err := l.acquire(method, req)
+		if err != nil {
+			log.Debugf("request rejected: %v", err.Error())
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusTooManyRequests)
+			response := map[string]interface{}{
+				"errors": []map[string]interface{}{{"message": err.Error()}},
+			}
+			if err := json.NewEncoder(w).Encode(response); err != nil {
+				log.Errorf("failed to write the rate limit response: %v", err)
+			}
+			return
+		}
+		defer release()
+		next.ServeHTTP(w, req)
+	})
+}
+
+// clientIP returns the IP of the client that sent the http request. When the
+// request is received from a trusted proxy the client IP is the last IP of the
+// X-Forwarded-For header that isn't a trusted proxy, or the X-Real-IP header
+func (l *rateLimiter) clientIP(httpRequest *http.Request) string {
+	if httpRequest == nil {
+		return ""
+	}
+
+	ip, _, err := net.SplitHostPort(httpRequest.RemoteAddr)
+	if err != nil {
+		ip = httpRequest.RemoteAddr
+	}
+	if !l.isTrustedProxy(ip) {
+		return ip
+	}
+
+	if forwardedFor := httpRequest.Header.Get("X-Forwarded-For"); forwardedFor != "" {
+		ips := strings.Split(forwardedFor, ",")
+		for i := len(ips) - 1; i >= 0; i-- {
+			ip = strings.TrimSpace(ips[i])
+			if !l.isTrustedProxy(ip) {
+				break
+			}
+		}
+		return ip
+	}
+	if realIP := strings.TrimSpace(httpRequest.Header.Get("X-Real-IP")); realIP != "" {
+		return realIP
+	}
+	return ip
+}
+
+// isTrustedProxy checks if the IP belongs to a trusted proxy
+func (l *rateLimiter) isTrustedProxy(ip string) bool {
+	parsedIP := net.ParseIP(ip)
+	if parsedIP == nil {
+		return false
+	}
+	for _, trustedProxy := range l.trustedProxies {
+		if trustedProxy.Contains(parsedIP) {
+			return true
+		}
+	}
+	return false
+}
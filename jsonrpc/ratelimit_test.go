@@ -0,0 +1,221 @@
+package jsonrpc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter(t *testing.T) {
+	const apiKey = "secret"
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	newLimiter := func() *rateLimiter {
+		l, err := newRateLimiter(RateLimitConfig{
+			Enabled:      true,
+			APIKeyHeader: "X-API-Key",
+			Methods: []MethodRateLimit{
+				{Method: "eth_getLogs", RequestsPerSecond: 2},
+				{Method: "debug_traceTransaction", MaxConcurrentRequests: 1},
+			},
+			APIKeys: []APIKeyRateLimit{
+				{Key: apiKey, DailyQuota: 3},
+			},
+			TrustedProxies: []string{"10.1.0.1", "10.2.0.0/16"},
+		})
+		require.NoError(t, err)
+		l.now = func() time.Time { return now }
+		return l
+	}
+
+	newRequest := func(ip, path, key string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "http://localhost"+path, nil)
+		req.RemoteAddr = ip + ":1234"
+		if key != "" {
+			req.Header.Set("X-API-Key", key)
+		}
+		return req
+	}
+
+	assertLimitExceeded := func(t *testing.T, err types.Error, message string) {
+		require.NotNil(t, err)
+		assert.Equal(t, types.LimitExceededErrorCode, err.ErrorCode())
+		assert.Equal(t, message, err.Error())
+	}
+
+	t.Run("requests per second per method and caller", func(t *testing.T) {
+		l := newLimiter()
+		for i := 0; i < 2; i++ {
+			release, err := l.acquire("eth_getLogs", newRequest("10.0.0.1", "/", ""))
+			require.Nil(t, err)
+			release()
+		}
+		_, err := l.acquire("eth_getLogs", newRequest("10.0.0.1", "/", ""))
+		assertLimitExceeded(t, err, "rate limit exceeded for eth_getLogs")
+
+		// other callers and methods are not affected
+		release, err := l.acquire("eth_getLogs", newRequest("10.0.0.2", "/", ""))
+		require.Nil(t, err)
+		release()
+		release, err = l.acquire("eth_chainId", newRequest("10.0.0.1", "/", ""))
+		require.Nil(t, err)
+		release()
+
+		// the bucket refills over time
+		now = now.Add(time.Second)
+		release, err = l.acquire("eth_getLogs", newRequest("10.0.0.1", "/", ""))
+		require.Nil(t, err)
+		release()
+	})
+
+	t.Run("max concurrent requests", func(t *testing.T) {
+		l := newLimiter()
+		release, err := l.acquire("debug_traceTransaction", newRequest("10.0.0.1", "/", ""))
+		require.Nil(t, err)
+
+		_, err = l.acquire("debug_traceTransaction", newRequest("10.0.0.1", "/", ""))
+		assertLimitExceeded(t, err, "too many concurrent requests for debug_traceTransaction")
+
+		release()
+		release, err = l.acquire("debug_traceTransaction", newRequest("10.0.0.1", "/", ""))
+		require.Nil(t, err)
+		release()
+	})
+
+	t.Run("daily quota per api key from header and path", func(t *testing.T) {
+		l := newLimiter()
+		for _, req := range []*http.Request{newRequest("10.0.0.1", "/", apiKey), newRequest("10.0.0.2", "/"+apiKey, ""), newRequest("10.0.0.3", "/", apiKey)} {
+			release, err := l.acquire("eth_chainId", req)
+			require.Nil(t, err)
+			release()
+		}
+		_, err := l.acquire("eth_chainId", newRequest("10.0.0.1", "/"+apiKey, ""))
+		assertLimitExceeded(t, err, "daily quota exceeded for api key")
+
+		// unknown keys are treated as anonymous callers
+		release, err := l.acquire("eth_chainId", newRequest("10.0.0.1", "/", "unknown"))
+		require.Nil(t, err)
+		release()
+
+		// the quota is reset on the next day
+		now = now.Add(24 * time.Hour)
+		release, err = l.acquire("eth_chainId", newRequest("10.0.0.1", "/", apiKey))
+		require.Nil(t, err)
+		release()
+	})
+
+	t.Run("expired usages are evicted", func(t *testing.T) {
+		l := newLimiter()
+		for _, ip := range []string{"10.0.0.1", "10.0.0.2", "10.0.0.3"} {
+			release, err := l.acquire("eth_getLogs", newRequest(ip, "/", ""))
+			require.Nil(t, err)
+			release()
+		}
+		release, err := l.acquire("eth_chainId", newRequest("10.0.0.1", "/", apiKey))
+		require.Nil(t, err)
+		release()
+		assert.Len(t, l.usages, 4)
+
+		// the rate limit windows have expired but the daily quota of the api key must be kept
+		now = now.Add(usagesSweepInterval)
+		release, err = l.acquire("eth_chainId", newRequest("10.0.0.4", "/", ""))
+		require.Nil(t, err)
+		release()
+		assert.Len(t, l.usages, 1)
+		assert.Contains(t, l.usages, "key:"+apiKey)
+		assert.Equal(t, l.lru.Len(), len(l.usages))
+	})
+
+	t.Run("usages are bounded", func(t *testing.T) {
+		l := newLimiter()
+		l.maxUsages = 2
+		for _, ip := range []string{"10.0.0.1", "10.0.0.2", "10.0.0.3"} {
+			release, err := l.acquire("eth_getLogs", newRequest(ip, "/", ""))
+			require.Nil(t, err)
+			release()
+		}
+		assert.Len(t, l.usages, 2)
+		assert.NotContains(t, l.usages, "method:eth_getLogs:10.0.0.1")
+
+		// usages with requests in progress are not evicted
+		release, err := l.acquire("debug_traceTransaction", newRequest("10.0.0.1", "/", ""))
+		require.Nil(t, err)
+		defer release()
+		for _, ip := range []string{"10.0.0.4", "10.0.0.5"} {
+			r, err := l.acquire("eth_getLogs", newRequest(ip, "/", ""))
+			require.Nil(t, err)
+			r()
+		}
+		assert.Contains(t, l.usages, "method:debug_traceTransaction:10.0.0.1")
+	})
+
+	t.Run("graphql handler", func(t *testing.T) {
+		l := newLimiter()
+		handler := l.limitHandler(graphQLMethod, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+
+		for i := 0; i < 3; i++ {
+			res := httptest.NewRecorder()
+			handler.ServeHTTP(res, newRequest("10.0.0.1", "/graphql", apiKey))
+			assert.Equal(t, http.StatusOK, res.Code)
+		}
+		res := httptest.NewRecorder()
+		handler.ServeHTTP(res, newRequest("10.0.0.1", "/graphql", apiKey))
+		assert.Equal(t, http.StatusTooManyRequests, res.Code)
+		assert.JSONEq(t, `{"errors":[{"message":"daily quota exceeded for api key"}]}`, res.Body.String())
+	})
+
+	t.Run("callers behind trusted proxies", func(t *testing.T) {
+		l := newLimiter()
+		newProxiedRequest := func(proxyIP, forwardedFor, realIP string) *http.Request {
+			req := newRequest(proxyIP, "/", "")
+			if forwardedFor != "" {
+				req.Header.Set("X-Forwarded-For", forwardedFor)
+			}
+			if realIP != "" {
+				req.Header.Set("X-Real-IP", realIP)
+			}
+			return req
+		}
+
+		assert.Equal(t, "10.0.0.5", l.clientIP(newProxiedRequest("10.1.0.1", "10.0.0.5", "")))
+		assert.Equal(t, "10.0.0.5", l.clientIP(newProxiedRequest("10.2.3.4", "1.2.3.4, 10.0.0.5, 10.1.0.1", "")))
+		assert.Equal(t, "10.0.0.6", l.clientIP(newProxiedRequest("10.1.0.1", "", "10.0.0.6")))
+		assert.Equal(t, "10.1.0.1", l.clientIP(newProxiedRequest("10.1.0.1", "", "")))
+		// the headers of the requests not received from a trusted proxy are ignored
+		assert.Equal(t, "10.0.0.7", l.clientIP(newProxiedRequest("10.0.0.7", "10.0.0.5", "10.0.0.6")))
+
+		// each client behind the proxy has its own limits
+		for i := 0; i < 2; i++ {
+			release, err := l.acquire("eth_getLogs", newProxiedRequest("10.1.0.1", "10.0.0.5", ""))
+			require.Nil(t, err)
+			release()
+		}
+		_, err := l.acquire("eth_getLogs", newProxiedRequest("10.1.0.1", "10.0.0.5", ""))
+		assertLimitExceeded(t, err, "rate limit exceeded for eth_getLogs")
+		release, err := l.acquire("eth_getLogs", newProxiedRequest("10.1.0.1", "10.0.0.6", ""))
+		require.Nil(t, err)
+		release()
+	})
+
+	t.Run("invalid trusted proxy", func(t *testing.T) {
+		_, err := newRateLimiter(RateLimitConfig{Enabled: true, TrustedProxies: []string{"proxy"}})
+		assert.EqualError(t, err, `invalid trusted proxy "proxy"`)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		l, err := newRateLimiter(RateLimitConfig{Enabled: false})
+		require.NoError(t, err)
+		assert.Nil(t, l)
+
+		res := httptest.NewRecorder()
+		l.limitHandler(graphQLMethod, http.NotFoundHandler()).ServeHTTP(res, newRequest("10.0.0.1", "/graphql", ""))
+		assert.Equal(t, http.StatusNotFound, res.Code)
+	})
+}
@@ -13,6 +13,7 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/graphql"
 	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/metrics"
 	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
 	"github.com/0xPolygonHermez/zkevm-node/log"
@@ -33,6 +34,10 @@ const (
 	APITxPool = "txpool"
 	// APIWeb3 represents the web3 API prefix.
 	APIWeb3 = "web3"
+	// APITrace represents the trace API prefix.
+	APITrace = "trace"
+	// APIAdmin represents the admin API prefix, only served by the admin server.
+	APIAdmin = "admin"
 
 	wsBufferSizeLimitInBytes = 1024
 	maxRequestContentLength  = 1024 * 1024 * 5
@@ -50,6 +55,11 @@ type Server struct {
 	srv        *http.Server
 	wsSrv      *http.Server
 	wsUpgrader websocket.Upgrader
+	storage    storageInterface
+	graphQL    http.Handler
+	jwtSecret  []byte
+
+	stopFilterCleanup context.CancelFunc
 }
 
 // Service defines a struct that will provide public methods to be exposed
@@ -76,9 +86,18 @@ func NewServer(
 ) *Server {
 	if cfg.WebSockets.Enabled {
 		s.StartToMonitorNewL2Blocks()
+		s.StartToMonitorZKEVMEvents()
+	} else if cfg.Cache.Enabled {
+		// the cache is purged when the monitor detects a reorg
+		s.StartToMonitorZKEVMEvents()
 	}
 
+	limiter, err := newRateLimiter(cfg.RateLimit)
+	if err != nil {
+		log.Fatalf("failed to create the rate limiter: %v", err)
+	}
 	handler := newJSONRpcHandler()
+	handler.limiter = limiter
 
 	for _, service := range services {
 		handler.registerService(service)
@@ -88,10 +107,58 @@ func NewServer(
 		config:  cfg,
 		handler: handler,
 		chainID: chainID,
+		storage: storage,
+	}
+
+	if cfg.GraphQL.Enabled {
+		graphQLHandler, err := graphql.NewHandler(chainID, graphql.Limits{
+			MaxBlockRange:           cfg.GraphQL.MaxBlockRange,
+			MaxLogsBlockRange:       cfg.MaxLogsBlockRange,
+			MaxDepth:                cfg.GraphQL.MaxDepth,
+			MaxParallelism:          cfg.GraphQL.MaxParallelism,
+			MaxBlocks:               cfg.GraphQL.MaxBlocks,
+			MaxExecutorCalls:        cfg.GraphQL.MaxExecutorCalls,
+			MaxRequestContentLength: maxRequestContentLength,
+		}, p, s)
+		if err != nil {
+			log.Fatalf("failed to create the GraphQL handler: %v", err)
+		}
+		srv.graphQL = handler.limiter.limitHandler(graphQLMethod, graphQLHandler)
 	}
+
 	return srv
 }
 
+// NewAdminServer returns a JsonRPC server listening on the admin host and port
+// that serves the provided services only to the requests authenticated with a
+// JWT signed with the secret of the admin config
+func NewAdminServer(cfg Config, services []Service) (*Server, error) {
+	secret, err := readJWTSecret(cfg.Admin.JWTSecretFile)
+	if err != nil {
+		return nil, err
+	}
+
+	handler := newJSONRpcHandler()
+	for _, service := range services {
+		handler.registerService(service)
+	}
+
+	adminCfg := Config{
+		Host:                      cfg.Admin.Host,
+		Port:                      cfg.Admin.Port,
+		ReadTimeout:               cfg.ReadTimeout,
+		WriteTimeout:              cfg.WriteTimeout,
+		MaxRequestsPerIPAndSecond: cfg.MaxRequestsPerIPAndSecond,
+		EnableHttpLog:             cfg.EnableHttpLog,
+	}
+
+	return &Server{
+		config:    adminCfg,
+		handler:   handler,
+		jwtSecret: secret,
+	}, nil
+}
+
 // Start initializes the JSON RPC server to listen for request
 func (s *Server) Start() error {
 	metrics.Register()
@@ -100,9 +167,34 @@ func (s *Server) Start() error {
 		go s.startWS()
 	}
 
+	if s.config.FilterTimeout.Duration > 0 {
+		ctx, cancel := context.WithCancel(context.Background())
+		s.stopFilterCleanup = cancel
+		go s.uninstallTimedOutFilters(ctx)
+	}
+
 	return s.startHTTP()
 }
 
+// uninstallTimedOutFilters periodically uninstalls the filters that
+// haven't been polled within the configured filter timeout
+func (s *Server) uninstallTimedOutFilters(ctx context.Context) {
+	ticker := time.NewTicker(s.config.FilterTimeout.Duration)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			since := time.Now().UTC().Add(-s.config.FilterTimeout.Duration)
+			if err := s.storage.UninstallFiltersNotPolledSince(since); err != nil {
+				log.Errorf("failed to uninstall timed out filters: %v", err)
+			}
+		}
+	}
+}
+
 // startHTTP starts a server to respond http requests
 func (s *Server) startHTTP() error {
 	if s.srv != nil {
@@ -120,7 +212,14 @@ func (s *Server) startHTTP() error {
 	mux := http.NewServeMux()
 
 	lmt := tollbooth.NewLimiter(s.config.MaxRequestsPerIPAndSecond, nil)
-	mux.Handle("/", tollbooth.LimitFuncHandler(lmt, s.handle))
+	if s.jwtSecret != nil {
+		mux.Handle("/", tollbooth.LimitHandler(lmt, newJWTHandler(s.jwtSecret, http.HandlerFunc(s.handle))))
+	} else {
+		mux.Handle("/", tollbooth.LimitFuncHandler(lmt, s.handle))
+	}
+	if s.graphQL != nil {
+		mux.Handle("/graphql", tollbooth.LimitHandler(lmt, s.graphQL))
+	}
 
 	s.srv = &http.Server{
 		Handler:           mux,
@@ -183,6 +282,11 @@ func (s *Server) startWS() {
 
 // Stop shutdown the rpc server
 func (s *Server) Stop() error {
+	if s.stopFilterCleanup != nil {
+		s.stopFilterCleanup()
+		s.stopFilterCleanup = nil
+	}
+
 	if s.srv != nil {
 		if err := s.srv.Shutdown(context.Background()); err != nil {
 			return err
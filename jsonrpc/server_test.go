@@ -58,11 +58,17 @@ func newMockedServer(t *testing.T, cfg Config) (*mockedServer, *mocksWrapper, *e
 		APIZKEVM:  true,
 		APITxPool: true,
 		APIWeb3:   true,
+		APITrace:  true,
 	}
 
 	var newL2BlockEventHandler state.NewL2BlockEventHandler = func(e state.NewL2BlockEvent) {}
 	st.On("RegisterNewL2BlockEventHandler", mock.IsType(newL2BlockEventHandler)).Once()
 	st.On("StartToMonitorNewL2Blocks").Once()
+	var zkEVMEventHandler state.ZKEVMEventHandler = func(e state.ZKEVMEvent) {}
+	st.On("RegisterZKEVMEventHandler", mock.IsType(zkEVMEventHandler)).Once()
+	st.On("StartToMonitorZKEVMEvents").Once()
+	pool.On("RegisterNewTxsEventHandler", mock.AnythingOfType("pool.NewTxsEventHandler")).Once()
+	pool.On("RegisterNewPreconfirmationsEventHandler", mock.AnythingOfType("pool.NewPreconfirmationsEventHandler")).Once()
 
 	services := []Service{}
 	if _, ok := apis[APIEth]; ok {
@@ -82,14 +88,14 @@ func newMockedServer(t *testing.T, cfg Config) (*mockedServer, *mocksWrapper, *e
 	if _, ok := apis[APIZKEVM]; ok {
 		services = append(services, Service{
 			Name:    APIZKEVM,
-			Service: NewZKEVMEndpoints(cfg, pool, st, etherman),
+			Service: NewZKEVMEndpoints(cfg, chainID, pool, st, etherman),
 		})
 	}
 
 	if _, ok := apis[APITxPool]; ok {
 		services = append(services, Service{
 			Name:    APITxPool,
-			Service: &TxPoolEndpoints{},
+			Service: NewTxPoolEndpoints(pool),
 		})
 	}
 
@@ -100,6 +106,13 @@ func newMockedServer(t *testing.T, cfg Config) (*mockedServer, *mocksWrapper, *e
 		})
 	}
 
+	if _, ok := apis[APITrace]; ok {
+		services = append(services, Service{
+			Name:    APITrace,
+			Service: NewTraceEndpoints(cfg, st, etherman),
+		})
+	}
+
 	if _, ok := apis[APIWeb3]; ok {
 		services = append(services, Service{
 			Name:    APIWeb3,
@@ -159,6 +172,9 @@ func getSequencerDefaultConfig() Config {
 		MaxLogsCount:                 10000,
 		MaxLogsBlockRange:            10000,
 		MaxNativeBlockHashBlockRange: 60000,
+		MaxTracesBlockRange:          100,
+		MaxL1BlockRange:              1000,
+		MaxStorageProofKeys:          2,
 		WebSockets: WebSocketsConfig{
 			Enabled:   true,
 			Host:      "0.0.0.0",
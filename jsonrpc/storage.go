@@ -6,6 +6,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/0xPolygonHermez/zkevm-node/db"
 	"github.com/0xPolygonHermez/zkevm-node/hex"
 	"github.com/0xPolygonHermez/zkevm-node/state"
 	"github.com/google/uuid"
@@ -14,6 +15,9 @@ import (
 // ErrNotFound represent a not found error.
 var ErrNotFound = errors.New("object not found")
 
+// ErrFilterWithoutWSConn indicates a filter that requires a web socket connection was created without it
+var ErrFilterWithoutWSConn = errors.New("filter requires a web socket connection")
+
 // ErrFilterInvalidPayload indicates there is an invalid payload when creating a filter
 var ErrFilterInvalidPayload = errors.New("invalid argument 0: cannot specify both BlockHash and FromBlock/ToBlock, choose one or the other")
 
@@ -25,10 +29,12 @@ type Storage struct {
 	blockFiltersWithWSConn     map[string]*Filter
 	logFiltersWithWSConn       map[string]*Filter
 	pendingTxFiltersWithWSConn map[string]*Filter
+	zkevmFiltersWithWSConn     map[FilterType]map[string]*Filter
 
 	blockMutex     *sync.Mutex
 	logMutex       *sync.Mutex
 	pendingTxMutex *sync.Mutex
+	zkevmMutex     *sync.Mutex
 }
 
 // NewStorage creates and initializes an instance of Storage
@@ -39,9 +45,28 @@ func NewStorage() *Storage {
 		blockFiltersWithWSConn:     make(map[string]*Filter),
 		logFiltersWithWSConn:       make(map[string]*Filter),
 		pendingTxFiltersWithWSConn: make(map[string]*Filter),
+		zkevmFiltersWithWSConn:     make(map[FilterType]map[string]*Filter),
 		blockMutex:                 &sync.Mutex{},
 		logMutex:                   &sync.Mutex{},
 		pendingTxMutex:             &sync.Mutex{},
+		zkevmMutex:                 &sync.Mutex{},
+	}
+}
+
+// NewFilterStorage creates the filter storage defined by the config,
+// the postgres storage uses the provided db config
+func NewFilterStorage(cfg Config, dbCfg db.Config) (storageInterface, error) {
+	switch cfg.FilterStorage {
+	case "", FilterStorageMemory:
+		return NewStorage(), nil
+	case FilterStoragePostgres:
+		storage, err := NewPostgresStorage(dbCfg)
+		if err != nil {
+			return nil, err
+		}
+		return storage, nil
+	default:
+		return nil, fmt.Errorf("invalid filter storage: %s", cfg.FilterStorage)
 	}
 }
 
@@ -64,10 +89,20 @@ func (s *Storage) NewPendingTransactionFilter(wsConn *concurrentWsConn) (string,
 	return s.createFilter(FilterTypePendingTx, nil, wsConn)
 }
 
+// NewSubscriptionFilter persists a new filter of the provided type bound to
+// the web socket connection, it's used by the subscriptions that can't be
+// polled, like the pending transactions with full transactions and the zkevm ones
+func (s *Storage) NewSubscriptionFilter(wsConn *concurrentWsConn, filterType FilterType, parameters interface{}) (string, error) {
+	if wsConn == nil {
+		return "", ErrFilterWithoutWSConn
+	}
+	return s.createFilter(filterType, parameters, wsConn)
+}
+
 // create persists the filter to the memory and provides the filter id
 func (s *Storage) createFilter(t FilterType, parameters interface{}, wsConn *concurrentWsConn) (string, error) {
 	lastPoll := time.Now().UTC()
-	id, err := s.generateFilterID()
+	id, err := generateFilterID()
 	if err != nil {
 		return "", fmt.Errorf("failed to generate filter ID: %w", err)
 	}
@@ -75,9 +110,11 @@ func (s *Storage) createFilter(t FilterType, parameters interface{}, wsConn *con
 	s.blockMutex.Lock()
 	s.logMutex.Lock()
 	s.pendingTxMutex.Lock()
+	s.zkevmMutex.Lock()
 	defer s.blockMutex.Unlock()
 	defer s.logMutex.Unlock()
 	defer s.pendingTxMutex.Unlock()
+	defer s.zkevmMutex.Unlock()
 
 	f := &Filter{
 		ID:            id,
@@ -104,12 +141,17 @@ func (s *Storage) createFilter(t FilterType, parameters interface{}, wsConn *con
 			s.logFiltersWithWSConn[id] = f
 		} else if t == FilterTypePendingTx {
 			s.pendingTxFiltersWithWSConn[id] = f
+		} else if isZKEVMFilterType(t) {
+			if _, found := s.zkevmFiltersWithWSConn[t]; !found {
+				s.zkevmFiltersWithWSConn[t] = make(map[string]*Filter)
+			}
+			s.zkevmFiltersWithWSConn[t][id] = f
 		}
 	}
 	return id, nil
 }
 
-func (s *Storage) generateFilterID() (string, error) {
+func generateFilterID() (string, error) {
 	r, err := uuid.NewRandom()
 	if err != nil {
 		return "", err
@@ -152,14 +194,44 @@ func (s *Storage) GetAllLogFiltersWithWSConn() []*Filter {
 	return filters
 }
 
+// GetAllPendingTxFiltersWithWSConn returns an array with all filter that have
+// a web socket connection and are filtering by new pending transactions
+func (s *Storage) GetAllPendingTxFiltersWithWSConn() []*Filter {
+	s.pendingTxMutex.Lock()
+	defer s.pendingTxMutex.Unlock()
+
+	filters := []*Filter{}
+	for _, filter := range s.pendingTxFiltersWithWSConn {
+		f := filter
+		filters = append(filters, f)
+	}
+	return filters
+}
+
+// GetAllZKEVMFiltersWithWSConn returns an array with all filter that have
+// a web socket connection and are filtering by the provided zkevm event type
+func (s *Storage) GetAllZKEVMFiltersWithWSConn(filterType FilterType) []*Filter {
+	s.zkevmMutex.Lock()
+	defer s.zkevmMutex.Unlock()
+
+	filters := []*Filter{}
+	for _, filter := range s.zkevmFiltersWithWSConn[filterType] {
+		f := filter
+		filters = append(filters, f)
+	}
+	return filters
+}
+
 // GetFilter gets a filter by its id
 func (s *Storage) GetFilter(filterID string) (*Filter, error) {
 	s.blockMutex.Lock()
 	s.logMutex.Lock()
 	s.pendingTxMutex.Lock()
+	s.zkevmMutex.Lock()
 	defer s.blockMutex.Unlock()
 	defer s.logMutex.Unlock()
 	defer s.pendingTxMutex.Unlock()
+	defer s.zkevmMutex.Unlock()
 
 	filter, found := s.allFilters[filterID]
 	if !found {
@@ -174,9 +246,11 @@ func (s *Storage) UpdateFilterLastPoll(filterID string) error {
 	s.blockMutex.Lock()
 	s.logMutex.Lock()
 	s.pendingTxMutex.Lock()
+	s.zkevmMutex.Lock()
 	defer s.blockMutex.Unlock()
 	defer s.logMutex.Unlock()
 	defer s.pendingTxMutex.Unlock()
+	defer s.zkevmMutex.Unlock()
 
 	filter, found := s.allFilters[filterID]
 	if !found {
@@ -192,9 +266,11 @@ func (s *Storage) UninstallFilter(filterID string) error {
 	s.blockMutex.Lock()
 	s.logMutex.Lock()
 	s.pendingTxMutex.Lock()
+	s.zkevmMutex.Lock()
 	defer s.blockMutex.Unlock()
 	defer s.logMutex.Unlock()
 	defer s.pendingTxMutex.Unlock()
+	defer s.zkevmMutex.Unlock()
 
 	filter, found := s.allFilters[filterID]
 	if !found {
@@ -205,14 +281,37 @@ func (s *Storage) UninstallFilter(filterID string) error {
 	return nil
 }
 
+// UninstallFiltersNotPolledSince deletes the filters without a web socket
+// connection that haven't been polled since the provided time
+func (s *Storage) UninstallFiltersNotPolledSince(since time.Time) error {
+	s.blockMutex.Lock()
+	s.logMutex.Lock()
+	s.pendingTxMutex.Lock()
+	s.zkevmMutex.Lock()
+	defer s.blockMutex.Unlock()
+	defer s.logMutex.Unlock()
+	defer s.pendingTxMutex.Unlock()
+	defer s.zkevmMutex.Unlock()
+
+	for _, filter := range s.allFilters {
+		if filter.WsConn == nil && filter.LastPoll.Before(since) {
+			s.deleteFilter(filter)
+		}
+	}
+
+	return nil
+}
+
 // UninstallFilterByWSConn deletes all filters connected to the provided web socket connection
 func (s *Storage) UninstallFilterByWSConn(wsConn *concurrentWsConn) error {
 	s.blockMutex.Lock()
 	s.logMutex.Lock()
 	s.pendingTxMutex.Lock()
+	s.zkevmMutex.Lock()
 	defer s.blockMutex.Unlock()
 	defer s.logMutex.Unlock()
 	defer s.pendingTxMutex.Unlock()
+	defer s.zkevmMutex.Unlock()
 
 	filters, found := s.allFiltersWithWSConn[wsConn]
 	if !found {
@@ -234,6 +333,8 @@ func (s *Storage) deleteFilter(filter *Filter) {
 		delete(s.logFiltersWithWSConn, filter.ID)
 	} else if filter.Type == FilterTypePendingTx {
 		delete(s.pendingTxFiltersWithWSConn, filter.ID)
+	} else if isZKEVMFilterType(filter.Type) {
+		delete(s.zkevmFiltersWithWSConn[filter.Type], filter.ID)
 	}
 
 	if filter.WsConn != nil {
@@ -0,0 +1,37 @@
+package jsonrpc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUninstallFiltersNotPolledSince(t *testing.T) {
+	s := NewStorage()
+
+	wsConn := newConcurrentWsConn(nil)
+	wsFilterID, err := s.NewBlockFilter(wsConn)
+	require.NoError(t, err)
+
+	oldFilterID, err := s.NewBlockFilter(nil)
+	require.NoError(t, err)
+	s.allFilters[oldFilterID].LastPoll = time.Now().UTC().Add(-time.Hour)
+	s.allFilters[wsFilterID].LastPoll = time.Now().UTC().Add(-time.Hour)
+
+	recentFilterID, err := s.NewPendingTransactionFilter(nil)
+	require.NoError(t, err)
+
+	err = s.UninstallFiltersNotPolledSince(time.Now().UTC().Add(-time.Minute))
+	require.NoError(t, err)
+
+	_, err = s.GetFilter(oldFilterID)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = s.GetFilter(recentFilterID)
+	assert.NoError(t, err)
+
+	_, err = s.GetFilter(wsFilterID)
+	assert.NoError(t, err)
+}
@@ -15,6 +15,8 @@ const (
 	InvalidParamsErrorCode = -32602
 	// ParserErrorCode error code for parsing errors
 	ParserErrorCode = -32700
+	// LimitExceededErrorCode error code for requests rejected by the rate limits
+	LimitExceededErrorCode = -32005
 )
 
 var (
@@ -25,6 +27,10 @@ var (
 	// ErrBatchRequestsLimitExceeded returned by the server when a batch request
 	// is detected and the number of requests are greater than the configured limit.
 	ErrBatchRequestsLimitExceeded = fmt.Errorf("batch requests limit exceeded")
+
+	// ErrMissingDynamicFeeFields returned when a dynamic fee transaction
+	// doesn't have both the max fee per gas and the max priority fee per gas
+	ErrMissingDynamicFeeFields = fmt.Errorf("dynamic fee transaction requires maxFeePerGas and maxPriorityFeePerGas")
 )
 
 // Error interface
@@ -5,6 +5,7 @@ import (
 	"math/big"
 	"time"
 
+	"github.com/0xPolygonHermez/zkevm-node/merkletree"
 	"github.com/0xPolygonHermez/zkevm-node/pool"
 	"github.com/0xPolygonHermez/zkevm-node/state"
 	"github.com/0xPolygonHermez/zkevm-node/state/runtime"
@@ -16,6 +17,7 @@ import (
 // PoolInterface contains the methods required to interact with the tx pool.
 type PoolInterface interface {
 	AddTx(ctx context.Context, tx types.Transaction, ip string) error
+	AddBundle(ctx context.Context, txs []types.Transaction, blockNumber uint64, maxTimestamp uint64, ip string) (common.Hash, error)
 	GetGasPrices(ctx context.Context) (pool.GasPrices, error)
 	GetNonce(ctx context.Context, address common.Address) (uint64, error)
 	GetPendingTxHashesSince(ctx context.Context, since time.Time) ([]common.Hash, error)
@@ -26,21 +28,39 @@ type PoolInterface interface {
 	CalculateEffectiveGasPrice(rawTx []byte, txGasPrice *big.Int, txGasUsed uint64, l1GasPrice uint64, l2GasPrice uint64) (*big.Int, error)
 	CalculateEffectiveGasPricePercentage(gasPrice *big.Int, effectiveGasPrice *big.Int) (uint8, error)
 	EffectiveGasPriceEnabled() bool
+	GetContent(ctx context.Context, senderOffset, senderLimit uint64) (*pool.Content, error)
+	GetContentFrom(ctx context.Context, from common.Address) (*pool.Content, error)
+	GetStatus(ctx context.Context) (*pool.Status, error)
+	StartToMonitorNewTxs()
+	StopToMonitorNewTxs()
+	RegisterNewTxsEventHandler(h pool.NewTxsEventHandler)
+	GetPreconfirmation(ctx context.Context, txHash common.Hash) (*pool.Preconfirmation, error)
+	StartToMonitorNewPreconfirmations()
+	StopToMonitorNewPreconfirmations()
+	RegisterNewPreconfirmationsEventHandler(h pool.NewPreconfirmationsEventHandler)
+	BlockAddress(ctx context.Context, address common.Address, reason string) error
+	UnblockAddress(ctx context.Context, address common.Address) error
+	GetBlockedAddresses(ctx context.Context) ([]common.Address, error)
 }
 
 // StateInterface gathers the methods required to interact with the state.
 type StateInterface interface {
 	StartToMonitorNewL2Blocks()
+	StartToMonitorZKEVMEvents()
 	BeginStateTransaction(ctx context.Context) (pgx.Tx, error)
 	DebugTransaction(ctx context.Context, transactionHash common.Hash, traceConfig state.TraceConfig, dbTx pgx.Tx) (*runtime.ExecutionResult, error)
-	EstimateGas(transaction *types.Transaction, senderAddress common.Address, l2BlockNumber *uint64, dbTx pgx.Tx) (uint64, []byte, error)
+	DebugCall(ctx context.Context, tx *types.Transaction, senderAddress common.Address, l2BlockNumber *uint64, traceConfig state.TraceConfig, stateOverride state.StateOverride, dbTx pgx.Tx) (*runtime.ExecutionResult, error)
+	CreateAccessList(ctx context.Context, tx *types.Transaction, senderAddress common.Address, l2BlockNumber *uint64, dbTx pgx.Tx) (types.AccessList, *runtime.ExecutionResult, error)
+	EstimateGas(transaction *types.Transaction, senderAddress common.Address, l2BlockNumber *uint64, stateOverride state.StateOverride, dbTx pgx.Tx) (uint64, []byte, error)
 	GetBalance(ctx context.Context, address common.Address, root common.Hash) (*big.Int, error)
 	GetCode(ctx context.Context, address common.Address, root common.Hash) ([]byte, error)
 	GetL2BlockByHash(ctx context.Context, hash common.Hash, dbTx pgx.Tx) (*state.L2Block, error)
 	GetL2BlockByNumber(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) (*state.L2Block, error)
 	BatchNumberByL2BlockNumber(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) (uint64, error)
 	GetL2BlockHashesSince(ctx context.Context, since time.Time, dbTx pgx.Tx) ([]common.Hash, error)
+	GetL2BlockHeaderByHash(ctx context.Context, hash common.Hash, dbTx pgx.Tx) (*state.L2Header, error)
 	GetL2BlockHeaderByNumber(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) (*state.L2Header, error)
+	GetL2BlocksFeeDataInRange(ctx context.Context, fromBlockNumber, toBlockNumber uint64, dbTx pgx.Tx) ([]state.L2BlockFeeData, error)
 	GetL2BlockTransactionCountByHash(ctx context.Context, hash common.Hash, dbTx pgx.Tx) (uint64, error)
 	GetL2BlockTransactionCountByNumber(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) (uint64, error)
 	GetLastVirtualizedL2BlockNumber(ctx context.Context, dbTx pgx.Tx) (uint64, error)
@@ -50,16 +70,19 @@ type StateInterface interface {
 	GetLogs(ctx context.Context, fromBlock uint64, toBlock uint64, addresses []common.Address, topics [][]common.Hash, blockHash *common.Hash, since *time.Time, dbTx pgx.Tx) ([]*types.Log, error)
 	GetNonce(ctx context.Context, address common.Address, root common.Hash) (uint64, error)
 	GetStorageAt(ctx context.Context, address common.Address, position *big.Int, root common.Hash) (*big.Int, error)
+	GetAccountProof(ctx context.Context, address common.Address, positions []*big.Int, root common.Hash) (*merkletree.AccountProof, error)
 	GetSyncingInfo(ctx context.Context, dbTx pgx.Tx) (state.SyncingInfo, error)
 	GetTransactionByHash(ctx context.Context, transactionHash common.Hash, dbTx pgx.Tx) (*types.Transaction, error)
 	GetTransactionByL2Hash(ctx context.Context, transactionHash common.Hash, dbTx pgx.Tx) (*types.Transaction, error)
 	GetTransactionByL2BlockHashAndIndex(ctx context.Context, blockHash common.Hash, index uint64, dbTx pgx.Tx) (*types.Transaction, error)
 	GetTransactionByL2BlockNumberAndIndex(ctx context.Context, blockNumber uint64, index uint64, dbTx pgx.Tx) (*types.Transaction, error)
 	GetTransactionReceipt(ctx context.Context, transactionHash common.Hash, dbTx pgx.Tx) (*types.Receipt, error)
+	GetL2BlockReceipts(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) (txs []*types.Transaction, receipts []*types.Receipt, err error)
 	IsL2BlockConsolidated(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) (bool, error)
 	IsL2BlockVirtualized(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) (bool, error)
-	ProcessUnsignedTransaction(ctx context.Context, tx *types.Transaction, senderAddress common.Address, l2BlockNumber *uint64, noZKEVMCounters bool, dbTx pgx.Tx) (*runtime.ExecutionResult, error)
+	ProcessUnsignedTransaction(ctx context.Context, tx *types.Transaction, senderAddress common.Address, l2BlockNumber *uint64, noZKEVMCounters bool, stateOverride state.StateOverride, dbTx pgx.Tx) (*runtime.ExecutionResult, error)
 	RegisterNewL2BlockEventHandler(h state.NewL2BlockEventHandler)
+	RegisterZKEVMEventHandler(h state.ZKEVMEventHandler)
 	GetLastVirtualBatchNum(ctx context.Context, dbTx pgx.Tx) (uint64, error)
 	GetLastVerifiedBatch(ctx context.Context, dbTx pgx.Tx) (*state.VerifiedBatch, error)
 	GetLastBatchNumber(ctx context.Context, dbTx pgx.Tx) (uint64, error)
@@ -67,7 +90,18 @@ type StateInterface interface {
 	GetTransactionsByBatchNumber(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (txs []types.Transaction, effectivePercentages []uint8, err error)
 	GetVirtualBatch(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (*state.VirtualBatch, error)
 	GetVerifiedBatch(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (*state.VerifiedBatch, error)
+	GetVerifiedBatchCoveringBatch(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (*state.VerifiedBatch, uint64, error)
+	GetVerifiedBatchesByL1BlockRange(ctx context.Context, fromL1BlockNumber, toL1BlockNumber uint64, dbTx pgx.Tx) ([]state.VerifiedBatch, error)
+	GetVirtualBatchesByL1BlockRange(ctx context.Context, fromL1BlockNumber, toL1BlockNumber uint64, dbTx pgx.Tx) ([]state.VirtualBatch, error)
+	GetL2BlockRangeByBatchNumber(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (uint64, uint64, error)
+	GetBatchProof(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (*state.Proof, error)
+	GetForcedBatch(ctx context.Context, forcedBatchNumber uint64, dbTx pgx.Tx) (*state.ForcedBatch, error)
+	GetForkIDByBatchNumber(batchNumber uint64) uint64
 	GetExitRootByGlobalExitRoot(ctx context.Context, ger common.Hash, dbTx pgx.Tx) (*state.GlobalExitRoot, error)
+	GetLatestIndex(ctx context.Context, dbTx pgx.Tx) (uint32, error)
+	GetL1InfoRootLeafByIndex(ctx context.Context, l1InfoTreeIndex uint32, dbTx pgx.Tx) (state.L1InfoTreeExitRootStorageEntry, error)
+	GetL1InfoTreeLeafByGlobalExitRoot(ctx context.Context, ger common.Hash, dbTx pgx.Tx) (state.L1InfoTreeExitRootStorageEntry, error)
+	GetL1InfoTreeMerkleProof(ctx context.Context, index uint32, l1InfoRoot common.Hash, dbTx pgx.Tx) (state.L1InfoTreeExitRootStorageEntry, [][32]byte, error)
 	GetL2BlocksByBatchNumber(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) ([]state.L2Block, error)
 	GetNativeBlockHashesInRange(ctx context.Context, fromBlockNumber uint64, toBlockNumber uint64, dbTx pgx.Tx) ([]common.Hash, error)
 	GetLastClosedBatchNumber(ctx context.Context, dbTx pgx.Tx) (uint64, error)
@@ -84,3 +118,11 @@ type EthermanInterface interface {
 	GetSafeBlockNumber(ctx context.Context) (uint64, error)
 	GetFinalizedBlockNumber(ctx context.Context) (uint64, error)
 }
+
+// SequencerInterface contains the methods required to operate the sequencer.
+type SequencerInterface interface {
+	HaltFinalizer() error
+	ResumeFinalizer() error
+	FinalizerStatus() string
+	RegenerateDataStream(ctx context.Context) error
+}
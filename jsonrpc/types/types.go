@@ -3,12 +3,15 @@ package types
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
 	"strconv"
 	"strings"
 
 	"github.com/0xPolygonHermez/zkevm-node/hex"
+	"github.com/0xPolygonHermez/zkevm-node/merkletree"
+	"github.com/0xPolygonHermez/zkevm-node/pool"
 	"github.com/0xPolygonHermez/zkevm-node/state"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -176,16 +179,33 @@ func (arg *ArgAddress) Address() common.Address {
 	return result
 }
 
+// SendBundleArgs are the arguments of eth_sendBundle
+type SendBundleArgs struct {
+	// Txs are the signed raw txs of the bundle, in the order they must be executed
+	Txs []string `json:"txs"`
+	// BlockNumber is the L2 block in which the bundle must be included, if not set it can be included in any block
+	BlockNumber *ArgUint64 `json:"blockNumber,omitempty"`
+	// MaxTimestamp is the max L2 block timestamp in which the bundle can be included, if not set the bundle doesn't expire
+	MaxTimestamp *ArgUint64 `json:"maxTimestamp,omitempty"`
+}
+
+// SendBundleResponse is the response of eth_sendBundle
+type SendBundleResponse struct {
+	BundleHash common.Hash `json:"bundleHash"`
+}
+
 // TxArgs is the transaction argument for the rpc endpoints
 type TxArgs struct {
-	From     *common.Address
-	To       *common.Address
-	Gas      *ArgUint64
-	GasPrice *ArgBytes
-	Value    *ArgBytes
-	Data     *ArgBytes
-	Input    *ArgBytes
-	Nonce    *ArgUint64
+	From                 *common.Address
+	To                   *common.Address
+	Gas                  *ArgUint64
+	GasPrice             *ArgBytes
+	MaxFeePerGas         *ArgBytes
+	MaxPriorityFeePerGas *ArgBytes
+	Value                *ArgBytes
+	Data                 *ArgBytes
+	Input                *ArgBytes
+	Nonce                *ArgUint64
 }
 
 // ToTransaction transforms txnArgs into a Transaction
@@ -209,6 +229,18 @@ func (args *TxArgs) ToTransaction(ctx context.Context, st StateInterface, maxCum
 	gasPrice := big.NewInt(0)
 	if args.GasPrice != nil {
 		gasPrice.SetBytes(*args.GasPrice)
+	} else if args.MaxPriorityFeePerGas != nil {
+		// the network has no base fee, so the dynamic fee args
+		// pay the tip capped by the fee cap
+		gasPrice.SetBytes(*args.MaxPriorityFeePerGas)
+		if args.MaxFeePerGas != nil {
+			maxFeePerGas := new(big.Int).SetBytes(*args.MaxFeePerGas)
+			if maxFeePerGas.Cmp(gasPrice) < 0 {
+				gasPrice = maxFeePerGas
+			}
+		}
+	} else if args.MaxFeePerGas != nil {
+		gasPrice.SetBytes(*args.MaxFeePerGas)
 	}
 
 	var data []byte
@@ -237,6 +269,60 @@ func (args *TxArgs) ToTransaction(ctx context.Context, st StateInterface, maxCum
 	return sender, tx, nil
 }
 
+// OverrideAccount indicates the overriding fields of account during the execution
+// of a message call.
+// Note, state and stateDiff can't be specified at the same time. If state is
+// set, message execution will only use the data in the given state. Otherwise
+// if stateDiff is set, all diff will be applied first and then execute the call
+// message.
+type OverrideAccount struct {
+	Nonce     *ArgUint64                   `json:"nonce"`
+	Code      *ArgBytes                    `json:"code"`
+	Balance   *ArgBig                      `json:"balance"`
+	State     *map[common.Hash]common.Hash `json:"state"`
+	StateDiff *map[common.Hash]common.Hash `json:"stateDiff"`
+}
+
+// StateOverride is the collection of overridden accounts.
+type StateOverride map[common.Address]OverrideAccount
+
+// ToStateOverride converts the state override set into the
+// state representation used to process unsigned transactions
+func (so *StateOverride) ToStateOverride() (state.StateOverride, error) {
+	if so == nil {
+		return nil, nil
+	}
+
+	overrides := make(state.StateOverride, len(*so))
+	for addr, account := range *so {
+		if account.State != nil && account.StateDiff != nil {
+			return nil, fmt.Errorf("account %s has both 'state' and 'stateDiff'", addr.Hex())
+		}
+
+		override := state.OverrideAccount{}
+		if account.Nonce != nil {
+			nonce := uint64(*account.Nonce)
+			override.Nonce = &nonce
+		}
+		if account.Code != nil {
+			override.Code = *account.Code
+		}
+		if account.Balance != nil {
+			balance := big.Int(*account.Balance)
+			override.Balance = &balance
+		}
+		if account.State != nil {
+			override.State = *account.State
+		}
+		if account.StateDiff != nil {
+			override.StateDiff = *account.StateDiff
+		}
+		overrides[addr] = override
+	}
+
+	return overrides, nil
+}
+
 // Block structure
 type Block struct {
 	ParentHash      common.Hash         `json:"parentHash"`
@@ -520,39 +606,83 @@ func (bh *BlockOrHash) UnmarshalJSON(input []byte) error {
 
 // Transaction structure
 type Transaction struct {
-	Nonce       ArgUint64       `json:"nonce"`
-	GasPrice    ArgBig          `json:"gasPrice"`
-	Gas         ArgUint64       `json:"gas"`
-	To          *common.Address `json:"to"`
-	Value       ArgBig          `json:"value"`
-	Input       ArgBytes        `json:"input"`
-	V           ArgBig          `json:"v"`
-	R           ArgBig          `json:"r"`
-	S           ArgBig          `json:"s"`
-	Hash        common.Hash     `json:"hash"`
-	From        common.Address  `json:"from"`
-	BlockHash   *common.Hash    `json:"blockHash"`
-	BlockNumber *ArgUint64      `json:"blockNumber"`
-	TxIndex     *ArgUint64      `json:"transactionIndex"`
-	ChainID     ArgBig          `json:"chainId"`
-	Type        ArgUint64       `json:"type"`
-	Receipt     *Receipt        `json:"receipt,omitempty"`
-	L2Hash      *common.Hash    `json:"l2Hash,omitempty"`
+	Nonce                ArgUint64         `json:"nonce"`
+	GasPrice             ArgBig            `json:"gasPrice"`
+	MaxFeePerGas         *ArgBig           `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas *ArgBig           `json:"maxPriorityFeePerGas,omitempty"`
+	Gas                  ArgUint64         `json:"gas"`
+	To                   *common.Address   `json:"to"`
+	Value                ArgBig            `json:"value"`
+	Input                ArgBytes          `json:"input"`
+	AccessList           *types.AccessList `json:"accessList,omitempty"`
+	V                    ArgBig            `json:"v"`
+	R                    ArgBig            `json:"r"`
+	S                    ArgBig            `json:"s"`
+	YParity              *ArgUint64        `json:"yParity,omitempty"`
+	Hash                 common.Hash       `json:"hash"`
+	From                 common.Address    `json:"from"`
+	BlockHash            *common.Hash      `json:"blockHash"`
+	BlockNumber          *ArgUint64        `json:"blockNumber"`
+	TxIndex              *ArgUint64        `json:"transactionIndex"`
+	ChainID              ArgBig            `json:"chainId"`
+	Type                 ArgUint64         `json:"type"`
+	Receipt              *Receipt          `json:"receipt,omitempty"`
+	L2Hash               *common.Hash      `json:"l2Hash,omitempty"`
 }
 
 // CoreTx returns a geth core type Transaction
-func (t Transaction) CoreTx() *types.Transaction {
-	return types.NewTx(&types.LegacyTx{
-		Nonce:    uint64(t.Nonce),
-		GasPrice: (*big.Int)(&t.GasPrice),
-		Gas:      uint64(t.Gas),
-		To:       t.To,
-		Value:    (*big.Int)(&t.Value),
-		Data:     t.Input,
-		V:        (*big.Int)(&t.V),
-		R:        (*big.Int)(&t.R),
-		S:        (*big.Int)(&t.S),
-	})
+func (t Transaction) CoreTx() (*types.Transaction, error) {
+	var accessList types.AccessList
+	if t.AccessList != nil {
+		accessList = *t.AccessList
+	}
+
+	switch uint8(t.Type) {
+	case types.AccessListTxType:
+		return types.NewTx(&types.AccessListTx{
+			ChainID:    (*big.Int)(&t.ChainID),
+			Nonce:      uint64(t.Nonce),
+			GasPrice:   (*big.Int)(&t.GasPrice),
+			Gas:        uint64(t.Gas),
+			To:         t.To,
+			Value:      (*big.Int)(&t.Value),
+			Data:       t.Input,
+			AccessList: accessList,
+			V:          (*big.Int)(&t.V),
+			R:          (*big.Int)(&t.R),
+			S:          (*big.Int)(&t.S),
+		}), nil
+	case types.DynamicFeeTxType:
+		if t.MaxFeePerGas == nil || t.MaxPriorityFeePerGas == nil {
+			return nil, ErrMissingDynamicFeeFields
+		}
+		return types.NewTx(&types.DynamicFeeTx{
+			ChainID:    (*big.Int)(&t.ChainID),
+			Nonce:      uint64(t.Nonce),
+			GasTipCap:  (*big.Int)(t.MaxPriorityFeePerGas),
+			GasFeeCap:  (*big.Int)(t.MaxFeePerGas),
+			Gas:        uint64(t.Gas),
+			To:         t.To,
+			Value:      (*big.Int)(&t.Value),
+			Data:       t.Input,
+			AccessList: accessList,
+			V:          (*big.Int)(&t.V),
+			R:          (*big.Int)(&t.R),
+			S:          (*big.Int)(&t.S),
+		}), nil
+	default:
+		return types.NewTx(&types.LegacyTx{
+			Nonce:    uint64(t.Nonce),
+			GasPrice: (*big.Int)(&t.GasPrice),
+			Gas:      uint64(t.Gas),
+			To:       t.To,
+			Value:    (*big.Int)(&t.Value),
+			Data:     t.Input,
+			V:        (*big.Int)(&t.V),
+			R:        (*big.Int)(&t.R),
+			S:        (*big.Int)(&t.S),
+		}), nil
+	}
 }
 
 // NewTransaction creates a transaction instance
@@ -566,7 +696,7 @@ func NewTransaction(
 
 	res := &Transaction{
 		Nonce:    ArgUint64(tx.Nonce()),
-		GasPrice: ArgBig(*tx.GasPrice()),
+		GasPrice: ArgBig(*state.TxGasPrice(&tx)),
 		Gas:      ArgUint64(tx.Gas()),
 		To:       tx.To(),
 		Value:    ArgBig(*tx.Value()),
@@ -581,6 +711,18 @@ func NewTransaction(
 		L2Hash:   l2Hash,
 	}
 
+	if tx.Type() != types.LegacyTxType {
+		accessList := tx.AccessList()
+		res.AccessList = &accessList
+		yParity := ArgUint64(v.Uint64())
+		res.YParity = &yParity
+	}
+	if tx.Type() == types.DynamicFeeTxType {
+		maxFeePerGas, maxPriorityFeePerGas := ArgBig(*tx.GasFeeCap()), ArgBig(*tx.GasTipCap())
+		res.MaxFeePerGas = &maxFeePerGas
+		res.MaxPriorityFeePerGas = &maxPriorityFeePerGas
+	}
+
 	if receipt != nil {
 		bn := ArgUint64(receipt.BlockNumber.Uint64())
 		res.BlockNumber = &bn
@@ -655,7 +797,7 @@ func NewReceipt(tx types.Transaction, r *types.Receipt, l2Hash *common.Hash) (Re
 		ContractAddress:   contractAddress,
 		FromAddr:          from,
 		ToAddr:            to,
-		Type:              ArgUint64(r.Type),
+		Type:              ArgUint64(tx.Type()),
 		TxL2Hash:          l2Hash,
 	}
 	if len(r.PostState) > 0 {
@@ -773,3 +915,373 @@ func NewZKCountersResponse(zkCounters state.ZKCounters, limits ZKCountersLimits,
 		OOCError:       oocErrMsg,
 	}
 }
+
+// FeeHistory is the response of the eth_feeHistory endpoint
+type FeeHistory struct {
+	OldestBlock   ArgUint64  `json:"oldestBlock"`
+	Reward        [][]ArgBig `json:"reward,omitempty"`
+	BaseFeePerGas []ArgBig   `json:"baseFeePerGas"`
+	GasUsedRatio  []float64  `json:"gasUsedRatio"`
+}
+
+// AccessListResult is the response of the eth_createAccessList endpoint
+type AccessListResult struct {
+	AccessList types.AccessList `json:"accessList"`
+	Error      string           `json:"error,omitempty"`
+	GasUsed    ArgUint64        `json:"gasUsed"`
+}
+
+// AccountProof is the response of the zkevm_getProof endpoint, it contains the
+// sparse merkle tree proofs of the leaves of an account at a given state root
+type AccountProof struct {
+	Address       common.Address `json:"address"`
+	StateRoot     common.Hash    `json:"stateRoot"`
+	Balance       ArgBig         `json:"balance"`
+	BalanceProof  SMTProof       `json:"balanceProof"`
+	Nonce         ArgUint64      `json:"nonce"`
+	NonceProof    SMTProof       `json:"nonceProof"`
+	CodeHash      common.Hash    `json:"codeHash"`
+	CodeHashProof SMTProof       `json:"codeHashProof"`
+	StorageProof  []StorageProof `json:"storageProof"`
+}
+
+// StorageProof contains the sparse merkle tree proof of a storage position
+type StorageProof struct {
+	Key   common.Hash `json:"key"`
+	Value ArgBig      `json:"value"`
+	Proof SMTProof    `json:"proof"`
+}
+
+// SMTProof contains the field elements required to verify a leaf of the
+// sparse merkle tree, or its absence, against the state root
+type SMTProof struct {
+	Key      []ArgUint64   `json:"key"`
+	Value    []ArgUint64   `json:"value"`
+	Siblings [][]ArgUint64 `json:"siblings"`
+	InsKey   []ArgUint64   `json:"insKey,omitempty"`
+	InsValue []ArgUint64   `json:"insValue,omitempty"`
+	IsOld0   bool          `json:"isOld0"`
+}
+
+// NewAccountProof creates an AccountProof instance
+func NewAccountProof(address common.Address, root common.Hash, p *merkletree.AccountProof) AccountProof {
+	res := AccountProof{
+		Address:       address,
+		StateRoot:     root,
+		Balance:       ArgBig(*p.Balance),
+		BalanceProof:  NewSMTProof(p.BalanceProof),
+		Nonce:         ArgUint64(p.Nonce.Uint64()),
+		NonceProof:    NewSMTProof(p.NonceProof),
+		CodeHash:      common.BytesToHash(p.CodeHash),
+		CodeHashProof: NewSMTProof(p.CodeHashProof),
+		StorageProof:  make([]StorageProof, 0, len(p.StorageProofs)),
+	}
+	for _, sp := range p.StorageProofs {
+		res.StorageProof = append(res.StorageProof, StorageProof{
+			Key:   common.BigToHash(sp.Position),
+			Value: ArgBig(*sp.Value),
+			Proof: NewSMTProof(sp.Proof),
+		})
+	}
+	return res
+}
+
+// NewSMTProof creates a SMTProof instance
+func NewSMTProof(p *merkletree.Proof) SMTProof {
+	siblings := make([][]ArgUint64, 0, len(p.Siblings))
+	for _, s := range p.Siblings {
+		siblings = append(siblings, toArgUint64Slice(s))
+	}
+	return SMTProof{
+		Key:      toArgUint64Slice(p.Key),
+		Value:    toArgUint64Slice(p.Value),
+		Siblings: siblings,
+		InsKey:   toArgUint64Slice(p.InsKey),
+		InsValue: toArgUint64Slice(p.InsValue),
+		IsOld0:   p.IsOld0,
+	}
+}
+
+func toArgUint64Slice(values []uint64) []ArgUint64 {
+	if values == nil {
+		return nil
+	}
+	res := make([]ArgUint64, 0, len(values))
+	for _, v := range values {
+		res = append(res, ArgUint64(v))
+	}
+	return res
+}
+
+// VirtualizedBatch is the L1 sequencing of a batch, it is the notification sent to
+// the newVirtualizedBatches subscriptions
+type VirtualizedBatch struct {
+	Number              ArgUint64      `json:"number"`
+	SendSequencesTxHash common.Hash    `json:"sendSequencesTxHash"`
+	L1BlockNumber       ArgUint64      `json:"l1BlockNumber"`
+	Coinbase            common.Address `json:"coinbase"`
+	L1InfoRoot          *common.Hash   `json:"l1InfoRoot,omitempty"`
+}
+
+// NewVirtualizedBatch creates a VirtualizedBatch instance
+func NewVirtualizedBatch(b state.VirtualBatch) VirtualizedBatch {
+	return VirtualizedBatch{
+		Number:              ArgUint64(b.BatchNumber),
+		SendSequencesTxHash: b.TxHash,
+		L1BlockNumber:       ArgUint64(b.BlockNumber),
+		Coinbase:            b.Coinbase,
+		L1InfoRoot:          b.L1InfoRoot,
+	}
+}
+
+// VerifiedBatch is the L1 verification of a batch, it is the notification sent to
+// the newVerifiedBatches subscriptions
+type VerifiedBatch struct {
+	Number            ArgUint64      `json:"number"`
+	VerifyBatchTxHash common.Hash    `json:"verifyBatchTxHash"`
+	L1BlockNumber     ArgUint64      `json:"l1BlockNumber"`
+	Aggregator        common.Address `json:"aggregator"`
+}
+
+// NewVerifiedBatch creates a VerifiedBatch instance
+func NewVerifiedBatch(b state.VerifiedBatch) VerifiedBatch {
+	return VerifiedBatch{
+		Number:            ArgUint64(b.BatchNumber),
+		VerifyBatchTxHash: b.TxHash,
+		L1BlockNumber:     ArgUint64(b.BlockNumber),
+		Aggregator:        b.Aggregator,
+	}
+}
+
+// BatchBlockRange is the range of L2 blocks of a batch, the block numbers are
+// null when the batch doesn't have L2 blocks yet
+type BatchBlockRange struct {
+	BatchNumber ArgUint64  `json:"batchNumber"`
+	FromBlock   *ArgUint64 `json:"fromBlock"`
+	ToBlock     *ArgUint64 `json:"toBlock"`
+}
+
+// BatchesByL1Block contains the batches sequenced and verified in a range of L1 blocks
+type BatchesByL1Block struct {
+	VirtualizedBatches []VirtualizedBatch `json:"virtualizedBatches"`
+	VerifiedBatches    []VerifiedBatch    `json:"verifiedBatches"`
+}
+
+// L1InfoTreeLeaf is a leaf of the L1 info tree
+type L1InfoTreeLeaf struct {
+	Index             ArgUint64   `json:"index"`
+	L1InfoTreeRoot    common.Hash `json:"l1InfoTreeRoot"`
+	GlobalExitRoot    common.Hash `json:"globalExitRoot"`
+	MainnetExitRoot   common.Hash `json:"mainnetExitRoot"`
+	RollupExitRoot    common.Hash `json:"rollupExitRoot"`
+	PreviousBlockHash common.Hash `json:"previousBlockHash"`
+	L1BlockNumber     ArgUint64   `json:"l1BlockNumber"`
+	Timestamp         ArgUint64   `json:"timestamp"`
+}
+
+// NewL1InfoTreeLeaf creates a L1InfoTreeLeaf instance
+func NewL1InfoTreeLeaf(l state.L1InfoTreeExitRootStorageEntry) L1InfoTreeLeaf {
+	return L1InfoTreeLeaf{
+		Index:             ArgUint64(l.L1InfoTreeIndex),
+		L1InfoTreeRoot:    l.L1InfoTreeRoot,
+		GlobalExitRoot:    l.GlobalExitRoot.GlobalExitRoot,
+		MainnetExitRoot:   l.MainnetExitRoot,
+		RollupExitRoot:    l.RollupExitRoot,
+		PreviousBlockHash: l.PreviousBlockHash,
+		L1BlockNumber:     ArgUint64(l.BlockNumber),
+		Timestamp:         ArgUint64(l.Timestamp.Unix()),
+	}
+}
+
+// Preconfirmation is the soft confirmation signed by the sequencer committing to include a tx in
+// the given L2 block and position, it is the notification sent to the newPreconfirmations subscriptions
+type Preconfirmation struct {
+	TxHash      common.Hash    `json:"transactionHash"`
+	BlockNumber ArgUint64      `json:"blockNumber"`
+	Position    ArgUint64      `json:"transactionIndex"`
+	StateRoot   common.Hash    `json:"stateRoot"`
+	Hash        common.Hash    `json:"hash"`
+	Signature   ArgBytes       `json:"signature"`
+	Signer      common.Address `json:"signer"`
+}
+
+// NewPreconfirmation creates a Preconfirmation instance for the network with the given chain ID
+func NewPreconfirmation(p pool.Preconfirmation, chainID uint64) (Preconfirmation, error) {
+	signer, err := p.Signer(chainID)
+	if err != nil {
+		return Preconfirmation{}, err
+	}
+
+	return Preconfirmation{
+		TxHash:      p.TxHash,
+		BlockNumber: ArgUint64(p.L2BlockNumber),
+		Position:    ArgUint64(p.Position),
+		StateRoot:   p.StateRoot,
+		Hash:        p.Hash(chainID),
+		Signature:   ArgBytes(p.Signature),
+		Signer:      signer,
+	}, nil
+}
+
+// L1InfoTreeRoot is the current root of the L1 info tree and its number of leaves
+type L1InfoTreeRoot struct {
+	L1InfoTreeRoot common.Hash `json:"l1InfoTreeRoot"`
+	LeafCount      ArgUint64   `json:"leafCount"`
+}
+
+// L1InfoTreeProof is the merkle proof of a leaf of the L1 info tree against a L1 info root
+type L1InfoTreeProof struct {
+	L1InfoTreeRoot common.Hash    `json:"l1InfoTreeRoot"`
+	Leaf           L1InfoTreeLeaf `json:"leaf"`
+	Proof          []common.Hash  `json:"proof"`
+}
+
+// BatchProof contains the public inputs of the proof that verifies a range of
+// batches, the proof itself when the node keeps it and the L1 verification
+// data when the range was verified on L1
+type BatchProof struct {
+	BatchNumber       ArgUint64       `json:"batchNumber"`
+	FromBatchNumber   ArgUint64       `json:"fromBatchNumber"`
+	ToBatchNumber     ArgUint64       `json:"toBatchNumber"`
+	OldStateRoot      common.Hash     `json:"oldStateRoot"`
+	NewStateRoot      common.Hash     `json:"newStateRoot"`
+	NewLocalExitRoot  common.Hash     `json:"newLocalExitRoot"`
+	OldAccInputHash   common.Hash     `json:"oldAccInputHash"`
+	NewAccInputHash   common.Hash     `json:"newAccInputHash"`
+	Proof             *string         `json:"proof"`
+	VerifyBatchTxHash *common.Hash    `json:"verifyBatchTxHash"`
+	L1BlockNumber     *ArgUint64      `json:"l1BlockNumber"`
+	Aggregator        *common.Address `json:"aggregator"`
+}
+
+// BatchL2Data contains the exact L2 data of a batch and its decoded
+// representation, the L2 data of the batches created before the etrog
+// fork and the forced batches don't contain L2 blocks, so only the
+// transactions are decoded. When the L2 data of a forced batch can't be
+// decoded, only the raw data is returned
+type BatchL2Data struct {
+	Number       ArgUint64          `json:"number"`
+	ForkID       ArgUint64          `json:"forkId"`
+	BatchL2Data  ArgBytes           `json:"batchL2Data"`
+	ForcedBatch  *ForcedBatch       `json:"forcedBatch"`
+	Blocks       []BatchL2DataBlock `json:"blocks,omitempty"`
+	Transactions []BatchL2DataTx    `json:"transactions,omitempty"`
+}
+
+// BatchL2DataBlock is a L2 block decoded from the batch L2 data
+type BatchL2DataBlock struct {
+	DeltaTimestamp  ArgUint64       `json:"deltaTimestamp"`
+	IndexL1InfoTree ArgUint64       `json:"indexL1InfoTree"`
+	Transactions    []BatchL2DataTx `json:"transactions"`
+}
+
+// BatchL2DataTx is a transaction decoded from the batch L2 data
+type BatchL2DataTx struct {
+	EffectivePercentage ArgUint64    `json:"effectivePercentage"`
+	Transaction         *Transaction `json:"transaction"`
+}
+
+// ForcedBatch is the data of a batch forced on L1
+type ForcedBatch struct {
+	ForcedBatchNumber ArgUint64      `json:"forcedBatchNumber"`
+	L1BlockNumber     ArgUint64      `json:"l1BlockNumber"`
+	Sequencer         common.Address `json:"sequencer"`
+	GlobalExitRoot    common.Hash    `json:"globalExitRoot"`
+	RawTxsData        ArgBytes       `json:"rawTxsData"`
+	ForcedAt          ArgUint64      `json:"forcedAt"`
+}
+
+// NewBatchL2Data creates a BatchL2Data instance decoding the L2 data
+// of the provided batch accordingly to its fork id
+func NewBatchL2Data(batch state.Batch, forkID uint64, forcedBatch *state.ForcedBatch) (*BatchL2Data, error) {
+	res := &BatchL2Data{
+		Number:      ArgUint64(batch.BatchNumber),
+		ForkID:      ArgUint64(forkID),
+		BatchL2Data: batch.BatchL2Data,
+	}
+
+	if forcedBatch != nil {
+		res.ForcedBatch = &ForcedBatch{
+			ForcedBatchNumber: ArgUint64(forcedBatch.ForcedBatchNumber),
+			L1BlockNumber:     ArgUint64(forcedBatch.BlockNumber),
+			Sequencer:         forcedBatch.Sequencer,
+			GlobalExitRoot:    forcedBatch.GlobalExitRoot,
+			RawTxsData:        forcedBatch.RawTxsData,
+			ForcedAt:          ArgUint64(forcedBatch.ForcedAt.Unix()),
+		}
+	}
+
+	// the L2 data of the forced batches is sent to L1 by the users and it's
+	// not validated, so when it can't be decoded only the raw data is returned
+	if err := res.decode(batch, forkID); err != nil {
+		if batch.ForcedBatchNum == nil {
+			return nil, err
+		}
+		res.Blocks, res.Transactions = nil, nil
+	}
+
+	return res, nil
+}
+
+// decode fills the L2 blocks and the transactions decoded from the batch L2 data
+func (b *BatchL2Data) decode(batch state.Batch, forkID uint64) error {
+	if forkID < state.FORKID_ETROG {
+		txs, _, efficiencyPercentages, err := state.DecodeTxs(batch.BatchL2Data, forkID)
+		if err != nil {
+			return err
+		}
+		rawTxs := make([]state.L2TxRaw, 0, len(txs))
+		for i, tx := range txs {
+			rawTx := state.L2TxRaw{Tx: tx}
+			if i < len(efficiencyPercentages) {
+				rawTx.EfficiencyPercentage = efficiencyPercentages[i]
+			}
+			rawTxs = append(rawTxs, rawTx)
+		}
+		b.Transactions, err = newBatchL2DataTxs(rawTxs)
+		return err
+	}
+
+	// forced batches and the batches with injected txs don't start with a changeL2Block
+	rawBatch, err := state.DecodeBatchV2(batch.BatchL2Data)
+	if batch.ForcedBatchNum != nil || errors.Is(err, state.ErrBatchV2DontStartWithChangeL2Block) {
+		rawForcedBatch, err := state.DecodeForcedBatchV2(batch.BatchL2Data)
+		if err != nil {
+			return err
+		}
+		b.Transactions, err = newBatchL2DataTxs(rawForcedBatch.Transactions)
+		return err
+	} else if err != nil {
+		return err
+	}
+
+	b.Blocks = make([]BatchL2DataBlock, 0, len(rawBatch.Blocks))
+	for _, rawBlock := range rawBatch.Blocks {
+		txs, err := newBatchL2DataTxs(rawBlock.Transactions)
+		if err != nil {
+			return err
+		}
+		b.Blocks = append(b.Blocks, BatchL2DataBlock{
+			DeltaTimestamp:  ArgUint64(rawBlock.DeltaTimestamp),
+			IndexL1InfoTree: ArgUint64(rawBlock.IndexL1InfoTree),
+			Transactions:    txs,
+		})
+	}
+
+	return nil
+}
+
+func newBatchL2DataTxs(rawTxs []state.L2TxRaw) ([]BatchL2DataTx, error) {
+	txs := make([]BatchL2DataTx, 0, len(rawTxs))
+	for _, rawTx := range rawTxs {
+		tx, err := NewTransaction(rawTx.Tx, nil, false, nil)
+		if err != nil {
+			return nil, err
+		}
+		txs = append(txs, BatchL2DataTx{
+			EffectivePercentage: ArgUint64(rawTx.EfficiencyPercentage),
+			Transaction:         tx,
+		})
+	}
+	return txs, nil
+}
@@ -3,11 +3,14 @@ package types
 import (
 	"encoding/json"
 	"fmt"
+	"math/big"
 	"testing"
 
 	"github.com/0xPolygonHermez/zkevm-node/hex"
 	"github.com/0xPolygonHermez/zkevm-node/state"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -244,6 +247,105 @@ func TestBatchUnmarshal(t *testing.T) {
 	}
 }
 
+func TestNewTransactionWithTypedTxs(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	chainID := big.NewInt(1000)
+	signer := types.NewLondonSigner(chainID)
+	to := common.HexToAddress("0x4d5Cf5032B2a844602278b01199ED191A86c93ff")
+	accessList := types.AccessList{{Address: to, StorageKeys: []common.Hash{common.HexToHash("0x1")}}}
+
+	type testCase struct {
+		name                         string
+		txData                       types.TxData
+		expectedGasPrice             *big.Int
+		expectedMaxFeePerGas         *big.Int
+		expectedMaxPriorityFeePerGas *big.Int
+	}
+
+	testCases := []testCase{
+		{
+			name:             "legacy tx",
+			txData:           &types.LegacyTx{Nonce: 1, GasPrice: big.NewInt(10), Gas: 21000, To: &to, Value: big.NewInt(1)},
+			expectedGasPrice: big.NewInt(10),
+		},
+		{
+			name:             "access list tx",
+			txData:           &types.AccessListTx{ChainID: chainID, Nonce: 1, GasPrice: big.NewInt(10), Gas: 30000, To: &to, Value: big.NewInt(1), AccessList: accessList},
+			expectedGasPrice: big.NewInt(10),
+		},
+		{
+			name:                         "dynamic fee tx paying the tip",
+			txData:                       &types.DynamicFeeTx{ChainID: chainID, Nonce: 1, GasTipCap: big.NewInt(5), GasFeeCap: big.NewInt(20), Gas: 30000, To: &to, Value: big.NewInt(1), AccessList: accessList},
+			expectedGasPrice:             big.NewInt(5),
+			expectedMaxFeePerGas:         big.NewInt(20),
+			expectedMaxPriorityFeePerGas: big.NewInt(5),
+		},
+		{
+			name:                         "dynamic fee tx capped by the fee cap",
+			txData:                       &types.DynamicFeeTx{ChainID: chainID, Nonce: 1, GasTipCap: big.NewInt(30), GasFeeCap: big.NewInt(20), Gas: 21000, To: &to, Value: big.NewInt(1)},
+			expectedGasPrice:             big.NewInt(20),
+			expectedMaxFeePerGas:         big.NewInt(20),
+			expectedMaxPriorityFeePerGas: big.NewInt(30),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tx, err := types.SignNewTx(privateKey, signer, tc.txData)
+			require.NoError(t, err)
+			receipt := &types.Receipt{TxHash: tx.Hash(), BlockNumber: big.NewInt(1)}
+
+			res, err := NewTransaction(*tx, receipt, true, nil)
+			require.NoError(t, err)
+
+			b, err := json.Marshal(res)
+			require.NoError(t, err)
+			var rpcTx Transaction
+			require.NoError(t, json.Unmarshal(b, &rpcTx))
+
+			assert.Equal(t, ArgUint64(tx.Type()), rpcTx.Type)
+			assert.Equal(t, ArgUint64(tx.Type()), rpcTx.Receipt.Type)
+			assert.Equal(t, tc.expectedGasPrice, (*big.Int)(&rpcTx.GasPrice))
+			if tc.expectedMaxFeePerGas != nil {
+				require.NotNil(t, rpcTx.MaxFeePerGas)
+				require.NotNil(t, rpcTx.MaxPriorityFeePerGas)
+				assert.Equal(t, tc.expectedMaxFeePerGas, (*big.Int)(rpcTx.MaxFeePerGas))
+				assert.Equal(t, tc.expectedMaxPriorityFeePerGas, (*big.Int)(rpcTx.MaxPriorityFeePerGas))
+			} else {
+				assert.Nil(t, rpcTx.MaxFeePerGas)
+				assert.Nil(t, rpcTx.MaxPriorityFeePerGas)
+			}
+			if tx.Type() == types.LegacyTxType {
+				assert.Nil(t, rpcTx.AccessList)
+				assert.Nil(t, rpcTx.YParity)
+			} else {
+				require.NotNil(t, rpcTx.AccessList)
+				assert.Equal(t, tx.AccessList(), *rpcTx.AccessList)
+				require.NotNil(t, rpcTx.YParity)
+			}
+
+			// the core tx rebuilt from the RPC one is the same tx
+			coreTx, err := rpcTx.CoreTx()
+			require.NoError(t, err)
+			assert.Equal(t, tx.Hash(), coreTx.Hash())
+
+			// the dynamic fee txs can't be rebuilt without both fee fields
+			if tx.Type() == types.DynamicFeeTxType {
+				withoutTip := rpcTx
+				withoutTip.MaxPriorityFeePerGas = nil
+				_, err = withoutTip.CoreTx()
+				assert.ErrorIs(t, err, ErrMissingDynamicFeeFields)
+
+				withoutFeeCap := rpcTx
+				withoutFeeCap.MaxFeePerGas = nil
+				_, err = withoutFeeCap.CoreTx()
+				assert.ErrorIs(t, err, ErrMissingDynamicFeeFields)
+			}
+		})
+	}
+}
+
 func hexToBytes(str string) []byte {
 	bytes, _ := hex.DecodeHex(str)
 	return bytes
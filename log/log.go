@@ -30,13 +30,16 @@ type Logger struct {
 // root logger
 var log atomic.Pointer[Logger]
 
+// level of the root logger, it can be changed at runtime
+var logLevel atomic.Pointer[zap.AtomicLevel]
+
 func getDefaultLog() *Logger {
 	l := log.Load()
 	if l != nil {
 		return l
 	}
 	// default level: debug
-	zapLogger, _, err := NewLogger(Config{
+	zapLogger, level, err := NewLogger(Config{
 		Environment: EnvironmentDevelopment,
 		Level:       "debug",
 		Outputs:     []string{"stderr"},
@@ -44,6 +47,7 @@ func getDefaultLog() *Logger {
 	if err != nil {
 		panic(err)
 	}
+	logLevel.Store(level)
 	log.Store(&Logger{x: zapLogger})
 	return log.Load()
 }
@@ -54,13 +58,30 @@ func getDefaultLog() *Logger {
 // should be added at the outputs array. To avoid printing the logs but storing
 // them on a file, can use []string{"pathtofile.log"}
 func Init(cfg Config) {
-	zapLogger, _, err := NewLogger(cfg)
+	zapLogger, level, err := NewLogger(cfg)
 	if err != nil {
 		panic(err)
 	}
+	logLevel.Store(level)
 	log.Store(&Logger{x: zapLogger})
 }
 
+// SetLevel changes the level of the root logger at runtime, the allowed
+// values are the same ones allowed by the Level of the Config
+func SetLevel(level string) error {
+	getDefaultLog()
+	if err := logLevel.Load().UnmarshalText([]byte(level)); err != nil {
+		return fmt.Errorf("error on setting log level: %s", err)
+	}
+	return nil
+}
+
+// GetLevel returns the current level of the root logger
+func GetLevel() string {
+	getDefaultLog()
+	return logLevel.Load().String()
+}
+
 // NewLogger creates the logger with defined level. outputs defines the outputs where the
 // logs will be sent. By default, outputs contains "stdout", which prints the
 // logs at the output of the process. To add a log file as output, the path
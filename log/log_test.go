@@ -35,3 +35,28 @@ func TestLog(t *testing.T) {
 	Warnf("Test log.Warnf %d", 10)
 	Warnw("Test log.Warnw", "value", 10)
 }
+
+func TestSetLevel(t *testing.T) {
+	Init(Config{
+		Environment: EnvironmentDevelopment,
+		Level:       "info",
+		Outputs:     []string{"stderr"},
+	})
+	if level := GetLevel(); level != "info" {
+		t.Fatalf("unexpected log level %s", level)
+	}
+
+	if err := SetLevel("debug"); err != nil {
+		t.Fatal(err)
+	}
+	if level := GetLevel(); level != "debug" {
+		t.Fatalf("unexpected log level %s", level)
+	}
+
+	if err := SetLevel("unknown"); err == nil {
+		t.Fatal("expected error setting an unknown log level")
+	}
+	if level := GetLevel(); level != "debug" {
+		t.Fatalf("unexpected log level %s", level)
+	}
+}
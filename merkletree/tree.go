@@ -126,6 +126,65 @@ func (tree *StateTree) GetStorageAt(ctx context.Context, address common.Address,
 	return fea2scalar(proof.Value), nil
 }
 
+// GetAccountProof returns the proofs of the balance, nonce and code hash of an
+// account and of the provided storage positions of the account.
+func (tree *StateTree) GetAccountProof(ctx context.Context, address common.Address, positions []*big.Int, root []byte) (*AccountProof, error) {
+	balanceKey, err := KeyEthAddrBalance(address)
+	if err != nil {
+		return nil, err
+	}
+	balanceProof, err := tree.getProof(ctx, root, balanceKey)
+	if err != nil {
+		return nil, err
+	}
+
+	nonceKey, err := KeyEthAddrNonce(address)
+	if err != nil {
+		return nil, err
+	}
+	nonceProof, err := tree.getProof(ctx, root, nonceKey)
+	if err != nil {
+		return nil, err
+	}
+
+	codeHashKey, err := KeyContractCode(address)
+	if err != nil {
+		return nil, err
+	}
+	codeHashProof, err := tree.getProof(ctx, root, codeHashKey)
+	if err != nil {
+		return nil, err
+	}
+
+	accountProof := &AccountProof{
+		Balance:       fea2scalar(balanceProof.Value),
+		BalanceProof:  balanceProof,
+		Nonce:         fea2scalar(nonceProof.Value),
+		NonceProof:    nonceProof,
+		CodeHash:      ScalarToFilledByteSlice(fea2scalar(codeHashProof.Value)),
+		CodeHashProof: codeHashProof,
+		StorageProofs: make([]*StorageProof, 0, len(positions)),
+	}
+
+	for _, position := range positions {
+		storageKey, err := KeyContractStorage(address, position.Bytes())
+		if err != nil {
+			return nil, err
+		}
+		storageProof, err := tree.getProof(ctx, root, storageKey)
+		if err != nil {
+			return nil, err
+		}
+		accountProof.StorageProofs = append(accountProof.StorageProofs, &StorageProof{
+			Position: position,
+			Value:    fea2scalar(storageProof.Value),
+			Proof:    storageProof,
+		})
+	}
+
+	return accountProof, nil
+}
+
 // SetBalance sets balance.
 func (tree *StateTree) SetBalance(ctx context.Context, address common.Address, balance *big.Int, root []byte, uuid string) (newRoot []byte, proof *UpdateProof, err error) {
 	if balance.Cmp(big.NewInt(0)) == -1 {
@@ -263,6 +322,55 @@ func (tree *StateTree) get(ctx context.Context, root, key []uint64) (*Proof, err
 	}, nil
 }
 
+// getProof returns the value of the key along with the siblings of its path,
+// so the value, or its absence, can be verified against the root.
+func (tree *StateTree) getProof(ctx context.Context, root, key []byte) (*Proof, error) {
+	r := scalarToh4(new(big.Int).SetBytes(root))
+	k := scalarToh4(new(big.Int).SetBytes(key))
+	result, err := tree.grpcClient.Get(ctx, &hashdb.GetRequest{
+		Root:    &hashdb.Fea{Fe0: r[0], Fe1: r[1], Fe2: r[2], Fe3: r[3]},
+		Key:     &hashdb.Fea{Fe0: k[0], Fe1: k[1], Fe2: k[2], Fe3: k[3]},
+		Details: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := string2fea(result.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	// siblings are indexed by level, starting from the root
+	siblings := make([][]uint64, len(result.Siblings))
+	for level := range siblings {
+		siblingList, found := result.Siblings[uint64(level)]
+		if !found {
+			return nil, fmt.Errorf("missing siblings of level %d in the proof", level)
+		}
+		siblings[level] = siblingList.GetSibling()
+	}
+
+	proof := &Proof{
+		Root:     r,
+		Key:      k,
+		Value:    value,
+		Siblings: siblings,
+		IsOld0:   result.IsOld0,
+	}
+	if insKey := result.InsKey; insKey != nil {
+		proof.InsKey = []uint64{insKey.Fe0, insKey.Fe1, insKey.Fe2, insKey.Fe3}
+	}
+	if result.InsValue != "" {
+		proof.InsValue, err = string2fea(result.InsValue)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return proof, nil
+}
+
 func (tree *StateTree) getProgram(ctx context.Context, key []uint64) (*ProgramProof, error) {
 	result, err := tree.grpcClient.GetProgram(ctx, &hashdb.GetProgramRequest{
 		Key: &hashdb.Fea{Fe0: key[0], Fe1: key[1], Fe2: key[2], Fe3: key[3]},
@@ -1,5 +1,7 @@
 package merkletree
 
+import "math/big"
+
 // ResultCode represents the result code.
 type ResultCode int64
 
@@ -24,6 +26,43 @@ type Proof struct {
 	Key []uint64
 	// Value is the proof value.
 	Value []uint64
+	// Siblings are the siblings of the path from the root to the key, sorted by level.
+	// Only returned by the proof operations.
+	Siblings [][]uint64
+	// InsKey is the key of the leaf found in the path of a non-existent key.
+	InsKey []uint64
+	// InsValue is the value of the leaf found in the path of a non-existent key.
+	InsValue []uint64
+	// IsOld0 indicates if the path of a non-existent key ends in an empty node.
+	IsOld0 bool
+}
+
+// AccountProof contains the proofs of the leaves of an account.
+type AccountProof struct {
+	// Balance is the account balance.
+	Balance *big.Int
+	// BalanceProof is the proof of the balance leaf.
+	BalanceProof *Proof
+	// Nonce is the account nonce.
+	Nonce *big.Int
+	// NonceProof is the proof of the nonce leaf.
+	NonceProof *Proof
+	// CodeHash is the hash of the account code.
+	CodeHash []byte
+	// CodeHashProof is the proof of the code hash leaf.
+	CodeHashProof *Proof
+	// StorageProofs are the proofs of the requested storage positions.
+	StorageProofs []*StorageProof
+}
+
+// StorageProof contains the proof of a storage position of an account.
+type StorageProof struct {
+	// Position is the storage position.
+	Position *big.Int
+	// Value is the value stored in the position.
+	Value *big.Int
+	// Proof is the proof of the storage leaf.
+	Proof *Proof
 }
 
 // UpdateProof is a proof generated on Set operation.
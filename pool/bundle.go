@@ -0,0 +1,65 @@
+package pool
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// BundleStatusPending represents a bundle that has not been processed
+	BundleStatusPending BundleStatus = "pending"
+	// BundleStatusWIP represents a bundle that has been loaded by the sequencer and is waiting to be processed
+	BundleStatusWIP BundleStatus = "wip"
+	// BundleStatusIncluded represents a bundle whose txs have been included in a L2 block
+	BundleStatusIncluded BundleStatus = "included"
+	// BundleStatusFailed represents a bundle that has been discarded because one of its txs failed
+	BundleStatusFailed BundleStatus = "failed"
+	// BundleStatusExpired represents a bundle that has not been included before its target block or max timestamp
+	BundleStatusExpired BundleStatus = "expired"
+)
+
+// BundleStatus represents the state of a bundle
+type BundleStatus string
+
+// String returns a representation of the bundle state in a string format
+func (s BundleStatus) String() string {
+	return string(s)
+}
+
+// Bundle represents an ordered list of txs that must be included all together and consecutively
+// in the same L2 block, or none of them
+type Bundle struct {
+	Hash         common.Hash
+	Txs          []types.Transaction
+	Status       BundleStatus
+	BlockNumber  uint64 // L2 block in which the bundle must be included, 0 means any block
+	MaxTimestamp uint64 // Max L2 block timestamp in which the bundle can be included, 0 means no expiration
+	ReceivedAt   time.Time
+	IP           string
+	FailedReason *string
+}
+
+// NewBundle creates a new pending bundle
+func NewBundle(txs []types.Transaction, blockNumber uint64, maxTimestamp uint64, ip string) *Bundle {
+	return &Bundle{
+		Hash:         BundleHash(txs),
+		Txs:          txs,
+		Status:       BundleStatusPending,
+		BlockNumber:  blockNumber,
+		MaxTimestamp: maxTimestamp,
+		ReceivedAt:   time.Now(),
+		IP:           ip,
+	}
+}
+
+// BundleHash returns the hash of a bundle, computed as the keccak256 of the concatenation of its tx hashes
+func BundleHash(txs []types.Transaction) common.Hash {
+	hashes := make([]byte, 0, len(txs)*common.HashLength)
+	for _, tx := range txs {
+		hashes = append(hashes, tx.Hash().Bytes()...)
+	}
+	return crypto.Keccak256Hash(hashes)
+}
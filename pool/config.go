@@ -38,6 +38,13 @@ type Config struct {
 	// GlobalQueue represents the maximum number of non-executable transaction slots for all accounts
 	GlobalQueue uint64 `mapstructure:"GlobalQueue"`
 
+	// MaxBundleTxs is the maximum number of transactions allowed in a bundle (0 means bundles are disabled)
+	MaxBundleTxs uint64 `mapstructure:"MaxBundleTxs"`
+
+	// FailedBundlePenalty is the time during which the IP and the tx senders of a failed bundle
+	// can't send new bundles (0 means the senders of failed bundles are not penalized)
+	FailedBundlePenalty types.Duration `mapstructure:"FailedBundlePenalty"`
+
 	// EffectiveGasPrice is the config for the effective gas price calculation
 	EffectiveGasPrice EffectiveGasPriceCfg `mapstructure:"EffectiveGasPrice"`
 
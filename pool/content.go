@@ -0,0 +1,211 @@
+package pool
+
+import (
+	"context"
+	"errors"
+
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Content represents the pending transactions stored in the pool grouped by
+// sender and split accordingly to the nonce of the sender account:
+// - Pending contains the txs that can be executed, because their nonces are
+// consecutive starting from the current nonce of the sender account
+// - Queued contains the txs that can't be executed until a nonce gap is filled
+type Content struct {
+	Pending map[common.Address][]Transaction
+	Queued  map[common.Address][]Transaction
+}
+
+// Status represents the number of pending and queued txs in the pool
+type Status struct {
+	Pending uint64
+	Queued  uint64
+}
+
+// GetContent returns the pending txs of the pool split into pending and queued.
+// senderOffset and senderLimit are used to paginate the content by sender
+func (p *Pool) GetContent(ctx context.Context, senderOffset, senderLimit uint64) (*Content, error) {
+	txsBySender, err := p.storage.GetTxsByStatusGroupedBySender(ctx, TxStatusPending, senderOffset, senderLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.newContent(ctx, txsBySender)
+}
+
+// GetContentFrom returns the pending txs of the pool sent by the provided
+// address split into pending and queued
+func (p *Pool) GetContentFrom(ctx context.Context, from common.Address) (*Content, error) {
+	txs, err := p.storage.GetTxsByFromAndStatus(ctx, from, TxStatusPending)
+	if err != nil {
+		return nil, err
+	}
+
+	txsBySender := make(map[common.Address][]Transaction)
+	if len(txs) > 0 {
+		txsBySender[from] = txs
+	}
+
+	return p.newContent(ctx, txsBySender)
+}
+
+// GetStatus returns the number of pending and queued txs in the pool
+func (p *Pool) GetStatus(ctx context.Context) (*Status, error) {
+	noncesBySender, err := p.storage.GetNoncesByStatus(ctx, TxStatusPending)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := p.lastL2BlockRoot(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	senders := make([]common.Address, 0, len(noncesBySender))
+	for from := range noncesBySender {
+		senders = append(senders, from)
+	}
+	currentNonces, err := p.getCurrentNonces(ctx, root, senders)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &Status{}
+	for from, nonces := range noncesBySender {
+		pending, queued := countByNonceGap(currentNonces[from], nonces)
+		status.Pending += pending
+		status.Queued += queued
+	}
+
+	return status, nil
+}
+
+func (p *Pool) newContent(ctx context.Context, txsBySender map[common.Address][]Transaction) (*Content, error) {
+	content := &Content{
+		Pending: make(map[common.Address][]Transaction),
+		Queued:  make(map[common.Address][]Transaction),
+	}
+	if len(txsBySender) == 0 {
+		return content, nil
+	}
+
+	root, err := p.lastL2BlockRoot(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	senders := make([]common.Address, 0, len(txsBySender))
+	for from := range txsBySender {
+		senders = append(senders, from)
+	}
+	currentNonces, err := p.getCurrentNonces(ctx, root, senders)
+	if err != nil {
+		return nil, err
+	}
+
+	for from, txs := range txsBySender {
+		pending, queued := splitByNonceGap(currentNonces[from], txs)
+		if len(pending) > 0 {
+			content.Pending[from] = pending
+		}
+		if len(queued) > 0 {
+			content.Queued[from] = queued
+		}
+	}
+
+	return content, nil
+}
+
+func (p *Pool) lastL2BlockRoot(ctx context.Context) (common.Hash, error) {
+	lastL2Block, err := p.state.GetLastL2Block(ctx, nil)
+	if err != nil {
+		log.Errorf("failed to load last l2 block to compute the pool content: %v", err)
+		return common.Hash{}, err
+	}
+	return lastL2Block.Root(), nil
+}
+
+// getCurrentNonces returns the nonces of the provided accounts at the provided root. The nonces
+// at the root of the last L2 block are cached until a new L2 block is added, so the HashDB is
+// only queried for the senders that weren't already queried at that root
+func (p *Pool) getCurrentNonces(ctx context.Context, root common.Hash, senders []common.Address) (map[common.Address]uint64, error) {
+	p.accountNoncesMux.Lock()
+	defer p.accountNoncesMux.Unlock()
+
+	if p.accountNonces == nil || p.accountNoncesRoot != root {
+		p.accountNoncesRoot = root
+		p.accountNonces = make(map[common.Address]uint64, len(senders))
+	}
+
+	nonces := make(map[common.Address]uint64, len(senders))
+	for _, from := range senders {
+		nonce, found := p.accountNonces[from]
+		if !found {
+			var err error
+			nonce, err = p.getCurrentNonce(ctx, from, root)
+			if err != nil {
+				return nil, err
+			}
+			p.accountNonces[from] = nonce
+		}
+		nonces[from] = nonce
+	}
+	return nonces, nil
+}
+
+func (p *Pool) getCurrentNonce(ctx context.Context, from common.Address, root common.Hash) (uint64, error) {
+	nonce, err := p.state.GetNonce(ctx, from, root)
+	if errors.Is(err, state.ErrNotFound) {
+		return 0, nil
+	} else if err != nil {
+		log.Errorf("failed to get nonce of account %v to compute the pool content: %v", from.String(), err)
+		return 0, err
+	}
+	return nonce, nil
+}
+
+// splitByNonceGap splits the txs of a sender, sorted by nonce, into the ones
+// that can be executed consecutively starting from the current nonce and the
+// ones placed after a nonce gap. Txs with a nonce lower than the current nonce
+// can't be executed anymore, so they are ignored
+func splitByNonceGap(currentNonce uint64, txs []Transaction) (pending []Transaction, queued []Transaction) {
+	expectedNonce := currentNonce
+	for _, tx := range txs {
+		nonce := tx.Nonce()
+		if nonce < currentNonce {
+			continue
+		}
+		if len(queued) == 0 && nonce <= expectedNonce {
+			if nonce == expectedNonce {
+				expectedNonce++
+			}
+			pending = append(pending, tx)
+			continue
+		}
+		queued = append(queued, tx)
+	}
+	return pending, queued
+}
+
+// countByNonceGap counts the nonces of a sender, sorted in ascending order,
+// the same way splitByNonceGap splits the txs
+func countByNonceGap(currentNonce uint64, nonces []uint64) (pending uint64, queued uint64) {
+	expectedNonce := currentNonce
+	for _, nonce := range nonces {
+		if nonce < currentNonce {
+			continue
+		}
+		if queued == 0 && nonce <= expectedNonce {
+			if nonce == expectedNonce {
+				expectedNonce++
+			}
+			pending++
+			continue
+		}
+		queued++
+	}
+	return pending, queued
+}
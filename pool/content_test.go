@@ -0,0 +1,118 @@
+package pool
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitByNonceGap(t *testing.T) {
+	newTx := func(nonce uint64) Transaction {
+		return Transaction{Transaction: *types.NewTransaction(nonce, common.Address{}, big.NewInt(0), 21000, big.NewInt(1), nil)}
+	}
+	nonces := func(txs []Transaction) []uint64 {
+		res := make([]uint64, 0, len(txs))
+		for _, tx := range txs {
+			res = append(res, tx.Nonce())
+		}
+		return res
+	}
+
+	testCases := []struct {
+		name            string
+		currentNonce    uint64
+		nonces          []uint64
+		expectedPending []uint64
+		expectedQueued  []uint64
+	}{
+		{"no txs", 0, []uint64{}, []uint64{}, []uint64{}},
+		{"all pending", 2, []uint64{2, 3, 4}, []uint64{2, 3, 4}, []uint64{}},
+		{"all queued", 2, []uint64{4, 5}, []uint64{}, []uint64{4, 5}},
+		{"nonce gap", 0, []uint64{0, 1, 3, 4}, []uint64{0, 1}, []uint64{3, 4}},
+		{"stale txs are ignored", 5, []uint64{3, 4, 5, 7}, []uint64{5}, []uint64{7}},
+		{"repeated nonce", 1, []uint64{1, 1, 2, 4, 4}, []uint64{1, 1, 2}, []uint64{4, 4}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			txs := make([]Transaction, 0, len(tc.nonces))
+			for _, nonce := range tc.nonces {
+				txs = append(txs, newTx(nonce))
+			}
+
+			pending, queued := splitByNonceGap(tc.currentNonce, txs)
+			assert.Equal(t, tc.expectedPending, nonces(pending))
+			assert.Equal(t, tc.expectedQueued, nonces(queued))
+
+			pendingCount, queuedCount := countByNonceGap(tc.currentNonce, tc.nonces)
+			assert.Equal(t, uint64(len(tc.expectedPending)), pendingCount)
+			assert.Equal(t, uint64(len(tc.expectedQueued)), queuedCount)
+		})
+	}
+}
+
+// nonceStateCounter counts the nonces read from the state, that are
+// read from the HashDB
+type nonceStateCounter struct {
+	stateInterface
+	root   common.Hash
+	nonces map[common.Address]uint64
+	calls  int
+}
+
+func (s *nonceStateCounter) GetLastL2Block(ctx context.Context, dbTx pgx.Tx) (*state.L2Block, error) {
+	return state.NewL2BlockWithHeader(state.NewL2Header(&types.Header{Root: s.root})), nil
+}
+
+func (s *nonceStateCounter) GetNonce(ctx context.Context, address common.Address, root common.Hash) (uint64, error) {
+	s.calls++
+	return s.nonces[address], nil
+}
+
+type noncesStorage struct {
+	storage
+	nonces map[common.Address][]uint64
+}
+
+func (s *noncesStorage) GetNoncesByStatus(ctx context.Context, status TxStatus) (map[common.Address][]uint64, error) {
+	return s.nonces, nil
+}
+
+func TestGetStatusCachesAccountNonces(t *testing.T) {
+	sender1 := common.HexToAddress("0x1")
+	sender2 := common.HexToAddress("0x2")
+	st := &nonceStateCounter{
+		root:   common.HexToHash("0x1"),
+		nonces: map[common.Address]uint64{sender1: 1, sender2: 0},
+	}
+	s := &noncesStorage{nonces: map[common.Address][]uint64{sender1: {1, 2, 4}, sender2: {0}}}
+	p := &Pool{storage: s, state: st, accountNoncesMux: new(sync.Mutex)}
+
+	status, err := p.GetStatus(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &Status{Pending: 3, Queued: 1}, status)
+	assert.Equal(t, 2, st.calls)
+
+	// the nonces are cached while there isn't a new L2 block
+	s.nonces[sender2] = []uint64{0, 1}
+	status, err = p.GetStatus(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &Status{Pending: 4, Queued: 1}, status)
+	assert.Equal(t, 2, st.calls)
+
+	// and read again for the new L2 block
+	st.root = common.HexToHash("0x2")
+	st.nonces[sender2] = 1
+	status, err = p.GetStatus(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &Status{Pending: 3, Queued: 1}, status)
+	assert.Equal(t, 4, st.calls)
+}
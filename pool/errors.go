@@ -3,6 +3,7 @@ package pool
 import (
 	"errors"
 
+	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
 )
 
@@ -74,6 +75,37 @@ var (
 	// ErrOutOfCounters is returned if the pool is out of counters.
 	ErrOutOfCounters = errors.New("out of counters")
 
+	// ErrTipAboveFeeCap is returned if a dynamic fee transaction has a tip higher
+	// than its fee cap.
+	ErrTipAboveFeeCap = core.ErrTipAboveFeeCap
+
+	// ErrTipVeryHigh is returned if a dynamic fee transaction has a tip bigger
+	// than 256 bits.
+	ErrTipVeryHigh = core.ErrTipVeryHigh
+
+	// ErrFeeCapVeryHigh is returned if a dynamic fee transaction has a fee cap
+	// bigger than 256 bits.
+	ErrFeeCapVeryHigh = core.ErrFeeCapVeryHigh
+
 	// ErrZeroL1GasPrice is returned if the L1 gas price is 0.
 	ErrZeroL1GasPrice = errors.New("L1 gas price 0")
+
+	// ErrBundlesDisabled is returned if a bundle is received and the pool doesn't accept bundles.
+	ErrBundlesDisabled = errors.New("bundles are disabled")
+
+	// ErrEmptyBundle is returned if a bundle without transactions is received.
+	ErrEmptyBundle = errors.New("empty bundle")
+
+	// ErrBundleTooLarge is returned if a bundle has more transactions than the allowed.
+	ErrBundleTooLarge = errors.New("bundle too large")
+
+	// ErrBundleDuplicatedTx is returned if a bundle contains the same transaction more than once.
+	ErrBundleDuplicatedTx = errors.New("duplicated transaction in bundle")
+
+	// ErrBundleExpired is returned if a bundle is received after its max timestamp.
+	ErrBundleExpired = errors.New("bundle expired")
+
+	// ErrBundleSenderPenalized is returned if a bundle is received from an IP or with txs of a sender
+	// whose bundles failed recently.
+	ErrBundleSenderPenalized = errors.New("bundle sender penalized due to a recently failed bundle")
 )
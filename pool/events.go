@@ -0,0 +1,235 @@
+package pool
+
+import (
+	"context"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const newTxsCheckInterval = 200 * time.Millisecond
+
+// NewTxsEventHandler represent a func that will be called by the
+// pool when a NewTxsEvent is triggered
+type NewTxsEventHandler func(e NewTxsEvent)
+
+// NewTxsEvent is a struct provided from the pool to the NewTxsEventHandler
+// when new txs are detected in the pool, the txs are sorted by the time
+// they were received
+type NewTxsEvent struct {
+	Txs []Transaction
+}
+
+// StartToMonitorNewTxs starts a go routine that will monitor the txs
+// added to the pool and execute the handlers registered to be executed
+// when new txs are detected. The txs are read from the pool storage, so
+// the txs added by any instance sharing the pool db are detected. This is
+// used by the RPC WebSocket pending transactions subscription, that starts
+// the monitor only while there are subscriptions. If the monitor is already
+// running this does nothing.
+func (p *Pool) StartToMonitorNewTxs() {
+	p.newTxsMonitorMux.Lock()
+	defer p.newTxsMonitorMux.Unlock()
+
+	if p.newTxsMonitorCancel != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p.newTxsMonitorCancel = cancel
+	log.Info("monitor of new pool txs started")
+
+	go func() {
+		for ctx.Err() == nil {
+			state.SafeRun(func() { p.monitorNewTxs(ctx) }, "fail to monitor new pool txs: %v")
+			select {
+			case <-ctx.Done():
+			case <-time.After(time.Second):
+			}
+		}
+	}()
+}
+
+// StopToMonitorNewTxs stops the go routine started by StartToMonitorNewTxs,
+// if the monitor is not running this does nothing.
+func (p *Pool) StopToMonitorNewTxs() {
+	p.newTxsMonitorMux.Lock()
+	defer p.newTxsMonitorMux.Unlock()
+
+	if p.newTxsMonitorCancel == nil {
+		return
+	}
+
+	p.newTxsMonitorCancel()
+	p.newTxsMonitorCancel = nil
+	log.Info("monitor of new pool txs stopped")
+}
+
+// RegisterNewTxsEventHandler add the provided handler to the list of handlers
+// that will be triggered when a new txs event is triggered
+func (p *Pool) RegisterNewTxsEventHandler(h NewTxsEventHandler) {
+	log.Info("new txs event handler registered")
+	p.newTxsEventHandlers = append(p.newTxsEventHandlers, h)
+}
+
+func (p *Pool) monitorNewTxs(ctx context.Context) {
+	// txs received at the same time as the last tx seen are read again
+	// in the next cycle, so we keep track of them to avoid duplicates
+	lastReceivedAtSeen := time.Now()
+	txsSeenAtLastReceivedAt := map[common.Hash]struct{}{}
+
+	ticker := time.NewTicker(newTxsCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if len(p.newTxsEventHandlers) == 0 {
+			continue
+		}
+
+		txs, err := p.storage.GetTxsReceivedSince(ctx, lastReceivedAtSeen)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			log.Errorf("failed to get txs while monitoring new txs: %v", err)
+			continue
+		}
+
+		newTxs := make([]Transaction, 0, len(txs))
+		for _, tx := range txs {
+			if _, found := txsSeenAtLastReceivedAt[tx.Hash()]; found {
+				continue
+			}
+			if tx.ReceivedAt.After(lastReceivedAtSeen) {
+				lastReceivedAtSeen = tx.ReceivedAt
+				txsSeenAtLastReceivedAt = map[common.Hash]struct{}{}
+			}
+			txsSeenAtLastReceivedAt[tx.Hash()] = struct{}{}
+			newTxs = append(newTxs, tx)
+		}
+
+		if len(newTxs) == 0 {
+			continue
+		}
+
+		log.Debugf("[monitorNewTxs] %v new txs detected", len(newTxs))
+		event := NewTxsEvent{Txs: newTxs}
+		for _, handler := range p.newTxsEventHandlers {
+			state.SafeRun(func() { handler(event) }, "failed and recovered in NewTxsEventHandler: %v")
+		}
+	}
+}
+
+// NewPreconfirmationsEventHandler represent a func that will be called by the
+// pool when a NewPreconfirmationsEvent is triggered
+type NewPreconfirmationsEventHandler func(e NewPreconfirmationsEvent)
+
+// NewPreconfirmationsEvent is a struct provided from the pool to the
+// NewPreconfirmationsEventHandler when new preconfirmations signed by the
+// sequencer are detected in the pool, sorted by the order they were added
+type NewPreconfirmationsEvent struct {
+	Preconfirmations []Preconfirmation
+}
+
+// StartToMonitorNewPreconfirmations starts a go routine that will monitor the
+// preconfirmations added to the pool by the sequencer and execute the handlers
+// registered to be executed when new preconfirmations are detected. This is used
+// by the RPC WebSocket preconfirmations subscription, that starts the monitor only
+// while there are subscriptions. If the monitor is already running this does nothing.
+func (p *Pool) StartToMonitorNewPreconfirmations() {
+	p.newPreconfirmationsMonitorMux.Lock()
+	defer p.newPreconfirmationsMonitorMux.Unlock()
+
+	if p.newPreconfirmationsMonitorCancel != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p.newPreconfirmationsMonitorCancel = cancel
+	log.Info("monitor of new pool preconfirmations started")
+
+	go func() {
+		for ctx.Err() == nil {
+			state.SafeRun(func() { p.monitorNewPreconfirmations(ctx) }, "fail to monitor new pool preconfirmations: %v")
+			select {
+			case <-ctx.Done():
+			case <-time.After(time.Second):
+			}
+		}
+	}()
+}
+
+// StopToMonitorNewPreconfirmations stops the go routine started by
+// StartToMonitorNewPreconfirmations, if the monitor is not running this does nothing.
+func (p *Pool) StopToMonitorNewPreconfirmations() {
+	p.newPreconfirmationsMonitorMux.Lock()
+	defer p.newPreconfirmationsMonitorMux.Unlock()
+
+	if p.newPreconfirmationsMonitorCancel == nil {
+		return
+	}
+
+	p.newPreconfirmationsMonitorCancel()
+	p.newPreconfirmationsMonitorCancel = nil
+	log.Info("monitor of new pool preconfirmations stopped")
+}
+
+// RegisterNewPreconfirmationsEventHandler add the provided handler to the list of handlers
+// that will be triggered when a new preconfirmations event is triggered
+func (p *Pool) RegisterNewPreconfirmationsEventHandler(h NewPreconfirmationsEventHandler) {
+	log.Info("new preconfirmations event handler registered")
+	p.newPreconfirmationsEventHandlers = append(p.newPreconfirmationsEventHandlers, h)
+}
+
+func (p *Pool) monitorNewPreconfirmations(ctx context.Context) {
+	// only the preconfirmations added after the monitor has started are notified
+	lastIDSeen, err := p.storage.GetLastPreconfirmationID(ctx)
+	if err != nil {
+		// the monitor is restarted while it's not stopped
+		log.Errorf("failed to get last preconfirmation id: %v", err)
+		return
+	}
+
+	ticker := time.NewTicker(newTxsCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if len(p.newPreconfirmationsEventHandlers) == 0 {
+			continue
+		}
+
+		preconfirmations, err := p.storage.GetPreconfirmationsAfterID(ctx, lastIDSeen)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			log.Errorf("failed to get preconfirmations while monitoring new preconfirmations: %v", err)
+			continue
+		}
+
+		if len(preconfirmations) == 0 {
+			continue
+		}
+		lastIDSeen = preconfirmations[len(preconfirmations)-1].ID
+
+		log.Debugf("[monitorNewPreconfirmations] %v new preconfirmations detected", len(preconfirmations))
+		event := NewPreconfirmationsEvent{Preconfirmations: preconfirmations}
+		for _, handler := range p.newPreconfirmationsEventHandlers {
+			state.SafeRun(func() { handler(event) }, "failed and recovered in NewPreconfirmationsEventHandler: %v")
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package pool
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// preconfirmationsStorageCounter counts the calls done by the preconfirmations
+// monitor to the pool storage
+type preconfirmationsStorageCounter struct {
+	storage
+	calls int64
+}
+
+func (s *preconfirmationsStorageCounter) GetLastPreconfirmationID(ctx context.Context) (uint64, error) {
+	atomic.AddInt64(&s.calls, 1)
+	return 0, nil
+}
+
+func (s *preconfirmationsStorageCounter) GetPreconfirmationsAfterID(ctx context.Context, id uint64) ([]Preconfirmation, error) {
+	atomic.AddInt64(&s.calls, 1)
+	return nil, nil
+}
+
+func TestMonitorNewPreconfirmationsOnlyPollsWhileStarted(t *testing.T) {
+	s := &preconfirmationsStorageCounter{}
+	p := &Pool{
+		storage:                       s,
+		newPreconfirmationsMonitorMux: new(sync.Mutex),
+	}
+	p.RegisterNewPreconfirmationsEventHandler(func(e NewPreconfirmationsEvent) {})
+
+	// no subscribers, the monitor is not started
+	time.Sleep(3 * newTxsCheckInterval)
+	assert.Equal(t, int64(0), atomic.LoadInt64(&s.calls))
+
+	p.StartToMonitorNewPreconfirmations()
+	// starting it again doesn't run a second monitor
+	p.StartToMonitorNewPreconfirmations()
+	time.Sleep(3 * newTxsCheckInterval)
+	assert.Greater(t, atomic.LoadInt64(&s.calls), int64(1))
+
+	p.StopToMonitorNewPreconfirmations()
+	// the last tick may still be in progress
+	time.Sleep(newTxsCheckInterval)
+	calls := atomic.LoadInt64(&s.calls)
+	time.Sleep(3 * newTxsCheckInterval)
+	assert.Equal(t, calls, atomic.LoadInt64(&s.calls))
+}
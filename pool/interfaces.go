@@ -19,8 +19,12 @@ type storage interface {
 	GetGasPrices(ctx context.Context) (uint64, uint64, error)
 	GetNonce(ctx context.Context, address common.Address) (uint64, error)
 	GetPendingTxHashesSince(ctx context.Context, since time.Time) ([]common.Hash, error)
+	GetTxsReceivedSince(ctx context.Context, since time.Time) ([]Transaction, error)
 	GetTxsByFromAndNonce(ctx context.Context, from common.Address, nonce uint64) ([]Transaction, error)
 	GetTxsByStatus(ctx context.Context, state TxStatus, limit uint64) ([]Transaction, error)
+	GetTxsByFromAndStatus(ctx context.Context, from common.Address, status ...TxStatus) ([]Transaction, error)
+	GetTxsByStatusGroupedBySender(ctx context.Context, status TxStatus, senderOffset, senderLimit uint64) (map[common.Address][]Transaction, error)
+	GetNoncesByStatus(ctx context.Context, status TxStatus) (map[common.Address][]uint64, error)
 	GetNonWIPPendingTxs(ctx context.Context) ([]Transaction, error)
 	IsTxPending(ctx context.Context, hash common.Hash) (bool, error)
 	SetGasPrices(ctx context.Context, l2GasPrice uint64, l1GasPrice uint64) error
@@ -37,8 +41,19 @@ type storage interface {
 	DeleteTransactionByHash(ctx context.Context, hash common.Hash) error
 	MarkWIPTxsAsPending(ctx context.Context) error
 	GetAllAddressesBlocked(ctx context.Context) ([]common.Address, error)
+	AddBlockedAddress(ctx context.Context, address common.Address, reason string) error
+	DeleteBlockedAddress(ctx context.Context, address common.Address) error
 	MinL2GasPriceSince(ctx context.Context, timestamp time.Time) (uint64, error)
 	GetEarliestProcessedTx(ctx context.Context) (common.Hash, error)
+	AddBundle(ctx context.Context, bundle Bundle) error
+	GetBundlesByStatus(ctx context.Context, status BundleStatus) ([]Bundle, error)
+	UpdateBundleStatus(ctx context.Context, hash common.Hash, newStatus BundleStatus, failedReason *string) error
+	HasFailedBundlesSince(ctx context.Context, senders []common.Address, ip string, since time.Time) (bool, error)
+	MarkWIPBundlesAsPending(ctx context.Context) error
+	AddPreconfirmation(ctx context.Context, preconfirmation Preconfirmation) error
+	GetPreconfirmationByTxHash(ctx context.Context, txHash common.Hash) (*Preconfirmation, error)
+	GetPreconfirmationsAfterID(ctx context.Context, id uint64) ([]Preconfirmation, error)
+	GetLastPreconfirmationID(ctx context.Context) (uint64, error)
 }
 
 type stateInterface interface {
@@ -12,10 +12,16 @@ import (
 	"github.com/0xPolygonHermez/zkevm-node/state"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/jackc/pgconn"
 	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 )
 
+// execer is the common interface of the pool DB and its transactions to execute statements
+type execer interface {
+	Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
+}
+
 // PostgresPoolStorage is an implementation of the Pool interface
 // that uses a postgres database to store the data
 type PostgresPoolStorage struct {
@@ -36,6 +42,12 @@ func NewPostgresPoolStorage(cfg db.Config) (*PostgresPoolStorage, error) {
 
 // AddTx adds a transaction to the pool table with the provided status
 func (p *PostgresPoolStorage) AddTx(ctx context.Context, tx pool.Transaction) error {
+	return addTx(ctx, p.db, tx, nil)
+}
+
+// addTx adds a transaction to the pool table, linking it to the bundle with the given hash (if any),
+// a tx already linked to a bundle keeps the link when it's added again
+func addTx(ctx context.Context, e execer, tx pool.Transaction, bundleHash *string) error {
 	hash := tx.Hash().Hex()
 
 	b, err := tx.MarshalBinary()
@@ -50,7 +62,7 @@ func (p *PostgresPoolStorage) AddTx(ctx context.Context, tx pool.Transaction) er
 	}
 	decoded := string(b)
 
-	gasPrice := tx.GasPrice().Uint64()
+	gasPrice := state.TxGasPrice(&tx.Transaction).Uint64()
 	nonce := tx.Nonce()
 
 	sql := `
@@ -76,10 +88,11 @@ func (p *PostgresPoolStorage) AddTx(ctx context.Context, tx pool.Transaction) er
 			is_wip,
 			ip,
 			failed_reason,
-			reserved_zkcounters
+			reserved_zkcounters,
+			bundle_hash
 		) 
 		VALUES 
-			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, NULL, $20)
+			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, NULL, $20, $21)
 			ON CONFLICT (hash) DO UPDATE SET 
 			encoded = $2,
 			decoded = $3,
@@ -100,7 +113,8 @@ func (p *PostgresPoolStorage) AddTx(ctx context.Context, tx pool.Transaction) er
 			is_wip = $18,
 			ip = $19,
 			failed_reason = NULL,
-			reserved_zkcounters = $20
+			reserved_zkcounters = $20,
+			bundle_hash = COALESCE(pool.transaction.bundle_hash, EXCLUDED.bundle_hash)
 	`
 
 	// Get FromAddress from the JSON data
@@ -110,7 +124,7 @@ func (p *PostgresPoolStorage) AddTx(ctx context.Context, tx pool.Transaction) er
 	}
 	fromAddress := data.String()
 
-	if _, err := p.db.Exec(ctx, sql,
+	if _, err := e.Exec(ctx, sql,
 		hash,
 		encoded,
 		decoded,
@@ -130,7 +144,8 @@ func (p *PostgresPoolStorage) AddTx(ctx context.Context, tx pool.Transaction) er
 		fromAddress,
 		tx.IsWIP,
 		tx.IP,
-		tx.ReservedZKCounters); err != nil {
+		tx.ReservedZKCounters,
+		bundleHash); err != nil {
 		return err
 	}
 	return nil
@@ -171,7 +186,8 @@ func (p *PostgresPoolStorage) GetTxsByStatus(ctx context.Context, status pool.Tx
 	return txs, nil
 }
 
-// GetNonWIPPendingTxs returns an array of transactions
+// GetNonWIPPendingTxs returns an array of transactions, the txs of the bundles are excluded
+// as they are only sequenced together with their bundle
 func (p *PostgresPoolStorage) GetNonWIPPendingTxs(ctx context.Context) ([]pool.Transaction, error) {
 	var (
 		rows pgx.Rows
@@ -180,7 +196,7 @@ func (p *PostgresPoolStorage) GetNonWIPPendingTxs(ctx context.Context) ([]pool.T
 	)
 
 	sql = `SELECT encoded, status, received_at, is_wip, ip, cumulative_gas_used, used_keccak_hashes, used_poseidon_hashes, used_poseidon_paddings, used_mem_aligns,
-		used_arithmetics, used_binaries, used_steps, used_sha256_hashes, failed_reason, reserved_zkcounters FROM pool.transaction WHERE is_wip IS FALSE and status = $1`
+		used_arithmetics, used_binaries, used_steps, used_sha256_hashes, failed_reason, reserved_zkcounters FROM pool.transaction WHERE is_wip IS FALSE and status = $1 and bundle_hash IS NULL`
 	rows, err = p.db.Query(ctx, sql, pool.TxStatusPending)
 
 	if err != nil {
@@ -221,6 +237,30 @@ func (p *PostgresPoolStorage) GetPendingTxHashesSince(ctx context.Context, since
 	return hashes, nil
 }
 
+// GetTxsReceivedSince returns the txs received since the given time,
+// sorted by the time they were received, the txs of the bundles are excluded
+// as they are private until their bundle is included
+func (p *PostgresPoolStorage) GetTxsReceivedSince(ctx context.Context, since time.Time) ([]pool.Transaction, error) {
+	sql := `SELECT encoded, status, received_at, is_wip, ip, cumulative_gas_used, used_keccak_hashes, used_poseidon_hashes, used_poseidon_paddings, used_mem_aligns,
+			used_arithmetics, used_binaries, used_steps, used_sha256_hashes, failed_reason, reserved_zkcounters FROM pool.transaction WHERE received_at >= $1 AND bundle_hash IS NULL ORDER BY received_at ASC`
+	rows, err := p.db.Query(ctx, sql, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	txs := make([]pool.Transaction, 0, len(rows.RawValues()))
+	for rows.Next() {
+		tx, err := scanTx(rows)
+		if err != nil {
+			return nil, err
+		}
+		txs = append(txs, *tx)
+	}
+
+	return txs, nil
+}
+
 // GetTxs gets txs with the lowest nonce
 func (p *PostgresPoolStorage) GetTxs(ctx context.Context, filterStatus pool.TxStatus, minGasPrice, limit uint64) ([]*pool.Transaction, error) {
 	query := `
@@ -543,6 +583,101 @@ func (p *PostgresPoolStorage) GetTxsByFromAndNonce(ctx context.Context, from com
 	return txs, nil
 }
 
+// GetTxsByFromAndStatus gets all the transactions from the pool sent by the
+// provided address with one of the provided statuses, sorted by nonce. The
+// txs of the bundles are excluded
+func (p *PostgresPoolStorage) GetTxsByFromAndStatus(ctx context.Context, from common.Address, status ...pool.TxStatus) ([]pool.Transaction, error) {
+	sql := `SELECT encoded, status, received_at, is_wip, ip, cumulative_gas_used, used_keccak_hashes, used_poseidon_hashes,
+				   used_poseidon_paddings, used_mem_aligns, used_arithmetics, used_binaries, used_steps, used_sha256_hashes, failed_reason, reserved_zkcounters
+	          FROM pool.transaction
+			 WHERE from_address = $1
+			   AND status = ANY ($2)
+			   AND bundle_hash IS NULL
+		  ORDER BY nonce ASC`
+	rows, err := p.db.Query(ctx, sql, from.String(), status)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	txs := make([]pool.Transaction, 0, len(rows.RawValues()))
+	for rows.Next() {
+		tx, err := scanTx(rows)
+		if err != nil {
+			return nil, err
+		}
+		txs = append(txs, *tx)
+	}
+
+	return txs, nil
+}
+
+// GetTxsByStatusGroupedBySender returns the transactions with the provided status
+// grouped by sender for a page of senders, the senders are sorted by address and
+// the transactions of each sender by nonce. senderOffset and senderLimit are used
+// to paginate the senders. The txs of the bundles are excluded
+func (p *PostgresPoolStorage) GetTxsByStatusGroupedBySender(ctx context.Context, status pool.TxStatus, senderOffset, senderLimit uint64) (map[common.Address][]pool.Transaction, error) {
+	sql := `SELECT encoded, status, received_at, is_wip, ip, cumulative_gas_used, used_keccak_hashes, used_poseidon_hashes,
+				   used_poseidon_paddings, used_mem_aligns, used_arithmetics, used_binaries, used_steps, used_sha256_hashes, failed_reason, reserved_zkcounters,
+				   from_address
+	          FROM pool.transaction
+			 WHERE status = $1
+			   AND bundle_hash IS NULL
+			   AND from_address IN (SELECT DISTINCT from_address FROM pool.transaction WHERE status = $1 AND bundle_hash IS NULL ORDER BY from_address OFFSET $2 LIMIT $3)
+		  ORDER BY from_address ASC, nonce ASC`
+	rows, err := p.db.Query(ctx, sql, status.String(), senderOffset, senderLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	txsBySender := make(map[common.Address][]pool.Transaction)
+	for rows.Next() {
+		var fromAddr string
+		tx, err := scanTx(rows, &fromAddr)
+		if err != nil {
+			return nil, err
+		}
+		from := common.HexToAddress(fromAddr)
+		txsBySender[from] = append(txsBySender[from], *tx)
+	}
+
+	return txsBySender, nil
+}
+
+// GetNoncesByStatus returns the nonces of the transactions with the provided
+// status grouped by sender, the nonces of each sender are sorted in ascending order.
+// The txs of the bundles are excluded
+func (p *PostgresPoolStorage) GetNoncesByStatus(ctx context.Context, status pool.TxStatus) (map[common.Address][]uint64, error) {
+	sql := `SELECT from_address, nonce
+	          FROM pool.transaction
+			 WHERE status = $1
+			   AND bundle_hash IS NULL
+		  ORDER BY from_address ASC, nonce ASC`
+	rows, err := p.db.Query(ctx, sql, status.String())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	nonces := make(map[common.Address][]uint64)
+	for rows.Next() {
+		var (
+			fromAddr string
+			nonce    uint64
+		)
+		if err := rows.Scan(&fromAddr, &nonce); err != nil {
+			return nil, err
+		}
+		from := common.HexToAddress(fromAddr)
+		nonces[from] = append(nonces[from], nonce)
+	}
+
+	return nonces, nil
+}
+
 // GetTxFromAddressFromByHash gets tx from address by hash
 func (p *PostgresPoolStorage) GetTxFromAddressFromByHash(ctx context.Context, hash common.Hash) (common.Address, uint64, error) {
 	query := `SELECT from_address, nonce
@@ -675,7 +810,9 @@ func (p *PostgresPoolStorage) GetTransactionByL2Hash(ctx context.Context, hash c
 	return poolTx, nil
 }
 
-func scanTx(rows pgx.Rows) (*pool.Transaction, error) {
+// scanTx scans a tx from the provided rows, the extra destinations are used
+// to scan the columns selected after the tx ones
+func scanTx(rows pgx.Rows, extraDest ...interface{}) (*pool.Transaction, error) {
 	var (
 		encoded, status, ip  string
 		receivedAt           time.Time
@@ -693,8 +830,9 @@ func scanTx(rows pgx.Rows) (*pool.Transaction, error) {
 		reservedZKCounters   state.ZKCounters
 	)
 
-	if err := rows.Scan(&encoded, &status, &receivedAt, &isWIP, &ip, &cumulativeGasUsed, &usedKeccakHashes, &usedPoseidonHashes,
-		&usedPoseidonPaddings, &usedMemAligns, &usedArithmetics, &usedBinaries, &usedSteps, &usedSHA256Hashes, &failedReason, &reservedZKCounters); err != nil {
+	dest := []interface{}{&encoded, &status, &receivedAt, &isWIP, &ip, &cumulativeGasUsed, &usedKeccakHashes, &usedPoseidonHashes,
+		&usedPoseidonPaddings, &usedMemAligns, &usedArithmetics, &usedBinaries, &usedSteps, &usedSHA256Hashes, &failedReason, &reservedZKCounters}
+	if err := rows.Scan(append(dest, extraDest...)...); err != nil {
 		return nil, err
 	}
 
@@ -802,6 +940,26 @@ func (p *PostgresPoolStorage) GetAllAddressesBlocked(ctx context.Context) ([]com
 	return addrs, nil
 }
 
+// AddBlockedAddress adds an address to the list of blocked addresses, updating
+// the block reason when the address is already blocked
+func (p *PostgresPoolStorage) AddBlockedAddress(ctx context.Context, address common.Address, reason string) error {
+	sql := `INSERT INTO pool.blocked (addr, block_reason) VALUES ($1, $2)
+		ON CONFLICT (addr) DO UPDATE SET block_reason = EXCLUDED.block_reason`
+	if _, err := p.db.Exec(ctx, sql, address.String(), reason); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteBlockedAddress removes an address from the list of blocked addresses
+func (p *PostgresPoolStorage) DeleteBlockedAddress(ctx context.Context, address common.Address) error {
+	sql := `DELETE FROM pool.blocked WHERE addr = $1`
+	if _, err := p.db.Exec(ctx, sql, address.String()); err != nil {
+		return err
+	}
+	return nil
+}
+
 // GetEarliestProcessedTx gets the earliest processed tx from the pool. Mainly used for cleanup
 func (p *PostgresPoolStorage) GetEarliestProcessedTx(ctx context.Context) (common.Hash, error) {
 	const getEarliestProcessedTxnFromTxnPool = `SELECT hash
@@ -821,3 +979,248 @@ func (p *PostgresPoolStorage) GetEarliestProcessedTx(ctx context.Context) (commo
 
 	return common.HexToHash(txnHash), nil
 }
+
+// AddBundle adds a bundle to the pool, its txs are added to the pool txs linked to the bundle
+func (p *PostgresPoolStorage) AddBundle(ctx context.Context, bundle pool.Bundle) error {
+	txHashes := make([]string, 0, len(bundle.Txs))
+	encodedTxs := make([]string, 0, len(bundle.Txs))
+	for _, tx := range bundle.Txs {
+		b, err := tx.MarshalBinary()
+		if err != nil {
+			return err
+		}
+		txHashes = append(txHashes, tx.Hash().Hex())
+		encodedTxs = append(encodedTxs, hex.EncodeToHex(b))
+	}
+
+	dbTx, err := p.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	const addBundleSQL = `
+		INSERT INTO pool.bundle (hash, tx_hashes, encoded_txs, status, block_num, max_timestamp, received_at, ip, failed_reason)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+	if _, err := dbTx.Exec(ctx, addBundleSQL, bundle.Hash.Hex(), txHashes, encodedTxs, bundle.Status.String(), bundle.BlockNumber,
+		bundle.MaxTimestamp, bundle.ReceivedAt, bundle.IP, bundle.FailedReason); err != nil {
+		_ = dbTx.Rollback(ctx)
+		return err
+	}
+
+	bundleHash := bundle.Hash.Hex()
+	for _, tx := range bundle.Txs {
+		poolTx := pool.NewTransaction(tx, bundle.IP, false)
+		poolTx.ReceivedAt = bundle.ReceivedAt
+		if err := addTx(ctx, dbTx, *poolTx, &bundleHash); err != nil {
+			_ = dbTx.Rollback(ctx)
+			return err
+		}
+	}
+
+	return dbTx.Commit(ctx)
+}
+
+// GetBundlesByStatus returns the bundles with the given status sorted by the time they were received
+func (p *PostgresPoolStorage) GetBundlesByStatus(ctx context.Context, status pool.BundleStatus) ([]pool.Bundle, error) {
+	const getBundlesSQL = `
+		SELECT hash, encoded_txs, status, block_num, max_timestamp, received_at, ip, failed_reason
+		  FROM pool.bundle
+		 WHERE status = $1
+		 ORDER BY received_at ASC`
+	rows, err := p.db.Query(ctx, getBundlesSQL, status.String())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	bundles := make([]pool.Bundle, 0, len(rows.RawValues()))
+	for rows.Next() {
+		var (
+			hash, bundleStatus string
+			encodedTxs         []string
+			ip                 *string
+			bundle             pool.Bundle
+		)
+		err := rows.Scan(&hash, &encodedTxs, &bundleStatus, &bundle.BlockNumber, &bundle.MaxTimestamp, &bundle.ReceivedAt, &ip, &bundle.FailedReason)
+		if err != nil {
+			return nil, err
+		}
+
+		bundle.Hash = common.HexToHash(hash)
+		bundle.Status = pool.BundleStatus(bundleStatus)
+		if ip != nil {
+			bundle.IP = *ip
+		}
+
+		bundle.Txs = make([]types.Transaction, 0, len(encodedTxs))
+		for _, encoded := range encodedTxs {
+			b, err := hex.DecodeHex(encoded)
+			if err != nil {
+				return nil, err
+			}
+			tx := types.Transaction{}
+			if err := tx.UnmarshalBinary(b); err != nil {
+				return nil, err
+			}
+			bundle.Txs = append(bundle.Txs, tx)
+		}
+
+		bundles = append(bundles, bundle)
+	}
+
+	return bundles, nil
+}
+
+// UpdateBundleStatus updates the status of a bundle. When the bundle is discarded (failed or expired)
+// its txs still pending are set as failed with the same reason
+func (p *PostgresPoolStorage) UpdateBundleStatus(ctx context.Context, hash common.Hash, newStatus pool.BundleStatus, failedReason *string) error {
+	dbTx, err := p.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	var failedAt *time.Time
+	if newStatus == pool.BundleStatusFailed {
+		now := time.Now()
+		failedAt = &now
+	}
+
+	const updateBundleStatusSQL = "UPDATE pool.bundle SET status = $1, failed_reason = $2, failed_at = $3 WHERE hash = $4"
+	if _, err := dbTx.Exec(ctx, updateBundleStatusSQL, newStatus.String(), failedReason, failedAt, hash.Hex()); err != nil {
+		_ = dbTx.Rollback(ctx)
+		return err
+	}
+
+	if newStatus == pool.BundleStatusFailed || newStatus == pool.BundleStatusExpired {
+		txsFailedReason := failedReason
+		if txsFailedReason == nil {
+			reason := "bundle " + newStatus.String()
+			txsFailedReason = &reason
+		}
+		const failBundleTxsSQL = "UPDATE pool.transaction SET status = $1, failed_reason = $2 WHERE bundle_hash = $3 AND status = $4"
+		if _, err := dbTx.Exec(ctx, failBundleTxsSQL, pool.TxStatusFailed, txsFailedReason, hash.Hex(), pool.TxStatusPending); err != nil {
+			_ = dbTx.Rollback(ctx)
+			return err
+		}
+	}
+
+	return dbTx.Commit(ctx)
+}
+
+// HasFailedBundlesSince checks if a bundle sent from the given IP or containing txs of the given senders has failed since the given time
+func (p *PostgresPoolStorage) HasFailedBundlesSince(ctx context.Context, senders []common.Address, ip string, since time.Time) (bool, error) {
+	const hasFailedBundlesSQL = `
+		SELECT EXISTS (
+			SELECT 1
+			  FROM pool.bundle b
+			 WHERE b.status = $1
+			   AND b.failed_at >= $2
+			   AND (($3 <> '' AND b.ip = $3) OR EXISTS (
+					SELECT 1 FROM pool.transaction t WHERE t.bundle_hash = b.hash AND t.from_address = ANY ($4))))`
+	fromAddresses := make([]string, 0, len(senders))
+	for _, sender := range senders {
+		fromAddresses = append(fromAddresses, sender.String())
+	}
+
+	var exists bool
+	err := p.db.QueryRow(ctx, hasFailedBundlesSQL, pool.BundleStatusFailed.String(), since, ip, fromAddresses).Scan(&exists)
+	if err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+// MarkWIPBundlesAsPending updates the status of the bundles in WIP status to pending
+func (p *PostgresPoolStorage) MarkWIPBundlesAsPending(ctx context.Context) error {
+	const markWIPBundlesAsPendingSQL = "UPDATE pool.bundle SET status = $1 WHERE status = $2"
+	if _, err := p.db.Exec(ctx, markWIPBundlesAsPendingSQL, pool.BundleStatusPending.String(), pool.BundleStatusWIP.String()); err != nil {
+		return err
+	}
+	return nil
+}
+
+// AddPreconfirmation adds the preconfirmation of a tx to the pool
+func (p *PostgresPoolStorage) AddPreconfirmation(ctx context.Context, preconfirmation pool.Preconfirmation) error {
+	const addPreconfirmationSQL = `
+		INSERT INTO pool.preconfirmation (tx_hash, l2_block_num, position, state_root, signature)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (tx_hash) DO UPDATE SET
+			id = DEFAULT,
+			l2_block_num = $2,
+			position = $3,
+			state_root = $4,
+			signature = $5,
+			created_at = NOW()`
+	if _, err := p.db.Exec(ctx, addPreconfirmationSQL, preconfirmation.TxHash.Hex(), preconfirmation.L2BlockNumber, preconfirmation.Position,
+		preconfirmation.StateRoot.Hex(), hex.EncodeToHex(preconfirmation.Signature)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetPreconfirmationByTxHash returns the preconfirmation of the tx with the given hash
+func (p *PostgresPoolStorage) GetPreconfirmationByTxHash(ctx context.Context, txHash common.Hash) (*pool.Preconfirmation, error) {
+	const getPreconfirmationSQL = `
+		SELECT id, tx_hash, l2_block_num, position, state_root, signature, created_at
+		  FROM pool.preconfirmation
+		 WHERE tx_hash = $1`
+	preconfirmation, err := scanPreconfirmation(p.db.QueryRow(ctx, getPreconfirmationSQL, txHash.Hex()))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, pool.ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return preconfirmation, nil
+}
+
+// GetPreconfirmationsAfterID returns the preconfirmations added after the one with the given id, sorted by id
+func (p *PostgresPoolStorage) GetPreconfirmationsAfterID(ctx context.Context, id uint64) ([]pool.Preconfirmation, error) {
+	const getPreconfirmationsSQL = `
+		SELECT id, tx_hash, l2_block_num, position, state_root, signature, created_at
+		  FROM pool.preconfirmation
+		 WHERE id > $1
+		 ORDER BY id ASC`
+	rows, err := p.db.Query(ctx, getPreconfirmationsSQL, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	preconfirmations := make([]pool.Preconfirmation, 0, len(rows.RawValues()))
+	for rows.Next() {
+		preconfirmation, err := scanPreconfirmation(rows)
+		if err != nil {
+			return nil, err
+		}
+		preconfirmations = append(preconfirmations, *preconfirmation)
+	}
+
+	return preconfirmations, nil
+}
+
+// GetLastPreconfirmationID returns the id of the last preconfirmation added to the pool, 0 if there are none
+func (p *PostgresPoolStorage) GetLastPreconfirmationID(ctx context.Context) (uint64, error) {
+	const getLastPreconfirmationIDSQL = "SELECT COALESCE(MAX(id), 0) FROM pool.preconfirmation"
+	var id uint64
+	err := p.db.QueryRow(ctx, getLastPreconfirmationIDSQL).Scan(&id)
+	return id, err
+}
+
+func scanPreconfirmation(row pgx.Row) (*pool.Preconfirmation, error) {
+	var (
+		preconfirmation              pool.Preconfirmation
+		txHash, stateRoot, signature string
+	)
+	err := row.Scan(&preconfirmation.ID, &txHash, &preconfirmation.L2BlockNumber, &preconfirmation.Position, &stateRoot, &signature, &preconfirmation.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	preconfirmation.TxHash = common.HexToHash(txHash)
+	preconfirmation.StateRoot = common.HexToHash(stateRoot)
+	preconfirmation.Signature, err = hex.DecodeHex(signature)
+	if err != nil {
+		return nil, err
+	}
+	return &preconfirmation, nil
+}
@@ -38,18 +38,27 @@ var (
 // that uses a postgres database to store the data
 type Pool struct {
 	storage
-	state                   stateInterface
-	chainID                 uint64
-	cfg                     Config
-	batchConstraintsCfg     state.BatchConstraintsCfg
-	blockedAddresses        sync.Map
-	minSuggestedGasPrice    *big.Int
-	minSuggestedGasPriceMux *sync.RWMutex
-	eventLog                *event.EventLog
-	startTimestamp          time.Time
-	gasPrices               GasPrices
-	gasPricesMux            *sync.RWMutex
-	effectiveGasPrice       *EffectiveGasPrice
+	state                            stateInterface
+	chainID                          uint64
+	cfg                              Config
+	batchConstraintsCfg              state.BatchConstraintsCfg
+	blockedAddresses                 sync.Map
+	minSuggestedGasPrice             *big.Int
+	minSuggestedGasPriceMux          *sync.RWMutex
+	eventLog                         *event.EventLog
+	startTimestamp                   time.Time
+	gasPrices                        GasPrices
+	gasPricesMux                     *sync.RWMutex
+	effectiveGasPrice                *EffectiveGasPrice
+	newTxsEventHandlers              []NewTxsEventHandler
+	newTxsMonitorCancel              context.CancelFunc
+	newTxsMonitorMux                 *sync.Mutex
+	newPreconfirmationsEventHandlers []NewPreconfirmationsEventHandler
+	newPreconfirmationsMonitorCancel context.CancelFunc
+	newPreconfirmationsMonitorMux    *sync.Mutex
+	accountNonces                    map[common.Address]uint64
+	accountNoncesRoot                common.Hash
+	accountNoncesMux                 *sync.Mutex
 }
 
 type preExecutionResponse struct {
@@ -72,19 +81,22 @@ type GasPrices struct {
 func NewPool(cfg Config, batchConstraintsCfg state.BatchConstraintsCfg, s storage, st stateInterface, chainID uint64, eventLog *event.EventLog) *Pool {
 	startTimestamp := time.Now()
 	p := &Pool{
-		cfg:                     cfg,
-		batchConstraintsCfg:     batchConstraintsCfg,
-		startTimestamp:          startTimestamp,
-		storage:                 s,
-		state:                   st,
-		chainID:                 chainID,
-		blockedAddresses:        sync.Map{},
-		minSuggestedGasPriceMux: new(sync.RWMutex),
-		minSuggestedGasPrice:    big.NewInt(int64(cfg.DefaultMinGasPriceAllowed)),
-		eventLog:                eventLog,
-		gasPrices:               GasPrices{0, 0},
-		gasPricesMux:            new(sync.RWMutex),
-		effectiveGasPrice:       NewEffectiveGasPrice(cfg.EffectiveGasPrice),
+		cfg:                           cfg,
+		batchConstraintsCfg:           batchConstraintsCfg,
+		startTimestamp:                startTimestamp,
+		storage:                       s,
+		state:                         st,
+		chainID:                       chainID,
+		blockedAddresses:              sync.Map{},
+		minSuggestedGasPriceMux:       new(sync.RWMutex),
+		minSuggestedGasPrice:          big.NewInt(int64(cfg.DefaultMinGasPriceAllowed)),
+		eventLog:                      eventLog,
+		gasPrices:                     GasPrices{0, 0},
+		gasPricesMux:                  new(sync.RWMutex),
+		effectiveGasPrice:             NewEffectiveGasPrice(cfg.EffectiveGasPrice),
+		newTxsMonitorMux:              new(sync.Mutex),
+		newPreconfirmationsMonitorMux: new(sync.Mutex),
+		accountNoncesMux:              new(sync.Mutex),
 	}
 	p.refreshGasPrices()
 	go func(cfg *Config, p *Pool) {
@@ -154,6 +166,31 @@ func (p *Pool) refreshBlockedAddresses() {
 	}
 }
 
+// BlockAddress adds the address to the blocked addresses, the txs sent by it are
+// rejected right away by this instance and by the other instances of the pool as
+// soon as they refresh the blocked addresses
+func (p *Pool) BlockAddress(ctx context.Context, address common.Address, reason string) error {
+	if err := p.storage.AddBlockedAddress(ctx, address, reason); err != nil {
+		return err
+	}
+	p.blockedAddresses.Store(address.String(), 1)
+	return nil
+}
+
+// UnblockAddress removes the address from the blocked addresses
+func (p *Pool) UnblockAddress(ctx context.Context, address common.Address) error {
+	if err := p.storage.DeleteBlockedAddress(ctx, address); err != nil {
+		return err
+	}
+	p.blockedAddresses.Delete(address.String())
+	return nil
+}
+
+// GetBlockedAddresses returns the blocked addresses
+func (p *Pool) GetBlockedAddresses(ctx context.Context) ([]common.Address, error) {
+	return p.storage.GetAllAddressesBlocked(ctx)
+}
+
 // StartPollingMinSuggestedGasPrice starts polling the minimum suggested gas price
 func (p *Pool) StartPollingMinSuggestedGasPrice(ctx context.Context) {
 	p.tryUpdateMinSuggestedGasPrice(p.cfg.DefaultMinGasPriceAllowed)
@@ -249,7 +286,7 @@ func (p *Pool) StoreTx(ctx context.Context, tx types.Transaction, ip string, isW
 // ValidateBreakEvenGasPrice validates the effective gas price
 func (p *Pool) ValidateBreakEvenGasPrice(ctx context.Context, tx types.Transaction, preExecutionGasUsed uint64, gasPrices GasPrices) error {
 	// Get the tx gas price we will use in the egp calculation. If egp is disabled we will use a "simulated" tx gas price and l2 gas price
-	txGasPrice, l2GasPrice := p.effectiveGasPrice.GetTxAndL2GasPrice(tx.GasPrice(), gasPrices.L1GasPrice, gasPrices.L2GasPrice)
+	txGasPrice, l2GasPrice := p.effectiveGasPrice.GetTxAndL2GasPrice(state.TxGasPrice(&tx), gasPrices.L1GasPrice, gasPrices.L2GasPrice)
 
 	breakEvenGasPrice, err := p.effectiveGasPrice.CalculateBreakEvenGasPrice(tx.Data(), txGasPrice, preExecutionGasUsed, gasPrices.L1GasPrice)
 	if err != nil {
@@ -424,8 +461,8 @@ func (p *Pool) validateTx(ctx context.Context, poolTx Transaction) error {
 		return ErrInvalidChainID
 	}
 
-	// Accept only legacy transactions until EIP-2718/2930 activates.
-	if poolTx.Type() != types.LegacyTxType {
+	// Accept the typed transactions only once the fork supporting them is active
+	if !state.IsTxTypeSupported(poolTx.Type(), p.cfg.ForkID) {
 		return ErrTxTypeNotSupported
 	}
 
@@ -511,9 +548,10 @@ func (p *Pool) validateTx(ctx context.Context, poolTx Transaction) error {
 
 	// Reject transactions with a gas price lower than the minimum gas price
 	p.minSuggestedGasPriceMux.RLock()
-	gasPriceCmp := poolTx.GasPrice().Cmp(p.minSuggestedGasPrice)
+	txGasPrice := state.TxGasPrice(&poolTx.Transaction)
+	gasPriceCmp := txGasPrice.Cmp(p.minSuggestedGasPrice)
 	if gasPriceCmp == -1 {
-		log.Debugf("low gas price: minSuggestedGasPrice %v got %v", p.minSuggestedGasPrice, poolTx.GasPrice())
+		log.Debugf("low gas price: minSuggestedGasPrice %v got %v", p.minSuggestedGasPrice, txGasPrice)
 	}
 	p.minSuggestedGasPriceMux.RUnlock()
 	if gasPriceCmp == -1 {
@@ -558,8 +596,8 @@ func (p *Pool) validateTx(ctx context.Context, poolTx Transaction) error {
 			continue
 		}
 
-		oldTxPrice := new(big.Int).Mul(oldTx.GasPrice(), new(big.Int).SetUint64(oldTx.Gas()))
-		txPrice := new(big.Int).Mul(poolTx.GasPrice(), new(big.Int).SetUint64(poolTx.Gas()))
+		oldTxPrice := new(big.Int).Mul(state.TxGasPrice(&oldTx.Transaction), new(big.Int).SetUint64(oldTx.Gas()))
+		txPrice := new(big.Int).Mul(txGasPrice, new(big.Int).SetUint64(poolTx.Gas()))
 
 		if oldTx.Hash() == poolTx.Hash() {
 			return ErrAlreadyKnown
@@ -616,6 +654,8 @@ func (p *Pool) tryUpdateMinSuggestedGasPrice(minSuggestedGasPrice uint64) {
 // they ar compatible with the Executor needs
 // GasLimit: 256 bits
 // GasPrice: 256 bits
+// GasTipCap: 256 bits
+// GasFeeCap: 256 bits
 // Value: 256 bits
 // Data: 30000 bytes
 // Nonce: 64 bits
@@ -639,6 +679,20 @@ func (p *Pool) checkTxFieldCompatibilityWithExecutor(ctx context.Context, tx typ
 		return fmt.Errorf("chain id higher than allowed, max allowed is %v", uint64(math.MaxUint64))
 	}
 
+	// The fee cap is checked against the balance as part of the tx cost, but not
+	// the tip, so both are limited here the same way it's done by geth
+	if tx.Type() == types.DynamicFeeTxType {
+		if tx.GasFeeCap().BitLen() > maxUint256BitLen {
+			return ErrFeeCapVeryHigh
+		}
+		if tx.GasTipCap().BitLen() > maxUint256BitLen {
+			return ErrTipVeryHigh
+		}
+		if tx.GasFeeCap().Cmp(tx.GasTipCap()) < 0 {
+			return ErrTipAboveFeeCap
+		}
+	}
+
 	return nil
 }
 
@@ -659,6 +713,102 @@ func (p *Pool) UpdateTxWIPStatus(ctx context.Context, hash common.Hash, isWIP bo
 	return p.storage.UpdateTxWIPStatus(ctx, hash, isWIP)
 }
 
+// AddBundle validates and adds a bundle of txs to the pool with the pending state,
+// it returns the hash of the bundle. The txs of the bundle are also added to the pool
+// txs linked to the bundle, so they can be queried and their status tracked as any other tx
+func (p *Pool) AddBundle(ctx context.Context, txs []types.Transaction, blockNumber uint64, maxTimestamp uint64, ip string) (common.Hash, error) {
+	if p.cfg.MaxBundleTxs == 0 {
+		return common.Hash{}, ErrBundlesDisabled
+	}
+	if len(txs) == 0 {
+		return common.Hash{}, ErrEmptyBundle
+	}
+	// the txs of a bundle are included in the same L2 block, so they must fit in a single batch
+	if uint64(len(txs)) > p.cfg.MaxBundleTxs ||
+		(p.batchConstraintsCfg.MaxTxsPerBatch != 0 && uint64(len(txs)) > p.batchConstraintsCfg.MaxTxsPerBatch) {
+		return common.Hash{}, ErrBundleTooLarge
+	}
+	if maxTimestamp != 0 && maxTimestamp < uint64(time.Now().Unix()) {
+		return common.Hash{}, ErrBundleExpired
+	}
+
+	if blockNumber != 0 {
+		lastL2Block, err := p.state.GetLastL2Block(ctx, nil)
+		if err != nil {
+			log.Errorf("failed to load last l2 block while adding bundle to the pool, error: %v", err)
+			return common.Hash{}, err
+		}
+		if blockNumber <= lastL2Block.NumberU64() {
+			return common.Hash{}, ErrBundleExpired
+		}
+	}
+
+	hashes := make(map[common.Hash]struct{}, len(txs))
+	for i, tx := range txs {
+		if _, found := hashes[tx.Hash()]; found {
+			return common.Hash{}, ErrBundleDuplicatedTx
+		}
+		hashes[tx.Hash()] = struct{}{}
+
+		if err := p.validateTx(ctx, *NewTransaction(tx, ip, false)); err != nil {
+			return common.Hash{}, fmt.Errorf("invalid bundle tx %d %s: %w", i, tx.Hash().String(), err)
+		}
+	}
+
+	// the senders of the bundles that fail are penalized, so they can't keep the sequencer busy
+	// executing bundles that are not included
+	if p.cfg.FailedBundlePenalty.Duration > 0 {
+		senders := make([]common.Address, 0, len(txs))
+		for _, tx := range txs {
+			sender, err := state.GetSender(tx)
+			if err != nil {
+				return common.Hash{}, ErrInvalidSender
+			}
+			senders = append(senders, sender)
+		}
+		penalized, err := p.storage.HasFailedBundlesSince(ctx, senders, ip, time.Now().Add(-p.cfg.FailedBundlePenalty.Duration))
+		if err != nil {
+			log.Errorf("failed to check the failed bundles of the senders while adding bundle to the pool, error: %v", err)
+			return common.Hash{}, err
+		}
+		if penalized {
+			return common.Hash{}, ErrBundleSenderPenalized
+		}
+	}
+
+	bundle := NewBundle(txs, blockNumber, maxTimestamp, ip)
+	if err := p.storage.AddBundle(ctx, *bundle); err != nil {
+		return common.Hash{}, err
+	}
+
+	return bundle.Hash, nil
+}
+
+// GetPendingBundles returns the pending bundles sorted by the time they were received
+func (p *Pool) GetPendingBundles(ctx context.Context) ([]Bundle, error) {
+	return p.storage.GetBundlesByStatus(ctx, BundleStatusPending)
+}
+
+// UpdateBundleStatus updates the status of a bundle
+func (p *Pool) UpdateBundleStatus(ctx context.Context, hash common.Hash, newStatus BundleStatus, failedReason *string) error {
+	return p.storage.UpdateBundleStatus(ctx, hash, newStatus, failedReason)
+}
+
+// MarkWIPBundlesAsPending updates the status of the bundles loaded by the sequencer but not processed yet to pending
+func (p *Pool) MarkWIPBundlesAsPending(ctx context.Context) error {
+	return p.storage.MarkWIPBundlesAsPending(ctx)
+}
+
+// AddPreconfirmation adds the preconfirmation signed by the sequencer for a tx
+func (p *Pool) AddPreconfirmation(ctx context.Context, preconfirmation Preconfirmation) error {
+	return p.storage.AddPreconfirmation(ctx, preconfirmation)
+}
+
+// GetPreconfirmation returns the preconfirmation signed by the sequencer for the tx with the given hash
+func (p *Pool) GetPreconfirmation(ctx context.Context, txHash common.Hash) (*Preconfirmation, error) {
+	return p.storage.GetPreconfirmationByTxHash(ctx, txHash)
+}
+
 // GetDefaultMinGasPriceAllowed return the configured DefaultMinGasPriceAllowed value
 func (p *Pool) GetDefaultMinGasPriceAllowed() uint64 {
 	return p.cfg.DefaultMinGasPriceAllowed
@@ -674,10 +824,13 @@ func (p *Pool) GetL1AndL2GasPrice() (uint64, uint64) {
 }
 
 const (
-	txDataNonZeroGas      uint64 = 16
-	txGasContractCreation uint64 = 53000
-	txGas                 uint64 = 21000
-	txDataZeroGas         uint64 = 4
+	txDataNonZeroGas          uint64 = 16
+	txGasContractCreation     uint64 = 53000
+	txGas                     uint64 = 21000
+	txDataZeroGas             uint64 = 4
+	txAccessListAddressGas    uint64 = 2400
+	txAccessListStorageKeyGas uint64 = 1900
+	maxUint256BitLen                 = 256
 )
 
 // CalculateEffectiveGasPrice calculates the final effective gas price for a tx
@@ -727,5 +880,11 @@ func IntrinsicGas(tx types.Transaction) (uint64, error) {
 		}
 		gas += z * txDataZeroGas
 	}
+	// The addresses and storage keys of the access list are paid upfront
+	accessList := tx.AccessList()
+	if len(accessList) > 0 {
+		gas += uint64(len(accessList)) * txAccessListAddressGas
+		gas += uint64(accessList.StorageKeys()) * txAccessListStorageKeyGas
+	}
 	return gas, nil
 }
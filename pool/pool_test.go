@@ -744,6 +744,123 @@ func Test_UpdateTxStatus(t *testing.T) {
 	assert.Equal(t, expectedFailedReason, failedReason)
 }
 
+func Test_AddBundle(t *testing.T) {
+	ctx := context.Background()
+
+	initOrResetDB(t)
+
+	stateSqlDB, err := db.NewSQLDB(stateDBCfg)
+	require.NoError(t, err)
+	defer stateSqlDB.Close() //nolint:gosec,errcheck
+
+	poolSqlDB, err := db.NewSQLDB(poolDBCfg)
+	require.NoError(t, err)
+	defer poolSqlDB.Close() //nolint:gosec,errcheck
+
+	eventStorage, err := nileventstorage.NewNilEventStorage()
+	if err != nil {
+		log.Fatal(err)
+	}
+	eventLog := event.NewEventLog(event.Config{}, eventStorage)
+
+	st := newState(stateSqlDB, eventLog)
+
+	genesisBlock := state.Block{
+		BlockNumber: 0,
+		BlockHash:   state.ZeroHash,
+		ParentHash:  state.ZeroHash,
+		ReceivedAt:  time.Now(),
+	}
+	dbTx, err := st.BeginStateTransaction(ctx)
+	require.NoError(t, err)
+	_, err = st.SetGenesis(ctx, genesisBlock, genesis, metrics.SynchronizerCallerLabel, dbTx)
+	require.NoError(t, err)
+	require.NoError(t, dbTx.Commit(ctx))
+
+	s, err := pgpoolstorage.NewPostgresPoolStorage(poolDBCfg)
+	require.NoError(t, err)
+	poolCfg := cfg
+	poolCfg.MaxBundleTxs = 3
+	poolCfg.FailedBundlePenalty = cfgTypes.NewDuration(time.Minute)
+	batchCfg := bc
+	batchCfg.MaxTxsPerBatch = 2
+	p := setupPool(t, poolCfg, batchCfg, s, st, chainID.Uint64(), ctx, eventLog)
+
+	privateKey, err := crypto.HexToECDSA(strings.TrimPrefix(senderPrivateKey, "0x"))
+	require.NoError(t, err)
+	auth, err := bind.NewKeyedTransactorWithChainID(privateKey, chainID)
+	require.NoError(t, err)
+
+	txs := make([]ethTypes.Transaction, 0, 3)
+	for nonce := uint64(0); nonce < 3; nonce++ {
+		tx := ethTypes.NewTransaction(nonce, common.Address{}, big.NewInt(10), gasLimit, gasPrice, []byte{})
+		signedTx, err := auth.Signer(auth.From, tx)
+		require.NoError(t, err)
+		txs = append(txs, *signedTx)
+	}
+
+	// the txs of a bundle must fit in a single batch
+	_, err = p.AddBundle(ctx, txs, 0, 0, ip)
+	require.ErrorIs(t, err, pool.ErrBundleTooLarge)
+
+	bundleHash, err := p.AddBundle(ctx, txs[:2], 0, 0, ip)
+	require.NoError(t, err)
+
+	// the txs of the bundle are known by the pool
+	for _, tx := range txs[:2] {
+		poolTx, err := p.GetTransactionByHash(ctx, tx.Hash())
+		require.NoError(t, err)
+		assert.Equal(t, pool.TxStatusPending, poolTx.Status)
+	}
+	nonce, err := p.GetNonce(ctx, auth.From)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), nonce)
+	err = p.AddTx(ctx, txs[0], ip)
+	assert.ErrorIs(t, err, pool.ErrAlreadyKnown)
+
+	// but they are only sequenced with their bundle
+	pendingTxs, err := p.GetNonWIPPendingTxs(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, pendingTxs)
+
+	// and they are not published before their bundle is included
+	receivedTxs, err := s.GetTxsReceivedSince(ctx, time.Time{})
+	require.NoError(t, err)
+	assert.Empty(t, receivedTxs)
+	content, err := p.GetContentFrom(ctx, auth.From)
+	require.NoError(t, err)
+	assert.Empty(t, content.Pending)
+	assert.Empty(t, content.Queued)
+	status, err := p.GetStatus(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), status.Pending)
+	assert.Equal(t, uint64(0), status.Queued)
+
+	// storing again a tx of the bundle without bundle keeps the link to the bundle
+	require.NoError(t, s.AddTx(ctx, *pool.NewTransaction(txs[0], ip, false)))
+	var txBundleHash string
+	err = poolSqlDB.QueryRow(ctx, "SELECT bundle_hash FROM pool.transaction WHERE hash = $1", txs[0].Hash().Hex()).Scan(&txBundleHash)
+	require.NoError(t, err)
+	assert.Equal(t, bundleHash.Hex(), txBundleHash)
+
+	// the txs of a discarded bundle are set as failed
+	failedReason := "out of counters"
+	require.NoError(t, p.UpdateBundleStatus(ctx, bundleHash, pool.BundleStatusFailed, &failedReason))
+	for _, tx := range txs[:2] {
+		var status, txFailedReason string
+		err := poolSqlDB.QueryRow(ctx, "SELECT status, failed_reason FROM pool.transaction WHERE hash = $1", tx.Hash().Hex()).Scan(&status, &txFailedReason)
+		require.NoError(t, err)
+		assert.Equal(t, pool.TxStatusFailed, pool.TxStatus(status))
+		assert.Equal(t, failedReason, txFailedReason)
+	}
+
+	// the IP and the senders of a failed bundle are penalized
+	_, err = p.AddBundle(ctx, txs[2:], 0, 0, ip)
+	assert.ErrorIs(t, err, pool.ErrBundleSenderPenalized)
+	_, err = p.AddBundle(ctx, txs[2:], 0, 0, "")
+	assert.ErrorIs(t, err, pool.ErrBundleSenderPenalized)
+}
+
 func Test_SetAndGetGasPrice(t *testing.T) {
 	initOrResetDB(t)
 
@@ -1035,6 +1152,21 @@ func Test_TryAddIncompatibleTxs(t *testing.T) {
 	bigIntOver256Bits, _ := big.NewInt(0).SetString(encoding.MaxUint256StrNumber, encoding.Base10)
 	bigIntOver256Bits = bigIntOver256Bits.Add(bigIntOver256Bits, big.NewInt(2))
 
+	to := common.HexToAddress("0x1")
+	signDynamicFeeTx := func(gasTipCap, gasFeeCap *big.Int) ethTypes.Transaction {
+		l2ChainID := big.NewInt(0).SetUint64(operations.DefaultL2ChainID)
+		signedTx, err := ethTypes.SignNewTx(privateKey, ethTypes.NewLondonSigner(l2ChainID), &ethTypes.DynamicFeeTx{
+			ChainID:   l2ChainID,
+			GasTipCap: gasTipCap,
+			GasFeeCap: gasFeeCap,
+			Gas:       gasLimit,
+			To:        &to,
+			Value:     big.NewInt(1),
+		})
+		require.NoError(t, err)
+		return *signedTx
+	}
+
 	testCases := []testCase{
 		{
 			name: "Gas price over 256 bits",
@@ -1085,6 +1217,13 @@ func Test_TryAddIncompatibleTxs(t *testing.T) {
 			},
 			expectedError: fmt.Errorf("chain id higher than allowed, max allowed is %v", uint64(math.MaxUint64)),
 		},
+		{
+			name: "dynamic fee tx not supported by the batches",
+			createIncompatibleTx: func() ethTypes.Transaction {
+				return signDynamicFeeTx(gasPrice, gasPrice)
+			},
+			expectedError: pool.ErrTxTypeNotSupported,
+		},
 	}
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
@@ -1538,6 +1677,38 @@ func Test_BlockedAddress(t *testing.T) {
 	// allowed to add tx again
 	err = p.AddTx(ctx, *signedTx, ip)
 	require.NoError(t, err)
+
+	// block address through the pool, no need to wait the refresh
+	err = p.BlockAddress(ctx, auth.From, "test")
+	require.NoError(t, err)
+
+	blockedAddresses, err := p.GetBlockedAddresses(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []common.Address{auth.From}, blockedAddresses)
+
+	tx = ethTypes.NewTx(&ethTypes.LegacyTx{
+		Nonce:    2,
+		GasPrice: big.NewInt(0).SetInt64(int64(gasPrices.L2GasPrice)),
+		Gas:      24000,
+		To:       &auth.From,
+		Value:    big.NewInt(1000),
+	})
+	signedTx, err = auth.Signer(auth.From, tx)
+	require.NoError(t, err)
+
+	err = p.AddTx(ctx, *signedTx, ip)
+	require.Equal(t, pool.ErrBlockedSender, err)
+
+	// unblock address through the pool
+	err = p.UnblockAddress(ctx, auth.From)
+	require.NoError(t, err)
+
+	blockedAddresses, err = p.GetBlockedAddresses(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, blockedAddresses)
+
+	err = p.AddTx(ctx, *signedTx, ip)
+	require.NoError(t, err)
 }
 
 /*
@@ -0,0 +1,54 @@
+package pool
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Preconfirmation is a soft confirmation signed by the sequencer when a tx has been executed successfully
+// in the WIP L2 block, committing to include the tx in the given L2 block and position
+type Preconfirmation struct {
+	ID            uint64
+	TxHash        common.Hash
+	L2BlockNumber uint64
+	Position      uint64      // Index of the tx in the L2 block
+	StateRoot     common.Hash // Intermediate state root after the execution of the tx
+	Signature     []byte
+	CreatedAt     time.Time
+}
+
+// PreconfirmationDomain is the fixed prefix of the data hashed for the preconfirmations. As in EIP-191 it
+// starts with 0x19, so the signed data can't be a valid RLP encoded tx, and it's followed by a string that
+// separates the preconfirmations from any other data signed by the sequencer key
+const PreconfirmationDomain = "\x19zkEVM Preconfirmation:\n"
+
+// PreconfirmationHash returns the hash signed by the sequencer for a preconfirmation, computed as
+// keccak256(abi.encodePacked(PreconfirmationDomain, uint64 chainID, bytes32 txHash, uint64 l2BlockNumber, uint64 position, bytes32 stateRoot)).
+// The chain ID binds the preconfirmation to the network, so it can't be replayed in other networks sequenced with the same key
+func PreconfirmationHash(chainID uint64, txHash common.Hash, l2BlockNumber uint64, position uint64, stateRoot common.Hash) common.Hash {
+	data := make([]byte, 0, len(PreconfirmationDomain)+common.HashLength*2+24) //nolint:gomnd
+	data = append(data, PreconfirmationDomain...)
+	data = binary.BigEndian.AppendUint64(data, chainID)
+	data = append(data, txHash.Bytes()...)
+	data = binary.BigEndian.AppendUint64(data, l2BlockNumber)
+	data = binary.BigEndian.AppendUint64(data, position)
+	data = append(data, stateRoot.Bytes()...)
+	return crypto.Keccak256Hash(data)
+}
+
+// Hash returns the hash signed by the sequencer for the preconfirmation in the network with the given chain ID
+func (p Preconfirmation) Hash(chainID uint64) common.Hash {
+	return PreconfirmationHash(chainID, p.TxHash, p.L2BlockNumber, p.Position, p.StateRoot)
+}
+
+// Signer recovers the address of the account that signed the preconfirmation in the network with the given chain ID
+func (p Preconfirmation) Signer(chainID uint64) (common.Address, error) {
+	pubKey, err := crypto.SigToPub(p.Hash(chainID).Bytes(), p.Signature)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return crypto.PubkeyToAddress(*pubKey), nil
+}
@@ -0,0 +1,34 @@
+package pool
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreconfirmationHash(t *testing.T) {
+	txHash := common.HexToHash("0x123")
+	stateRoot := common.HexToHash("0x456")
+
+	// keccak256("\x19zkEVM Preconfirmation:\n" ‖ uint64 chainID ‖ txHash ‖ uint64 l2BlockNumber ‖ uint64 position ‖ stateRoot)
+	preimage := hexutil.MustDecode("0x19" + common.Bytes2Hex([]byte("zkEVM Preconfirmation:\n")) +
+		"00000000000003e8" + common.Bytes2Hex(txHash.Bytes()) + "000000000000000a" + "0000000000000002" + common.Bytes2Hex(stateRoot.Bytes()))
+	assert.Equal(t, crypto.Keccak256Hash(preimage), PreconfirmationHash(1000, txHash, 10, 2, stateRoot))
+
+	// the hash depends on the chain ID, so the signature of a network is not valid in another one
+	assert.NotEqual(t, PreconfirmationHash(1000, txHash, 10, 2, stateRoot), PreconfirmationHash(1001, txHash, 10, 2, stateRoot))
+
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	preconfirmation := Preconfirmation{TxHash: txHash, L2BlockNumber: 10, Position: 2, StateRoot: stateRoot}
+	preconfirmation.Signature, err = crypto.Sign(preconfirmation.Hash(1000).Bytes(), privateKey)
+	require.NoError(t, err)
+
+	signer, err := preconfirmation.Signer(1000)
+	require.NoError(t, err)
+	assert.Equal(t, crypto.PubkeyToAddress(privateKey.PublicKey), signer)
+}
@@ -0,0 +1,111 @@
+package sequencer
+
+import (
+	"context"
+	"errors"
+
+	"github.com/0xPolygonHermez/zkevm-data-streamer/datastreamer"
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+)
+
+const (
+	// FinalizerStatusNotStarted means the finalizer is waiting for the synchronizer to be synced
+	FinalizerStatusNotStarted = "not started"
+	// FinalizerStatusRunning means the finalizer is processing txs
+	FinalizerStatusRunning = "running"
+	// FinalizerStatusPausing means the finalizer has been requested to pause and it's waiting
+	// for the pending L2 blocks to be processed and stored
+	FinalizerStatusPausing = "pausing"
+	// FinalizerStatusPaused means the finalizer has been paused by the operator
+	FinalizerStatusPaused = "paused"
+	// FinalizerStatusHalted means the finalizer has been halted due to an error
+	FinalizerStatusHalted = "halted"
+)
+
+// dataStreamRegenerationRequest is sent through the data stream channel to regenerate the
+// data stream in the same goroutine that writes to it, after the previous data is streamed
+type dataStreamRegenerationRequest struct {
+	ctx    context.Context
+	result chan error
+}
+
+// HaltFinalizer requests the finalizer to stop processing txs until it's resumed, unlike
+// the halts due to errors, the sequencer keeps running and the wip batch is kept open
+func (s *Sequencer) HaltFinalizer() error {
+	f := s.finalizer.Load()
+	if f == nil {
+		return ErrFinalizerNotStarted
+	}
+	return f.pause()
+}
+
+// ResumeFinalizer resumes the processing of txs after a HaltFinalizer
+func (s *Sequencer) ResumeFinalizer() error {
+	f := s.finalizer.Load()
+	if f == nil {
+		return ErrFinalizerNotStarted
+	}
+	return f.resume()
+}
+
+// FinalizerStatus returns the status of the finalizer
+func (s *Sequencer) FinalizerStatus() string {
+	f := s.finalizer.Load()
+	if f == nil {
+		return FinalizerStatusNotStarted
+	}
+	return f.status()
+}
+
+// RegenerateDataStream adds to the data stream the L2 blocks found in the state that are
+// missing in the stream file, as it's done when the sequencer starts. The finalizer must
+// be halted by HaltFinalizer to avoid adding the L2 blocks being stored at the same time
+func (s *Sequencer) RegenerateDataStream(ctx context.Context) error {
+	if !s.cfg.StreamServer.Enabled {
+		return ErrStreamServerDisabled
+	}
+	if s.FinalizerStatus() != FinalizerStatusPaused {
+		return ErrFinalizerNotPaused
+	}
+
+	request := dataStreamRegenerationRequest{
+		ctx:    ctx,
+		result: make(chan error, 1),
+	}
+	select {
+	case s.dataToStream <- request:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	select {
+	case err := <-request.result:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// regenerateDataStream handles a dataStreamRegenerationRequest
+func (s *Sequencer) regenerateDataStream(request dataStreamRegenerationRequest, chainID uint64) {
+	if s.streamServer == nil {
+		request.result <- ErrStreamServerDisabled
+		return
+	}
+
+	log.Infof("regenerating data stream")
+	err := state.GenerateDataStreamerFile(request.ctx, s.streamServer, s.stateIntf, true, nil, chainID, s.cfg.StreamServer.UpgradeEtrogBatchNumber)
+	if err != nil {
+		log.Errorf("failed to regenerate data stream, error: %v", err)
+		// discard the entries of the atomic op that failed, if any
+		rollbackErr := s.streamServer.RollbackAtomicOp()
+		if rollbackErr != nil && !errors.Is(rollbackErr, datastreamer.ErrRollbackNotAllowed) {
+			log.Errorf("failed to rollback atomic op, error: %v", rollbackErr)
+			s.streamServer = nil
+		}
+	} else {
+		log.Infof("data stream regenerated")
+	}
+	request.result <- err
+}
@@ -228,6 +228,10 @@ func (f *finalizer) closeAndOpenNewWIPBatch(ctx context.Context, closeReason sta
 		return fmt.Errorf("failed to open new wip batch, error: %v", err)
 	}
 
+	if f.cfg.Throttle.Enabled {
+		f.workerIntf.ResetBatchThrottleUsage()
+	}
+
 	if f.wipL2Block != nil {
 		f.wipBatch.imStateRoot = f.wipL2Block.imStateRoot
 		// Subtract the WIP L2 block used resources to batch
@@ -260,6 +264,14 @@ func (f *finalizer) openNewWIPBatch(ctx context.Context, batchNumber uint64, sta
 		return nil, fmt.Errorf("failed to begin state transaction to open batch, error: %v", err)
 	}
 
+	err = f.checkLeadership(ctx, dbTx)
+	if err != nil {
+		if rollbackErr := dbTx.Rollback(ctx); rollbackErr != nil {
+			return nil, fmt.Errorf("failed to rollback due to error when checking leadership, rollback error: %v, error: %v", rollbackErr, err)
+		}
+		return nil, fmt.Errorf("leadership check failed on opening new wip batch, error: %w", err)
+	}
+
 	// OpenBatch opens a new wip batch in the state
 	err = f.stateIntf.OpenWIPBatch(ctx, newStateBatch, dbTx)
 	if err != nil {
@@ -316,7 +328,10 @@ func (f *finalizer) closeWIPBatch(ctx context.Context) error {
 		return err
 	}
 
-	err = f.stateIntf.CloseWIPBatch(ctx, receipt, dbTx)
+	err = f.checkLeadership(ctx, dbTx)
+	if err == nil {
+		err = f.stateIntf.CloseWIPBatch(ctx, receipt, dbTx)
+	}
 	if err != nil {
 		rollbackErr := dbTx.Rollback(ctx)
 		if rollbackErr != nil {
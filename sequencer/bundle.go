@@ -0,0 +1,222 @@
+package sequencer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/event"
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	stateMetrics "github.com/0xPolygonHermez/zkevm-node/state/metrics"
+	"github.com/0xPolygonHermez/zkevm-node/state/runtime"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// getBestFittingBundle gets from the worker the bundle to process in the wip L2 block (if any), setting as expired in the pool
+// the bundles that can't be included anymore
+func (f *finalizer) getBestFittingBundle(ctx context.Context) (*BundleTracker, error) {
+	remainingTxs := uint64(math.MaxUint64)
+	if f.batchConstraints.MaxTxsPerBatch != 0 {
+		remainingTxs = 0
+		if uint64(f.wipBatch.countOfTxs) < f.batchConstraints.MaxTxsPerBatch {
+			remainingTxs = f.batchConstraints.MaxTxsPerBatch - uint64(f.wipBatch.countOfTxs)
+		}
+	}
+
+	bundle, expiredBundles, err := f.workerIntf.GetBestFittingBundle(f.wipBatch.imRemainingResources, remainingTxs, f.wipL2Block.blockNumber, f.wipL2Block.timestamp)
+
+	for _, expiredBundle := range expiredBundles {
+		errUpdate := f.poolIntf.UpdateBundleStatus(ctx, expiredBundle.Hash, pool.BundleStatusExpired, nil)
+		if errUpdate != nil {
+			log.Errorf("failed to update status to expired in the pool for bundle %s, error: %v", expiredBundle.HashStr, errUpdate)
+		}
+	}
+
+	return bundle, err
+}
+
+// isBundleBeforeTx returns true if the bundle must be processed before the tx, that is if the bundle pays an effective gas price
+// higher or equal than the one the tx would pay. The txs of a bundle always pay their full gas price, while the effective gas
+// price of the tx is estimated with the gas used in its pre-execution, as it's done when the tx is processed for the first time
+func (f *finalizer) isBundleBeforeTx(bundle *BundleTracker, tx *TxTracker) bool {
+	txEffectiveGasPrice := tx.GasPrice
+	if f.effectiveGasPrice.IsEnabled() {
+		l1GasPrice, l2GasPrice := f.poolIntf.GetL1AndL2GasPrice()
+		egp, err := f.effectiveGasPrice.CalculateEffectiveGasPrice(tx.RawTx, tx.GasPrice, tx.UsedZKCounters.GasUsed, l1GasPrice, l2GasPrice)
+		if err != nil {
+			log.Warnf("failed to estimate the effective gas price of tx %s to compare it with bundle %s, error: %v", tx.HashStr, bundle.HashStr, err)
+		} else if egp.Cmp(txEffectiveGasPrice) < 0 {
+			txEffectiveGasPrice = egp
+		}
+	}
+
+	return bundle.gasPrice().Cmp(txEffectiveGasPrice) >= 0
+}
+
+// processBundle processes the txs of a bundle in a single execution on top of the wip L2 block. The txs are added consecutively
+// to the wip L2 block only if all of them are executed successfully and fit in the remaining batch resources, otherwise the wip
+// L2 block is left untouched. The txs of a bundle are always sequenced with their full gas price (no effective gas price), as
+// reprocessing a single tx to adjust its effective gas price would break the atomicity of the bundle
+func (f *finalizer) processBundle(ctx context.Context, bundle *BundleTracker) error {
+	start := time.Now()
+
+	log.Infof("processing bundle %s (%d txs), batchNumber: %d, l2Block: %d [%d], oldStateRoot: %s, L1InfoRootIndex: %d",
+		bundle.HashStr, len(bundle.Txs), f.wipBatch.batchNumber, f.wipL2Block.blockNumber, f.wipL2Block.trackingNum, f.wipBatch.imStateRoot,
+		f.wipL2Block.l1InfoTreeExitRoot.L1InfoTreeIndex)
+
+	l1GasPrice, l2GasPrice := f.poolIntf.GetL1AndL2GasPrice()
+
+	batchL2Data := []byte{}
+	for _, tx := range bundle.Txs {
+		tx.L1GasPrice, tx.L2GasPrice = l1GasPrice, l2GasPrice
+		tx.EGPPercentage = state.MaxEffectivePercentage
+		tx.EffectiveGasPrice.Set(tx.GasPrice)
+		tx.IsLastExecution = true
+
+		// Save values for later logging
+		tx.EGPLog.L1GasPrice = l1GasPrice
+		tx.EGPLog.L2GasPrice = l2GasPrice
+		tx.EGPLog.GasPrice.Set(tx.GasPrice)
+		tx.EGPLog.ValueFinal.Set(tx.EffectiveGasPrice)
+		tx.EGPLog.Percentage = tx.EGPPercentage
+
+		batchL2Data = append(batchL2Data, tx.RawTx...)
+		batchL2Data = append(batchL2Data, tx.EGPPercentage)
+	}
+
+	batchRequest := state.ProcessRequest{
+		BatchNumber:               f.wipBatch.batchNumber,
+		OldStateRoot:              f.wipBatch.imStateRoot,
+		Coinbase:                  f.wipBatch.coinbase,
+		L1InfoRoot_V2:             state.GetMockL1InfoRoot(),
+		TimestampLimit_V2:         f.wipL2Block.timestamp,
+		Caller:                    stateMetrics.DiscardCallerLabel,
+		ForkID:                    f.stateIntf.GetForkIDByBatchNumber(f.wipBatch.batchNumber),
+		Transactions:              batchL2Data,
+		SkipFirstChangeL2Block_V2: true,
+		SkipWriteBlockInfoRoot_V2: true,
+		SkipVerifyL1InfoRoot_V2:   true,
+		L1InfoTreeData_V2:         map[uint32]state.L1DataV2{},
+	}
+
+	executionStart := time.Now()
+	batchResponse, err := f.stateIntf.ProcessBatchV2(ctx, batchRequest, false)
+	executionTime := time.Since(executionStart)
+	f.wipL2Block.metrics.transactionsTimes.executor += executionTime
+
+	if err != nil && (errors.Is(err, runtime.ErrExecutorDBError) || errors.Is(err, runtime.ErrInvalidTxChangeL2BlockMinTimestamp)) {
+		// The bundle is kept in the worker to be processed again
+		log.Errorf("failed to process bundle %s, error: %v", bundle.HashStr, err)
+		return err
+	} else if err != nil {
+		log.Errorf("error received from executor processing bundle %s, error: %v", bundle.HashStr, err)
+		return f.discardBundle(ctx, bundle, err.Error())
+	} else if batchResponse.IsRomOOCError {
+		return f.discardBundle(ctx, bundle, "out of counters")
+	} else if len(batchResponse.BlockResponses) == 0 {
+		err = fmt.Errorf("executor returned no errors and no responses for bundle %s", bundle.HashStr)
+		f.Halt(ctx, err, false)
+	}
+
+	txResponses := batchResponse.BlockResponses[0].TransactionResponses
+	if len(txResponses) != len(bundle.Txs) {
+		return f.discardBundle(ctx, bundle, fmt.Sprintf("executor returned %d tx responses for %d txs", len(txResponses), len(bundle.Txs)))
+	}
+	for i, txResponse := range txResponses {
+		if txResponse.RomError != nil {
+			return f.discardBundle(ctx, bundle, fmt.Sprintf("tx %d %s failed, error: %v", i, bundle.Txs[i].HashStr, txResponse.RomError))
+		}
+	}
+
+	// Check if reserved resources of the bundle fits in the remaining batch resources
+	subOverflow := false
+	fits, overflowResource := f.wipBatch.imRemainingResources.Fits(state.BatchResources{ZKCounters: batchResponse.ReservedZkCounters, Bytes: bundle.Bytes})
+	if fits {
+		// Subtract the used resources from the batch
+		subOverflow, overflowResource = f.wipBatch.imRemainingResources.Sub(state.BatchResources{ZKCounters: batchResponse.UsedZkCounters, Bytes: bundle.Bytes})
+		if subOverflow { // Sanity check, this cannot happen as reservedZKCounters should be >= that usedZKCounters
+			sLog := fmt.Sprintf("bundle %s used resources exceeds the remaining batch resources, overflow resource: %s, updating metadata for bundle in worker and continuing. Batch counters: %s, bundle used counters: %s",
+				bundle.HashStr, overflowResource, f.logZKCounters(f.wipBatch.imRemainingResources.ZKCounters), f.logZKCounters(batchResponse.UsedZkCounters))
+
+			log.Errorf(sLog)
+
+			f.LogEvent(ctx, event.Level_Error, event.EventID_UsedZKCountersOverflow, sLog, nil)
+		}
+	} else {
+		log.Infof("bundle %s reserved resources exceeds the remaining batch resources, overflow resource: %s, updating metadata for bundle in worker and continuing. Batch counters: %s, bundle reserved counters: %s",
+			bundle.HashStr, overflowResource, f.logZKCounters(f.wipBatch.imRemainingResources.ZKCounters), f.logZKCounters(batchResponse.ReservedZkCounters))
+		if !f.batchConstraints.IsWithinConstraints(batchResponse.ReservedZkCounters) {
+			log.Infof("bundle %s reserved resources exceeds the max limit for batch resources (node OOC), discarding bundle", bundle.HashStr)
+			return f.discardBundle(ctx, bundle, "node OOC")
+		}
+	}
+
+	// If reserved bundle resources don't fit in the remaining batch resources (or we got an overflow when trying to subtract the used resources)
+	// we update the ZKCounters of the bundle and returns ErrBatchResourceOverFlow error
+	if !fits || subOverflow {
+		f.workerIntf.UpdateBundleZKCounters(bundle.Hash, batchResponse.ReservedZkCounters)
+		return ErrBatchResourceOverFlow
+	}
+
+	// All the txs of the bundle have been executed successfully, we add them to the wip L2 block
+	f.wipL2Block.addBundle(bundle)
+	f.wipBatch.countOfTxs += len(bundle.Txs)
+	f.wipBatch.imStateRoot = batchResponse.NewStateRoot
+
+	if f.preconfirmer != nil {
+		firstPosition := uint64(len(f.wipL2Block.transactions) - len(bundle.Txs))
+		for i, tx := range bundle.Txs {
+			f.preconfirmer.preconfirm(tx.Hash, f.wipL2Block.blockNumber, firstPosition+uint64(i), txResponses[i].StateRoot)
+		}
+	}
+
+	f.workerIntf.DeleteBundle(bundle.Hash)
+
+	if f.cfg.Throttle.Enabled {
+		f.workerIntf.AddBundleThrottleUsage(bundle, batchResponse.UsedZkCounters)
+	}
+
+	// Update the worker with the new nonces and balances of the senders of the bundle
+	senders := make(map[common.Address]struct{})
+	for i, tx := range bundle.Txs {
+		f.wipL2Block.metrics.processedTxsCount++
+		f.wipL2Block.metrics.gas += txResponses[i].GasUsed
+
+		if _, found := senders[tx.From]; found {
+			continue
+		}
+		senders[tx.From] = struct{}{}
+
+		txsToDelete := f.workerIntf.UpdateAfterSingleSuccessfulTxExecution(tx.From, batchResponse.ReadWriteAddresses)
+		for _, txToDelete := range txsToDelete {
+			err := f.poolIntf.UpdateTxStatus(ctx, txToDelete.Hash, pool.TxStatusFailed, false, txToDelete.FailedReason)
+			if err != nil {
+				log.Errorf("failed to update status to failed in the pool for tx %s, error: %v", txToDelete.Hash.String(), err)
+			}
+		}
+	}
+
+	log.Infof("processed bundle %s (%d txs), batchNumber: %d, l2Block: %d [%d], newStateRoot: %s, oldStateRoot: %s, time: {process: %v, executor: %v}, used counters: %s, reserved counters: %s",
+		bundle.HashStr, len(bundle.Txs), batchRequest.BatchNumber, f.wipL2Block.blockNumber, f.wipL2Block.trackingNum, batchResponse.NewStateRoot.String(),
+		batchRequest.OldStateRoot.String(), time.Since(start), executionTime, f.logZKCounters(batchResponse.UsedZkCounters), f.logZKCounters(batchResponse.ReservedZkCounters))
+
+	return nil
+}
+
+// discardBundle deletes the bundle from the worker and sets it as failed in the pool. The wip L2 block is not modified
+func (f *finalizer) discardBundle(ctx context.Context, bundle *BundleTracker, failedReason string) error {
+	log.Infof("discarding bundle %s, reason: %s", bundle.HashStr, failedReason)
+
+	f.workerIntf.DeleteBundle(bundle.Hash)
+
+	err := f.poolIntf.UpdateBundleStatus(ctx, bundle.Hash, pool.BundleStatusFailed, &failedReason)
+	if err != nil {
+		log.Errorf("failed to update status to failed in the pool for bundle %s, error: %v", bundle.HashStr, err)
+	}
+
+	return fmt.Errorf("%w: %s", ErrBundleFailed, failedReason)
+}
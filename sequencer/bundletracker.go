@@ -0,0 +1,73 @@
+package sequencer
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// BundleTracker is a struct that contains all the bundle data needed to be managed by the worker and the finalizer
+type BundleTracker struct {
+	Hash               common.Hash
+	HashStr            string
+	Txs                []*TxTracker
+	BlockNumber        uint64 // L2 block in which the bundle must be included, 0 means any block
+	MaxTimestamp       uint64 // Max L2 block timestamp in which the bundle can be included, 0 means no expiration
+	Bytes              uint64
+	ReservedZKCounters state.ZKCounters // Reserved counters of the whole bundle, known after its first execution
+	ReceivedAt         time.Time
+	IP                 string
+}
+
+// newBundleTracker creates and inits a BundleTracker
+func newBundleTracker(bundle pool.Bundle) (*BundleTracker, error) {
+	bundleTracker := &BundleTracker{
+		Hash:         bundle.Hash,
+		HashStr:      bundle.Hash.String(),
+		Txs:          make([]*TxTracker, 0, len(bundle.Txs)),
+		BlockNumber:  bundle.BlockNumber,
+		MaxTimestamp: bundle.MaxTimestamp,
+		ReceivedAt:   bundle.ReceivedAt,
+		IP:           bundle.IP,
+	}
+
+	for _, tx := range bundle.Txs {
+		txTracker, err := newTxTracker(tx, state.ZKCounters{}, state.ZKCounters{}, bundle.IP)
+		if err != nil {
+			return nil, err
+		}
+		txTracker.PoolReceivedAt = bundle.ReceivedAt
+		bundleTracker.Txs = append(bundleTracker.Txs, txTracker)
+		bundleTracker.Bytes += txTracker.Bytes
+	}
+
+	return bundleTracker, nil
+}
+
+// isExpired returns true if the bundle can't be included anymore in the L2 block with the given number and timestamp
+// or in any of the following L2 blocks
+func (b *BundleTracker) isExpired(blockNumber uint64, timestamp uint64) bool {
+	return (b.BlockNumber != 0 && blockNumber > b.BlockNumber) || (b.MaxTimestamp != 0 && timestamp > b.MaxTimestamp)
+}
+
+// gasPrice returns the gas price paid by the bundle, that is the gas weighted average of the gas prices of its txs. The txs
+// of a bundle always pay their full gas price, so it's also the effective gas price of the bundle
+func (b *BundleTracker) gasPrice() *big.Int {
+	fee, gas := new(big.Int), new(big.Int)
+	for _, tx := range b.Txs {
+		fee.Add(fee, new(big.Int).Mul(tx.GasPrice, new(big.Int).SetUint64(tx.Gas)))
+		gas.Add(gas, new(big.Int).SetUint64(tx.Gas))
+	}
+	if gas.Sign() == 0 {
+		return fee
+	}
+	return fee.Div(fee, gas)
+}
+
+// isTargetBlock returns true if the bundle can be included in the L2 block with the given number
+func (b *BundleTracker) isTargetBlock(blockNumber uint64) bool {
+	return b.BlockNumber == 0 || b.BlockNumber == blockNumber
+}
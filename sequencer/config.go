@@ -3,6 +3,7 @@ package sequencer
 import (
 	"github.com/0xPolygonHermez/zkevm-data-streamer/log"
 	"github.com/0xPolygonHermez/zkevm-node/config/types"
+	"github.com/ethereum/go-ethereum/common"
 )
 
 // Config represents the configuration of a sequencer
@@ -30,6 +31,37 @@ type Config struct {
 
 	// StreamServerCfg is the config for the stream server
 	StreamServer StreamServerCfg `mapstructure:"StreamServer"`
+
+	// LeaderElection is the config for the leader election between several sequencer instances
+	LeaderElection LeaderElectionCfg `mapstructure:"LeaderElection"`
+
+	// Preconfirmations is the config for the preconfirmations signed by the sequencer
+	Preconfirmations PreconfirmationsCfg `mapstructure:"Preconfirmations"`
+}
+
+// PreconfirmationsCfg contains the preconfirmations' configuration properties. When it's enabled the sequencer signs
+// a preconfirmation for each tx executed successfully in the WIP L2 block, committing to include it in that L2 block
+type PreconfirmationsCfg struct {
+	// Enabled is a flag to enable/disable the preconfirmations
+	Enabled bool `mapstructure:"Enabled"`
+	// PrivateKey is the key store file of the account used to sign the preconfirmations, it must be the trusted sequencer
+	PrivateKey types.KeystoreFileConfig `mapstructure:"PrivateKey"`
+}
+
+// LeaderElectionCfg contains the leader election's configuration properties. When it's enabled several sequencer
+// instances can run against the same state database, only the one holding the lease sequences and the rest wait
+// in standby to take over when the lease expires
+type LeaderElectionCfg struct {
+	// Enabled is a flag to enable/disable the leader election
+	Enabled bool `mapstructure:"Enabled"`
+	// NodeID identifies this sequencer instance, if empty a random one is generated on startup
+	NodeID string `mapstructure:"NodeID"`
+	// LeaseDuration is the time the leader holds the lease without renewing it. A standby instance takes over
+	// the sequencing once the lease has expired
+	LeaseDuration types.Duration `mapstructure:"LeaseDuration"`
+	// RenewInterval is the time the leader waits to renew the lease and the standby instances wait to try to
+	// acquire it. It must be lower than LeaseDuration
+	RenewInterval types.Duration `mapstructure:"RenewInterval"`
 }
 
 // StreamServerCfg contains the data streamer's configuration properties
@@ -91,10 +123,52 @@ type FinalizerCfg struct {
 	// in the processPendingL2Blocks go func
 	SequentialProcessL2Block bool `mapstructure:"SequentialProcessL2Block"`
 
+	// TxOrderingPolicy is the policy used by the worker to sort the ready txs offered to the finalizer.
+	// Valid values are "gasprice" (default), "fifo", "efficiency" and "roundrobin"
+	TxOrderingPolicy string `mapstructure:"TxOrderingPolicy"`
+
+	// Throttle is the config for the per sender and per destination address sequencing throttles
+	Throttle ThrottleCfg `mapstructure:"Throttle"`
+
 	// Metrics is the config for the sequencer metrics
 	Metrics MetricsCfg `mapstructure:"Metrics"`
 }
 
+// ThrottleCfg contains the sequencing throttles' configuration properties. When it's enabled the worker defers (doesn't offer
+// to the finalizer) the txs of a sender, or sent to a destination address, that has reached its share of the wip batch resources
+// or its max number of txs in the wip L2 block. The deferred txs are kept in the worker and offered again in the next L2 block/batch,
+// the throttles don't close the wip L2 block or batch earlier. Bundles are deferred when some of their addresses has reached
+// its limits, accounting the resources of the whole bundle to each of them
+type ThrottleCfg struct {
+	// Enabled is a flag to enable/disable the sequencing throttles
+	Enabled bool `mapstructure:"Enabled"`
+	// Sender are the default limits applied to each tx sender
+	Sender ThrottleLimitsCfg `mapstructure:"Sender"`
+	// Contract are the default limits applied to each tx destination address
+	Contract ThrottleLimitsCfg `mapstructure:"Contract"`
+	// SenderOverrides are the limits applied to specific senders instead of the default ones
+	SenderOverrides []ThrottleOverrideCfg `mapstructure:"SenderOverrides"`
+	// ContractOverrides are the limits applied to specific destination addresses instead of the default ones
+	ContractOverrides []ThrottleOverrideCfg `mapstructure:"ContractOverrides"`
+}
+
+// ThrottleLimitsCfg contains the sequencing limits of an address
+type ThrottleLimitsCfg struct {
+	// MaxBatchResourcesPct is the max percentage of the batch steps, keccak hashes, poseidon hashes and gas that the
+	// txs of an address can use. 0 means no limit
+	MaxBatchResourcesPct uint32 `mapstructure:"MaxBatchResourcesPct"`
+	// MaxTxsPerL2Block is the max number of txs of an address in a L2 block. 0 means no limit
+	MaxTxsPerL2Block uint64 `mapstructure:"MaxTxsPerL2Block"`
+}
+
+// ThrottleOverrideCfg contains the sequencing limits of a specific address
+type ThrottleOverrideCfg struct {
+	// Address is the address the limits apply to
+	Address common.Address `mapstructure:"Address"`
+	// Limits are the limits applied to the address
+	Limits ThrottleLimitsCfg `mapstructure:"Limits"`
+}
+
 // MetricsCfg contains the sequencer metrics configuration properties
 type MetricsCfg struct {
 	// Interval is the interval of time to calculate sequencer metrics
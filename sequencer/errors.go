@@ -31,6 +31,29 @@ var (
 	ErrNoFittingTransaction = errors.New("no fit transaction")
 	// ErrBatchResourceOverFlow happens when there is a tx that overlows remaining batch resources
 	ErrBatchResourceOverFlow = errors.New("batch resource overflow")
+	// ErrNoFittingBundle happens when there are bundles for the wip L2 block but none of them fits in the remaining batch resources
+	ErrNoFittingBundle = errors.New("no fit bundle")
+	// ErrBundleFailed happens when a tx of a bundle fails and the whole bundle is discarded
+	ErrBundleFailed = errors.New("bundle failed")
 	// ErrTransactionsListEmpty happens when txSortedList is empty
 	ErrTransactionsListEmpty = errors.New("transactions list empty")
+	// ErrFinalizerHalted happens when the finalizer has been halted due to an error and can't be paused or resumed
+	ErrFinalizerHalted = errors.New("finalizer halted due to an error")
+	// ErrFinalizerNotStarted happens when the finalizer is requested to be paused or resumed before it has been started
+	ErrFinalizerNotStarted = errors.New("finalizer not started")
+	// ErrFinalizerNotPaused happens when an operation requiring the finalizer to be paused is requested while it's running
+	ErrFinalizerNotPaused = errors.New("finalizer not paused")
+	// ErrStreamServerDisabled happens when a data stream operation is requested and the stream server is disabled or stopped
+	ErrStreamServerDisabled = errors.New("stream server disabled")
+	// ErrUnknownTxOrderingPolicy happens when the configured tx ordering policy doesn't exist
+	ErrUnknownTxOrderingPolicy = errors.New("unknown tx ordering policy")
+	// ErrInvalidThrottleLimit happens when a configured sequencing throttle limit is out of range
+	ErrInvalidThrottleLimit = errors.New("invalid throttle limit")
+	// ErrTransactionsThrottled happens when there are txs that fit in the batch but all of them are deferred by the sequencing throttles
+	ErrTransactionsThrottled = errors.New("transactions throttled")
+	// ErrTransactionsThrottledInBatch happens when there are txs that fit in the batch but all of them are deferred by the sequencing
+	// throttles because their addresses have reached their share of the batch resources
+	ErrTransactionsThrottledInBatch = errors.New("transactions throttled in batch")
+	// ErrInvalidPreconfirmationsSigner happens when the account configured to sign the preconfirmations is not the trusted sequencer
+	ErrInvalidPreconfirmationsSigner = errors.New("preconfirmations signer is not the trusted sequencer")
 )
@@ -19,11 +19,13 @@ import (
 	"github.com/0xPolygonHermez/zkevm-node/state/runtime"
 	"github.com/0xPolygonHermez/zkevm-node/state/runtime/executor"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/jackc/pgx/v4"
 )
 
 const (
-	pendingL2BlocksBufferSize = 100
-	changeL2BlockSize         = 9 //1 byte (tx type = 0B) + 4 bytes for deltaTimestamp + 4 for l1InfoTreeIndex
+	pendingL2BlocksBufferSize   = 100
+	changeL2BlockSize           = 9 //1 byte (tx type = 0B) + 4 bytes for deltaTimestamp + 4 for l1InfoTreeIndex
+	finalizerPauseCheckInterval = time.Second
 )
 
 var (
@@ -43,6 +45,9 @@ type finalizer struct {
 	wipL2Block       *L2Block
 	batchConstraints state.BatchConstraintsCfg
 	haltFinalizer    atomic.Bool
+	// pause requested by the operator
+	pauseFinalizer  atomic.Bool
+	finalizerPaused atomic.Bool
 	// forced batches
 	nextForcedBatches       []state.ForcedBatch
 	nextForcedBatchDeadline int64
@@ -78,6 +83,10 @@ type finalizer struct {
 	// stream server
 	streamServer *datastreamer.StreamServer
 	dataToStream chan interface{}
+	// leader election, nil if it's disabled
+	leader *leaderElection
+	// preconfirmations signer, nil if it's disabled
+	preconfirmer *preconfirmer
 }
 
 // newFinalizer returns a new instance of Finalizer.
@@ -95,6 +104,8 @@ func newFinalizer(
 	streamServer *datastreamer.StreamServer,
 	workerReadyTxsCond *timeoutCond,
 	dataToStream chan interface{},
+	leader *leaderElection,
+	preconfirmer *preconfirmer,
 ) *finalizer {
 	f := finalizer{
 		cfg:              cfg,
@@ -136,6 +147,10 @@ func newFinalizer(
 		// stream server
 		streamServer: streamServer,
 		dataToStream: dataToStream,
+		// leader election
+		leader: leader,
+		// preconfirmations
+		preconfirmer: preconfirmer,
 	}
 
 	f.haltFinalizer.Store(false)
@@ -276,15 +291,33 @@ func (f *finalizer) finalizeBatches(ctx context.Context) {
 			f.finalizeWIPL2Block(ctx)
 		}
 
+		// Bundles and single txs are processed in order of the effective gas price they pay
 		tx, err := f.workerIntf.GetBestFittingTx(f.wipBatch.imRemainingResources)
+		bundle, bundleErr := f.getBestFittingBundle(ctx)
+		if bundle != nil && tx != nil {
+			if f.isBundleBeforeTx(bundle, tx) {
+				tx = nil
+			} else {
+				bundle = nil
+			}
+		}
 
-		// If we have txs pending to process but none of them fits into the wip batch, we close the wip batch and open a new one
-		if err == ErrNoFittingTransaction {
+		// If we have txs or bundles pending to process but none of them fits into the wip batch, we close the wip batch and open a new one
+		if bundle == nil && (err == ErrNoFittingTransaction || (bundleErr == ErrNoFittingBundle && err == ErrTransactionsListEmpty)) {
 			f.finalizeWIPBatch(ctx, state.NoTxFitsClosingReason)
 			continue
 		}
 
-		if tx != nil {
+		if bundle != nil {
+			showNotFoundTxLog = true
+
+			err := f.processBundle(ctx, bundle)
+			if err == ErrBatchResourceOverFlow {
+				log.Infof("skipping bundle %s due to a batch resource overflow", bundle.HashStr)
+			} else if err != nil {
+				log.Errorf("failed to process bundle %s, error: %v", bundle.HashStr, err)
+			}
+		} else if tx != nil {
 			showNotFoundTxLog = true
 
 			firstTxProcess := true
@@ -307,10 +340,18 @@ func (f *finalizer) finalizeBatches(ctx context.Context) {
 				break
 			}
 		} else {
+			// If all the txs or bundles that fit are deferred by the sequencing throttles we wait as if there were no txs, they are
+			// offered again when the wip L2 block or batch is closed by the L2 block time or the batch closing conditions
 			idleTime := time.Now()
 
 			if showNotFoundTxLog {
-				log.Debug("no transactions to be processed. Waiting...")
+				throttled := err == ErrTransactionsThrottled || err == ErrTransactionsThrottledInBatch ||
+					bundleErr == ErrTransactionsThrottled || bundleErr == ErrTransactionsThrottledInBatch
+				if throttled {
+					log.Debug("all the transactions that fit are throttled. Waiting...")
+				} else {
+					log.Debug("no transactions to be processed. Waiting...")
+				}
 				showNotFoundTxLog = false
 			}
 
@@ -330,6 +371,10 @@ func (f *finalizer) finalizeBatches(ctx context.Context) {
 			}
 		}
 
+		if f.pauseFinalizer.Load() {
+			f.waitWhilePaused(ctx)
+		}
+
 		// Check if we must finalize the batch due to a closing reason (resources exhausted, max txs, timestamp resolution, forced batches deadline)
 		if finalize, closeReason := f.checkIfFinalizeBatch(); finalize {
 			f.finalizeWIPBatch(ctx, closeReason)
@@ -595,6 +640,14 @@ func (f *finalizer) handleProcessTransactionResponse(ctx context.Context, tx *Tx
 
 	f.wipBatch.countOfTxs++
 
+	if f.cfg.Throttle.Enabled {
+		f.workerIntf.AddThrottleUsage(tx, result.UsedZkCounters)
+	}
+
+	if f.preconfirmer != nil {
+		f.preconfirmer.preconfirm(tx.Hash, f.wipL2Block.blockNumber, uint64(len(f.wipL2Block.transactions)-1), txResponse.StateRoot)
+	}
+
 	f.updateWorkerAfterSuccessfulProcessing(ctx, tx.Hash, tx.From, false, result)
 
 	// Update metrics
@@ -766,6 +819,66 @@ func (f *finalizer) Halt(ctx context.Context, err error, isFatal bool) {
 	}
 }
 
+// checkLeadership checks, if the leader election is enabled, that the sequencer is still the leader. It must be called
+// within the db tx used to store data in the state, so a stale leader can't store anything once another node has taken over
+func (f *finalizer) checkLeadership(ctx context.Context, dbTx pgx.Tx) error {
+	if f.leader == nil {
+		return nil
+	}
+	return f.leader.checkLeadership(ctx, dbTx)
+}
+
+// pause requests the finalizer to stop processing new txs, it's paused once the
+// pending L2 blocks have been processed and stored
+func (f *finalizer) pause() error {
+	if f.haltFinalizer.Load() {
+		return ErrFinalizerHalted
+	}
+	f.pauseFinalizer.Store(true)
+	return nil
+}
+
+// resume resumes the processing of txs after a pause
+func (f *finalizer) resume() error {
+	if f.haltFinalizer.Load() {
+		return ErrFinalizerHalted
+	}
+	f.pauseFinalizer.Store(false)
+	return nil
+}
+
+// status returns the status of the finalizer
+func (f *finalizer) status() string {
+	switch {
+	case f.haltFinalizer.Load():
+		return FinalizerStatusHalted
+	case f.finalizerPaused.Load():
+		return FinalizerStatusPaused
+	case f.pauseFinalizer.Load():
+		return FinalizerStatusPausing
+	default:
+		return FinalizerStatusRunning
+	}
+}
+
+// waitWhilePaused waits for the pending L2 blocks to be processed and stored and
+// then blocks until the finalizer is resumed or the context is done
+func (f *finalizer) waitWhilePaused(ctx context.Context) {
+	f.pendingL2BlocksToProcessWG.Wait()
+	f.pendingL2BlocksToStoreWG.Wait()
+
+	f.finalizerPaused.Store(true)
+	f.LogEvent(ctx, event.Level_Warning, event.EventID_FinalizerPause, "finalizer paused by the operator", nil)
+	log.Warnf("finalizer paused, wip batch %d, wip L2 block [%d]", f.wipBatch.batchNumber, f.wipL2Block.trackingNum)
+
+	for f.pauseFinalizer.Load() && ctx.Err() == nil {
+		time.Sleep(finalizerPauseCheckInterval)
+	}
+
+	f.finalizerPaused.Store(false)
+	log.Infof("finalizer resumed")
+}
+
 // LogEvent adds an event for runtime debugging
 func (f *finalizer) LogEvent(ctx context.Context, level event.Level, eventId event.EventID, description string, json interface{}) {
 	event := &event.Event{
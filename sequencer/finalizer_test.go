@@ -3,6 +3,7 @@ package sequencer
 import (
 	"context"
 	"fmt"
+	"math/big"
 	"sync"
 	"testing"
 	"time"
@@ -13,7 +14,9 @@ import (
 	"github.com/0xPolygonHermez/zkevm-node/hex"
 	"github.com/0xPolygonHermez/zkevm-node/pool"
 	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/0xPolygonHermez/zkevm-node/state/runtime"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -117,7 +120,7 @@ func TestNewFinalizer(t *testing.T) {
 	poolMock.On("GetLastSentFlushID", context.Background()).Return(uint64(0), nil)
 
 	// arrange and act
-	f = newFinalizer(cfg, poolCfg, workerMock, poolMock, stateMock, ethermanMock, seqAddr, isSynced, bc, eventLog, nil, newTimeoutCond(&sync.Mutex{}), nil)
+	f = newFinalizer(cfg, poolCfg, workerMock, poolMock, stateMock, ethermanMock, seqAddr, isSynced, bc, eventLog, nil, newTimeoutCond(&sync.Mutex{}), nil, nil, nil)
 
 	// assert
 	assert.NotNil(t, f)
@@ -2070,6 +2073,40 @@ func TestFinalizer_setNextForcedBatchDeadline(t *testing.T) {
 	assert.Equal(t, expected, f.nextForcedBatchDeadline)
 }
 
+func TestFinalizer_pauseAndResume(t *testing.T) {
+	// arrange
+	f = setupFinalizer(true)
+	f.wipL2Block = &L2Block{trackingNum: 1}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// act and assert
+	assert.Equal(t, FinalizerStatusRunning, f.status())
+
+	require.NoError(t, f.pause())
+	assert.Equal(t, FinalizerStatusPausing, f.status())
+
+	done := make(chan struct{})
+	go func() {
+		f.waitWhilePaused(ctx)
+		close(done)
+	}()
+	assert.Eventually(t, func() bool { return f.status() == FinalizerStatusPaused }, 3*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, f.resume())
+	select {
+	case <-done:
+	case <-time.After(3 * time.Second):
+		t.Fatal("finalizer not resumed")
+	}
+	assert.Equal(t, FinalizerStatusRunning, f.status())
+
+	f.haltFinalizer.Store(true)
+	assert.Equal(t, FinalizerStatusHalted, f.status())
+	assert.ErrorIs(t, f.pause(), ErrFinalizerHalted)
+	assert.ErrorIs(t, f.resume(), ErrFinalizerHalted)
+}
+
 func TestFinalizer_getConstraintThresholdUint64(t *testing.T) {
 	// arrange
 	f = setupFinalizer(false)
@@ -2179,6 +2216,240 @@ func Test_sortForcedBatches(t *testing.T) {
 	}
 }
 
+func TestFinalizer_processBundle(t *testing.T) {
+	txResponse := &state.ProcessTransactionResponse{GasUsed: 21000}
+	usedCounters := state.ZKCounters{GasUsed: 42000, Steps: 100}
+
+	testCases := []struct {
+		name                 string
+		processBatchResponse *state.ProcessBatchResponse
+		processBatchErr      error
+		expectedErr          error
+		expectedFailed       bool
+		expectedIncluded     bool
+	}{
+		{
+			name: "All txs succeed",
+			processBatchResponse: &state.ProcessBatchResponse{
+				NewStateRoot:       newHash,
+				UsedZkCounters:     usedCounters,
+				ReservedZkCounters: usedCounters,
+				BlockResponses: []*state.ProcessBlockResponse{{
+					TransactionResponses: []*state.ProcessTransactionResponse{txResponse, txResponse},
+				}},
+				ReadWriteAddresses: map[common.Address]*state.InfoReadWrite{},
+			},
+			expectedIncluded: true,
+		},
+		{
+			name: "Second tx fails",
+			processBatchResponse: &state.ProcessBatchResponse{
+				NewStateRoot:       newHash,
+				UsedZkCounters:     usedCounters,
+				ReservedZkCounters: usedCounters,
+				BlockResponses: []*state.ProcessBlockResponse{{
+					TransactionResponses: []*state.ProcessTransactionResponse{txResponse, {RomError: runtime.ErrExecutionReverted}},
+				}},
+			},
+			expectedErr:    ErrBundleFailed,
+			expectedFailed: true,
+		},
+		{
+			name: "Out of counters",
+			processBatchResponse: &state.ProcessBatchResponse{
+				IsRomOOCError: true,
+			},
+			expectedErr:    ErrBundleFailed,
+			expectedFailed: true,
+		},
+		{
+			name: "Reserved counters don't fit in the batch",
+			processBatchResponse: &state.ProcessBatchResponse{
+				NewStateRoot:       newHash,
+				UsedZkCounters:     usedCounters,
+				ReservedZkCounters: state.ZKCounters{Steps: bc.MaxSteps},
+				BlockResponses: []*state.ProcessBlockResponse{{
+					TransactionResponses: []*state.ProcessTransactionResponse{txResponse, txResponse},
+				}},
+			},
+			expectedErr: ErrBatchResourceOverFlow,
+		},
+		{
+			name:            "Executor DB error",
+			processBatchErr: runtime.ErrExecutorDBError,
+			expectedErr:     runtime.ErrExecutorDBError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// arrange
+			ctx = context.Background()
+			f = setupFinalizer(true)
+			f.wipBatch.imStateRoot = oldHash
+			f.wipBatch.imRemainingResources.ZKCounters.Steps = bc.MaxSteps - 1
+			f.wipL2Block = &L2Block{blockNumber: 10, timestamp: uint64(now().Unix())}
+			key, err := crypto.GenerateKey()
+			require.NoError(t, err)
+			f.preconfirmer = &preconfirmer{key: key, pending: make(chan pool.Preconfirmation, 2)}
+			f.cfg.Throttle.Enabled = true
+
+			bundle := &BundleTracker{
+				Hash:    common.Hash{1},
+				HashStr: common.Hash{1}.String(),
+				Txs: []*TxTracker{
+					{Hash: common.Hash{2}, HashStr: common.Hash{2}.String(), From: senderAddr, RawTx: []byte{0x02}, GasPrice: big.NewInt(10), EffectiveGasPrice: new(big.Int), EGPLog: state.EffectiveGasPriceLog{GasPrice: new(big.Int), ValueFinal: new(big.Int)}},
+					{Hash: common.Hash{3}, HashStr: common.Hash{3}.String(), From: senderAddr, RawTx: []byte{0x03}, GasPrice: big.NewInt(10), EffectiveGasPrice: new(big.Int), EGPLog: state.EffectiveGasPriceLog{GasPrice: new(big.Int), ValueFinal: new(big.Int)}},
+				},
+				Bytes: 4,
+			}
+
+			poolMock.On("GetL1AndL2GasPrice").Return(uint64(1), uint64(1)).Once()
+			stateMock.On("GetForkIDByBatchNumber", f.wipBatch.batchNumber).Return(uint64(state.FORKID_ETROG)).Once()
+			stateMock.On("ProcessBatchV2", ctx, mock.MatchedBy(func(request state.ProcessRequest) bool {
+				return request.OldStateRoot == oldHash && assert.Equal(t, []byte{0x02, 0xff, 0x03, 0xff}, request.Transactions)
+			}), false).Return(tc.processBatchResponse, tc.processBatchErr).Once()
+
+			if tc.expectedIncluded || tc.expectedFailed {
+				workerMock.On("DeleteBundle", bundle.Hash).Return().Once()
+			}
+			if tc.expectedIncluded {
+				workerMock.On("AddBundleThrottleUsage", bundle, tc.processBatchResponse.UsedZkCounters).Return().Once()
+				workerMock.On("UpdateAfterSingleSuccessfulTxExecution", senderAddr, tc.processBatchResponse.ReadWriteAddresses).Return([]*TxTracker{}).Once()
+			}
+			if tc.expectedFailed {
+				poolMock.On("UpdateBundleStatus", ctx, bundle.Hash, pool.BundleStatusFailed, mock.Anything).Return(nil).Once()
+			}
+			if tc.expectedErr == ErrBatchResourceOverFlow {
+				workerMock.On("UpdateBundleZKCounters", bundle.Hash, tc.processBatchResponse.ReservedZkCounters).Return().Once()
+			}
+
+			// act
+			err = f.processBundle(ctx, bundle)
+
+			// assert
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			if tc.expectedIncluded {
+				assert.Equal(t, newHash, f.wipBatch.imStateRoot)
+				assert.Equal(t, bundle.Txs, f.wipL2Block.transactions)
+				assert.Equal(t, []*BundleTracker{bundle}, f.wipL2Block.bundles)
+				assert.Equal(t, 2, f.wipBatch.countOfTxs)
+				require.Len(t, f.preconfirmer.pending, 2)
+				for i, tx := range bundle.Txs {
+					preconfirmation := <-f.preconfirmer.pending
+					assert.Equal(t, tx.Hash, preconfirmation.TxHash)
+					assert.Equal(t, f.wipL2Block.blockNumber, preconfirmation.L2BlockNumber)
+					assert.Equal(t, uint64(i), preconfirmation.Position)
+				}
+			} else {
+				// The wip L2 block is left untouched
+				assert.Equal(t, oldHash, f.wipBatch.imStateRoot)
+				assert.Empty(t, f.wipL2Block.transactions)
+				assert.Equal(t, 0, f.wipBatch.countOfTxs)
+				assert.Empty(t, f.preconfirmer.pending)
+			}
+			poolMock.AssertExpectations(t)
+			stateMock.AssertExpectations(t)
+			workerMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFinalizer_isBundleBeforeTx(t *testing.T) {
+	bundle := &BundleTracker{
+		HashStr: common.Hash{1}.String(),
+		Txs: []*TxTracker{
+			{GasPrice: big.NewInt(10), Gas: 30000},
+			{GasPrice: big.NewInt(40), Gas: 10000},
+		},
+	}
+	// the gas price of the bundle is the gas weighted average of the gas prices of its txs
+	assert.Equal(t, big.NewInt(17), bundle.gasPrice())
+
+	testCases := []struct {
+		name     string
+		gasPrice int64
+		expected bool
+	}{
+		{
+			name:     "Tx pays less than the bundle",
+			gasPrice: 16,
+			expected: true,
+		},
+		{
+			name:     "Tx pays the same as the bundle",
+			gasPrice: 17,
+			expected: true,
+		},
+		{
+			name:     "Tx pays more than the bundle",
+			gasPrice: 18,
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			f = setupFinalizer(true)
+			tx := &TxTracker{HashStr: common.Hash{2}.String(), GasPrice: big.NewInt(tc.gasPrice)}
+
+			assert.Equal(t, tc.expected, f.isBundleBeforeTx(bundle, tx))
+		})
+	}
+}
+
+func TestFinalizer_finalizeBatchesWithThrottledTxs(t *testing.T) {
+	testCases := []struct {
+		name  string
+		txErr error
+	}{
+		{
+			name:  "No txs",
+			txErr: ErrTransactionsListEmpty,
+		},
+		{
+			name:  "Sender throttled in the L2 block",
+			txErr: ErrTransactionsThrottled,
+		},
+		{
+			name:  "Sender throttled in the batch",
+			txErr: ErrTransactionsThrottledInBatch,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// arrange
+			f = setupFinalizer(true)
+			f.cfg.L2BlockMaxDeltaTimestamp = cfgTypes.NewDuration(time.Hour)
+			f.cfg.BatchMaxDeltaTimestamp = cfgTypes.NewDuration(time.Hour)
+			f.cfg.NewTxsWaitInterval = cfgTypes.NewDuration(10 * time.Millisecond)
+			f.workerReadyTxsCond = newTimeoutCond(&sync.Mutex{})
+			f.wipL2Block = &L2Block{trackingNum: 1, blockNumber: 1, timestamp: uint64(time.Now().Unix())}
+			ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+			defer cancel()
+
+			workerMock.On("GetBestFittingBundle", f.wipBatch.imRemainingResources, bc.MaxTxsPerBatch, uint64(1), f.wipL2Block.timestamp).Return(nil, nil, nil)
+			workerMock.On("GetBestFittingTx", f.wipBatch.imRemainingResources).Return(nil, tc.txErr)
+
+			// act
+			f.finalizeBatches(ctx)
+
+			// assert
+			// the throttled txs don't close the wip L2 block or batch before the L2 block time or the batch closing conditions
+			assert.Equal(t, uint64(1), f.wipBatch.batchNumber)
+			assert.Equal(t, uint64(1), f.wipL2Block.trackingNum)
+			assert.Equal(t, state.EmptyClosingReason, f.wipBatch.closingReason)
+			stateMock.AssertExpectations(t)
+			workerMock.AssertExpectations(t)
+		})
+	}
+}
+
 func setupFinalizer(withWipBatch bool) *finalizer {
 	wipBatch := new(Batch)
 	poolMock = new(PoolMock)
@@ -73,6 +73,11 @@ func (f *finalizer) processForcedBatch(ctx context.Context, forcedBatch state.Fo
 		return lastBatchNumber, stateRoot, retError
 	}
 
+	err = f.checkLeadership(ctx, dbTx)
+	if err != nil {
+		return rollbackOnError(fmt.Errorf("leadership check failed on processing forced batch %d, error: %w", forcedBatch.ForcedBatchNumber, err))
+	}
+
 	// Get L1 block for the forced batch
 	fbL1Block, err := f.stateIntf.GetBlockByNumber(ctx, forcedBatch.BlockNumber, dbTx)
 	if err != nil {
@@ -204,6 +209,15 @@ func (f *finalizer) handleProcessForcedBatchResponse(ctx context.Context, newBat
 		}
 	}
 
+	if len(batchResponse.BlockResponses) > 0 {
+		firstBlockNumber := batchResponse.BlockResponses[0].BlockNumber
+		lastBlockNumber := batchResponse.BlockResponses[len(batchResponse.BlockResponses)-1].BlockNumber
+		err := f.stateIntf.StoreArchiveStateDiff(ctx, firstBlockNumber, lastBlockNumber, batchResponse.ReadWriteAddresses, dbTx)
+		if err != nil {
+			return fmt.Errorf("database error on archiving L2 blocks [%d-%d], error: %v", firstBlockNumber, lastBlockNumber, err)
+		}
+	}
+
 	return nil
 }
 
@@ -28,6 +28,10 @@ type txPool interface {
 	GetDefaultMinGasPriceAllowed() uint64
 	GetL1AndL2GasPrice() (uint64, uint64)
 	GetEarliestProcessedTx(ctx context.Context) (common.Hash, error)
+	GetPendingBundles(ctx context.Context) ([]pool.Bundle, error)
+	UpdateBundleStatus(ctx context.Context, hash common.Hash, newStatus pool.BundleStatus, failedReason *string) error
+	MarkWIPBundlesAsPending(ctx context.Context) error
+	AddPreconfirmation(ctx context.Context, preconfirmation pool.Preconfirmation) error
 }
 
 // etherman contains the methods required to interact with ethereum.
@@ -69,6 +73,7 @@ type stateInterface interface {
 	GetDSL2Transactions(ctx context.Context, firstL2Block, lastL2Block uint64, dbTx pgx.Tx) ([]*state.DSL2Transaction, error)
 	GetStorageAt(ctx context.Context, address common.Address, position *big.Int, root common.Hash) (*big.Int, error)
 	StoreL2Block(ctx context.Context, batchNumber uint64, l2Block *state.ProcessBlockResponse, txsEGPLog []*state.EffectiveGasPriceLog, dbTx pgx.Tx) error
+	StoreArchiveStateDiff(ctx context.Context, fromL2BlockNumber, toL2BlockNumber uint64, readWriteAddresses map[common.Address]*state.InfoReadWrite, dbTx pgx.Tx) error
 	BuildChangeL2Block(deltaTimestamp uint32, l1InfoTreeIndex uint32) []byte
 	GetL1InfoTreeDataFromBatchL2Data(ctx context.Context, batchL2Data []byte, dbTx pgx.Tx) (map[uint32]state.L1DataV2, common.Hash, common.Hash, error)
 	GetBlockByNumber(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) (*state.Block, error)
@@ -77,6 +82,9 @@ type stateInterface interface {
 	GetL1InfoRootLeafByIndex(ctx context.Context, l1InfoTreeIndex uint32, dbTx pgx.Tx) (state.L1InfoTreeExitRootStorageEntry, error)
 	GetLatestBatchGlobalExitRoot(ctx context.Context, dbTx pgx.Tx) (common.Hash, error)
 	GetNotCheckedBatches(ctx context.Context, dbTx pgx.Tx) ([]*state.Batch, error)
+	AcquireSequencerLease(ctx context.Context, nodeID string, leaseDuration time.Duration, dbTx pgx.Tx) (uint64, error)
+	RenewSequencerLease(ctx context.Context, nodeID string, fencingToken uint64, leaseDuration time.Duration, dbTx pgx.Tx) error
+	CheckSequencerLease(ctx context.Context, nodeID string, fencingToken uint64, dbTx pgx.Tx) error
 }
 
 type workerInterface interface {
@@ -91,4 +99,11 @@ type workerInterface interface {
 	NewTxTracker(tx types.Transaction, usedZKcounters state.ZKCounters, reservedZKCouners state.ZKCounters, ip string) (*TxTracker, error)
 	AddForcedTx(txHash common.Hash, addr common.Address)
 	DeleteForcedTx(txHash common.Hash, addr common.Address)
+	GetBestFittingBundle(resources state.BatchResources, remainingTxs uint64, blockNumber uint64, timestamp uint64) (*BundleTracker, []*BundleTracker, error)
+	UpdateBundleZKCounters(bundleHash common.Hash, reservedZKCounters state.ZKCounters)
+	DeleteBundle(bundleHash common.Hash)
+	AddThrottleUsage(tx *TxTracker, usedZKCounters state.ZKCounters)
+	AddBundleThrottleUsage(bundle *BundleTracker, usedZKCounters state.ZKCounters)
+	ResetBatchThrottleUsage()
+	ResetL2BlockThrottleUsage()
 }
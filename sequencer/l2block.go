@@ -18,6 +18,7 @@ import (
 type L2Block struct {
 	createdAt                 time.Time
 	trackingNum               uint64
+	blockNumber               uint64
 	timestamp                 uint64
 	deltaTimestamp            uint32
 	imStateRoot               common.Hash
@@ -27,6 +28,7 @@ type L2Block struct {
 	usedZKCounters            state.ZKCounters
 	reservedZKCounters        state.ZKCounters
 	transactions              []*TxTracker
+	bundles                   []*BundleTracker
 	batchResponse             *state.ProcessBatchResponse
 	metrics                   metrics
 }
@@ -40,6 +42,12 @@ func (b *L2Block) addTx(tx *TxTracker) {
 	b.transactions = append(b.transactions, tx)
 }
 
+// addBundle adds the txs of a bundle to the L2 block
+func (b *L2Block) addBundle(bundle *BundleTracker) {
+	b.transactions = append(b.transactions, bundle.Txs...)
+	b.bundles = append(b.bundles, bundle)
+}
+
 // getL1InfoTreeIndex returns the L1InfoTreeIndex that must be used when processing/storing the block
 func (b *L2Block) getL1InfoTreeIndex() uint32 {
 	// If the L1InfoTreeIndex has changed in this block then we return the new index, otherwise we return 0
@@ -347,6 +355,11 @@ func (f *finalizer) storeL2Block(ctx context.Context, l2Block *L2Block) error {
 		return retError
 	}
 
+	err = f.checkLeadership(ctx, dbTx)
+	if err != nil {
+		return rollbackOnError(fmt.Errorf("leadership check failed on storing L2 block %d [%d], error: %w", blockResponse.BlockNumber, l2Block.trackingNum, err))
+	}
+
 	forkID := f.stateIntf.GetForkIDByBatchNumber(f.wipBatch.batchNumber)
 
 	txsEGPLog := []*state.EffectiveGasPriceLog{}
@@ -361,6 +374,11 @@ func (f *finalizer) storeL2Block(ctx context.Context, l2Block *L2Block) error {
 		return rollbackOnError(fmt.Errorf("database error on storing L2 block %d [%d], error: %v", blockResponse.BlockNumber, l2Block.trackingNum, err))
 	}
 
+	err = f.stateIntf.StoreArchiveStateDiff(ctx, blockResponse.BlockNumber, blockResponse.BlockNumber, l2Block.batchResponse.ReadWriteAddresses, dbTx)
+	if err != nil {
+		return rollbackOnError(fmt.Errorf("database error on archiving L2 block %d [%d], error: %v", blockResponse.BlockNumber, l2Block.trackingNum, err))
+	}
+
 	// Now we need to update de BatchL2Data of the wip batch and also update the status of the L2 block txs in the pool
 
 	batch, err := f.stateIntf.GetBatchByNumber(ctx, f.wipBatch.batchNumber, dbTx)
@@ -420,6 +438,14 @@ func (f *finalizer) storeL2Block(ctx context.Context, l2Block *L2Block) error {
 		}
 	}
 
+	// Update the status of the bundles included in the L2 block
+	for _, bundle := range l2Block.bundles {
+		err = f.poolIntf.UpdateBundleStatus(ctx, bundle.Hash, pool.BundleStatusIncluded, nil)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Send L2 block to data streamer
 	err = f.DSSendL2Block(f.wipBatch.batchNumber, blockResponse, l2Block.getL1InfoTreeIndex(), l2Block.batchResponse.NewLocalExitRoot)
 	if err != nil {
@@ -512,6 +538,10 @@ func (f *finalizer) openNewWIPL2Block(ctx context.Context, prevTimestamp uint64,
 
 	f.wipL2Block = newL2Block
 
+	if f.cfg.Throttle.Enabled {
+		f.workerIntf.ResetL2BlockThrottleUsage()
+	}
+
 	log.Debugf("creating new WIP L2 block [%d], batch: %d, deltaTimestamp: %d, timestamp: %d, l1InfoTreeIndex: %d, l1InfoTreeIndexChanged: %v",
 		f.wipL2Block.trackingNum, f.wipBatch.batchNumber, f.wipL2Block.deltaTimestamp, f.wipL2Block.timestamp, f.wipL2Block.l1InfoTreeExitRoot.L1InfoTreeIndex, f.wipL2Block.l1InfoTreeExitRootChanged)
 
@@ -525,6 +555,9 @@ func (f *finalizer) openNewWIPL2Block(ctx context.Context, prevTimestamp uint64,
 		f.Halt(ctx, fmt.Errorf("number of L2 block [%d] responses returned by the executor is %d and must be 1", f.wipL2Block.trackingNum, len(batchResponse.BlockResponses)), false)
 	}
 
+	// Save the number of the new wip L2 block, needed to check the target block of the bundles and to sign the preconfirmations
+	f.wipL2Block.blockNumber = batchResponse.BlockResponses[0].BlockNumber
+
 	// Update imStateRoot
 	oldIMStateRoot := f.wipBatch.imStateRoot
 	f.wipL2Block.imStateRoot = batchResponse.NewStateRoot
@@ -0,0 +1,103 @@
+package sequencer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+// leaderElection elects the sequencer instance that sequences the batches when several instances run against
+// the same state database. The leader holds a lease in the state database that must renew periodically, the
+// standby instances wait until the lease expires to acquire it. Each time the lease changes of holder its fencing
+// token is increased, and the leader checks its token in the same db tx it stores the batches and L2 blocks, so
+// a stale leader can't store anything once a standby instance has taken over
+type leaderElection struct {
+	cfg          LeaderElectionCfg
+	nodeID       string
+	stateIntf    stateInterface
+	fencingToken atomic.Uint64
+}
+
+// newLeaderElection creates a new leaderElection
+func newLeaderElection(cfg LeaderElectionCfg, stateIntf stateInterface) (*leaderElection, error) {
+	if cfg.RenewInterval.Duration <= 0 || cfg.RenewInterval.Duration >= cfg.LeaseDuration.Duration {
+		return nil, fmt.Errorf("invalid leader election config, RenewInterval (%v) must be greater than 0 and lower than LeaseDuration (%v)",
+			cfg.RenewInterval.Duration, cfg.LeaseDuration.Duration)
+	}
+
+	nodeID := cfg.NodeID
+	if nodeID == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			hostname = "sequencer"
+		}
+		nodeID = fmt.Sprintf("%s-%s", hostname, uuid.NewString())
+	}
+
+	return &leaderElection{
+		cfg:       cfg,
+		nodeID:    nodeID,
+		stateIntf: stateIntf,
+	}, nil
+}
+
+// waitForLeadership waits in standby until the node acquires the sequencer lease
+func (l *leaderElection) waitForLeadership(ctx context.Context) error {
+	showStandbyLog := true
+	for {
+		fencingToken, err := l.stateIntf.AcquireSequencerLease(ctx, l.nodeID, l.cfg.LeaseDuration.Duration, nil)
+		if err == nil {
+			l.fencingToken.Store(fencingToken)
+			log.Infof("sequencer node %s elected as leader, fencingToken: %d", l.nodeID, fencingToken)
+			return nil
+		} else if errors.Is(err, state.ErrSequencerLeaseHeld) {
+			if showStandbyLog {
+				log.Infof("sequencer node %s in standby, the sequencer lease is held by another node", l.nodeID)
+				showStandbyLog = false
+			}
+		} else {
+			log.Errorf("failed to acquire sequencer lease for node %s, error: %v", l.nodeID, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(l.cfg.RenewInterval.Duration):
+		}
+	}
+}
+
+// keepLeadership renews periodically the sequencer lease, calling onLost if the lease is lost
+func (l *leaderElection) keepLeadership(ctx context.Context, onLost func(err error)) {
+	ticker := time.NewTicker(l.cfg.RenewInterval.Duration)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := l.stateIntf.RenewSequencerLease(ctx, l.nodeID, l.fencingToken.Load(), l.cfg.LeaseDuration.Duration, nil)
+			if errors.Is(err, state.ErrSequencerLeaseLost) {
+				onLost(fmt.Errorf("sequencer node %s lost the leadership, fencingToken: %d", l.nodeID, l.fencingToken.Load()))
+				return
+			} else if err != nil {
+				// If the error persists the lease expires and the next renewal returns ErrSequencerLeaseLost
+				log.Errorf("failed to renew sequencer lease for node %s, error: %v", l.nodeID, err)
+			}
+		}
+	}
+}
+
+// checkLeadership checks the node still holds the sequencer lease, locking it until the end of the provided db tx
+func (l *leaderElection) checkLeadership(ctx context.Context, dbTx pgx.Tx) error {
+	return l.stateIntf.CheckSequencerLease(ctx, l.nodeID, l.fencingToken.Load(), dbTx)
+}
@@ -0,0 +1,104 @@
+package sequencer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/config/types"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var leaderCfg = LeaderElectionCfg{
+	Enabled:       true,
+	NodeID:        "node1",
+	LeaseDuration: types.NewDuration(30 * time.Millisecond),
+	RenewInterval: types.NewDuration(10 * time.Millisecond),
+}
+
+func TestNewLeaderElection(t *testing.T) {
+	testCases := []struct {
+		name          string
+		cfg           LeaderElectionCfg
+		expectedErr   bool
+		expectedIDSet bool
+	}{
+		{name: "valid config", cfg: leaderCfg},
+		{name: "random node id", cfg: LeaderElectionCfg{LeaseDuration: leaderCfg.LeaseDuration, RenewInterval: leaderCfg.RenewInterval}, expectedIDSet: true},
+		{name: "renew interval not set", cfg: LeaderElectionCfg{LeaseDuration: leaderCfg.LeaseDuration}, expectedErr: true},
+		{name: "renew interval greater than lease", cfg: LeaderElectionCfg{LeaseDuration: leaderCfg.RenewInterval, RenewInterval: leaderCfg.LeaseDuration}, expectedErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			leader, err := newLeaderElection(tc.cfg, NewStateMock(t))
+			if tc.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			if tc.expectedIDSet {
+				assert.NotEmpty(t, leader.nodeID)
+			} else {
+				assert.Equal(t, tc.cfg.NodeID, leader.nodeID)
+			}
+		})
+	}
+}
+
+func TestLeaderElection(t *testing.T) {
+	ctx := context.Background()
+	stateMock := NewStateMock(t)
+	leader, err := newLeaderElection(leaderCfg, stateMock)
+	require.NoError(t, err)
+
+	// The node waits in standby while the lease is held by another node
+	stateMock.On("AcquireSequencerLease", ctx, "node1", leaderCfg.LeaseDuration.Duration, nil).Return(uint64(0), state.ErrSequencerLeaseHeld).Twice()
+	stateMock.On("AcquireSequencerLease", ctx, "node1", leaderCfg.LeaseDuration.Duration, nil).Return(uint64(5), nil).Once()
+	require.NoError(t, leader.waitForLeadership(ctx))
+	assert.Equal(t, uint64(5), leader.fencingToken.Load())
+
+	// The fencing token is used to check the leadership
+	stateMock.On("CheckSequencerLease", ctx, "node1", uint64(5), nil).Return(nil).Once()
+	require.NoError(t, leader.checkLeadership(ctx, nil))
+
+	// The lease is renewed until it's lost
+	stateMock.On("RenewSequencerLease", ctx, "node1", uint64(5), leaderCfg.LeaseDuration.Duration, nil).Return(nil).Once()
+	stateMock.On("RenewSequencerLease", ctx, "node1", uint64(5), leaderCfg.LeaseDuration.Duration, nil).Return(state.ErrSequencerLeaseLost).Once()
+	lost := make(chan error, 1)
+	go leader.keepLeadership(ctx, func(err error) { lost <- err })
+	select {
+	case err := <-lost:
+		assert.Error(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("leadership lost not notified")
+	}
+}
+
+func TestLeaderElection_waitForLeadershipCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	stateMock := NewStateMock(t)
+	leader, err := newLeaderElection(leaderCfg, stateMock)
+	require.NoError(t, err)
+
+	stateMock.On("AcquireSequencerLease", ctx, "node1", leaderCfg.LeaseDuration.Duration, nil).Return(uint64(0), state.ErrSequencerLeaseHeld).Run(func(args mock.Arguments) { cancel() }).Once()
+	assert.ErrorIs(t, leader.waitForLeadership(ctx), context.Canceled)
+}
+
+func TestFinalizer_checkLeadership(t *testing.T) {
+	f = setupFinalizer(false)
+	ctx = context.Background()
+
+	// The leader election is disabled
+	assert.NoError(t, f.checkLeadership(ctx, dbTxMock))
+
+	f.leader, err = newLeaderElection(leaderCfg, stateMock)
+	require.NoError(t, err)
+	f.leader.fencingToken.Store(2)
+	stateMock.On("CheckSequencerLease", ctx, "node1", uint64(2), dbTxMock).Return(state.ErrSequencerLeaseLost).Once()
+	assert.ErrorIs(t, f.checkLeadership(ctx, dbTxMock), state.ErrSequencerLeaseLost)
+	stateMock.AssertExpectations(t)
+}
@@ -0,0 +1,70 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	prefix                      = "sequencer_"
+	txOrderingPrefix            = prefix + "tx_ordering_"
+	txSelectedName              = txOrderingPrefix + "selected"
+	txSelectionWaitName         = txOrderingPrefix + "wait_time"
+	txOrderingPolicyName        = "policy"
+	preconfirmationsDroppedName = prefix + "preconfirmations_dropped"
+)
+
+// Register the metrics for the sequencer package.
+func Register() {
+	var (
+		counters      []prometheus.CounterOpts
+		counterVecs   []metrics.CounterVecOpts
+		histogramVecs []metrics.HistogramVecOpts
+	)
+
+	counters = []prometheus.CounterOpts{
+		{
+			Name: preconfirmationsDroppedName,
+			Help: "[SEQUENCER] number of preconfirmations dropped because the buffer of the preconfirmations pending to store is full",
+		},
+	}
+
+	counterVecs = []metrics.CounterVecOpts{
+		{
+			CounterOpts: prometheus.CounterOpts{
+				Name: txSelectedName,
+				Help: "[SEQUENCER] number of txs selected by the worker per tx ordering policy",
+			},
+			Labels: []string{txOrderingPolicyName},
+		},
+	}
+
+	histogramVecs = []metrics.HistogramVecOpts{
+		{
+			HistogramOpts: prometheus.HistogramOpts{
+				Name:    txSelectionWaitName,
+				Help:    "[SEQUENCER] Histogram for the time the txs wait in the worker until they are selected per tx ordering policy",
+				Buckets: prometheus.ExponentialBuckets(0.01, 2, 16), //nolint:gomnd
+			},
+			Labels: []string{txOrderingPolicyName},
+		},
+	}
+
+	metrics.RegisterCounters(counters...)
+	metrics.RegisterCounterVecs(counterVecs...)
+	metrics.RegisterHistogramVecs(histogramVecs...)
+}
+
+// PreconfirmationDropped increments the dropped preconfirmations counter by one.
+func PreconfirmationDropped() {
+	metrics.CounterInc(preconfirmationsDroppedName)
+}
+
+// TxSelected increments the selected txs counter vector by one and observes
+// (histogram) the time the tx waited since it was received, for the given policy.
+func TxSelected(policy string, receivedAt time.Time) {
+	metrics.CounterVecInc(txSelectedName, policy)
+	metrics.HistogramVecObserve(txSelectionWaitName, policy, time.Since(receivedAt).Seconds())
+}
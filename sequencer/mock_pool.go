@@ -21,6 +21,24 @@ type PoolMock struct {
 	mock.Mock
 }
 
+// AddPreconfirmation provides a mock function with given fields: ctx, preconfirmation
+func (_m *PoolMock) AddPreconfirmation(ctx context.Context, preconfirmation pool.Preconfirmation) error {
+	ret := _m.Called(ctx, preconfirmation)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddPreconfirmation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, pool.Preconfirmation) error); ok {
+		r0 = rf(ctx, preconfirmation)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteFailedTransactionsOlderThan provides a mock function with given fields: ctx, date
 func (_m *PoolMock) DeleteFailedTransactionsOlderThan(ctx context.Context, date time.Time) error {
 	ret := _m.Called(ctx, date)
@@ -209,6 +227,36 @@ func (_m *PoolMock) GetNonWIPPendingTxs(ctx context.Context) ([]pool.Transaction
 	return r0, r1
 }
 
+// GetPendingBundles provides a mock function with given fields: ctx
+func (_m *PoolMock) GetPendingBundles(ctx context.Context) ([]pool.Bundle, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPendingBundles")
+	}
+
+	var r0 []pool.Bundle
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]pool.Bundle, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []pool.Bundle); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]pool.Bundle)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTxZkCountersByHash provides a mock function with given fields: ctx, hash
 func (_m *PoolMock) GetTxZkCountersByHash(ctx context.Context, hash common.Hash) (*state.ZKCounters, *state.ZKCounters, error) {
 	ret := _m.Called(ctx, hash)
@@ -248,6 +296,24 @@ func (_m *PoolMock) GetTxZkCountersByHash(ctx context.Context, hash common.Hash)
 	return r0, r1, r2
 }
 
+// MarkWIPBundlesAsPending provides a mock function with given fields: ctx
+func (_m *PoolMock) MarkWIPBundlesAsPending(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkWIPBundlesAsPending")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // MarkWIPTxsAsPending provides a mock function with given fields: ctx
 func (_m *PoolMock) MarkWIPTxsAsPending(ctx context.Context) error {
 	ret := _m.Called(ctx)
@@ -266,6 +332,24 @@ func (_m *PoolMock) MarkWIPTxsAsPending(ctx context.Context) error {
 	return r0
 }
 
+// UpdateBundleStatus provides a mock function with given fields: ctx, hash, newStatus, failedReason
+func (_m *PoolMock) UpdateBundleStatus(ctx context.Context, hash common.Hash, newStatus pool.BundleStatus, failedReason *string) error {
+	ret := _m.Called(ctx, hash, newStatus, failedReason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateBundleStatus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash, pool.BundleStatus, *string) error); ok {
+		r0 = rf(ctx, hash, newStatus, failedReason)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateTxStatus provides a mock function with given fields: ctx, hash, newStatus, isWIP, failedReason
 func (_m *PoolMock) UpdateTxStatus(ctx context.Context, hash common.Hash, newStatus pool.TxStatus, isWIP bool, failedReason *string) error {
 	ret := _m.Called(ctx, hash, newStatus, isWIP, failedReason)
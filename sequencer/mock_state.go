@@ -13,6 +13,8 @@ import (
 	pgx "github.com/jackc/pgx/v4"
 
 	state "github.com/0xPolygonHermez/zkevm-node/state"
+
+	time "time"
 )
 
 // StateMock is an autogenerated mock type for the stateInterface type
@@ -20,6 +22,34 @@ type StateMock struct {
 	mock.Mock
 }
 
+// AcquireSequencerLease provides a mock function with given fields: ctx, nodeID, leaseDuration, dbTx
+func (_m *StateMock) AcquireSequencerLease(ctx context.Context, nodeID string, leaseDuration time.Duration, dbTx pgx.Tx) (uint64, error) {
+	ret := _m.Called(ctx, nodeID, leaseDuration, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AcquireSequencerLease")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration, pgx.Tx) (uint64, error)); ok {
+		return rf(ctx, nodeID, leaseDuration, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration, pgx.Tx) uint64); ok {
+		r0 = rf(ctx, nodeID, leaseDuration, dbTx)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration, pgx.Tx) error); ok {
+		r1 = rf(ctx, nodeID, leaseDuration, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // BeginStateTransaction provides a mock function with given fields: ctx
 func (_m *StateMock) BeginStateTransaction(ctx context.Context) (pgx.Tx, error) {
 	ret := _m.Called(ctx)
@@ -70,6 +100,24 @@ func (_m *StateMock) BuildChangeL2Block(deltaTimestamp uint32, l1InfoTreeIndex u
 	return r0
 }
 
+// CheckSequencerLease provides a mock function with given fields: ctx, nodeID, fencingToken, dbTx
+func (_m *StateMock) CheckSequencerLease(ctx context.Context, nodeID string, fencingToken uint64, dbTx pgx.Tx) error {
+	ret := _m.Called(ctx, nodeID, fencingToken, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckSequencerLease")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint64, pgx.Tx) error); ok {
+		r0 = rf(ctx, nodeID, fencingToken, dbTx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CloseBatch provides a mock function with given fields: ctx, receipt, dbTx
 func (_m *StateMock) CloseBatch(ctx context.Context, receipt state.ProcessingReceipt, dbTx pgx.Tx) error {
 	ret := _m.Called(ctx, receipt, dbTx)
@@ -1011,6 +1059,42 @@ func (_m *StateMock) ProcessBatchV2(ctx context.Context, request state.ProcessRe
 	return r0, r1
 }
 
+// RenewSequencerLease provides a mock function with given fields: ctx, nodeID, fencingToken, leaseDuration, dbTx
+func (_m *StateMock) RenewSequencerLease(ctx context.Context, nodeID string, fencingToken uint64, leaseDuration time.Duration, dbTx pgx.Tx) error {
+	ret := _m.Called(ctx, nodeID, fencingToken, leaseDuration, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RenewSequencerLease")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint64, time.Duration, pgx.Tx) error); ok {
+		r0 = rf(ctx, nodeID, fencingToken, leaseDuration, dbTx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StoreArchiveStateDiff provides a mock function with given fields: ctx, fromL2BlockNumber, toL2BlockNumber, readWriteAddresses, dbTx
+func (_m *StateMock) StoreArchiveStateDiff(ctx context.Context, fromL2BlockNumber uint64, toL2BlockNumber uint64, readWriteAddresses map[common.Address]*state.InfoReadWrite, dbTx pgx.Tx) error {
+	ret := _m.Called(ctx, fromL2BlockNumber, toL2BlockNumber, readWriteAddresses, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StoreArchiveStateDiff")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, map[common.Address]*state.InfoReadWrite, pgx.Tx) error); ok {
+		r0 = rf(ctx, fromL2BlockNumber, toL2BlockNumber, readWriteAddresses, dbTx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // StoreL2Block provides a mock function with given fields: ctx, batchNumber, l2Block, txsEGPLog, dbTx
 func (_m *StateMock) StoreL2Block(ctx context.Context, batchNumber uint64, l2Block *state.ProcessBlockResponse, txsEGPLog []*state.EffectiveGasPriceLog, dbTx pgx.Tx) error {
 	ret := _m.Called(ctx, batchNumber, l2Block, txsEGPLog, dbTx)
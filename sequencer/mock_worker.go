@@ -20,6 +20,11 @@ type WorkerMock struct {
 	mock.Mock
 }
 
+// AddBundleThrottleUsage provides a mock function with given fields: bundle, usedZKCounters
+func (_m *WorkerMock) AddBundleThrottleUsage(bundle *BundleTracker, usedZKCounters state.ZKCounters) {
+	_m.Called(bundle, usedZKCounters)
+}
+
 // AddForcedTx provides a mock function with given fields: txHash, addr
 func (_m *WorkerMock) AddForcedTx(txHash common.Hash, addr common.Address) {
 	_m.Called(txHash, addr)
@@ -30,6 +35,11 @@ func (_m *WorkerMock) AddPendingTxToStore(txHash common.Hash, addr common.Addres
 	_m.Called(txHash, addr)
 }
 
+// AddThrottleUsage provides a mock function with given fields: tx, usedZKCounters
+func (_m *WorkerMock) AddThrottleUsage(tx *TxTracker, usedZKCounters state.ZKCounters) {
+	_m.Called(tx, usedZKCounters)
+}
+
 // AddTxTracker provides a mock function with given fields: ctx, txTracker
 func (_m *WorkerMock) AddTxTracker(ctx context.Context, txTracker *TxTracker) (*TxTracker, error) {
 	ret := _m.Called(ctx, txTracker)
@@ -60,6 +70,11 @@ func (_m *WorkerMock) AddTxTracker(ctx context.Context, txTracker *TxTracker) (*
 	return r0, r1
 }
 
+// DeleteBundle provides a mock function with given fields: bundleHash
+func (_m *WorkerMock) DeleteBundle(bundleHash common.Hash) {
+	_m.Called(bundleHash)
+}
+
 // DeleteForcedTx provides a mock function with given fields: txHash, addr
 func (_m *WorkerMock) DeleteForcedTx(txHash common.Hash, addr common.Address) {
 	_m.Called(txHash, addr)
@@ -75,6 +90,45 @@ func (_m *WorkerMock) DeleteTx(txHash common.Hash, from common.Address) {
 	_m.Called(txHash, from)
 }
 
+// GetBestFittingBundle provides a mock function with given fields: resources, remainingTxs, blockNumber, timestamp
+func (_m *WorkerMock) GetBestFittingBundle(resources state.BatchResources, remainingTxs uint64, blockNumber uint64, timestamp uint64) (*BundleTracker, []*BundleTracker, error) {
+	ret := _m.Called(resources, remainingTxs, blockNumber, timestamp)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBestFittingBundle")
+	}
+
+	var r0 *BundleTracker
+	var r1 []*BundleTracker
+	var r2 error
+	if rf, ok := ret.Get(0).(func(state.BatchResources, uint64, uint64, uint64) (*BundleTracker, []*BundleTracker, error)); ok {
+		return rf(resources, remainingTxs, blockNumber, timestamp)
+	}
+	if rf, ok := ret.Get(0).(func(state.BatchResources, uint64, uint64, uint64) *BundleTracker); ok {
+		r0 = rf(resources, remainingTxs, blockNumber, timestamp)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*BundleTracker)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(state.BatchResources, uint64, uint64, uint64) []*BundleTracker); ok {
+		r1 = rf(resources, remainingTxs, blockNumber, timestamp)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]*BundleTracker)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(state.BatchResources, uint64, uint64, uint64) error); ok {
+		r2 = rf(resources, remainingTxs, blockNumber, timestamp)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetBestFittingTx provides a mock function with given fields: resources
 func (_m *WorkerMock) GetBestFittingTx(resources state.BatchResources) (*TxTracker, error) {
 	ret := _m.Called(resources)
@@ -155,6 +209,16 @@ func (_m *WorkerMock) NewTxTracker(tx types.Transaction, usedZKcounters state.ZK
 	return r0, r1
 }
 
+// ResetBatchThrottleUsage provides a mock function with given fields:
+func (_m *WorkerMock) ResetBatchThrottleUsage() {
+	_m.Called()
+}
+
+// ResetL2BlockThrottleUsage provides a mock function with given fields:
+func (_m *WorkerMock) ResetL2BlockThrottleUsage() {
+	_m.Called()
+}
+
 // UpdateAfterSingleSuccessfulTxExecution provides a mock function with given fields: from, touchedAddresses
 func (_m *WorkerMock) UpdateAfterSingleSuccessfulTxExecution(from common.Address, touchedAddresses map[common.Address]*state.InfoReadWrite) []*TxTracker {
 	ret := _m.Called(from, touchedAddresses)
@@ -175,6 +239,11 @@ func (_m *WorkerMock) UpdateAfterSingleSuccessfulTxExecution(from common.Address
 	return r0
 }
 
+// UpdateBundleZKCounters provides a mock function with given fields: bundleHash, reservedZKCounters
+func (_m *WorkerMock) UpdateBundleZKCounters(bundleHash common.Hash, reservedZKCounters state.ZKCounters) {
+	_m.Called(bundleHash, reservedZKCounters)
+}
+
 // UpdateTxZKCounters provides a mock function with given fields: txHash, from, usedZKCounters, reservedZKCounters
 func (_m *WorkerMock) UpdateTxZKCounters(txHash common.Hash, from common.Address, usedZKCounters state.ZKCounters, reservedZKCounters state.ZKCounters) {
 	_m.Called(txHash, from, usedZKCounters, reservedZKCounters)
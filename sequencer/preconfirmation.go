@@ -0,0 +1,95 @@
+package sequencer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	seqMetrics "github.com/0xPolygonHermez/zkevm-node/sequencer/metrics"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	pendingPreconfirmationsBufferSize = 1024
+)
+
+// preconfirmer signs the preconfirmations of the txs executed successfully in the WIP L2 block and stores them
+// in the pool, from where they are served by the RPC. The preconfirmations are stored asynchronously to not delay
+// the processing of the txs
+type preconfirmer struct {
+	key      *ecdsa.PrivateKey
+	address  common.Address
+	chainID  uint64
+	poolIntf txPool
+	pending  chan pool.Preconfirmation
+}
+
+// newPreconfirmer creates a new preconfirmer that signs the preconfirmations with the key of the given key store file,
+// the key must be the one of the trusted sequencer as the preconfirmations are verified against its address. The preconfirmations
+// are signed for the network with the given chain ID
+func newPreconfirmer(cfg PreconfirmationsCfg, poolIntf txPool, sequencerAddress common.Address, chainID uint64) (*preconfirmer, error) {
+	keystoreEncrypted, err := os.ReadFile(filepath.Clean(cfg.PrivateKey.Path))
+	if err != nil {
+		return nil, err
+	}
+	key, err := keystore.DecryptKey(keystoreEncrypted, cfg.PrivateKey.Password)
+	if err != nil {
+		return nil, err
+	}
+	if key.Address != sequencerAddress {
+		return nil, fmt.Errorf("%w: signer %s, trusted sequencer %s", ErrInvalidPreconfirmationsSigner, key.Address, sequencerAddress)
+	}
+
+	return &preconfirmer{
+		key:      key.PrivateKey,
+		address:  key.Address,
+		chainID:  chainID,
+		poolIntf: poolIntf,
+		pending:  make(chan pool.Preconfirmation, pendingPreconfirmationsBufferSize),
+	}, nil
+}
+
+// preconfirm signs the preconfirmation of a tx and adds it to the pending preconfirmations to store
+func (p *preconfirmer) preconfirm(txHash common.Hash, l2BlockNumber uint64, position uint64, stateRoot common.Hash) {
+	preconfirmation := pool.Preconfirmation{
+		TxHash:        txHash,
+		L2BlockNumber: l2BlockNumber,
+		Position:      position,
+		StateRoot:     stateRoot,
+	}
+
+	signature, err := crypto.Sign(preconfirmation.Hash(p.chainID).Bytes(), p.key)
+	if err != nil {
+		log.Errorf("failed to sign preconfirmation for tx %s, error: %v", txHash, err)
+		return
+	}
+	preconfirmation.Signature = signature
+
+	select {
+	case p.pending <- preconfirmation:
+	default:
+		log.Warnf("pending preconfirmations buffer is full, discarding preconfirmation for tx %s", txHash)
+		seqMetrics.PreconfirmationDropped()
+	}
+}
+
+// storePendingPreconfirmations stores in the pool the pending preconfirmations
+func (p *preconfirmer) storePendingPreconfirmations(ctx context.Context) {
+	for {
+		select {
+		case preconfirmation := <-p.pending:
+			err := p.poolIntf.AddPreconfirmation(ctx, preconfirmation)
+			if err != nil {
+				log.Errorf("failed to store preconfirmation for tx %s, error: %v", preconfirmation.TxHash, err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
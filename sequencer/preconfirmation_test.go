@@ -0,0 +1,99 @@
+package sequencer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/config/types"
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPreconfirmer(t *testing.T) {
+	sequencerAddress := common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+
+	testCases := []struct {
+		name             string
+		cfg              PreconfirmationsCfg
+		sequencerAddress common.Address
+		expectedAddress  common.Address
+		expectedErr      bool
+	}{
+		{
+			name:             "valid keystore",
+			cfg:              PreconfirmationsCfg{Enabled: true, PrivateKey: types.KeystoreFileConfig{Path: "../test/sequencer.keystore", Password: "testonly"}},
+			sequencerAddress: sequencerAddress,
+			expectedAddress:  sequencerAddress,
+		},
+		{
+			name:             "signer is not the trusted sequencer",
+			cfg:              PreconfirmationsCfg{Enabled: true, PrivateKey: types.KeystoreFileConfig{Path: "../test/sequencer.keystore", Password: "testonly"}},
+			sequencerAddress: common.HexToAddress("0x1"),
+			expectedErr:      true,
+		},
+		{
+			name:        "wrong password",
+			cfg:         PreconfirmationsCfg{Enabled: true, PrivateKey: types.KeystoreFileConfig{Path: "../test/sequencer.keystore", Password: "wrong"}},
+			expectedErr: true,
+		},
+		{
+			name:        "keystore not found",
+			cfg:         PreconfirmationsCfg{Enabled: true, PrivateKey: types.KeystoreFileConfig{Path: "../test/notfound.keystore", Password: "testonly"}},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := newPreconfirmer(tc.cfg, NewPoolMock(t), tc.sequencerAddress, 1000)
+			if tc.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedAddress, p.address)
+		})
+	}
+}
+
+func TestPreconfirmerPreconfirm(t *testing.T) {
+	poolMock := NewPoolMock(t)
+	p, err := newPreconfirmer(PreconfirmationsCfg{Enabled: true, PrivateKey: types.KeystoreFileConfig{Path: "../test/sequencer.keystore", Password: "testonly"}}, poolMock, common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"), 1000)
+	require.NoError(t, err)
+
+	txHash := common.HexToHash("0x1")
+	stateRoot := common.HexToHash("0x2")
+	p.preconfirm(txHash, 5, 3, stateRoot)
+
+	stored := make(chan pool.Preconfirmation, 1)
+	poolMock.On("AddPreconfirmation", mock.Anything, mock.AnythingOfType("pool.Preconfirmation")).Run(func(args mock.Arguments) {
+		stored <- args.Get(1).(pool.Preconfirmation)
+	}).Return(nil).Once()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go p.storePendingPreconfirmations(ctx)
+
+	select {
+	case preconfirmation := <-stored:
+		assert.Equal(t, txHash, preconfirmation.TxHash)
+		assert.Equal(t, uint64(5), preconfirmation.L2BlockNumber)
+		assert.Equal(t, uint64(3), preconfirmation.Position)
+		assert.Equal(t, stateRoot, preconfirmation.StateRoot)
+
+		signer, err := preconfirmation.Signer(1000)
+		require.NoError(t, err)
+		assert.Equal(t, p.address, signer)
+
+		// the preconfirmation is only valid in the network it was signed for
+		signer, err = preconfirmation.Signer(1001)
+		require.NoError(t, err)
+		assert.NotEqual(t, p.address, signer)
+	case <-time.After(time.Second):
+		t.Fatal("preconfirmation not stored in the pool")
+	}
+}
@@ -4,12 +4,14 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/0xPolygonHermez/zkevm-data-streamer/datastreamer"
 	"github.com/0xPolygonHermez/zkevm-node/event"
 	"github.com/0xPolygonHermez/zkevm-node/log"
 	"github.com/0xPolygonHermez/zkevm-node/pool"
+	seqMetrics "github.com/0xPolygonHermez/zkevm-node/sequencer/metrics"
 	"github.com/0xPolygonHermez/zkevm-node/state"
 	"github.com/ethereum/go-ethereum/common"
 )
@@ -29,7 +31,16 @@ type Sequencer struct {
 	eventLog  *event.EventLog
 	etherman  etherman
 	worker    *Worker
-	finalizer *finalizer
+	// finalizer is set when the sequencer starts, it's read by the admin requests
+	finalizer atomic.Pointer[finalizer]
+
+	txOrderingPolicy TxOrderingPolicy
+
+	throttler *txThrottler
+
+	leader *leaderElection
+
+	preconfirmer *preconfirmer
 
 	workerReadyTxsCond *timeoutCond
 
@@ -48,6 +59,11 @@ func New(cfg Config, batchCfg state.BatchConfig, poolCfg pool.Config, txPool txP
 		return nil, fmt.Errorf("failed to get trusted sequencer address, error: %v", err)
 	}
 
+	txOrderingPolicy, err := NewTxOrderingPolicy(cfg.Finalizer.TxOrderingPolicy, batchCfg.Constraints)
+	if err != nil {
+		return nil, err
+	}
+
 	sequencer := &Sequencer{
 		cfg:       cfg,
 		batchCfg:  batchCfg,
@@ -57,8 +73,33 @@ func New(cfg Config, batchCfg state.BatchConfig, poolCfg pool.Config, txPool txP
 		etherman:  etherman,
 		address:   addr,
 		eventLog:  eventLog,
+
+		txOrderingPolicy: txOrderingPolicy,
+	}
+
+	if cfg.LeaderElection.Enabled {
+		sequencer.leader, err = newLeaderElection(cfg.LeaderElection, stateIntf)
+		if err != nil {
+			return nil, err
+		}
 	}
 
+	if cfg.Finalizer.Throttle.Enabled {
+		sequencer.throttler, err = newTxThrottler(cfg.Finalizer.Throttle, batchCfg.Constraints)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.Preconfirmations.Enabled {
+		sequencer.preconfirmer, err = newPreconfirmer(cfg.Preconfirmations, txPool, addr, cfg.StreamServer.ChainID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create the preconfirmations signer, error: %w", err)
+		}
+	}
+
+	seqMetrics.Register()
+
 	// TODO: Make configurable
 	channelBufferSize := 200 * datastreamChannelMultiplier // nolint:gomnd
 	sequencer.dataToStream = make(chan interface{}, channelBufferSize)
@@ -73,11 +114,29 @@ func (s *Sequencer) Start(ctx context.Context) {
 		time.Sleep(time.Second)
 	}
 
+	// If the leader election is enabled we wait in standby until this node becomes the leader. Once elected, the finalizer
+	// resumes the sequencing from the WIP batch stored in the state by the previous leader
+	if s.leader != nil {
+		if err := s.leader.waitForLeadership(ctx); err != nil {
+			log.Errorf("stopped waiting for sequencer leadership, error: %v", err)
+			return
+		}
+
+		go s.leader.keepLeadership(ctx, func(err error) {
+			log.Fatalf("stopping sequencer, error: %v", err)
+		})
+	}
+
 	err := s.pool.MarkWIPTxsAsPending(ctx)
 	if err != nil {
 		log.Fatalf("failed to mark WIP txs as pending, error: %v", err)
 	}
 
+	err = s.pool.MarkWIPBundlesAsPending(ctx)
+	if err != nil {
+		log.Fatalf("failed to mark WIP bundles as pending, error: %v", err)
+	}
+
 	// Start stream server if enabled
 	if s.cfg.StreamServer.Enabled {
 		s.streamServer, err = datastreamer.NewServer(s.cfg.StreamServer.Port, s.cfg.StreamServer.Version, s.cfg.StreamServer.ChainID, state.StreamTypeSequencer, s.cfg.StreamServer.Filename, &s.cfg.StreamServer.Log)
@@ -100,9 +159,14 @@ func (s *Sequencer) Start(ctx context.Context) {
 	}
 
 	s.workerReadyTxsCond = newTimeoutCond(&sync.Mutex{})
-	s.worker = NewWorker(s.stateIntf, s.batchCfg.Constraints, s.workerReadyTxsCond)
-	s.finalizer = newFinalizer(s.cfg.Finalizer, s.poolCfg, s.worker, s.pool, s.stateIntf, s.etherman, s.address, s.isSynced, s.batchCfg.Constraints, s.eventLog, s.streamServer, s.workerReadyTxsCond, s.dataToStream)
-	go s.finalizer.Start(ctx)
+	s.worker = NewWorker(s.stateIntf, s.batchCfg.Constraints, s.txOrderingPolicy, s.throttler, s.workerReadyTxsCond)
+	f := newFinalizer(s.cfg.Finalizer, s.poolCfg, s.worker, s.pool, s.stateIntf, s.etherman, s.address, s.isSynced, s.batchCfg.Constraints, s.eventLog, s.streamServer, s.workerReadyTxsCond, s.dataToStream, s.leader, s.preconfirmer)
+	s.finalizer.Store(f)
+	go f.Start(ctx)
+
+	if s.preconfirmer != nil {
+		go s.preconfirmer.storePendingPreconfirmations(ctx)
+	}
 
 	go s.deleteOldPoolTxs(ctx)
 
@@ -129,7 +193,7 @@ func (s *Sequencer) checkStateInconsistency(ctx context.Context) {
 		}
 
 		if stateInconsistenciesDetected != s.numberOfStateInconsistencies {
-			s.finalizer.Halt(ctx, fmt.Errorf("state inconsistency detected, halting finalizer"), false)
+			s.finalizer.Load().Halt(ctx, fmt.Errorf("state inconsistency detected, halting finalizer"), false)
 		}
 
 		time.Sleep(s.cfg.StateConsistencyCheckInterval.Duration)
@@ -207,7 +271,19 @@ func (s *Sequencer) loadFromPool(ctx context.Context) {
 			}
 		}
 
-		if len(poolTransactions) == 0 {
+		poolBundles, err := s.pool.GetPendingBundles(ctx)
+		if err != nil && err != pool.ErrNotFound {
+			log.Errorf("error loading bundles from pool, error: %v", err)
+		}
+
+		for _, bundle := range poolBundles {
+			err := s.addBundleToWorker(ctx, bundle)
+			if err != nil {
+				log.Errorf("error adding bundle to worker, error: %v", err)
+			}
+		}
+
+		if len(poolTransactions) == 0 && len(poolBundles) == 0 {
 			time.Sleep(s.cfg.LoadPoolTxsCheckInterval.Duration)
 		}
 	}
@@ -218,6 +294,7 @@ func (s *Sequencer) addTxToWorker(ctx context.Context, tx pool.Transaction) erro
 	if err != nil {
 		return err
 	}
+	txTracker.PoolReceivedAt = tx.ReceivedAt
 	replacedTx, dropReason := s.worker.AddTxTracker(ctx, txTracker)
 	if dropReason != nil {
 		failedReason := dropReason.Error()
@@ -234,6 +311,16 @@ func (s *Sequencer) addTxToWorker(ctx context.Context, tx pool.Transaction) erro
 	}
 }
 
+func (s *Sequencer) addBundleToWorker(ctx context.Context, bundle pool.Bundle) error {
+	bundleTracker, err := newBundleTracker(bundle)
+	if err != nil {
+		failedReason := err.Error()
+		return s.pool.UpdateBundleStatus(ctx, bundle.Hash, pool.BundleStatusFailed, &failedReason)
+	}
+	s.worker.AddBundle(bundleTracker)
+	return s.pool.UpdateBundleStatus(ctx, bundle.Hash, pool.BundleStatusWIP, nil)
+}
+
 // sendDataToStreamer sends data to the data stream server
 func (s *Sequencer) sendDataToStreamer(chainID uint64) {
 	var err error
@@ -250,6 +337,11 @@ func (s *Sequencer) sendDataToStreamer(chainID uint64) {
 		// Read data from channel
 		dataStream := <-s.dataToStream
 
+		if request, ok := dataStream.(dataStreamRegenerationRequest); ok {
+			s.regenerateDataStream(request, chainID)
+			continue
+		}
+
 		if s.streamServer != nil {
 			switch data := dataStream.(type) {
 			// Stream a complete L2 block with its transactions
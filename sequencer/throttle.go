@@ -0,0 +1,210 @@
+package sequencer
+
+import (
+	"fmt"
+
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	maxThrottleResourcesPct = 100
+)
+
+// throttleLimit is the limit reached by a throttled address
+type throttleLimit int
+
+const (
+	// noThrottleLimit means that the address has not reached any limit
+	noThrottleLimit throttleLimit = iota
+	// l2BlockThrottleLimit means that the address has reached its max txs in the wip L2 block
+	l2BlockThrottleLimit
+	// batchThrottleLimit means that the address has reached its share of the wip batch resources
+	batchThrottleLimit
+)
+
+// txThrottler keeps track of the resources used by each sender and destination address in the wip batch and of their txs
+// in the wip L2 block, to defer the txs of the addresses that have reached their limits
+type txThrottler struct {
+	constraints state.BatchConstraintsCfg
+	sender      *addressThrottle
+	contract    *addressThrottle
+}
+
+// addressThrottle contains the limits and the current usage of the addresses for a role (sender or destination)
+type addressThrottle struct {
+	role       string
+	limits     ThrottleLimitsCfg
+	overrides  map[common.Address]ThrottleLimitsCfg
+	batchUsage map[common.Address]state.ZKCounters
+	l2BlockTxs map[common.Address]uint64
+}
+
+// newTxThrottler creates a new txThrottler for the given config, it returns an error if some of the limits is out of range
+func newTxThrottler(cfg ThrottleCfg, constraints state.BatchConstraintsCfg) (*txThrottler, error) {
+	sender, err := newAddressThrottle("sender", cfg.Sender, cfg.SenderOverrides)
+	if err != nil {
+		return nil, err
+	}
+	contract, err := newAddressThrottle("contract", cfg.Contract, cfg.ContractOverrides)
+	if err != nil {
+		return nil, err
+	}
+
+	return &txThrottler{
+		constraints: constraints,
+		sender:      sender,
+		contract:    contract,
+	}, nil
+}
+
+func newAddressThrottle(role string, limits ThrottleLimitsCfg, overrides []ThrottleOverrideCfg) (*addressThrottle, error) {
+	if limits.MaxBatchResourcesPct > maxThrottleResourcesPct {
+		return nil, fmt.Errorf("%w: %s MaxBatchResourcesPct %d is greater than %d", ErrInvalidThrottleLimit, role, limits.MaxBatchResourcesPct, maxThrottleResourcesPct)
+	}
+
+	a := &addressThrottle{
+		role:       role,
+		limits:     limits,
+		overrides:  make(map[common.Address]ThrottleLimitsCfg, len(overrides)),
+		batchUsage: make(map[common.Address]state.ZKCounters),
+		l2BlockTxs: make(map[common.Address]uint64),
+	}
+
+	for _, o := range overrides {
+		if o.Limits.MaxBatchResourcesPct > maxThrottleResourcesPct {
+			return nil, fmt.Errorf("%w: %s %s MaxBatchResourcesPct %d is greater than %d", ErrInvalidThrottleLimit, role, o.Address, o.Limits.MaxBatchResourcesPct, maxThrottleResourcesPct)
+		}
+		a.overrides[o.Address] = o.Limits
+	}
+
+	return a, nil
+}
+
+// isThrottled returns the limit reached by the sender or the destination address of the tx if the tx must be deferred,
+// and the reason
+func (t *txThrottler) isThrottled(tx *TxTracker) (throttleLimit, string) {
+	if limit, reason := t.sender.isThrottled(tx.From, 1, tx.ReservedZKCounters, t.constraints); limit != noThrottleLimit {
+		return limit, reason
+	}
+	if tx.To != nil {
+		return t.contract.isThrottled(*tx.To, 1, tx.ReservedZKCounters, t.constraints)
+	}
+	return noThrottleLimit, ""
+}
+
+// isBundleThrottled returns the limit reached by some of the senders or destination addresses of the bundle txs if the bundle
+// must be deferred, and the reason. As the counters of each tx of a bundle are unknown, the reserved counters of the whole bundle
+// are checked against the usage of each of its addresses
+func (t *txThrottler) isBundleThrottled(bundle *BundleTracker) (throttleLimit, string) {
+	senders, contracts := bundleAddresses(bundle)
+	for addr, txs := range senders {
+		if limit, reason := t.sender.isThrottled(addr, txs, bundle.ReservedZKCounters, t.constraints); limit != noThrottleLimit {
+			return limit, reason
+		}
+	}
+	for addr, txs := range contracts {
+		if limit, reason := t.contract.isThrottled(addr, txs, bundle.ReservedZKCounters, t.constraints); limit != noThrottleLimit {
+			return limit, reason
+		}
+	}
+	return noThrottleLimit, ""
+}
+
+// addUsage adds the resources used by a tx sequenced in the wip L2 block to the usage of its sender and destination address
+func (t *txThrottler) addUsage(tx *TxTracker, usedZKCounters state.ZKCounters) {
+	t.sender.addUsage(tx.From, 1, usedZKCounters)
+	if tx.To != nil {
+		t.contract.addUsage(*tx.To, 1, usedZKCounters)
+	}
+}
+
+// addBundleUsage adds the resources used by a bundle sequenced in the wip L2 block to the usage of each of the senders and
+// destination addresses of its txs
+func (t *txThrottler) addBundleUsage(bundle *BundleTracker, usedZKCounters state.ZKCounters) {
+	senders, contracts := bundleAddresses(bundle)
+	for addr, txs := range senders {
+		t.sender.addUsage(addr, txs, usedZKCounters)
+	}
+	for addr, txs := range contracts {
+		t.contract.addUsage(addr, txs, usedZKCounters)
+	}
+}
+
+// bundleAddresses returns the number of txs of the bundle for each sender and destination address
+func bundleAddresses(bundle *BundleTracker) (map[common.Address]uint64, map[common.Address]uint64) {
+	senders := make(map[common.Address]uint64)
+	contracts := make(map[common.Address]uint64)
+	for _, tx := range bundle.Txs {
+		senders[tx.From]++
+		if tx.To != nil {
+			contracts[*tx.To]++
+		}
+	}
+	return senders, contracts
+}
+
+// resetBatchUsage resets the batch resources used by the addresses
+func (t *txThrottler) resetBatchUsage() {
+	t.sender.batchUsage = make(map[common.Address]state.ZKCounters)
+	t.contract.batchUsage = make(map[common.Address]state.ZKCounters)
+}
+
+// resetL2BlockUsage resets the txs of the addresses in the L2 block
+func (t *txThrottler) resetL2BlockUsage() {
+	t.sender.l2BlockTxs = make(map[common.Address]uint64)
+	t.contract.l2BlockTxs = make(map[common.Address]uint64)
+}
+
+func (a *addressThrottle) limitsOf(addr common.Address) ThrottleLimitsCfg {
+	if limits, found := a.overrides[addr]; found {
+		return limits
+	}
+	return a.limits
+}
+
+// isThrottled checks if adding the txs and the counters to the usage of the address exceeds its limits. The limits are only
+// checked if the address has already been used in the L2 block or in the batch, so a tx (or bundle) that alone exceeds the
+// limits of the address can still be sequenced as the first one of the address in an L2 block or in a batch
+func (a *addressThrottle) isThrottled(addr common.Address, txs uint64, counters state.ZKCounters, constraints state.BatchConstraintsCfg) (throttleLimit, string) {
+	limits := a.limitsOf(addr)
+
+	if l2BlockTxs := a.l2BlockTxs[addr]; limits.MaxTxsPerL2Block > 0 && l2BlockTxs > 0 && l2BlockTxs+txs > limits.MaxTxsPerL2Block {
+		return l2BlockThrottleLimit, fmt.Sprintf("%s %s reached max txs per L2 block %d", a.role, addr, limits.MaxTxsPerL2Block)
+	}
+
+	if limits.MaxBatchResourcesPct == 0 {
+		return noThrottleLimit, ""
+	}
+
+	usage, found := a.batchUsage[addr]
+	if !found {
+		return noThrottleLimit, ""
+	}
+	usage.SumUp(counters)
+
+	pct := uint64(limits.MaxBatchResourcesPct)
+	for _, r := range []struct {
+		name string
+		used uint64
+		max  uint64
+	}{
+		{"Steps", uint64(usage.Steps), uint64(constraints.MaxSteps)},
+		{"KeccakHashes", uint64(usage.KeccakHashes), uint64(constraints.MaxKeccakHashes)},
+		{"PoseidonHashes", uint64(usage.PoseidonHashes), uint64(constraints.MaxPoseidonHashes)},
+		{"GasUsed", usage.GasUsed, constraints.MaxCumulativeGasUsed},
+	} {
+		if r.used*maxThrottleResourcesPct > r.max*pct {
+			return batchThrottleLimit, fmt.Sprintf("%s %s reached %d%% of batch %s", a.role, addr, pct, r.name)
+		}
+	}
+
+	return noThrottleLimit, ""
+}
+
+func (a *addressThrottle) addUsage(addr common.Address, txs uint64, counters state.ZKCounters) {
+	usage := a.batchUsage[addr]
+	usage.SumUp(counters)
+	a.batchUsage[addr] = usage
+	a.l2BlockTxs[addr] += txs
+}
@@ -0,0 +1,238 @@
+package sequencer
+
+import (
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTxThrottler(t *testing.T) {
+	testCases := []struct {
+		name        string
+		cfg         ThrottleCfg
+		expectedErr error
+	}{
+		{name: "valid config", cfg: ThrottleCfg{Enabled: true, Sender: ThrottleLimitsCfg{MaxBatchResourcesPct: 20, MaxTxsPerL2Block: 5}}},
+		{name: "sender pct out of range", cfg: ThrottleCfg{Enabled: true, Sender: ThrottleLimitsCfg{MaxBatchResourcesPct: 101}}, expectedErr: ErrInvalidThrottleLimit},
+		{name: "contract pct out of range", cfg: ThrottleCfg{Enabled: true, Contract: ThrottleLimitsCfg{MaxBatchResourcesPct: 200}}, expectedErr: ErrInvalidThrottleLimit},
+		{
+			name:        "override pct out of range",
+			cfg:         ThrottleCfg{Enabled: true, SenderOverrides: []ThrottleOverrideCfg{{Address: common.Address{1}, Limits: ThrottleLimitsCfg{MaxBatchResourcesPct: 101}}}},
+			expectedErr: ErrInvalidThrottleLimit,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := newTxThrottler(tc.cfg, rcMax)
+			assert.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestTxThrottlerIsThrottled(t *testing.T) {
+	sender := common.Address{1}
+	privileged := common.Address{2}
+	contract := common.Address{3}
+	otherContract := common.Address{4}
+
+	counters := func(n uint32) state.ZKCounters {
+		return state.ZKCounters{GasUsed: uint64(n), KeccakHashes: n, PoseidonHashes: n, Steps: n}
+	}
+
+	type sequencedTx struct {
+		from     common.Address
+		to       *common.Address
+		counters state.ZKCounters
+	}
+
+	testCases := []struct {
+		name          string
+		sequenced     []sequencedTx
+		resetBatch    bool
+		resetL2Block  bool
+		tx            *TxTracker
+		expectedLimit throttleLimit
+	}{
+		{
+			name:          "first tx of the sender in the batch exceeding its share",
+			tx:            &TxTracker{From: sender, ReservedZKCounters: counters(5)},
+			expectedLimit: noThrottleLimit,
+		},
+		{
+			name:          "sender within its share",
+			sequenced:     []sequencedTx{{from: sender, counters: counters(1)}},
+			tx:            &TxTracker{From: sender, ReservedZKCounters: counters(1)},
+			expectedLimit: noThrottleLimit,
+		},
+		{
+			name:          "sender exceeds its share",
+			sequenced:     []sequencedTx{{from: sender, counters: counters(2)}},
+			tx:            &TxTracker{From: sender, ReservedZKCounters: counters(1)},
+			expectedLimit: batchThrottleLimit,
+		},
+		{
+			name:          "sender exceeds its share of a single resource",
+			sequenced:     []sequencedTx{{from: sender, counters: state.ZKCounters{Steps: 3}}},
+			tx:            &TxTracker{From: sender},
+			expectedLimit: batchThrottleLimit,
+		},
+		{
+			name:          "sender reaches max txs per L2 block",
+			sequenced:     []sequencedTx{{from: sender}, {from: sender}},
+			tx:            &TxTracker{From: sender},
+			expectedLimit: l2BlockThrottleLimit,
+		},
+		{
+			name:          "sender share is reset with a new batch",
+			sequenced:     []sequencedTx{{from: sender, counters: counters(2)}},
+			resetBatch:    true,
+			tx:            &TxTracker{From: sender, ReservedZKCounters: counters(1)},
+			expectedLimit: noThrottleLimit,
+		},
+		{
+			name:          "sender txs are reset with a new L2 block",
+			sequenced:     []sequencedTx{{from: sender}, {from: sender}},
+			resetL2Block:  true,
+			tx:            &TxTracker{From: sender},
+			expectedLimit: noThrottleLimit,
+		},
+		{
+			name:          "override limits of the sender",
+			sequenced:     []sequencedTx{{from: privileged, counters: counters(5)}, {from: privileged}, {from: privileged}},
+			tx:            &TxTracker{From: privileged, ReservedZKCounters: counters(1)},
+			expectedLimit: noThrottleLimit,
+		},
+		{
+			name:          "contract reaches max txs per L2 block",
+			sequenced:     []sequencedTx{{from: common.Address{5}, to: &contract}},
+			tx:            &TxTracker{From: sender, To: &contract},
+			expectedLimit: l2BlockThrottleLimit,
+		},
+		{
+			name:          "contract limits don't apply to other contracts",
+			sequenced:     []sequencedTx{{from: common.Address{5}, to: &contract}},
+			tx:            &TxTracker{From: sender, To: &otherContract},
+			expectedLimit: noThrottleLimit,
+		},
+		{
+			name:          "contract limits don't apply to contract creations",
+			sequenced:     []sequencedTx{{from: common.Address{5}, to: &contract}},
+			tx:            &TxTracker{From: sender},
+			expectedLimit: noThrottleLimit,
+		},
+	}
+
+	cfg := ThrottleCfg{
+		Enabled:  true,
+		Sender:   ThrottleLimitsCfg{MaxBatchResourcesPct: 20, MaxTxsPerL2Block: 2},
+		Contract: ThrottleLimitsCfg{MaxTxsPerL2Block: 1},
+		SenderOverrides: []ThrottleOverrideCfg{
+			{Address: privileged, Limits: ThrottleLimitsCfg{}},
+		},
+		ContractOverrides: []ThrottleOverrideCfg{
+			{Address: otherContract, Limits: ThrottleLimitsCfg{MaxTxsPerL2Block: 10}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			throttler, err := newTxThrottler(cfg, rcMax)
+			require.NoError(t, err)
+
+			for _, s := range tc.sequenced {
+				throttler.addUsage(&TxTracker{From: s.from, To: s.to}, s.counters)
+			}
+			if tc.resetBatch {
+				throttler.resetBatchUsage()
+			}
+			if tc.resetL2Block {
+				throttler.resetL2BlockUsage()
+			}
+
+			limit, reason := throttler.isThrottled(tc.tx)
+			assert.Equal(t, tc.expectedLimit, limit, reason)
+		})
+	}
+}
+
+func TestTxThrottlerIsBundleThrottled(t *testing.T) {
+	sender := common.Address{1}
+	otherSender := common.Address{2}
+	contract := common.Address{3}
+
+	counters := func(n uint32) state.ZKCounters {
+		return state.ZKCounters{GasUsed: uint64(n), KeccakHashes: n, PoseidonHashes: n, Steps: n}
+	}
+
+	testCases := []struct {
+		name          string
+		sequenced     []*TxTracker
+		bundle        *BundleTracker
+		expectedLimit throttleLimit
+	}{
+		{
+			name:          "bundle within the limits of the sender",
+			bundle:        &BundleTracker{Txs: []*TxTracker{{From: sender}, {From: sender}}},
+			expectedLimit: noThrottleLimit,
+		},
+		{
+			name:          "first bundle of the sender in the L2 block exceeding its max txs",
+			bundle:        &BundleTracker{Txs: []*TxTracker{{From: sender}, {From: sender}, {From: sender}}},
+			expectedLimit: noThrottleLimit,
+		},
+		{
+			name:          "bundle txs exceed the max txs per L2 block of the sender",
+			sequenced:     []*TxTracker{{From: sender}},
+			bundle:        &BundleTracker{Txs: []*TxTracker{{From: otherSender}, {From: sender}, {From: sender}}},
+			expectedLimit: l2BlockThrottleLimit,
+		},
+		{
+			name:          "bundle exceeds the share of the sender",
+			sequenced:     []*TxTracker{{From: sender, ReservedZKCounters: counters(1)}},
+			bundle:        &BundleTracker{Txs: []*TxTracker{{From: sender}}, ReservedZKCounters: counters(2)},
+			expectedLimit: batchThrottleLimit,
+		},
+		{
+			name:          "contract reaches max txs per L2 block",
+			sequenced:     []*TxTracker{{From: otherSender, To: &contract}},
+			bundle:        &BundleTracker{Txs: []*TxTracker{{From: sender, To: &contract}}},
+			expectedLimit: l2BlockThrottleLimit,
+		},
+	}
+
+	cfg := ThrottleCfg{
+		Enabled:  true,
+		Sender:   ThrottleLimitsCfg{MaxBatchResourcesPct: 20, MaxTxsPerL2Block: 2},
+		Contract: ThrottleLimitsCfg{MaxTxsPerL2Block: 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			throttler, err := newTxThrottler(cfg, rcMax)
+			require.NoError(t, err)
+
+			for _, tx := range tc.sequenced {
+				throttler.addUsage(tx, tx.ReservedZKCounters)
+			}
+
+			limit, reason := throttler.isBundleThrottled(tc.bundle)
+			assert.Equal(t, tc.expectedLimit, limit, reason)
+		})
+	}
+
+	t.Run("bundle usage is added to each of its addresses", func(t *testing.T) {
+		throttler, err := newTxThrottler(cfg, rcMax)
+		require.NoError(t, err)
+
+		throttler.addBundleUsage(&BundleTracker{Txs: []*TxTracker{{From: sender}, {From: sender}, {From: otherSender}}}, counters(1))
+
+		limit, _ := throttler.isThrottled(&TxTracker{From: sender})
+		assert.Equal(t, l2BlockThrottleLimit, limit)
+		limit, _ = throttler.isThrottled(&TxTracker{From: otherSender, ReservedZKCounters: counters(2)})
+		assert.Equal(t, batchThrottleLimit, limit)
+	})
+}
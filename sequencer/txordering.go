@@ -0,0 +1,185 @@
+package sequencer
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/0xPolygonHermez/zkevm-node/state"
+)
+
+const (
+	// TxOrderingPolicyGasPrice sorts the ready txs by gas price, highest first
+	TxOrderingPolicyGasPrice = "gasprice"
+	// TxOrderingPolicyFIFO sorts the ready txs by the time the pool received them, oldest first
+	TxOrderingPolicyFIFO = "fifo"
+	// TxOrderingPolicyEfficiency sorts the ready txs by the fee paid per fraction of the batch resources used, highest first
+	TxOrderingPolicyEfficiency = "efficiency"
+	// TxOrderingPolicyRoundRobin sorts the ready txs giving a turn to each sender, breaking ties by arrival time
+	TxOrderingPolicyRoundRobin = "roundrobin"
+)
+
+// TxOrderingPolicy defines the order in which the worker offers the ready txs to the finalizer.
+// All the methods are called with the worker locked, therefore the implementations don't need
+// to be thread safe
+type TxOrderingPolicy interface {
+	// Name returns the name of the policy, used as label in the metrics
+	Name() string
+	// OnReady is called when a tx becomes ready (or its ZK counters are updated) just before adding it to the sorted list.
+	// The policy can compute here the sort key it needs to store in the tx
+	OnReady(tx *TxTracker)
+	// Before returns true if tx1 must be offered to the finalizer before tx2. Txs for which Before returns false in both
+	// directions keep their arrival order in the sorted list
+	Before(tx1 *TxTracker, tx2 *TxTracker) bool
+	// OnSelected is called when the worker returns a tx to the finalizer
+	OnSelected(tx *TxTracker)
+}
+
+// NewTxOrderingPolicy creates the tx ordering policy with the given name
+func NewTxOrderingPolicy(name string, constraints state.BatchConstraintsCfg) (TxOrderingPolicy, error) {
+	switch name {
+	case TxOrderingPolicyGasPrice, "":
+		return &gasPricePolicy{}, nil
+	case TxOrderingPolicyFIFO:
+		return &fifoPolicy{}, nil
+	case TxOrderingPolicyEfficiency:
+		return &efficiencyPolicy{constraints: constraints}, nil
+	case TxOrderingPolicyRoundRobin:
+		return newRoundRobinPolicy(), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownTxOrderingPolicy, name)
+	}
+}
+
+// gasPricePolicy sorts the txs by gas price
+type gasPricePolicy struct{}
+
+func (p *gasPricePolicy) Name() string { return TxOrderingPolicyGasPrice }
+
+func (p *gasPricePolicy) OnReady(tx *TxTracker) {}
+
+func (p *gasPricePolicy) Before(tx1 *TxTracker, tx2 *TxTracker) bool {
+	return tx1.GasPrice.Cmp(tx2.GasPrice) > 0
+}
+
+func (p *gasPricePolicy) OnSelected(tx *TxTracker) {}
+
+// fifoPolicy sorts the txs by the time the pool received them
+type fifoPolicy struct{}
+
+func (p *fifoPolicy) Name() string { return TxOrderingPolicyFIFO }
+
+func (p *fifoPolicy) OnReady(tx *TxTracker) {}
+
+func (p *fifoPolicy) Before(tx1 *TxTracker, tx2 *TxTracker) bool {
+	return tx1.PoolReceivedAt.Before(tx2.PoolReceivedAt)
+}
+
+func (p *fifoPolicy) OnSelected(tx *TxTracker) {}
+
+// efficiencyPolicy sorts the txs by the fee they pay divided by the fraction of the batch resources they reserve
+type efficiencyPolicy struct {
+	constraints state.BatchConstraintsCfg
+}
+
+func (p *efficiencyPolicy) Name() string { return TxOrderingPolicyEfficiency }
+
+func (p *efficiencyPolicy) OnReady(tx *TxTracker) {
+	tx.Efficiency = p.efficiency(tx)
+}
+
+func (p *efficiencyPolicy) Before(tx1 *TxTracker, tx2 *TxTracker) bool {
+	return tx1.Efficiency > tx2.Efficiency
+}
+
+func (p *efficiencyPolicy) OnSelected(tx *TxTracker) {}
+
+// efficiency returns the fee paid by the tx divided by the highest fraction of a batch resource the tx reserves,
+// so txs that pay the same but exhaust a resource (counter or bytes) faster are sorted later
+func (p *efficiencyPolicy) efficiency(tx *TxTracker) float64 {
+	gas := tx.UsedZKCounters.GasUsed
+	if gas == 0 {
+		gas = tx.Gas
+	}
+	fee, _ := new(big.Float).Mul(new(big.Float).SetInt(tx.GasPrice), new(big.Float).SetUint64(gas)).Float64()
+
+	c := p.constraints
+	counters := tx.ReservedZKCounters
+	fraction := 0.0
+	for _, r := range []struct {
+		used uint64
+		max  uint64
+	}{
+		{counters.GasUsed, c.MaxCumulativeGasUsed},
+		{uint64(counters.KeccakHashes), uint64(c.MaxKeccakHashes)},
+		{uint64(counters.PoseidonHashes), uint64(c.MaxPoseidonHashes)},
+		{uint64(counters.PoseidonPaddings), uint64(c.MaxPoseidonPaddings)},
+		{uint64(counters.MemAligns), uint64(c.MaxMemAligns)},
+		{uint64(counters.Arithmetics), uint64(c.MaxArithmetics)},
+		{uint64(counters.Binaries), uint64(c.MaxBinaries)},
+		{uint64(counters.Steps), uint64(c.MaxSteps)},
+		{uint64(counters.Sha256Hashes_V2), uint64(c.MaxSHA256Hashes)},
+		{tx.Bytes, c.MaxBatchBytesSize},
+	} {
+		if r.max == 0 {
+			continue
+		}
+		if f := float64(r.used) / float64(r.max); f > fraction {
+			fraction = f
+		}
+	}
+
+	if fraction == 0 {
+		return fee
+	}
+	return fee / fraction
+}
+
+// roundRobinPolicy gives a turn to each sender. Each ready tx is assigned to the first round in which its sender
+// hasn't had a tx selected yet, and the txs are sorted by round and then by arrival time. This way a sender with
+// many txs can't delay the txs of the rest of the senders
+type roundRobinPolicy struct {
+	currentRound uint64
+	nextRound    map[string]uint64
+}
+
+func newRoundRobinPolicy() *roundRobinPolicy {
+	return &roundRobinPolicy{
+		currentRound: 1,
+		nextRound:    make(map[string]uint64),
+	}
+}
+
+func (p *roundRobinPolicy) Name() string { return TxOrderingPolicyRoundRobin }
+
+func (p *roundRobinPolicy) OnReady(tx *TxTracker) {
+	if tx.orderingRound != 0 {
+		// The tx already has a round assigned (its ZK counters have been updated), we keep its turn
+		return
+	}
+	tx.orderingRound = p.currentRound
+	if next := p.nextRound[tx.FromStr]; next > tx.orderingRound {
+		tx.orderingRound = next
+	}
+}
+
+func (p *roundRobinPolicy) Before(tx1 *TxTracker, tx2 *TxTracker) bool {
+	if tx1.orderingRound != tx2.orderingRound {
+		return tx1.orderingRound < tx2.orderingRound
+	}
+	return tx1.PoolReceivedAt.Before(tx2.PoolReceivedAt)
+}
+
+func (p *roundRobinPolicy) OnSelected(tx *TxTracker) {
+	if tx.orderingRound > p.currentRound {
+		p.currentRound = tx.orderingRound
+		// Senders whose next round is already reached don't need to be tracked anymore
+		for from, next := range p.nextRound {
+			if next <= p.currentRound {
+				delete(p.nextRound, from)
+			}
+		}
+	}
+	if next := tx.orderingRound + 1; next > p.nextRound[tx.FromStr] {
+		p.nextRound[tx.FromStr] = next
+	}
+}
@@ -0,0 +1,130 @@
+package sequencer
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTxOrderingPolicy(t *testing.T) {
+	testCases := []struct {
+		name         string
+		policy       string
+		expectedName string
+		expectedErr  error
+	}{
+		{name: "default", policy: "", expectedName: TxOrderingPolicyGasPrice},
+		{name: "gas price", policy: TxOrderingPolicyGasPrice, expectedName: TxOrderingPolicyGasPrice},
+		{name: "fifo", policy: TxOrderingPolicyFIFO, expectedName: TxOrderingPolicyFIFO},
+		{name: "efficiency", policy: TxOrderingPolicyEfficiency, expectedName: TxOrderingPolicyEfficiency},
+		{name: "round-robin", policy: TxOrderingPolicyRoundRobin, expectedName: TxOrderingPolicyRoundRobin},
+		{name: "unknown", policy: "lottery", expectedErr: ErrUnknownTxOrderingPolicy},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			policy, err := NewTxOrderingPolicy(tc.policy, rcMax)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedName, policy.Name())
+		})
+	}
+}
+
+func TestTxOrderingPolicies(t *testing.T) {
+	now := time.Now()
+	// the worker loads the txs from the pool in the reverse order in which the pool received them
+	txs := []*TxTracker{
+		// cheap, old and light
+		{HashStr: "0x01", FromStr: "0xA", GasPrice: big.NewInt(10), Gas: 1, ReceivedAt: now.Add(2 * time.Second), PoolReceivedAt: now, ReservedZKCounters: state.ZKCounters{GasUsed: 1, Steps: 1}},
+		// expensive, recent and heavy
+		{HashStr: "0x02", FromStr: "0xB", GasPrice: big.NewInt(30), Gas: 1, ReceivedAt: now, PoolReceivedAt: now.Add(2 * time.Second), ReservedZKCounters: state.ZKCounters{GasUsed: 1, Steps: 5}},
+		// medium price, medium age and light
+		{HashStr: "0x03", FromStr: "0xC", GasPrice: big.NewInt(20), Gas: 1, ReceivedAt: now.Add(time.Second), PoolReceivedAt: now.Add(time.Second), ReservedZKCounters: state.ZKCounters{GasUsed: 1, Steps: 1}},
+	}
+
+	testCases := []struct {
+		policy   string
+		expected []string
+	}{
+		{policy: TxOrderingPolicyGasPrice, expected: []string{"0x02", "0x03", "0x01"}},
+		{policy: TxOrderingPolicyFIFO, expected: []string{"0x01", "0x03", "0x02"}},
+		{policy: TxOrderingPolicyEfficiency, expected: []string{"0x03", "0x01", "0x02"}},
+		{policy: TxOrderingPolicyRoundRobin, expected: []string{"0x01", "0x03", "0x02"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.policy, func(t *testing.T) {
+			policy, err := NewTxOrderingPolicy(tc.policy, rcMax)
+			require.NoError(t, err)
+
+			el := newTxSortedList(policy)
+			for _, tx := range txs {
+				tx.orderingRound = 0
+				policy.OnReady(tx)
+				el.add(tx)
+			}
+
+			sorted := []string{}
+			for _, tx := range el.GetSorted() {
+				sorted = append(sorted, tx.HashStr)
+			}
+			assert.Equal(t, tc.expected, sorted)
+
+			for _, tx := range txs {
+				assert.True(t, el.delete(tx))
+			}
+			assert.Equal(t, 0, el.len())
+		})
+	}
+}
+
+func TestTxOrderingPolicyRoundRobin(t *testing.T) {
+	now := time.Now()
+	// Sender 0xA floods the worker with txs before 0xB and 0xC send a single tx each
+	pending := map[string][]*TxTracker{
+		"0xA": {
+			{HashStr: "0xA1", FromStr: "0xA", PoolReceivedAt: now},
+			{HashStr: "0xA2", FromStr: "0xA", PoolReceivedAt: now.Add(1 * time.Millisecond)},
+			{HashStr: "0xA3", FromStr: "0xA", PoolReceivedAt: now.Add(2 * time.Millisecond)},
+		},
+		"0xB": {{HashStr: "0xB1", FromStr: "0xB", PoolReceivedAt: now.Add(3 * time.Millisecond)}},
+		"0xC": {{HashStr: "0xC1", FromStr: "0xC", PoolReceivedAt: now.Add(4 * time.Millisecond)}},
+	}
+
+	policy := newRoundRobinPolicy()
+	el := newTxSortedList(policy)
+	addReady := func(from string) {
+		if len(pending[from]) == 0 {
+			return
+		}
+		tx := pending[from][0]
+		pending[from] = pending[from][1:]
+		policy.OnReady(tx)
+		el.add(tx)
+	}
+	addReady("0xA")
+	addReady("0xB")
+	addReady("0xC")
+
+	selected := []string{}
+	for el.len() > 0 {
+		tx := el.getByIndex(0)
+		policy.OnSelected(tx)
+		selected = append(selected, tx.HashStr)
+		require.True(t, el.delete(tx))
+		// The next tx of the sender becomes ready once the selected one has been executed
+		addReady(tx.FromStr)
+	}
+
+	assert.Equal(t, []string{"0xA1", "0xB1", "0xC1", "0xA2", "0xA3"}, selected)
+	assert.Empty(t, policy.nextRound["0xB"])
+	assert.Empty(t, policy.nextRound["0xC"])
+}
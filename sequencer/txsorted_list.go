@@ -8,18 +8,20 @@ import (
 	"github.com/0xPolygonHermez/zkevm-node/log"
 )
 
-// txSortedList represents a list of tx sorted by gasPrice
+// txSortedList represents a list of tx sorted by the tx ordering policy
 type txSortedList struct {
 	list   map[string]*TxTracker
 	sorted []*TxTracker
+	policy TxOrderingPolicy
 	mutex  sync.Mutex
 }
 
 // newTxSortedList creates and init an txSortedList
-func newTxSortedList() *txSortedList {
+func newTxSortedList(policy TxOrderingPolicy) *txSortedList {
 	return &txSortedList{
 		list:   make(map[string]*TxTracker),
 		sorted: []*TxTracker{},
+		policy: policy,
 	}
 }
 
@@ -47,18 +49,18 @@ func (e *txSortedList) delete(tx *TxTracker) bool {
 			return e.isGreaterOrEqualThan(tx, e.list[e.sorted[i].HashStr])
 		})
 
-		// i is the index of the first tx that has equal (or lower) gasPrice than the tx. From here we need to go down in the list
+		// i is the index of the first tx that has equal (or lower) priority than the tx. From here we need to go down in the list
 		// looking for the sorted[i].HashStr equal to tx.HashStr to get the index of tx in the sorted slice.
-		// We need to go down until we find the tx or we have a tx with different (lower) gasPrice or we reach the end of the list
+		// We need to go down until we find the tx or we have a tx with different (lower) priority or we reach the end of the list
 		for {
 			if i == sLen {
 				log.Warnf("error deleting tx %s from txSortedList, we reach the end of the list", tx.HashStr)
 				return false
 			}
 
-			if (e.sorted[i].GasPrice.Cmp(tx.GasPrice)) != 0 {
-				// we have a tx with different (lower) GasPrice than the tx we are looking for, therefore we haven't found the tx
-				log.Warnf("error deleting tx %s from txSortedList, not found in the list of txs with same gasPrice: %s", tx.HashStr)
+			if e.policy.Before(tx, e.sorted[i]) {
+				// we have a tx with different (lower) priority than the tx we are looking for, therefore we haven't found the tx
+				log.Warnf("error deleting tx %s from txSortedList, not found in the list of txs with same priority", tx.HashStr)
 				return false
 			}
 
@@ -123,24 +125,14 @@ func (e *txSortedList) addSort(tx *TxTracker) {
 	log.Debugf("added tx %s with  gasPrice %d to txSortedList at index %d from total %d", tx.HashStr, tx.GasPrice, i, len(e.sorted))
 }
 
-// isGreaterThan returns true if the tx1 has greater gasPrice than tx2
+// isGreaterThan returns true if the tx1 has greater priority than tx2 for the tx ordering policy
 func (e *txSortedList) isGreaterThan(tx1 *TxTracker, tx2 *TxTracker) bool {
-	cmp := tx1.GasPrice.Cmp(tx2.GasPrice)
-	if cmp == 1 {
-		return true
-	} else {
-		return false
-	}
+	return e.policy.Before(tx1, tx2)
 }
 
-// isGreaterOrEqualThan returns true if the tx1 has greater or equal gasPrice than tx2
+// isGreaterOrEqualThan returns true if the tx1 has greater or equal priority than tx2 for the tx ordering policy
 func (e *txSortedList) isGreaterOrEqualThan(tx1 *TxTracker, tx2 *TxTracker) bool {
-	cmp := tx1.GasPrice.Cmp(tx2.GasPrice)
-	if cmp >= 0 {
-		return true
-	} else {
-		return false
-	}
+	return !e.policy.Before(tx2, tx1)
 }
 
 // GetSorted returns the sorted list of tx
@@ -25,7 +25,7 @@ func randomBigInt() *big.Int {
 }
 
 func TestTxSortedList(t *testing.T) {
-	el := newTxSortedList()
+	el := newTxSortedList(&gasPricePolicy{})
 	nItems := 100
 
 	for i := 0; i < nItems; i++ {
@@ -46,7 +46,7 @@ func TestTxSortedList(t *testing.T) {
 }
 
 func TestTxSortedListDelete(t *testing.T) {
-	el := newTxSortedList()
+	el := newTxSortedList(&gasPricePolicy{})
 
 	el.add(&TxTracker{HashStr: "0x01", GasPrice: new(big.Int).SetInt64(10)})
 	el.add(&TxTracker{HashStr: "0x02", GasPrice: new(big.Int).SetInt64(20)})
@@ -88,7 +88,7 @@ func TestTxSortedListDelete(t *testing.T) {
 }
 
 func TestTxSortedListBench(t *testing.T) {
-	el := newTxSortedList()
+	el := newTxSortedList(&gasPricePolicy{})
 
 	start := time.Now()
 	for i := 0; i < 10000; i++ {
@@ -15,6 +15,7 @@ type TxTracker struct {
 	HashStr            string
 	From               common.Address
 	FromStr            string
+	To                 *common.Address // Destination address, nil for contract creations
 	Nonce              uint64
 	Gas                uint64 // To check if it fits into a batch
 	GasPrice           *big.Int
@@ -24,6 +25,7 @@ type TxTracker struct {
 	ReservedZKCounters state.ZKCounters
 	RawTx              []byte
 	ReceivedAt         time.Time // To check if it has been in the txSortedList for too long
+	PoolReceivedAt     time.Time // Time the tx was received by the pool, to sort the txs by arrival
 	IP                 string    // IP of the tx sender
 	FailedReason       *string   // FailedReason is the reason why the tx failed, if it failed
 	EffectiveGasPrice  *big.Int
@@ -32,6 +34,8 @@ type TxTracker struct {
 	EGPLog             state.EffectiveGasPriceLog
 	L1GasPrice         uint64
 	L2GasPrice         uint64
+	Efficiency         float64 // Sort key used by the efficiency tx ordering policy
+	orderingRound      uint64  // Sort key used by the round-robin tx ordering policy
 }
 
 // newTxTracker creates and inti a TxTracker
@@ -51,15 +55,17 @@ func newTxTracker(tx types.Transaction, usedZKCounters state.ZKCounters, reserve
 		HashStr:            tx.Hash().String(),
 		From:               addr,
 		FromStr:            addr.String(),
+		To:                 tx.To(),
 		Nonce:              tx.Nonce(),
 		Gas:                tx.Gas(),
-		GasPrice:           tx.GasPrice(),
+		GasPrice:           state.TxGasPrice(&tx),
 		Cost:               tx.Cost(),
 		Bytes:              uint64(len(rawTx)) + state.EfficiencyPercentageByteLength,
 		UsedZKCounters:     usedZKCounters,
 		ReservedZKCounters: reservedZKCounters,
 		RawTx:              rawTx,
 		ReceivedAt:         time.Now(),
+		PoolReceivedAt:     time.Now(),
 		IP:                 ip,
 		EffectiveGasPrice:  new(big.Int).SetUint64(0),
 		EGPLog: state.EffectiveGasPriceLog{
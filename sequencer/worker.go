@@ -10,6 +10,7 @@ import (
 
 	"github.com/0xPolygonHermez/zkevm-node/log"
 	"github.com/0xPolygonHermez/zkevm-node/pool"
+	seqMetrics "github.com/0xPolygonHermez/zkevm-node/sequencer/metrics"
 	"github.com/0xPolygonHermez/zkevm-node/state"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -19,19 +20,25 @@ import (
 type Worker struct {
 	pool             map[string]*addrQueue
 	txSortedList     *txSortedList
+	bundles          []*BundleTracker
 	workerMutex      sync.Mutex
 	state            stateInterface
 	batchConstraints state.BatchConstraintsCfg
+	txOrderingPolicy TxOrderingPolicy
+	throttler        *txThrottler
 	readyTxsCond     *timeoutCond
 }
 
-// NewWorker creates an init a worker
-func NewWorker(state stateInterface, constraints state.BatchConstraintsCfg, readyTxsCond *timeoutCond) *Worker {
+// NewWorker creates an init a worker, throttler can be nil if the sequencing throttles are disabled
+func NewWorker(state stateInterface, constraints state.BatchConstraintsCfg, txOrderingPolicy TxOrderingPolicy, throttler *txThrottler, readyTxsCond *timeoutCond) *Worker {
 	w := Worker{
 		pool:             make(map[string]*addrQueue),
-		txSortedList:     newTxSortedList(),
+		txSortedList:     newTxSortedList(txOrderingPolicy),
+		bundles:          []*BundleTracker{},
 		state:            state,
 		batchConstraints: constraints,
+		txOrderingPolicy: txOrderingPolicy,
+		throttler:        throttler,
 		readyTxsCond:     readyTxsCond,
 	}
 
@@ -240,7 +247,18 @@ func (w *Worker) UpdateTxZKCounters(txHash common.Hash, addr common.Address, use
 	addrQueue, found := w.pool[addr.String()]
 
 	if found {
+		// If the tx is in the txSortedList we need to take it out while updating the counters, as its position can change
+		readyTx := addrQueue.readyTx
+		if readyTx != nil && readyTx.HashStr != txHash.String() {
+			readyTx = nil
+		}
+		if readyTx != nil {
+			w.txSortedList.delete(readyTx)
+		}
 		addrQueue.UpdateTxZKCounters(txHash, usedZKCounters, reservedZKCounters)
+		if readyTx != nil {
+			w.addTxToSortedList(readyTx)
+		}
 	} else {
 		log.Warnf("addrQueue %s not found", addr.String())
 	}
@@ -288,7 +306,9 @@ func (w *Worker) DeletePendingTxToStore(txHash common.Hash, addr common.Address)
 	}
 }
 
-// GetBestFittingTx gets the most efficient tx that fits in the available batch resources
+// GetBestFittingTx gets the most efficient tx that fits in the available batch resources. If the sequencing throttles are enabled
+// the txs of the addresses that have reached their limits are skipped, if all the txs that fit are skipped it returns
+// ErrTransactionsThrottled when some of them has reached an L2 block limit, or ErrTransactionsThrottledInBatch otherwise
 func (w *Worker) GetBestFittingTx(resources state.BatchResources) (*TxTracker, error) {
 	w.workerMutex.Lock()
 	defer w.workerMutex.Unlock()
@@ -304,6 +324,9 @@ func (w *Worker) GetBestFittingTx(resources state.BatchResources) (*TxTracker, e
 
 	nGoRoutines := runtime.NumCPU()
 	foundAt := -1
+	throttledAt := -1
+	throttledReason := ""
+	throttledInL2Block := false
 
 	wg := sync.WaitGroup{}
 	wg.Add(nGoRoutines)
@@ -327,6 +350,20 @@ func (w *Worker) GetBestFittingTx(resources state.BatchResources) (*TxTracker, e
 					continue
 				}
 
+				if w.throttler != nil {
+					if limit, reason := w.throttler.isThrottled(txCandidate); limit != noThrottleLimit {
+						// We defer this Tx
+						foundMutex.Lock()
+						if throttledAt == -1 || throttledAt > i {
+							throttledAt = i
+							throttledReason = reason
+						}
+						throttledInL2Block = throttledInL2Block || limit == l2BlockThrottleLimit
+						foundMutex.Unlock()
+						continue
+					}
+				}
+
 				foundMutex.Lock()
 				if foundAt == -1 || foundAt > i {
 					foundAt = i
@@ -341,13 +378,176 @@ func (w *Worker) GetBestFittingTx(resources state.BatchResources) (*TxTracker, e
 	wg.Wait()
 
 	if foundAt != -1 {
-		log.Debugf("best fitting tx %s found at index %d with gasPrice %d", tx.HashStr, foundAt, tx.GasPrice)
+		log.Debugf("best fitting tx %s found at index %d with gasPrice %d (policy: %s)", tx.HashStr, foundAt, tx.GasPrice, w.txOrderingPolicy.Name())
+		w.txOrderingPolicy.OnSelected(tx)
+		seqMetrics.TxSelected(w.txOrderingPolicy.Name(), tx.ReceivedAt)
 		return tx, nil
+	} else if throttledAt != -1 {
+		log.Debugf("all fitting txs are throttled, first throttled tx %s: %s", w.txSortedList.getByIndex(throttledAt).HashStr, throttledReason)
+		if throttledInL2Block {
+			return nil, ErrTransactionsThrottled
+		}
+		return nil, ErrTransactionsThrottledInBatch
 	} else {
 		return nil, ErrNoFittingTransaction
 	}
 }
 
+// AddThrottleUsage adds the resources used by a tx sequenced in the wip L2 block to the usage of its sender and destination address
+func (w *Worker) AddThrottleUsage(tx *TxTracker, usedZKCounters state.ZKCounters) {
+	w.workerMutex.Lock()
+	defer w.workerMutex.Unlock()
+
+	if w.throttler != nil {
+		w.throttler.addUsage(tx, usedZKCounters)
+	}
+}
+
+// AddBundleThrottleUsage adds the resources used by a bundle sequenced in the wip L2 block to the usage of the senders and
+// destination addresses of its txs
+func (w *Worker) AddBundleThrottleUsage(bundle *BundleTracker, usedZKCounters state.ZKCounters) {
+	w.workerMutex.Lock()
+	defer w.workerMutex.Unlock()
+
+	if w.throttler != nil {
+		w.throttler.addBundleUsage(bundle, usedZKCounters)
+	}
+}
+
+// ResetBatchThrottleUsage resets the batch resources used by each address, it must be called when a new wip batch is opened
+func (w *Worker) ResetBatchThrottleUsage() {
+	w.workerMutex.Lock()
+	defer w.workerMutex.Unlock()
+
+	if w.throttler != nil {
+		w.throttler.resetBatchUsage()
+	}
+}
+
+// ResetL2BlockThrottleUsage resets the txs of each address in the L2 block, it must be called when a new wip L2 block is opened
+func (w *Worker) ResetL2BlockThrottleUsage() {
+	w.workerMutex.Lock()
+	defer w.workerMutex.Unlock()
+
+	if w.throttler != nil {
+		w.throttler.resetL2BlockUsage()
+	}
+}
+
+// AddBundle adds a new bundle to the Worker, the bundles are offered to the finalizer in the order they are added
+func (w *Worker) AddBundle(bundle *BundleTracker) bool {
+	w.workerMutex.Lock()
+	defer w.workerMutex.Unlock()
+
+	for _, b := range w.bundles {
+		if b.Hash == bundle.Hash {
+			return false
+		}
+	}
+
+	w.bundles = append(w.bundles, bundle)
+	log.Infof("added new bundle %s with %d txs (blockNumber: %d, maxTimestamp: %d)", bundle.HashStr, len(bundle.Txs), bundle.BlockNumber, bundle.MaxTimestamp)
+
+	if len(w.bundles) == 1 {
+		// There were no bundles before, we notify finalizer that we have something to process
+		w.readyTxsCond.L.Lock()
+		w.readyTxsCond.Signal()
+		w.readyTxsCond.L.Unlock()
+	}
+
+	return true
+}
+
+// GetBestFittingBundle gets the oldest bundle that can be included in the L2 block with the given number and timestamp
+// and fits in the available batch resources and in the remaining txs of the batch. The bundles that can't be included anymore
+// are deleted from the worker and returned as expired. If the sequencing throttles are enabled the bundles with txs of addresses
+// that have reached their limits are skipped, returning ErrTransactionsThrottled or ErrTransactionsThrottledInBatch as
+// GetBestFittingTx if no bundle is selected. If there are bundles for the L2 block but none of them fits in the batch it returns
+// ErrNoFittingBundle
+func (w *Worker) GetBestFittingBundle(resources state.BatchResources, remainingTxs uint64, blockNumber uint64, timestamp uint64) (*BundleTracker, []*BundleTracker, error) {
+	w.workerMutex.Lock()
+	defer w.workerMutex.Unlock()
+
+	var (
+		bundle    *BundleTracker
+		expired   []*BundleTracker
+		noFit     bool
+		throttled = noThrottleLimit
+	)
+
+	bundles := w.bundles[:0]
+	for _, b := range w.bundles {
+		if b.isExpired(blockNumber, timestamp) {
+			log.Infof("bundle %s expired (blockNumber: %d, maxTimestamp: %d)", b.HashStr, b.BlockNumber, b.MaxTimestamp)
+			expired = append(expired, b)
+			continue
+		}
+		bundles = append(bundles, b)
+
+		if bundle != nil || !b.isTargetBlock(blockNumber) {
+			continue
+		}
+
+		if uint64(len(b.Txs)) > remainingTxs {
+			noFit = true
+			continue
+		}
+		if fits, _ := resources.Fits(state.BatchResources{ZKCounters: b.ReservedZKCounters, Bytes: b.Bytes}); !fits {
+			noFit = true
+			continue
+		}
+		if w.throttler != nil {
+			if limit, reason := w.throttler.isBundleThrottled(b); limit != noThrottleLimit {
+				log.Debugf("bundle %s throttled: %s", b.HashStr, reason)
+				if throttled != l2BlockThrottleLimit {
+					throttled = limit
+				}
+				continue
+			}
+		}
+		bundle = b
+	}
+	w.bundles = bundles
+
+	if bundle == nil && throttled == l2BlockThrottleLimit {
+		return nil, expired, ErrTransactionsThrottled
+	} else if bundle == nil && throttled == batchThrottleLimit {
+		return nil, expired, ErrTransactionsThrottledInBatch
+	} else if bundle == nil && noFit {
+		return nil, expired, ErrNoFittingBundle
+	}
+
+	return bundle, expired, nil
+}
+
+// UpdateBundleZKCounters updates the reserved ZKCounters of a bundle
+func (w *Worker) UpdateBundleZKCounters(bundleHash common.Hash, reservedZKCounters state.ZKCounters) {
+	w.workerMutex.Lock()
+	defer w.workerMutex.Unlock()
+
+	for _, b := range w.bundles {
+		if b.Hash == bundleHash {
+			b.ReservedZKCounters = reservedZKCounters
+			return
+		}
+	}
+	log.Warnf("bundle %s not found", bundleHash.String())
+}
+
+// DeleteBundle deletes a bundle from the worker
+func (w *Worker) DeleteBundle(bundleHash common.Hash) {
+	w.workerMutex.Lock()
+	defer w.workerMutex.Unlock()
+
+	for i, b := range w.bundles {
+		if b.Hash == bundleHash {
+			w.bundles = append(w.bundles[:i], w.bundles[i+1:]...)
+			return
+		}
+	}
+	log.Warnf("bundle %s not found", bundleHash.String())
+}
+
 // ExpireTransactions deletes old txs
 func (w *Worker) ExpireTransactions(maxTime time.Duration) []*TxTracker {
 	w.workerMutex.Lock()
@@ -374,6 +574,7 @@ func (w *Worker) ExpireTransactions(maxTime time.Duration) []*TxTracker {
 }
 
 func (w *Worker) addTxToSortedList(readyTx *TxTracker) {
+	w.txOrderingPolicy.OnReady(readyTx)
 	w.txSortedList.add(readyTx)
 	if w.txSortedList.len() == 1 {
 		// The txSortedList was empty before to add the new tx, we notify finalizer that we have new ready txs to process
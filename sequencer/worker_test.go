@@ -10,6 +10,7 @@ import (
 	"github.com/0xPolygonHermez/zkevm-node/state"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 const (
@@ -283,7 +284,215 @@ func TestWorkerGetBestTx(t *testing.T) {
 	}
 }
 
+func TestWorkerGetBestFittingBundle(t *testing.T) {
+	rc := state.BatchResources{
+		ZKCounters: state.ZKCounters{GasUsed: 10, KeccakHashes: 10, PoseidonHashes: 10, PoseidonPaddings: 10, MemAligns: 10, Arithmetics: 10, Binaries: 10, Steps: 10, Sha256Hashes_V2: 10},
+		Bytes:      10,
+	}
+
+	testCases := []struct {
+		name            string
+		bundles         []*BundleTracker
+		remainingTxs    uint64
+		blockNumber     uint64
+		timestamp       uint64
+		expectedBundle  *common.Hash
+		expectedExpired []common.Hash
+		expectedErr     error
+		expectedLeft    int
+	}{
+		{
+			name:         "no bundles",
+			remainingTxs: 10, blockNumber: 10, timestamp: 100,
+		},
+		{
+			name: "any block bundle",
+			bundles: []*BundleTracker{
+				{Hash: common.Hash{1}, Bytes: 5},
+			},
+			remainingTxs: 10, blockNumber: 10, timestamp: 100,
+			expectedBundle: &common.Hash{1},
+			expectedLeft:   1,
+		},
+		{
+			name: "bundle for a future block",
+			bundles: []*BundleTracker{
+				{Hash: common.Hash{1}, BlockNumber: 11, Bytes: 5},
+			},
+			remainingTxs: 10, blockNumber: 10, timestamp: 100,
+			expectedLeft: 1,
+		},
+		{
+			name: "expired bundles",
+			bundles: []*BundleTracker{
+				{Hash: common.Hash{1}, BlockNumber: 9, Bytes: 5},
+				{Hash: common.Hash{2}, MaxTimestamp: 99, Bytes: 5},
+				{Hash: common.Hash{3}, BlockNumber: 10, MaxTimestamp: 100, Bytes: 5},
+			},
+			remainingTxs: 10, blockNumber: 10, timestamp: 100,
+			expectedBundle:  &common.Hash{3},
+			expectedExpired: []common.Hash{{1}, {2}},
+			expectedLeft:    1,
+		},
+		{
+			name: "oldest fitting bundle",
+			bundles: []*BundleTracker{
+				{Hash: common.Hash{1}, Bytes: 11},
+				{Hash: common.Hash{2}, Bytes: 5, ReservedZKCounters: state.ZKCounters{Steps: 5}},
+				{Hash: common.Hash{3}, Bytes: 1},
+			},
+			remainingTxs: 10, blockNumber: 10, timestamp: 100,
+			expectedBundle: &common.Hash{2},
+			expectedLeft:   3,
+		},
+		{
+			name: "no fitting bundle",
+			bundles: []*BundleTracker{
+				{Hash: common.Hash{1}, Bytes: 11},
+				{Hash: common.Hash{2}, Bytes: 5, ReservedZKCounters: state.ZKCounters{Steps: 11}},
+			},
+			remainingTxs: 10, blockNumber: 10, timestamp: 100,
+			expectedErr:  ErrNoFittingBundle,
+			expectedLeft: 2,
+		},
+		{
+			name: "bundle exceeding the remaining txs of the batch",
+			bundles: []*BundleTracker{
+				{Hash: common.Hash{1}, Bytes: 5, Txs: []*TxTracker{{}, {}}},
+				{Hash: common.Hash{2}, Bytes: 5, Txs: []*TxTracker{{}}},
+			},
+			remainingTxs: 1, blockNumber: 10, timestamp: 100,
+			expectedBundle: &common.Hash{2},
+			expectedLeft:   2,
+		},
+		{
+			name: "no bundle fits in the remaining txs of the batch",
+			bundles: []*BundleTracker{
+				{Hash: common.Hash{1}, Bytes: 5, Txs: []*TxTracker{{}, {}}},
+			},
+			remainingTxs: 1, blockNumber: 10, timestamp: 100,
+			expectedErr:  ErrNoFittingBundle,
+			expectedLeft: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			worker := initWorker(NewStateMock(t), rcMax)
+			for _, b := range tc.bundles {
+				b.HashStr = b.Hash.String()
+				assert.True(t, worker.AddBundle(b))
+			}
+
+			bundle, expired, err := worker.GetBestFittingBundle(rc, tc.remainingTxs, tc.blockNumber, tc.timestamp)
+			assert.ErrorIs(t, err, tc.expectedErr)
+			if tc.expectedBundle == nil {
+				assert.Nil(t, bundle)
+			} else {
+				require.NotNil(t, bundle)
+				assert.Equal(t, *tc.expectedBundle, bundle.Hash)
+			}
+
+			expiredHashes := []common.Hash{}
+			for _, b := range expired {
+				expiredHashes = append(expiredHashes, b.Hash)
+			}
+			assert.ElementsMatch(t, tc.expectedExpired, expiredHashes)
+			assert.Equal(t, tc.expectedLeft, len(worker.bundles))
+		})
+	}
+}
+
+func TestWorkerGetBestFittingTxThrottled(t *testing.T) {
+	rc := state.BatchResources{
+		ZKCounters: state.ZKCounters{GasUsed: 10, KeccakHashes: 10, PoseidonHashes: 10, PoseidonPaddings: 10, MemAligns: 10, Arithmetics: 10, Binaries: 10, Steps: 10, Sha256Hashes_V2: 10},
+		Bytes:      10,
+	}
+
+	ctx := context.Background()
+	stateMock := NewStateMock(t)
+	stateMock.On("GetLastStateRoot", ctx, nil).Return(common.Hash{0}, nil)
+
+	throttler, err := newTxThrottler(ThrottleCfg{Enabled: true, Contract: ThrottleLimitsCfg{MaxTxsPerL2Block: 1}}, rcMax)
+	require.NoError(t, err)
+	worker := NewWorker(stateMock, rcMax, &gasPricePolicy{}, throttler, newTimeoutCond(&sync.Mutex{}))
+
+	contract := common.Address{10}
+	txs := []*TxTracker{
+		{Hash: common.Hash{1}, HashStr: common.Hash{1}.String(), From: common.Address{1}, FromStr: common.Address{1}.String(), To: &contract, Nonce: 1, GasPrice: big.NewInt(20), Cost: big.NewInt(1), Bytes: 1, IP: validIP},
+		{Hash: common.Hash{2}, HashStr: common.Hash{2}.String(), From: common.Address{2}, FromStr: common.Address{2}.String(), To: &contract, Nonce: 1, GasPrice: big.NewInt(10), Cost: big.NewInt(1), Bytes: 1, IP: validIP},
+	}
+	for _, tx := range txs {
+		stateMock.On("GetNonceByStateRoot", ctx, tx.From, common.Hash{0}).Return(big.NewInt(1), nil)
+		stateMock.On("GetBalanceByStateRoot", ctx, tx.From, common.Hash{0}).Return(big.NewInt(10), nil)
+		_, err := worker.AddTxTracker(ctx, tx)
+		require.NoError(t, err)
+	}
+
+	tx, err := worker.GetBestFittingTx(rc)
+	require.NoError(t, err)
+	assert.Equal(t, common.Hash{1}, tx.Hash)
+
+	// The contract reaches its max txs in the L2 block, its txs are deferred but kept in the worker
+	worker.AddThrottleUsage(tx, state.ZKCounters{})
+	_, err = worker.GetBestFittingTx(rc)
+	assert.ErrorIs(t, err, ErrTransactionsThrottled)
+	assert.Equal(t, 2, worker.txSortedList.len())
+
+	// The deferred txs are offered again in the next L2 block
+	worker.ResetL2BlockThrottleUsage()
+	tx, err = worker.GetBestFittingTx(rc)
+	require.NoError(t, err)
+	assert.Equal(t, common.Hash{1}, tx.Hash)
+}
+
+func TestWorkerThrottledInBatch(t *testing.T) {
+	rc := state.BatchResources{
+		ZKCounters: state.ZKCounters{GasUsed: 10, KeccakHashes: 10, PoseidonHashes: 10, PoseidonPaddings: 10, MemAligns: 10, Arithmetics: 10, Binaries: 10, Steps: 10, Sha256Hashes_V2: 10},
+		Bytes:      10,
+	}
+
+	ctx := context.Background()
+	stateMock := NewStateMock(t)
+	stateMock.On("GetLastStateRoot", ctx, nil).Return(common.Hash{0}, nil)
+
+	throttler, err := newTxThrottler(ThrottleCfg{Enabled: true, Sender: ThrottleLimitsCfg{MaxBatchResourcesPct: 20}}, rcMax)
+	require.NoError(t, err)
+	worker := NewWorker(stateMock, rcMax, &gasPricePolicy{}, throttler, newTimeoutCond(&sync.Mutex{}))
+
+	sender := common.Address{1}
+	tx := &TxTracker{Hash: common.Hash{1}, HashStr: common.Hash{1}.String(), From: sender, FromStr: sender.String(), Nonce: 1, GasPrice: big.NewInt(10), Cost: big.NewInt(1), Bytes: 1, IP: validIP, ReservedZKCounters: state.ZKCounters{Steps: 2}}
+	stateMock.On("GetNonceByStateRoot", ctx, tx.From, common.Hash{0}).Return(big.NewInt(1), nil)
+	stateMock.On("GetBalanceByStateRoot", ctx, tx.From, common.Hash{0}).Return(big.NewInt(10), nil)
+	_, err = worker.AddTxTracker(ctx, tx)
+	require.NoError(t, err)
+
+	bundle := &BundleTracker{Hash: common.Hash{2}, HashStr: common.Hash{2}.String(), Bytes: 1, Txs: []*TxTracker{{From: sender}}, ReservedZKCounters: state.ZKCounters{Steps: 2}}
+	require.True(t, worker.AddBundle(bundle))
+
+	// The sender reaches its share of the batch, its tx and its bundle are deferred until the next batch
+	worker.AddThrottleUsage(&TxTracker{From: sender}, state.ZKCounters{Steps: 1})
+	_, err = worker.GetBestFittingTx(rc)
+	assert.ErrorIs(t, err, ErrTransactionsThrottledInBatch)
+	b, _, err := worker.GetBestFittingBundle(rc, 10, 10, 100)
+	assert.ErrorIs(t, err, ErrTransactionsThrottledInBatch)
+	assert.Nil(t, b)
+
+	// A new L2 block doesn't reset the share of the sender
+	worker.ResetL2BlockThrottleUsage()
+	_, err = worker.GetBestFittingTx(rc)
+	assert.ErrorIs(t, err, ErrTransactionsThrottledInBatch)
+
+	worker.ResetBatchThrottleUsage()
+	b, _, err = worker.GetBestFittingBundle(rc, 10, 10, 100)
+	require.NoError(t, err)
+	assert.Equal(t, bundle.Hash, b.Hash)
+	worker.AddBundleThrottleUsage(b, state.ZKCounters{Steps: 1})
+	_, err = worker.GetBestFittingTx(rc)
+	assert.ErrorIs(t, err, ErrTransactionsThrottledInBatch)
+}
+
 func initWorker(stateMock *StateMock, rcMax state.BatchConstraintsCfg) *Worker {
-	worker := NewWorker(stateMock, rcMax, newTimeoutCond(&sync.Mutex{}))
+	worker := NewWorker(stateMock, rcMax, &gasPricePolicy{}, nil, newTimeoutCond(&sync.Mutex{}))
 	return worker
 }
@@ -0,0 +1,168 @@
+package state
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jackc/pgx/v4"
+)
+
+// StoreArchiveStateDiff adds to the archive the nonces and balances of the accounts modified by the l2 blocks
+// in the range [fromL2BlockNumber, toL2BlockNumber], as reported by the executor. When the executor reports the
+// modified accounts for several l2 blocks at once, only their values at the end of the range are known, so they
+// are archived at toL2BlockNumber and the previous l2 blocks of the range are not added to the archive, their
+// queries keep using the HashDB. The archive must be contiguous from the genesis, so the range is not archived
+// when the l2 block previous to fromL2BlockNumber is not in the archive. The executor doesn't report the modified
+// code and storage, so the archive is not used to answer the code and storage queries at these l2 blocks
+func (s *State) StoreArchiveStateDiff(ctx context.Context, fromL2BlockNumber, toL2BlockNumber uint64, readWriteAddresses map[common.Address]*InfoReadWrite, dbTx pgx.Tx) error {
+	return s.storeArchiveStateDiff(ctx, fromL2BlockNumber, toL2BlockNumber, readWriteAddresses, false, dbTx)
+}
+
+// storeArchiveStateDiff adds the state diff to the archive, hasCodeAndStorage must be set only when
+// readWriteAddresses contains all the code and storage modified by the l2 blocks of the range
+func (s *State) storeArchiveStateDiff(ctx context.Context, fromL2BlockNumber, toL2BlockNumber uint64, readWriteAddresses map[common.Address]*InfoReadWrite, hasCodeAndStorage bool, dbTx pgx.Tx) error {
+	if !s.cfg.Archive.Enabled {
+		return nil
+	}
+	if dbTx == nil {
+		return ErrDBTxNil
+	}
+	if fromL2BlockNumber > toL2BlockNumber {
+		return fmt.Errorf("invalid l2 block range to archive [%d, %d]", fromL2BlockNumber, toL2BlockNumber)
+	}
+
+	if fromL2BlockNumber > 0 {
+		archived, err := s.IsL2BlockArchived(ctx, fromL2BlockNumber-1, dbTx)
+		if err != nil {
+			return err
+		}
+		if !archived {
+			log.Debugf("l2 block %d is not archived, skipping the archive of the l2 blocks [%d, %d]", fromL2BlockNumber-1, fromL2BlockNumber, toL2BlockNumber)
+			return nil
+		}
+	}
+
+	err := s.AddArchivedL2Block(ctx, toL2BlockNumber, hasCodeAndStorage, dbTx)
+	if err != nil {
+		return fmt.Errorf("failed to archive l2 block %d: %w", toL2BlockNumber, err)
+	}
+
+	for address, info := range readWriteAddresses {
+		if info == nil {
+			continue
+		}
+		if info.Nonce != nil || info.Balance != nil {
+			err := s.AddArchivedAccount(ctx, toL2BlockNumber, address, info.Nonce, info.Balance, dbTx)
+			if err != nil {
+				return fmt.Errorf("failed to archive account %s at l2 block %d: %w", address.String(), toL2BlockNumber, err)
+			}
+		}
+		if !hasCodeAndStorage {
+			continue
+		}
+		if info.Code != nil {
+			err := s.AddArchivedCode(ctx, toL2BlockNumber, address, info.Code, dbTx)
+			if err != nil {
+				return fmt.Errorf("failed to archive code of %s at l2 block %d: %w", address.String(), toL2BlockNumber, err)
+			}
+		}
+		for position, value := range info.Storage {
+			err := s.AddArchivedStorage(ctx, toL2BlockNumber, address, position, value, dbTx)
+			if err != nil {
+				return fmt.Errorf("failed to archive storage %s of %s at l2 block %d: %w", position.String(), address.String(), toL2BlockNumber, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// getArchivedBalance returns the balance of the account at the provided state root
+// from the archive, ErrNotFound means the archive is not able to answer the query
+func (s *State) getArchivedBalance(ctx context.Context, address common.Address, root common.Hash) (*big.Int, error) {
+	if !s.cfg.Archive.Enabled {
+		return nil, ErrNotFound
+	}
+	l2BlockNumber, err := s.GetArchivedL2BlockNumberByStateRoot(ctx, root, nil)
+	if err != nil {
+		return nil, err
+	}
+	balance, err := s.GetArchivedBalance(ctx, address, l2BlockNumber, nil)
+	if errors.Is(err, ErrNotFound) {
+		// the archive starts at the genesis, so an account never modified has no balance
+		return big.NewInt(0), nil
+	} else if err != nil {
+		return nil, err
+	}
+	return balance, nil
+}
+
+// getArchivedNonce returns the nonce of the account at the provided state root
+// from the archive, ErrNotFound means the archive is not able to answer the query
+func (s *State) getArchivedNonce(ctx context.Context, address common.Address, root common.Hash) (uint64, error) {
+	if !s.cfg.Archive.Enabled {
+		return 0, ErrNotFound
+	}
+	l2BlockNumber, err := s.GetArchivedL2BlockNumberByStateRoot(ctx, root, nil)
+	if err != nil {
+		return 0, err
+	}
+	nonce, err := s.GetArchivedNonce(ctx, address, l2BlockNumber, nil)
+	if errors.Is(err, ErrNotFound) {
+		// the archive starts at the genesis, so an account never modified has nonce zero
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+	return nonce, nil
+}
+
+// getArchivedCode returns the code of the smart contract at the provided state root
+// from the archive, ErrNotFound means the archive is not able to answer the query
+func (s *State) getArchivedCode(ctx context.Context, address common.Address, root common.Hash) ([]byte, error) {
+	if !s.cfg.Archive.Enabled {
+		return nil, ErrNotFound
+	}
+	l2BlockNumber, err := s.GetArchivedL2BlockNumberByStateRoot(ctx, root, nil)
+	if err != nil {
+		return nil, err
+	}
+	hasCodeAndStorage, err := s.HasArchivedCodeAndStorage(ctx, l2BlockNumber, nil)
+	if err != nil {
+		return nil, err
+	} else if !hasCodeAndStorage {
+		// the code modified by the l2 blocks is not reported by the executor
+		return nil, ErrNotFound
+	}
+	// ErrNotFound makes the query fall back to the HashDB when the code is not archived
+	return s.GetArchivedCode(ctx, address, l2BlockNumber, nil)
+}
+
+// getArchivedStorageAt returns the value of the storage position of the smart contract at the
+// provided state root from the archive, ErrNotFound means the archive is not able to answer the query
+func (s *State) getArchivedStorageAt(ctx context.Context, address common.Address, position *big.Int, root common.Hash) (*big.Int, error) {
+	if !s.cfg.Archive.Enabled {
+		return nil, ErrNotFound
+	}
+	l2BlockNumber, err := s.GetArchivedL2BlockNumberByStateRoot(ctx, root, nil)
+	if err != nil {
+		return nil, err
+	}
+	hasCodeAndStorage, err := s.HasArchivedCodeAndStorage(ctx, l2BlockNumber, nil)
+	if err != nil {
+		return nil, err
+	} else if !hasCodeAndStorage {
+		// the storage modified by the l2 blocks is not reported by the executor
+		return nil, ErrNotFound
+	}
+	// ErrNotFound makes the query fall back to the HashDB when the storage position is not archived
+	value, err := s.GetArchivedStorageAt(ctx, address, common.BigToHash(position), l2BlockNumber, nil)
+	if err != nil {
+		return nil, err
+	}
+	return value.Big(), nil
+}
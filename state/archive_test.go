@@ -0,0 +1,237 @@
+package state_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/0xPolygonHermez/zkevm-node/state/mocks"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStoreArchiveStateDiff(t *testing.T) {
+	ctx := context.Background()
+	address := common.HexToAddress("0x617b3a3528F9cDd6630fd3301B9c8911F7Bf063D")
+	nonce := uint64(3)
+	balance := big.NewInt(1000)
+	code := []byte{0x60, 0x80}
+	position, value := common.HexToHash("0x1"), common.HexToHash("0x2")
+	readWriteAddresses := map[common.Address]*state.InfoReadWrite{
+		address: {Address: address, Nonce: &nonce, Balance: balance, Code: code, Storage: map[common.Hash]common.Hash{position: value}},
+	}
+
+	type testCase struct {
+		name          string
+		enabled       bool
+		from          uint64
+		to            uint64
+		setupMocks    func(m *mocks.StorageMock, dbTx *mocks.DbTxMock)
+		expectedError string
+	}
+
+	testCases := []testCase{
+		{
+			name:    "archive disabled",
+			enabled: false,
+			from:    5,
+			to:      6,
+		},
+		{
+			name:          "invalid range",
+			enabled:       true,
+			from:          6,
+			to:            5,
+			expectedError: "invalid l2 block range to archive [6, 5]",
+		},
+		{
+			name:    "previous l2 block not archived",
+			enabled: true,
+			from:    5,
+			to:      6,
+			setupMocks: func(m *mocks.StorageMock, dbTx *mocks.DbTxMock) {
+				m.EXPECT().IsL2BlockArchived(ctx, uint64(4), dbTx).Return(false, nil).Once()
+			},
+		},
+		{
+			name:    "previous l2 block archived",
+			enabled: true,
+			from:    5,
+			to:      6,
+			setupMocks: func(m *mocks.StorageMock, dbTx *mocks.DbTxMock) {
+				// only the last l2 block of the range is archived with the values at the end of the range,
+				// the code and storage are not archived since the executor doesn't report all of them
+				m.EXPECT().IsL2BlockArchived(ctx, uint64(4), dbTx).Return(true, nil).Once()
+				m.EXPECT().AddArchivedL2Block(ctx, uint64(6), false, dbTx).Return(nil).Once()
+				m.EXPECT().AddArchivedAccount(ctx, uint64(6), address, &nonce, balance, dbTx).Return(nil).Once()
+			},
+		},
+		{
+			name:    "first l2 block",
+			enabled: true,
+			from:    0,
+			to:      0,
+			setupMocks: func(m *mocks.StorageMock, dbTx *mocks.DbTxMock) {
+				m.EXPECT().AddArchivedL2Block(ctx, uint64(0), false, dbTx).Return(nil).Once()
+				m.EXPECT().AddArchivedAccount(ctx, uint64(0), address, &nonce, balance, dbTx).Return(nil).Once()
+			},
+		},
+		{
+			name:    "l2 block not found",
+			enabled: true,
+			from:    5,
+			to:      5,
+			setupMocks: func(m *mocks.StorageMock, dbTx *mocks.DbTxMock) {
+				m.EXPECT().IsL2BlockArchived(ctx, uint64(4), dbTx).Return(true, nil).Once()
+				m.EXPECT().AddArchivedL2Block(ctx, uint64(5), false, dbTx).Return(state.ErrNotFound).Once()
+			},
+			expectedError: "failed to archive l2 block 5: object not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockStorage := mocks.NewStorageMock(t)
+			dbTx := mocks.NewDbTxMock(t)
+			if tc.setupMocks != nil {
+				tc.setupMocks(mockStorage, dbTx)
+			}
+			testState := state.NewState(state.Config{Archive: state.ArchiveConfig{Enabled: tc.enabled}}, mockStorage, nil, nil, nil, nil)
+
+			err := testState.StoreArchiveStateDiff(ctx, tc.from, tc.to, readWriteAddresses, dbTx)
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGetBalanceAndNonceFromArchive(t *testing.T) {
+	ctx := context.Background()
+	address := common.HexToAddress("0x617b3a3528F9cDd6630fd3301B9c8911F7Bf063D")
+	root := common.HexToHash("0x1")
+
+	t.Run("account archived", func(t *testing.T) {
+		mockStorage := mocks.NewStorageMock(t)
+		mockStorage.EXPECT().GetArchivedL2BlockNumberByStateRoot(ctx, root, nil).Return(uint64(6), nil).Twice()
+		mockStorage.EXPECT().GetArchivedBalance(ctx, address, uint64(6), nil).Return(big.NewInt(1000), nil).Once()
+		mockStorage.EXPECT().GetArchivedNonce(ctx, address, uint64(6), nil).Return(uint64(3), nil).Once()
+		testState := state.NewState(state.Config{Archive: state.ArchiveConfig{Enabled: true}}, mockStorage, nil, nil, nil, nil)
+
+		balance, err := testState.GetBalance(ctx, address, root)
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(1000), balance)
+
+		nonce, err := testState.GetNonce(ctx, address, root)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(3), nonce)
+	})
+
+	t.Run("account not archived", func(t *testing.T) {
+		mockStorage := mocks.NewStorageMock(t)
+		mockStorage.EXPECT().GetArchivedL2BlockNumberByStateRoot(ctx, root, nil).Return(uint64(6), nil).Twice()
+		mockStorage.EXPECT().GetArchivedBalance(ctx, address, uint64(6), nil).Return(nil, state.ErrNotFound).Once()
+		mockStorage.EXPECT().GetArchivedNonce(ctx, address, uint64(6), nil).Return(uint64(0), state.ErrNotFound).Once()
+		testState := state.NewState(state.Config{Archive: state.ArchiveConfig{Enabled: true}}, mockStorage, nil, nil, nil, nil)
+
+		balance, err := testState.GetBalance(ctx, address, root)
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(0), balance)
+
+		nonce, err := testState.GetNonce(ctx, address, root)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(0), nonce)
+	})
+
+	t.Run("state root not archived falls back to the tree", func(t *testing.T) {
+		mockStorage := mocks.NewStorageMock(t)
+		mockStorage.EXPECT().GetArchivedL2BlockNumberByStateRoot(ctx, root, mock.Anything).Return(uint64(0), state.ErrNotFound).Twice()
+		testState := state.NewState(state.Config{Archive: state.ArchiveConfig{Enabled: true}}, mockStorage, nil, nil, nil, nil)
+
+		_, err := testState.GetBalance(ctx, address, root)
+		require.ErrorIs(t, err, state.ErrStateTreeNil)
+
+		_, err = testState.GetNonce(ctx, address, root)
+		require.ErrorIs(t, err, state.ErrStateTreeNil)
+	})
+
+	t.Run("archive disabled", func(t *testing.T) {
+		mockStorage := mocks.NewStorageMock(t)
+		testState := state.NewState(state.Config{}, mockStorage, nil, nil, nil, nil)
+
+		_, err := testState.GetBalance(ctx, address, root)
+		require.ErrorIs(t, err, state.ErrStateTreeNil)
+
+		_, err = testState.GetNonce(ctx, address, root)
+		require.ErrorIs(t, err, state.ErrStateTreeNil)
+	})
+}
+
+func TestGetCodeAndStorageFromArchive(t *testing.T) {
+	ctx := context.Background()
+	address := common.HexToAddress("0x617b3a3528F9cDd6630fd3301B9c8911F7Bf063D")
+	root := common.HexToHash("0x1")
+	position := big.NewInt(1)
+
+	t.Run("smart contract archived", func(t *testing.T) {
+		mockStorage := mocks.NewStorageMock(t)
+		mockStorage.EXPECT().GetArchivedL2BlockNumberByStateRoot(ctx, root, nil).Return(uint64(6), nil).Twice()
+		mockStorage.EXPECT().HasArchivedCodeAndStorage(ctx, uint64(6), nil).Return(true, nil).Twice()
+		mockStorage.EXPECT().GetArchivedCode(ctx, address, uint64(6), nil).Return([]byte{0x60, 0x80}, nil).Once()
+		mockStorage.EXPECT().GetArchivedStorageAt(ctx, address, common.BigToHash(position), uint64(6), nil).Return(common.HexToHash("0x2"), nil).Once()
+		testState := state.NewState(state.Config{Archive: state.ArchiveConfig{Enabled: true}}, mockStorage, nil, nil, nil, nil)
+
+		code, err := testState.GetCode(ctx, address, root)
+		require.NoError(t, err)
+		assert.Equal(t, []byte{0x60, 0x80}, code)
+
+		value, err := testState.GetStorageAt(ctx, address, position, root)
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(2), value)
+	})
+
+	t.Run("smart contract not archived falls back to the tree", func(t *testing.T) {
+		mockStorage := mocks.NewStorageMock(t)
+		mockStorage.EXPECT().GetArchivedL2BlockNumberByStateRoot(ctx, root, nil).Return(uint64(6), nil).Twice()
+		mockStorage.EXPECT().HasArchivedCodeAndStorage(ctx, uint64(6), nil).Return(true, nil).Twice()
+		mockStorage.EXPECT().GetArchivedCode(ctx, address, uint64(6), nil).Return(nil, state.ErrNotFound).Once()
+		mockStorage.EXPECT().GetArchivedStorageAt(ctx, address, common.BigToHash(position), uint64(6), nil).Return(common.Hash{}, state.ErrNotFound).Once()
+		testState := state.NewState(state.Config{Archive: state.ArchiveConfig{Enabled: true}}, mockStorage, nil, nil, nil, nil)
+
+		_, err := testState.GetCode(ctx, address, root)
+		require.ErrorIs(t, err, state.ErrStateTreeNil)
+
+		_, err = testState.GetStorageAt(ctx, address, position, root)
+		require.ErrorIs(t, err, state.ErrStateTreeNil)
+	})
+
+	t.Run("l2 blocks archived without code and storage fall back to the tree", func(t *testing.T) {
+		mockStorage := mocks.NewStorageMock(t)
+		mockStorage.EXPECT().GetArchivedL2BlockNumberByStateRoot(ctx, root, nil).Return(uint64(6), nil).Twice()
+		mockStorage.EXPECT().HasArchivedCodeAndStorage(ctx, uint64(6), nil).Return(false, nil).Twice()
+		testState := state.NewState(state.Config{Archive: state.ArchiveConfig{Enabled: true}}, mockStorage, nil, nil, nil, nil)
+
+		_, err := testState.GetCode(ctx, address, root)
+		require.ErrorIs(t, err, state.ErrStateTreeNil)
+
+		_, err = testState.GetStorageAt(ctx, address, position, root)
+		require.ErrorIs(t, err, state.ErrStateTreeNil)
+	})
+
+	t.Run("state root not archived falls back to the tree", func(t *testing.T) {
+		mockStorage := mocks.NewStorageMock(t)
+		mockStorage.EXPECT().GetArchivedL2BlockNumberByStateRoot(ctx, root, mock.Anything).Return(uint64(0), state.ErrNotFound).Twice()
+		testState := state.NewState(state.Config{Archive: state.ArchiveConfig{Enabled: true}}, mockStorage, nil, nil, nil, nil)
+
+		_, err := testState.GetCode(ctx, address, root)
+		require.ErrorIs(t, err, state.ErrStateTreeNil)
+
+		_, err = testState.GetStorageAt(ctx, address, position, root)
+		require.ErrorIs(t, err, state.ErrStateTreeNil)
+	})
+}
@@ -535,11 +535,32 @@ func (s *State) ProcessAndStoreClosedBatch(ctx context.Context, processingCtx Pr
 	}
 
 	if len(processedBatch.BlockResponses) > 0 {
+		var lastL2BlockNumber uint64
+		if s.cfg.Archive.Enabled {
+			lastL2BlockNumber, err = s.GetLastL2BlockNumber(ctx, dbTx)
+			if err != nil {
+				return common.Hash{}, noFlushID, noProverID, err
+			}
+		}
+
 		// Store processed txs into the batch
 		err = s.StoreTransactions(ctx, processingCtx.BatchNumber, processedBatch.BlockResponses, nil, dbTx)
 		if err != nil {
 			return common.Hash{}, noFlushID, noProverID, err
 		}
+
+		if s.cfg.Archive.Enabled {
+			newLastL2BlockNumber, err := s.GetLastL2BlockNumber(ctx, dbTx)
+			if err != nil {
+				return common.Hash{}, noFlushID, noProverID, err
+			}
+			if newLastL2BlockNumber > lastL2BlockNumber {
+				err = s.StoreArchiveStateDiff(ctx, lastL2BlockNumber+1, newLastL2BlockNumber, processedBatch.ReadWriteAddresses, dbTx)
+				if err != nil {
+					return common.Hash{}, noFlushID, noProverID, err
+				}
+			}
+		}
 	}
 
 	// Close batch
@@ -405,6 +405,13 @@ func (s *State) ProcessAndStoreClosedBatchV2(ctx context.Context, processingCtx
 				return common.Hash{}, noFlushID, noProverID, err
 			}
 		}
+		firstBlock := processedBatch.BlockResponses[0]
+		lastBlock := processedBatch.BlockResponses[len(processedBatch.BlockResponses)-1]
+		err = s.StoreArchiveStateDiff(ctx, firstBlock.BlockNumber, lastBlock.BlockNumber, processedBatch.ReadWriteAddresses, dbTx)
+		if err != nil {
+			log.Errorf("%s error StoreArchiveStateDiff: %v", debugPrefix, err)
+			return common.Hash{}, noFlushID, noProverID, err
+		}
 	}
 	return common.BytesToHash(processed.NewStateRoot), processed.FlushId, processed.ProverId, s.CloseBatchInStorage(ctx, ProcessingReceipt{
 		BatchNumber:   processingCtx.BatchNumber,
@@ -49,6 +49,20 @@ type Config struct {
 	// AvoidForkIDInMemory is a configuration that forces the ForkID information to be loaded
 	// from the DB every time it's needed
 	AvoidForkIDInMemory bool
+
+	// Archive is the configuration of the local archive of the account balances, nonces, code and storage
+	Archive ArchiveConfig `mapstructure:"Archive"`
+}
+
+// ArchiveConfig represents the configuration of the local archive of the account
+// balances, nonces, code and storage indexed by L2 block, filled with the values
+// reported by the executor when the L2 blocks are stored, which don't include the
+// code and storage modified after the genesis. The archive allows to
+// answer the account queries without the HashDB, but it only covers the L2 blocks
+// stored since the genesis was set with the archive enabled
+type ArchiveConfig struct {
+	// Enabled defines if the archive is filled and used to answer the queries
+	Enabled bool `mapstructure:"Enabled"`
 }
 
 // BatchConfig represents the configuration of the batch constraints
@@ -317,3 +317,38 @@ func convertToCounters(resp *executor.ProcessBatchResponse) ZKCounters {
 		Steps:            resp.CntSteps,
 	}
 }
+
+func convertToExecutorStateOverride(stateOverride StateOverride) map[string]*executor.OverrideAccount {
+	if len(stateOverride) == 0 {
+		return nil
+	}
+
+	res := make(map[string]*executor.OverrideAccount, len(stateOverride))
+	for address, account := range stateOverride {
+		overrideAccount := &executor.OverrideAccount{
+			Code:      account.Code,
+			State:     convertToExecutorStorage(account.State),
+			StateDiff: convertToExecutorStorage(account.StateDiff),
+		}
+		if account.Nonce != nil {
+			overrideAccount.Nonce = *account.Nonce
+		}
+		if account.Balance != nil {
+			overrideAccount.Balance = account.Balance.Bytes()
+		}
+		res[address.String()] = overrideAccount
+	}
+	return res
+}
+
+func convertToExecutorStorage(storage map[common.Hash]common.Hash) map[string]string {
+	if storage == nil {
+		return nil
+	}
+
+	res := make(map[string]string, len(storage))
+	for key, value := range storage {
+		res[key.String()] = value.String()
+	}
+	return res
+}
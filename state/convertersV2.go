@@ -375,3 +375,26 @@ func convertProcessingContext(p *ProcessingContextV2) (*ProcessingContext, error
 	}
 	return &result, nil
 }
+
+func convertToExecutorStateOverrideV2(stateOverride StateOverride) map[string]*executor.OverrideAccountV2 {
+	if len(stateOverride) == 0 {
+		return nil
+	}
+
+	res := make(map[string]*executor.OverrideAccountV2, len(stateOverride))
+	for address, account := range stateOverride {
+		overrideAccount := &executor.OverrideAccountV2{
+			Code:      account.Code,
+			State:     convertToExecutorStorage(account.State),
+			StateDiff: convertToExecutorStorage(account.StateDiff),
+		}
+		if account.Nonce != nil {
+			overrideAccount.Nonce = *account.Nonce
+		}
+		if account.Balance != nil {
+			overrideAccount.Balance = account.Balance.Bytes()
+		}
+		res[address.String()] = overrideAccount
+	}
+	return res
+}
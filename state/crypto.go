@@ -18,7 +18,10 @@ func CheckSignature(tx types.Transaction) error {
 	v, r, s := tx.RawSignatureValues()
 	plainV := byte(0)
 	chainID := tx.ChainId().Uint64()
-	if chainID != 0 {
+	if tx.Type() != types.LegacyTxType {
+		// the v of the typed txs is already the y parity
+		plainV = byte(v.Uint64())
+	} else if chainID != 0 {
 		plainV = byte(v.Uint64() - 35 - 2*(chainID))
 	}
 	if !crypto.ValidateSignatureValues(plainV, r, s, false) {
@@ -1,10 +1,14 @@
 package state
 
 import (
+	"math/big"
 	"testing"
 
 	"github.com/0xPolygonHermez/zkevm-node/hex"
 	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/require"
 )
 
@@ -277,3 +281,45 @@ func TestEncodeBatchV2WithTxInBinary(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, expectedBatchData, batchData)
 }
+
+func signTxsOfAllTypes(t *testing.T) []types.Transaction {
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	chainID := big.NewInt(1000)
+	signer := types.NewLondonSigner(chainID)
+	to := common.HexToAddress("0x4d5Cf5032B2a844602278b01199ED191A86c93ff")
+	accessList := types.AccessList{{Address: to, StorageKeys: []common.Hash{common.HexToHash("0x1"), common.HexToHash("0x2")}}}
+
+	txsData := []types.TxData{
+		&types.LegacyTx{Nonce: 1, GasPrice: big.NewInt(1000000000), Gas: 100000, To: &to, Value: big.NewInt(1)},
+		&types.AccessListTx{ChainID: chainID, Nonce: 2, GasPrice: big.NewInt(1000000000), Gas: 100000, To: &to, Value: big.NewInt(1), Data: []byte{0x01, 0x02}, AccessList: accessList},
+		&types.DynamicFeeTx{ChainID: chainID, Nonce: 3, GasTipCap: big.NewInt(1000000000), GasFeeCap: big.NewInt(2000000000), Gas: 100000, Value: big.NewInt(0), Data: []byte{0x60, 0x00}, AccessList: accessList},
+	}
+	txs := make([]types.Transaction, 0, len(txsData))
+	for _, txData := range txsData {
+		tx, err := types.SignNewTx(privateKey, signer, txData)
+		require.NoError(t, err)
+		txs = append(txs, *tx)
+	}
+	return txs
+}
+
+func TestEncodeTypedTxsNotSupported(t *testing.T) {
+	txs := signTxsOfAllTypes(t)
+	effectivePercentages := []uint8{255, 128, 0}
+
+	batchL2Data, err := EncodeTransactions(txs[:1], effectivePercentages[:1], FORKID_ELDERBERRY)
+	require.NoError(t, err)
+	require.NotEmpty(t, batchL2Data)
+
+	// the batch L2 data of the current forks can't contain typed txs
+	_, err = EncodeTransactions(txs, effectivePercentages, FORKID_ELDERBERRY)
+	require.ErrorIs(t, err, types.ErrTxTypeNotSupported)
+
+	block := L2BlockRaw{ChangeL2BlockHeader: ChangeL2BlockHeader{DeltaTimestamp: 123, IndexL1InfoTree: 456}}
+	for _, tx := range txs {
+		block.Transactions = append(block.Transactions, L2TxRaw{Tx: tx, EfficiencyPercentage: 255})
+	}
+	_, err = EncodeBatchV2(&BatchRawV2{Blocks: []L2BlockRaw{block}})
+	require.ErrorIs(t, err, types.ErrTxTypeNotSupported)
+}
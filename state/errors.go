@@ -64,9 +64,27 @@ var (
 	// ErrMaxLogsBlockRangeLimitExceeded returned when the range between block number range
 	// to filter logs is bigger than the configured limit
 	ErrMaxLogsBlockRangeLimitExceeded = errors.New("logs are limited to a %v block range")
+	// ErrAccessListNotConverged is returned when the access list of a tx keeps changing after
+	// executing it the max number of times allowed
+	ErrAccessListNotConverged = errors.New("access list didn't converge")
 	// ErrMaxNativeBlockHashBlockRangeLimitExceeded returned when the range between block number range
 	// to filter native block hashes is bigger than the configured limit
 	ErrMaxNativeBlockHashBlockRangeLimitExceeded = errors.New("native block hashes are limited to a %v block range")
+	// ErrMaxTracesBlockRangeLimitExceeded returned when the range between block number range
+	// to filter traces is bigger than the configured limit
+	ErrMaxTracesBlockRangeLimitExceeded = errors.New("traces are limited to a %v block range")
+	// ErrMaxL1BlockRangeLimitExceeded returned when the range between L1 block number range
+	// to filter batches is bigger than the configured limit
+	ErrMaxL1BlockRangeLimitExceeded = errors.New("l1 blocks are limited to a %v block range")
+	// ErrL1InfoTreeLeafNotIncluded is returned when the requested L1 info tree leaf
+	// was added to the tree after the provided L1 info root
+	ErrL1InfoTreeLeafNotIncluded = errors.New("l1 info tree leaf not included in the l1 info root")
+	// ErrSequencerLeaseHeld is returned when the sequencer lease can't be acquired
+	// because it's held by another sequencer instance and it has not expired
+	ErrSequencerLeaseHeld = errors.New("sequencer lease held by another node")
+	// ErrSequencerLeaseLost is returned when the sequencer lease has expired or
+	// it has been acquired by another sequencer instance
+	ErrSequencerLeaseLost = errors.New("sequencer lease lost")
 )
 
 // ConstructErrorFromRevert extracts the reverted reason from the provided returnValue
@@ -1,11 +1,13 @@
 package state
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"math/big"
 
 	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/merkletree"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/params"
@@ -16,6 +18,22 @@ type FakeDB struct {
 	State     *State
 	stateRoot []byte
 	refund    uint64
+
+	// stateOverride are the accounts overridden in the execution of an unsigned tx, they are
+	// applied to the state read at overriddenStateRoot, the state root the tx is executed on top
+	// of. The state roots after it are reported by the executor and already include them
+	stateOverride       StateOverride
+	overriddenStateRoot []byte
+}
+
+// overriddenAccount returns the override of the given address when the state is read at the
+// state root the overrides are applied to
+func (f *FakeDB) overriddenAccount(address common.Address) (OverrideAccount, bool) {
+	if len(f.stateOverride) == 0 || !bytes.Equal(f.stateRoot, f.overriddenStateRoot) {
+		return OverrideAccount{}, false
+	}
+	account, found := f.stateOverride[address]
+	return account, found
 }
 
 // SetStateRoot is the stateRoot setter.
@@ -40,6 +58,10 @@ func (f *FakeDB) AddBalance(common.Address, *big.Int) {
 
 // GetBalance returns the balance of the given address.
 func (f *FakeDB) GetBalance(address common.Address) *big.Int {
+	if account, found := f.overriddenAccount(address); found && account.Balance != nil {
+		return new(big.Int).Set(account.Balance)
+	}
+
 	ctx := context.Background()
 	balance, err := f.State.GetTree().GetBalance(ctx, address, f.stateRoot)
 
@@ -53,6 +75,10 @@ func (f *FakeDB) GetBalance(address common.Address) *big.Int {
 
 // GetNonce returns the nonce of the given address.
 func (f *FakeDB) GetNonce(address common.Address) uint64 {
+	if account, found := f.overriddenAccount(address); found && account.Nonce != nil {
+		return *account.Nonce
+	}
+
 	ctx := context.Background()
 	nonce, err := f.State.GetTree().GetNonce(ctx, address, f.stateRoot)
 
@@ -72,6 +98,16 @@ func (f *FakeDB) SetNonce(common.Address, uint64) {
 
 // GetCodeHash gets the hash for the code at a given address
 func (f *FakeDB) GetCodeHash(address common.Address) common.Hash {
+	if account, found := f.overriddenAccount(address); found && account.Code != nil {
+		// the code is copied because the hash pads it
+		hash, err := merkletree.HashContractBytecode(append([]byte{}, account.Code...))
+		if err != nil {
+			log.Errorf("error on FakeDB GetCodeHash for overridden address %v, err: %v", address, err)
+			return ZeroHash
+		}
+		return common.HexToHash(merkletree.H4ToString(hash))
+	}
+
 	ctx := context.Background()
 	hash, err := f.State.GetTree().GetCodeHash(ctx, address, f.stateRoot)
 	if err != nil {
@@ -84,6 +120,10 @@ func (f *FakeDB) GetCodeHash(address common.Address) common.Hash {
 
 // GetCode returns the SC code of the given address.
 func (f *FakeDB) GetCode(address common.Address) []byte {
+	if account, found := f.overriddenAccount(address); found && account.Code != nil {
+		return account.Code
+	}
+
 	ctx := context.Background()
 	code, err := f.State.GetTree().GetCode(ctx, address, f.stateRoot)
 
@@ -131,6 +171,15 @@ func (f *FakeDB) GetCommittedState(common.Address, common.Hash) common.Hash {
 
 // GetState retrieves a value from the given account's storage trie.
 func (f *FakeDB) GetState(address common.Address, hash common.Hash) common.Hash {
+	if account, found := f.overriddenAccount(address); found {
+		// State replaces the whole storage of the account and StateDiff only the provided slots
+		if account.State != nil {
+			return account.State[hash]
+		} else if value, found := account.StateDiff[hash]; found {
+			return value
+		}
+	}
+
 	ctx := context.Background()
 	storage, err := f.State.GetTree().GetStorageAt(ctx, address, hash.Big(), f.stateRoot)
 
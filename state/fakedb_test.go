@@ -0,0 +1,55 @@
+package state
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/merkletree"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFakeDBStateOverride(t *testing.T) {
+	root := common.HexToHash("0x1").Bytes()
+	address := common.HexToAddress("0x2")
+	nonce := uint64(7)
+	code := []byte{0x60, 0x00}
+	slot1 := common.HexToHash("0x1")
+	slot2 := common.HexToHash("0x2")
+	value := common.HexToHash("0x3")
+
+	f := &FakeDB{
+		stateRoot: root,
+		stateOverride: StateOverride{
+			address: {
+				Nonce:   &nonce,
+				Balance: big.NewInt(100),
+				Code:    code,
+				State:   map[common.Hash]common.Hash{slot1: value},
+			},
+		},
+		overriddenStateRoot: root,
+	}
+
+	assert.Equal(t, nonce, f.GetNonce(address))
+	assert.Equal(t, big.NewInt(100), f.GetBalance(address))
+	assert.Equal(t, code, f.GetCode(address))
+	codeHash, err := merkletree.HashContractBytecode(append([]byte{}, code...))
+	require.NoError(t, err)
+	assert.Equal(t, common.HexToHash(merkletree.H4ToString(codeHash)), f.GetCodeHash(address))
+	// hashing the code doesn't modify the overridden code
+	assert.Equal(t, []byte{0x60, 0x00}, f.GetCode(address))
+
+	// the overridden state replaces the whole storage of the account
+	assert.Equal(t, value, f.GetState(address, slot1))
+	assert.Equal(t, common.Hash{}, f.GetState(address, slot2))
+
+	// the overrides are only applied to the state root the tx is executed on top of
+	f.SetStateRoot(common.HexToHash("0x2").Bytes())
+	_, found := f.overriddenAccount(address)
+	assert.False(t, found)
+	f.SetStateRoot(root)
+	_, found = f.overriddenAccount(address)
+	assert.True(t, found)
+}
@@ -53,6 +53,13 @@ func (s *State) SetGenesis(ctx context.Context, block Block, genesis Genesis, m
 	}
 
 	uuid := uuid.New().String()
+	genesisAccounts := map[common.Address]*InfoReadWrite{}
+	genesisAccount := func(address common.Address) *InfoReadWrite {
+		if _, found := genesisAccounts[address]; !found {
+			genesisAccounts[address] = &InfoReadWrite{Address: address}
+		}
+		return genesisAccounts[address]
+	}
 
 	err = s.tree.StartBlock(ctx, root, uuid)
 	if err != nil {
@@ -72,6 +79,7 @@ func (s *State) SetGenesis(ctx context.Context, block Block, genesis Genesis, m
 			if err != nil {
 				return common.Hash{}, err
 			}
+			genesisAccount(address).Balance = balance
 		case int(merkletree.LeafTypeNonce):
 			nonce, err := encoding.DecodeBigIntHexOrDecimal(action.Value)
 			if err != nil {
@@ -81,6 +89,8 @@ func (s *State) SetGenesis(ctx context.Context, block Block, genesis Genesis, m
 			if err != nil {
 				return common.Hash{}, err
 			}
+			nonceUint64 := nonce.Uint64()
+			genesisAccount(address).Nonce = &nonceUint64
 		case int(merkletree.LeafTypeCode):
 			code, err := hex.DecodeHex(action.Bytecode)
 			if err != nil {
@@ -90,6 +100,7 @@ func (s *State) SetGenesis(ctx context.Context, block Block, genesis Genesis, m
 			if err != nil {
 				return common.Hash{}, err
 			}
+			genesisAccount(address).Code = code
 		case int(merkletree.LeafTypeStorage):
 			// Parse position and value
 			positionBI, err := encoding.DecodeBigIntHexOrDecimal(action.StoragePosition)
@@ -105,6 +116,11 @@ func (s *State) SetGenesis(ctx context.Context, block Block, genesis Genesis, m
 			if err != nil {
 				return common.Hash{}, err
 			}
+			account := genesisAccount(address)
+			if account.Storage == nil {
+				account.Storage = make(map[common.Hash]common.Hash)
+			}
+			account.Storage[common.BigToHash(positionBI)] = common.BigToHash(valueBI)
 		case int(merkletree.LeafTypeSCLength):
 			log.Debug("Skipped genesis action of type merkletree.LeafTypeSCLength, these actions will be handled as part of merkletree.LeafTypeCode actions")
 		default:
@@ -208,5 +224,11 @@ func (s *State) SetGenesis(ctx context.Context, block Block, genesis Genesis, m
 	if err != nil {
 		return common.Hash{}, err
 	}
+
+	// the archive starts at the L2 genesis block, including all its code and storage
+	err = s.storeArchiveStateDiff(ctx, 0, 0, genesisAccounts, true, dbTx)
+	if err != nil {
+		return common.Hash{}, err
+	}
 	return root, nil
 }
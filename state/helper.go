@@ -32,6 +32,11 @@ const (
 
 	// EfficiencyPercentageByteLength is the length of the effective percentage in bytes
 	EfficiencyPercentageByteLength uint64 = 1
+
+	// fake signature used to encode unsigned txs
+	unsignedTxV = "0x1c"
+	unsignedTxR = "0xa54492cfacf71aef702421b7fbc70636537a7b2fbe5718c5ed970a001bb7756b"
+	unsignedTxS = "0x2e9fb27acc75955b898f0b12ec52aa34bf08f01db654374484b80bf12f0d841e"
 )
 
 // EncodeTransactions RLP encodes the given transactions
@@ -39,6 +44,9 @@ func EncodeTransactions(txs []types.Transaction, effectivePercentages []uint8, f
 	var batchL2Data []byte
 
 	for i, tx := range txs {
+		if !IsTxTypeSupported(tx.Type(), forkID) {
+			return nil, fmt.Errorf("%w: tx type %d in fork id %d", types.ErrTxTypeNotSupported, tx.Type(), forkID)
+		}
 		txData, err := prepareRLPTxData(tx)
 		if err != nil {
 			return nil, err
@@ -58,6 +66,10 @@ func EncodeTransactions(txs []types.Transaction, effectivePercentages []uint8, f
 }
 
 func prepareRLPTxData(tx types.Transaction) ([]byte, error) {
+	if tx.Type() != types.LegacyTxType {
+		return nil, types.ErrTxTypeNotSupported
+	}
+
 	v, r, s := tx.RawSignatureValues()
 	sign := 1 - (v.Uint64() & 1)
 
@@ -94,6 +106,23 @@ func prepareRLPTxData(tx types.Transaction) ([]byte, error) {
 	return txData, nil
 }
 
+// IsTxTypeSupported checks if txs of the provided type can be included in the
+// batches of the fork id. The ROM of the current forks only supports the legacy
+// txs in the batch L2 data, the EIP-2718 typed txs will be enabled here comparing
+// the fork id with the constant of the first fork whose ROM supports them
+func IsTxTypeSupported(txType uint8, forkID uint64) bool {
+	return txType == types.LegacyTxType
+}
+
+// TxGasPrice returns the gas price offered by the tx. The network has no base
+// fee, so for the dynamic fee txs it's the tip capped by the fee cap
+func TxGasPrice(tx *types.Transaction) *big.Int {
+	if tx.Type() == types.DynamicFeeTxType {
+		return tx.EffectiveGasTipValue(common.Big0)
+	}
+	return tx.GasPrice()
+}
+
 // EncodeTransactionsWithoutEffectivePercentage RLP encodes the given transactions without the effective percentage
 func EncodeTransactionsWithoutEffectivePercentage(txs []types.Transaction) ([]byte, error) {
 	var batchL2Data []byte
@@ -121,9 +150,9 @@ func EncodeTransaction(tx types.Transaction, effectivePercentage uint8, forkID u
 
 // EncodeUnsignedTransaction RLP encodes the given unsigned transaction
 func EncodeUnsignedTransaction(tx types.Transaction, chainID uint64, forcedNonce *uint64, forkID uint64) ([]byte, error) {
-	v, _ := new(big.Int).SetString("0x1c", 0)
-	r, _ := new(big.Int).SetString("0xa54492cfacf71aef702421b7fbc70636537a7b2fbe5718c5ed970a001bb7756b", 0)
-	s, _ := new(big.Int).SetString("0x2e9fb27acc75955b898f0b12ec52aa34bf08f01db654374484b80bf12f0d841e", 0)
+	v, _ := new(big.Int).SetString(unsignedTxV, 0)
+	r, _ := new(big.Int).SetString(unsignedTxR, 0)
+	s, _ := new(big.Int).SetString(unsignedTxS, 0)
 
 	sign := 1 - (v.Uint64() & 1)
 
@@ -166,6 +195,32 @@ func EncodeUnsignedTransaction(tx types.Transaction, chainID uint64, forcedNonce
 	return txData, nil
 }
 
+// unsignedTxHash returns the hash of an unsigned tx encoded with EncodeUnsignedTransaction,
+// which is the hash of the tx signed with the fake signature used to encode it
+func unsignedTxHash(tx types.Transaction, chainID uint64, nonce uint64) common.Hash {
+	v, _ := new(big.Int).SetString(unsignedTxV, 0)
+	r, _ := new(big.Int).SetString(unsignedTxR, 0)
+	s, _ := new(big.Int).SetString(unsignedTxS, 0)
+
+	// the recovery id encoded in v is converted to its EIP-155 form
+	recoveryID := new(big.Int).Sub(v, big.NewInt(ether155V))
+	eip155V := new(big.Int).SetUint64(chainID*double + etherPre155V)
+	eip155V.Add(eip155V, recoveryID)
+
+	signedTx := types.NewTx(&types.LegacyTx{
+		Nonce:    nonce,
+		GasPrice: tx.GasPrice(),
+		Gas:      tx.Gas(),
+		To:       tx.To(),
+		Value:    tx.Value(),
+		Data:     tx.Data(),
+		V:        eip155V,
+		R:        r,
+		S:        s,
+	})
+	return signedTx.Hash()
+}
+
 // DecodeTxs extracts Transactions for its encoded form
 func DecodeTxs(txsData []byte, forkID uint64) ([]types.Transaction, []byte, []uint8, error) {
 	// Process coded txs
@@ -264,6 +319,14 @@ func DecodeTxs(txsData []byte, forkID uint64) ([]types.Transaction, []byte, []ui
 	return txs, txsData, efficiencyPercentages, nil
 }
 
+// CalculateEffectiveGasPrice calculates the effective gas price paid for a tx
+// accordingly to its gas price and the effective percentage applied to it
+func CalculateEffectiveGasPrice(gasPrice *big.Int, effectivePercentage uint8) *big.Int {
+	const bits = 256
+	effectiveGasPrice := new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(uint64(effectivePercentage)+1))
+	return effectiveGasPrice.Div(effectiveGasPrice, big.NewInt(bits))
+}
+
 // DecodeTx decodes a string rlp tx representation into a types.Transaction instance
 func DecodeTx(encodedTx string) (*types.Transaction, error) {
 	b, err := hex.DecodeHex(encodedTx)
@@ -280,8 +343,9 @@ func DecodeTx(encodedTx string) (*types.Transaction, error) {
 
 // GenerateReceipt generates a receipt from a processed transaction
 func GenerateReceipt(blockNumber *big.Int, processedTx *ProcessTransactionResponse, txIndex uint, forkID uint64) *types.Receipt {
+	// the executor always returns the legacy type, so it's taken from the tx
 	receipt := &types.Receipt{
-		Type:             uint8(processedTx.Type),
+		Type:             processedTx.Tx.Type(),
 		BlockNumber:      blockNumber,
 		GasUsed:          processedTx.GasUsed,
 		TxHash:           processedTx.Tx.Hash(),
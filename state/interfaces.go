@@ -2,6 +2,7 @@ package state
 
 import (
 	"context"
+	"math/big"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -17,6 +18,7 @@ type storage interface {
 	Begin(ctx context.Context) (pgx.Tx, error)
 	StoreGenesisBatch(ctx context.Context, batch Batch, closingReason string, dbTx pgx.Tx) error
 	ResetToL1BlockNumber(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) error
+	GetResetEpoch(ctx context.Context, dbTx pgx.Tx) (uint64, error)
 	ResetForkID(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) error
 	ResetTrustedState(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) error
 	AddBlock(ctx context.Context, block *Block, dbTx pgx.Tx) error
@@ -34,6 +36,10 @@ type storage interface {
 	GetForcedBatchesSince(ctx context.Context, forcedBatchNumber, maxBlockNumber uint64, dbTx pgx.Tx) ([]*ForcedBatch, error)
 	AddVerifiedBatch(ctx context.Context, verifiedBatch *VerifiedBatch, dbTx pgx.Tx) error
 	GetVerifiedBatch(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (*VerifiedBatch, error)
+	GetVerifiedBatchCoveringBatch(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (*VerifiedBatch, uint64, error)
+	GetVerifiedBatchesByL1BlockRange(ctx context.Context, fromL1BlockNumber, toL1BlockNumber uint64, dbTx pgx.Tx) ([]VerifiedBatch, error)
+	GetVirtualBatchesByL1BlockRange(ctx context.Context, fromL1BlockNumber, toL1BlockNumber uint64, dbTx pgx.Tx) ([]VirtualBatch, error)
+	GetL2BlockRangeByBatchNumber(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (uint64, uint64, error)
 	GetLastNBatches(ctx context.Context, numBatches uint, dbTx pgx.Tx) ([]*Batch, error)
 	GetLastNBatchesByL2BlockNumber(ctx context.Context, l2BlockNumber *uint64, numBatches uint, dbTx pgx.Tx) ([]*Batch, common.Hash, error)
 	GetLastBatchNumber(ctx context.Context, dbTx pgx.Tx) (uint64, error)
@@ -67,6 +73,7 @@ type storage interface {
 	GetTransactionByHash(ctx context.Context, transactionHash common.Hash, dbTx pgx.Tx) (*types.Transaction, error)
 	GetTransactionByL2Hash(ctx context.Context, transactionHash common.Hash, dbTx pgx.Tx) (*types.Transaction, error)
 	GetTransactionReceipt(ctx context.Context, transactionHash common.Hash, dbTx pgx.Tx) (*types.Receipt, error)
+	GetL2BlockReceipts(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) (txs []*types.Transaction, receipts []*types.Receipt, err error)
 	GetTransactionByL2BlockHashAndIndex(ctx context.Context, blockHash common.Hash, index uint64, dbTx pgx.Tx) (*types.Transaction, error)
 	GetTransactionByL2BlockNumberAndIndex(ctx context.Context, blockNumber uint64, index uint64, dbTx pgx.Tx) (*types.Transaction, error)
 	GetL2BlockTransactionCountByHash(ctx context.Context, blockHash common.Hash, dbTx pgx.Tx) (uint64, error)
@@ -89,6 +96,7 @@ type storage interface {
 	GetTxsByBatchNumber(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) ([]*types.Transaction, error)
 	GetL2BlockHeaderByHash(ctx context.Context, hash common.Hash, dbTx pgx.Tx) (*L2Header, error)
 	GetL2BlockHeaderByNumber(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) (*L2Header, error)
+	GetL2BlocksFeeDataInRange(ctx context.Context, fromBlockNumber, toBlockNumber uint64, dbTx pgx.Tx) ([]L2BlockFeeData, error)
 	GetL2BlockHashesSince(ctx context.Context, since time.Time, dbTx pgx.Tx) ([]common.Hash, error)
 	IsL2BlockConsolidated(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) (bool, error)
 	IsL2BlockVirtualized(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) (bool, error)
@@ -102,6 +110,7 @@ type storage interface {
 	CheckProofContainsCompleteSequences(ctx context.Context, proof *Proof, dbTx pgx.Tx) (bool, error)
 	GetProofReadyForFinal(ctx context.Context, lastVerfiedBatchNumber uint64, dbTx pgx.Tx) (*Proof, error)
 	GetBatchProofsToAggregate(ctx context.Context, dbTx pgx.Tx) (*Proof, *Proof, error)
+	GetBatchProof(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (*Proof, error)
 	AddBatchProof(ctx context.Context, proof *Proof, dbTx pgx.Tx) error
 	UpdateBatchProof(ctx context.Context, proof *Proof, dbTx pgx.Tx) error
 	DeleteBatchProofs(ctx context.Context, batchNumber uint64, batchNumberFinal uint64, dbTx pgx.Tx) error
@@ -146,6 +155,7 @@ type storage interface {
 	GetL1InfoRootLeafByL1InfoRoot(ctx context.Context, l1InfoRoot common.Hash, dbTx pgx.Tx) (L1InfoTreeExitRootStorageEntry, error)
 	GetL1InfoRootLeafByIndex(ctx context.Context, l1InfoTreeIndex uint32, dbTx pgx.Tx) (L1InfoTreeExitRootStorageEntry, error)
 	GetLeafsByL1InfoRoot(ctx context.Context, l1InfoRoot common.Hash, dbTx pgx.Tx) ([]L1InfoTreeExitRootStorageEntry, error)
+	GetL1InfoTreeLeafByGlobalExitRoot(ctx context.Context, ger common.Hash, dbTx pgx.Tx) (L1InfoTreeExitRootStorageEntry, error)
 	GetBlockByNumber(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) (*Block, error)
 	GetVirtualBatchParentHash(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (common.Hash, error)
 	GetForcedBatchParentHash(ctx context.Context, forcedBatchNumber uint64, dbTx pgx.Tx) (common.Hash, error)
@@ -158,4 +168,18 @@ type storage interface {
 	UpdateBatchAsChecked(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) error
 	GetNotCheckedBatches(ctx context.Context, dbTx pgx.Tx) ([]*Batch, error)
 	GetLastL2BlockByBatchNumber(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (*L2Block, error)
+	AddArchivedL2Block(ctx context.Context, l2BlockNumber uint64, hasCodeAndStorage bool, dbTx pgx.Tx) error
+	IsL2BlockArchived(ctx context.Context, l2BlockNumber uint64, dbTx pgx.Tx) (bool, error)
+	HasArchivedCodeAndStorage(ctx context.Context, l2BlockNumber uint64, dbTx pgx.Tx) (bool, error)
+	AddArchivedAccount(ctx context.Context, l2BlockNumber uint64, address common.Address, nonce *uint64, balance *big.Int, dbTx pgx.Tx) error
+	AddArchivedCode(ctx context.Context, l2BlockNumber uint64, address common.Address, code []byte, dbTx pgx.Tx) error
+	AddArchivedStorage(ctx context.Context, l2BlockNumber uint64, address common.Address, position, value common.Hash, dbTx pgx.Tx) error
+	GetArchivedL2BlockNumberByStateRoot(ctx context.Context, stateRoot common.Hash, dbTx pgx.Tx) (uint64, error)
+	GetArchivedBalance(ctx context.Context, address common.Address, l2BlockNumber uint64, dbTx pgx.Tx) (*big.Int, error)
+	GetArchivedNonce(ctx context.Context, address common.Address, l2BlockNumber uint64, dbTx pgx.Tx) (uint64, error)
+	GetArchivedCode(ctx context.Context, address common.Address, l2BlockNumber uint64, dbTx pgx.Tx) ([]byte, error)
+	GetArchivedStorageAt(ctx context.Context, address common.Address, position common.Hash, l2BlockNumber uint64, dbTx pgx.Tx) (common.Hash, error)
+	AcquireSequencerLease(ctx context.Context, nodeID string, leaseDuration time.Duration, dbTx pgx.Tx) (uint64, error)
+	RenewSequencerLease(ctx context.Context, nodeID string, fencingToken uint64, leaseDuration time.Duration, dbTx pgx.Tx) error
+	CheckSequencerLease(ctx context.Context, nodeID string, fencingToken uint64, dbTx pgx.Tx) error
 }
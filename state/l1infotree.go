@@ -97,3 +97,36 @@ func (s *State) GetCurrentL1InfoRoot(ctx context.Context, dbTx pgx.Tx) (common.H
 	root, _, _ := s.l1InfoTree.GetCurrentRootCountAndSiblings()
 	return root, nil
 }
+
+// GetL1InfoTreeMerkleProof returns the leaf of the L1InfoTree at the provided index
+// and its merkle proof against the provided L1InfoRoot
+func (s *State) GetL1InfoTreeMerkleProof(ctx context.Context, index uint32, l1InfoRoot common.Hash, dbTx pgx.Tx) (L1InfoTreeExitRootStorageEntry, [][32]byte, error) {
+	entries, err := s.GetLeafsByL1InfoRoot(ctx, l1InfoRoot, dbTx)
+	if err != nil {
+		return L1InfoTreeExitRootStorageEntry{}, nil, err
+	}
+	if len(entries) == 0 {
+		return L1InfoTreeExitRootStorageEntry{}, nil, ErrNotFound
+	}
+	if index >= uint32(len(entries)) {
+		return L1InfoTreeExitRootStorageEntry{}, nil, ErrL1InfoTreeLeafNotIncluded
+	}
+
+	leaves := make([][32]byte, 0, len(entries))
+	for _, entry := range entries {
+		leaves = append(leaves, entry.Hash())
+	}
+	mt, err := l1infotree.NewL1InfoTree(uint8(32), [][32]byte{}) //nolint:gomnd
+	if err != nil {
+		return L1InfoTreeExitRootStorageEntry{}, nil, err
+	}
+	proof, root, err := mt.ComputeMerkleProof(index, leaves)
+	if err != nil {
+		return L1InfoTreeExitRootStorageEntry{}, nil, err
+	}
+	if root != l1InfoRoot {
+		return L1InfoTreeExitRootStorageEntry{}, nil, fmt.Errorf("l1InfoRoot mismatch. L1InfoRoot: %s, calculatedL1InfoRoot: %s", l1InfoRoot.String(), root.String())
+	}
+
+	return entries[index], proof, nil
+}
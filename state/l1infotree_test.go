@@ -3,7 +3,9 @@ package state_test
 import (
 	"context"
 	"math"
+	"math/big"
 	"testing"
+	"time"
 
 	"github.com/0xPolygonHermez/zkevm-node/db"
 	"github.com/0xPolygonHermez/zkevm-node/l1infotree"
@@ -147,3 +149,68 @@ func TestAddL1InfoTreeLeafIfNil(t *testing.T) {
 	require.Equal(t, addLeaf.L1InfoTreeRoot, common.HexToHash("0xea536769cad1a63ffb1ea52ae772983905c3f0e2f8914e6c0e2af956637e480c"))
 	require.Equal(t, addLeaf.L1InfoTreeIndex, uint32(0))
 }
+
+func TestGetL1InfoTreeMerkleProof(t *testing.T) {
+	mockStorage := mocks.NewStorageMock(t)
+	stateCfg := state.Config{
+		MaxCumulativeGasUsed: 800000,
+		ChainID:              1000,
+		MaxLogsCount:         10000,
+		MaxLogsBlockRange:    10000,
+		ForkIDIntervals: []state.ForkIDInterval{{
+			FromBatchNumber: 0,
+			ToBatchNumber:   math.MaxUint64,
+			ForkId:          uint64(state.FORKID_ETROG),
+			Version:         "",
+		}},
+	}
+	ctx := context.Background()
+	testState := state.NewState(stateCfg, mockStorage, nil, nil, nil, nil)
+
+	l1InfoTree, err := l1infotree.NewL1InfoTree(uint8(32), nil)
+	require.NoError(t, err)
+	entries := []state.L1InfoTreeExitRootStorageEntry{}
+	for i := 0; i < 3; i++ {
+		entry := state.L1InfoTreeExitRootStorageEntry{
+			L1InfoTreeLeaf: state.L1InfoTreeLeaf{
+				GlobalExitRoot: state.GlobalExitRoot{
+					GlobalExitRoot: common.BigToHash(big.NewInt(int64(i + 1))),
+					Timestamp:      time.Unix(int64(i+1), 0),
+				},
+				PreviousBlockHash: common.BigToHash(big.NewInt(int64(i + 10))),
+			},
+			L1InfoTreeIndex: uint32(i),
+		}
+		entry.L1InfoTreeRoot, err = l1InfoTree.AddLeaf(uint32(i), entry.Hash())
+		require.NoError(t, err)
+		entries = append(entries, entry)
+	}
+	l1InfoRoot := entries[2].L1InfoTreeRoot
+
+	mockStorage.EXPECT().GetLeafsByL1InfoRoot(ctx, l1InfoRoot, nil).Return(entries, nil)
+
+	for index := uint32(0); index < 3; index++ {
+		leaf, proof, err := testState.GetL1InfoTreeMerkleProof(ctx, index, l1InfoRoot, nil)
+		require.NoError(t, err)
+		require.Equal(t, entries[index], leaf)
+		require.Len(t, proof, 32)
+
+		// the root computed from the leaf and its proof must be the l1 info root
+		root := leaf.Hash()
+		for h, sibling := range proof {
+			if index&(1<<h) > 0 {
+				root = l1infotree.Hash(sibling, root)
+			} else {
+				root = l1infotree.Hash(root, sibling)
+			}
+		}
+		require.Equal(t, l1InfoRoot, common.Hash(root))
+	}
+
+	_, _, err = testState.GetL1InfoTreeMerkleProof(ctx, 3, l1InfoRoot, nil)
+	require.ErrorIs(t, err, state.ErrL1InfoTreeLeafNotIncluded)
+
+	mockStorage.EXPECT().GetLeafsByL1InfoRoot(ctx, common.HexToHash("0x1"), nil).Return([]state.L1InfoTreeExitRootStorageEntry{}, nil)
+	_, _, err = testState.GetL1InfoTreeMerkleProof(ctx, 0, common.HexToHash("0x1"), nil)
+	require.ErrorIs(t, err, state.ErrNotFound)
+}
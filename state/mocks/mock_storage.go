@@ -4,6 +4,7 @@ package mocks
 
 import (
 	context "context"
+	big "math/big"
 
 	common "github.com/ethereum/go-ethereum/common"
 
@@ -33,6 +34,65 @@ func (_m *StorageMock) EXPECT() *StorageMock_Expecter {
 	return &StorageMock_Expecter{mock: &_m.Mock}
 }
 
+// AcquireSequencerLease provides a mock function with given fields: ctx, nodeID, leaseDuration, dbTx
+func (_m *StorageMock) AcquireSequencerLease(ctx context.Context, nodeID string, leaseDuration time.Duration, dbTx pgx.Tx) (uint64, error) {
+	ret := _m.Called(ctx, nodeID, leaseDuration, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AcquireSequencerLease")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration, pgx.Tx) (uint64, error)); ok {
+		return rf(ctx, nodeID, leaseDuration, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration, pgx.Tx) uint64); ok {
+		r0 = rf(ctx, nodeID, leaseDuration, dbTx)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration, pgx.Tx) error); ok {
+		r1 = rf(ctx, nodeID, leaseDuration, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StorageMock_AcquireSequencerLease_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AcquireSequencerLease'
+type StorageMock_AcquireSequencerLease_Call struct {
+	*mock.Call
+}
+
+// AcquireSequencerLease is a helper method to define mock.On call
+//   - ctx context.Context
+//   - nodeID string
+//   - leaseDuration time.Duration
+//   - dbTx pgx.Tx
+func (_e *StorageMock_Expecter) AcquireSequencerLease(ctx interface{}, nodeID interface{}, leaseDuration interface{}, dbTx interface{}) *StorageMock_AcquireSequencerLease_Call {
+	return &StorageMock_AcquireSequencerLease_Call{Call: _e.mock.On("AcquireSequencerLease", ctx, nodeID, leaseDuration, dbTx)}
+}
+
+func (_c *StorageMock_AcquireSequencerLease_Call) Run(run func(ctx context.Context, nodeID string, leaseDuration time.Duration, dbTx pgx.Tx)) *StorageMock_AcquireSequencerLease_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Duration), args[3].(pgx.Tx))
+	})
+	return _c
+}
+
+func (_c *StorageMock_AcquireSequencerLease_Call) Return(_a0 uint64, _a1 error) *StorageMock_AcquireSequencerLease_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *StorageMock_AcquireSequencerLease_Call) RunAndReturn(run func(context.Context, string, time.Duration, pgx.Tx) (uint64, error)) *StorageMock_AcquireSequencerLease_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // AddAccumulatedInputHash provides a mock function with given fields: ctx, batchNum, accInputHash, dbTx
 func (_m *StorageMock) AddAccumulatedInputHash(ctx context.Context, batchNum uint64, accInputHash common.Hash, dbTx pgx.Tx) error {
 	ret := _m.Called(ctx, batchNum, accInputHash, dbTx)
@@ -82,6 +142,207 @@ func (_c *StorageMock_AddAccumulatedInputHash_Call) RunAndReturn(run func(contex
 	return _c
 }
 
+// AddArchivedAccount provides a mock function with given fields: ctx, l2BlockNumber, address, nonce, balance, dbTx
+func (_m *StorageMock) AddArchivedAccount(ctx context.Context, l2BlockNumber uint64, address common.Address, nonce *uint64, balance *big.Int, dbTx pgx.Tx) error {
+	ret := _m.Called(ctx, l2BlockNumber, address, nonce, balance, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddArchivedAccount")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, common.Address, *uint64, *big.Int, pgx.Tx) error); ok {
+		r0 = rf(ctx, l2BlockNumber, address, nonce, balance, dbTx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StorageMock_AddArchivedAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddArchivedAccount'
+type StorageMock_AddArchivedAccount_Call struct {
+	*mock.Call
+}
+
+// AddArchivedAccount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - l2BlockNumber uint64
+//   - address common.Address
+//   - nonce *uint64
+//   - balance *big.Int
+//   - dbTx pgx.Tx
+func (_e *StorageMock_Expecter) AddArchivedAccount(ctx interface{}, l2BlockNumber interface{}, address interface{}, nonce interface{}, balance interface{}, dbTx interface{}) *StorageMock_AddArchivedAccount_Call {
+	return &StorageMock_AddArchivedAccount_Call{Call: _e.mock.On("AddArchivedAccount", ctx, l2BlockNumber, address, nonce, balance, dbTx)}
+}
+
+func (_c *StorageMock_AddArchivedAccount_Call) Run(run func(ctx context.Context, l2BlockNumber uint64, address common.Address, nonce *uint64, balance *big.Int, dbTx pgx.Tx)) *StorageMock_AddArchivedAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].(common.Address), args[3].(*uint64), args[4].(*big.Int), args[5].(pgx.Tx))
+	})
+	return _c
+}
+
+func (_c *StorageMock_AddArchivedAccount_Call) Return(_a0 error) *StorageMock_AddArchivedAccount_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *StorageMock_AddArchivedAccount_Call) RunAndReturn(run func(context.Context, uint64, common.Address, *uint64, *big.Int, pgx.Tx) error) *StorageMock_AddArchivedAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddArchivedCode provides a mock function with given fields: ctx, l2BlockNumber, address, code, dbTx
+func (_m *StorageMock) AddArchivedCode(ctx context.Context, l2BlockNumber uint64, address common.Address, code []byte, dbTx pgx.Tx) error {
+	ret := _m.Called(ctx, l2BlockNumber, address, code, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddArchivedCode")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, common.Address, []byte, pgx.Tx) error); ok {
+		r0 = rf(ctx, l2BlockNumber, address, code, dbTx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StorageMock_AddArchivedCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddArchivedCode'
+type StorageMock_AddArchivedCode_Call struct {
+	*mock.Call
+}
+
+// AddArchivedCode is a helper method to define mock.On call
+//   - ctx context.Context
+//   - l2BlockNumber uint64
+//   - address common.Address
+//   - code []byte
+//   - dbTx pgx.Tx
+func (_e *StorageMock_Expecter) AddArchivedCode(ctx interface{}, l2BlockNumber interface{}, address interface{}, code interface{}, dbTx interface{}) *StorageMock_AddArchivedCode_Call {
+	return &StorageMock_AddArchivedCode_Call{Call: _e.mock.On("AddArchivedCode", ctx, l2BlockNumber, address, code, dbTx)}
+}
+
+func (_c *StorageMock_AddArchivedCode_Call) Run(run func(ctx context.Context, l2BlockNumber uint64, address common.Address, code []byte, dbTx pgx.Tx)) *StorageMock_AddArchivedCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].(common.Address), args[3].([]byte), args[4].(pgx.Tx))
+	})
+	return _c
+}
+
+func (_c *StorageMock_AddArchivedCode_Call) Return(_a0 error) *StorageMock_AddArchivedCode_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *StorageMock_AddArchivedCode_Call) RunAndReturn(run func(context.Context, uint64, common.Address, []byte, pgx.Tx) error) *StorageMock_AddArchivedCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddArchivedL2Block provides a mock function with given fields: ctx, l2BlockNumber, hasCodeAndStorage, dbTx
+func (_m *StorageMock) AddArchivedL2Block(ctx context.Context, l2BlockNumber uint64, hasCodeAndStorage bool, dbTx pgx.Tx) error {
+	ret := _m.Called(ctx, l2BlockNumber, hasCodeAndStorage, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddArchivedL2Block")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, bool, pgx.Tx) error); ok {
+		r0 = rf(ctx, l2BlockNumber, hasCodeAndStorage, dbTx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StorageMock_AddArchivedL2Block_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddArchivedL2Block'
+type StorageMock_AddArchivedL2Block_Call struct {
+	*mock.Call
+}
+
+// AddArchivedL2Block is a helper method to define mock.On call
+//   - ctx context.Context
+//   - l2BlockNumber uint64
+//   - hasCodeAndStorage bool
+//   - dbTx pgx.Tx
+func (_e *StorageMock_Expecter) AddArchivedL2Block(ctx interface{}, l2BlockNumber interface{}, hasCodeAndStorage interface{}, dbTx interface{}) *StorageMock_AddArchivedL2Block_Call {
+	return &StorageMock_AddArchivedL2Block_Call{Call: _e.mock.On("AddArchivedL2Block", ctx, l2BlockNumber, hasCodeAndStorage, dbTx)}
+}
+
+func (_c *StorageMock_AddArchivedL2Block_Call) Run(run func(ctx context.Context, l2BlockNumber uint64, hasCodeAndStorage bool, dbTx pgx.Tx)) *StorageMock_AddArchivedL2Block_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].(bool), args[3].(pgx.Tx))
+	})
+	return _c
+}
+
+func (_c *StorageMock_AddArchivedL2Block_Call) Return(_a0 error) *StorageMock_AddArchivedL2Block_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *StorageMock_AddArchivedL2Block_Call) RunAndReturn(run func(context.Context, uint64, bool, pgx.Tx) error) *StorageMock_AddArchivedL2Block_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddArchivedStorage provides a mock function with given fields: ctx, l2BlockNumber, address, position, value, dbTx
+func (_m *StorageMock) AddArchivedStorage(ctx context.Context, l2BlockNumber uint64, address common.Address, position common.Hash, value common.Hash, dbTx pgx.Tx) error {
+	ret := _m.Called(ctx, l2BlockNumber, address, position, value, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddArchivedStorage")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, common.Address, common.Hash, common.Hash, pgx.Tx) error); ok {
+		r0 = rf(ctx, l2BlockNumber, address, position, value, dbTx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StorageMock_AddArchivedStorage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddArchivedStorage'
+type StorageMock_AddArchivedStorage_Call struct {
+	*mock.Call
+}
+
+// AddArchivedStorage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - l2BlockNumber uint64
+//   - address common.Address
+//   - position common.Hash
+//   - value common.Hash
+//   - dbTx pgx.Tx
+func (_e *StorageMock_Expecter) AddArchivedStorage(ctx interface{}, l2BlockNumber interface{}, address interface{}, position interface{}, value interface{}, dbTx interface{}) *StorageMock_AddArchivedStorage_Call {
+	return &StorageMock_AddArchivedStorage_Call{Call: _e.mock.On("AddArchivedStorage", ctx, l2BlockNumber, address, position, value, dbTx)}
+}
+
+func (_c *StorageMock_AddArchivedStorage_Call) Run(run func(ctx context.Context, l2BlockNumber uint64, address common.Address, position common.Hash, value common.Hash, dbTx pgx.Tx)) *StorageMock_AddArchivedStorage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].(common.Address), args[3].(common.Hash), args[4].(common.Hash), args[5].(pgx.Tx))
+	})
+	return _c
+}
+
+func (_c *StorageMock_AddArchivedStorage_Call) Return(_a0 error) *StorageMock_AddArchivedStorage_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *StorageMock_AddArchivedStorage_Call) RunAndReturn(run func(context.Context, uint64, common.Address, common.Hash, common.Hash, pgx.Tx) error) *StorageMock_AddArchivedStorage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // AddBatchProof provides a mock function with given fields: ctx, proof, dbTx
 func (_m *StorageMock) AddBatchProof(ctx context.Context, proof *state.Proof, dbTx pgx.Tx) error {
 	ret := _m.Called(ctx, proof, dbTx)
@@ -934,6 +1195,55 @@ func (_c *StorageMock_CheckProofContainsCompleteSequences_Call) RunAndReturn(run
 	return _c
 }
 
+// CheckSequencerLease provides a mock function with given fields: ctx, nodeID, fencingToken, dbTx
+func (_m *StorageMock) CheckSequencerLease(ctx context.Context, nodeID string, fencingToken uint64, dbTx pgx.Tx) error {
+	ret := _m.Called(ctx, nodeID, fencingToken, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckSequencerLease")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint64, pgx.Tx) error); ok {
+		r0 = rf(ctx, nodeID, fencingToken, dbTx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StorageMock_CheckSequencerLease_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckSequencerLease'
+type StorageMock_CheckSequencerLease_Call struct {
+	*mock.Call
+}
+
+// CheckSequencerLease is a helper method to define mock.On call
+//   - ctx context.Context
+//   - nodeID string
+//   - fencingToken uint64
+//   - dbTx pgx.Tx
+func (_e *StorageMock_Expecter) CheckSequencerLease(ctx interface{}, nodeID interface{}, fencingToken interface{}, dbTx interface{}) *StorageMock_CheckSequencerLease_Call {
+	return &StorageMock_CheckSequencerLease_Call{Call: _e.mock.On("CheckSequencerLease", ctx, nodeID, fencingToken, dbTx)}
+}
+
+func (_c *StorageMock_CheckSequencerLease_Call) Run(run func(ctx context.Context, nodeID string, fencingToken uint64, dbTx pgx.Tx)) *StorageMock_CheckSequencerLease_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(uint64), args[3].(pgx.Tx))
+	})
+	return _c
+}
+
+func (_c *StorageMock_CheckSequencerLease_Call) Return(_a0 error) *StorageMock_CheckSequencerLease_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *StorageMock_CheckSequencerLease_Call) RunAndReturn(run func(context.Context, string, uint64, pgx.Tx) error) *StorageMock_CheckSequencerLease_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CleanupBatchProofs provides a mock function with given fields: ctx, batchNumber, dbTx
 func (_m *StorageMock) CleanupBatchProofs(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) error {
 	ret := _m.Called(ctx, batchNumber, dbTx)
@@ -1418,29 +1728,29 @@ func (_c *StorageMock_GetAllL1InfoRootEntries_Call) RunAndReturn(run func(contex
 	return _c
 }
 
-// GetBatchByForcedBatchNum provides a mock function with given fields: ctx, forcedBatchNumber, dbTx
-func (_m *StorageMock) GetBatchByForcedBatchNum(ctx context.Context, forcedBatchNumber uint64, dbTx pgx.Tx) (*state.Batch, error) {
-	ret := _m.Called(ctx, forcedBatchNumber, dbTx)
+// GetArchivedBalance provides a mock function with given fields: ctx, address, l2BlockNumber, dbTx
+func (_m *StorageMock) GetArchivedBalance(ctx context.Context, address common.Address, l2BlockNumber uint64, dbTx pgx.Tx) (*big.Int, error) {
+	ret := _m.Called(ctx, address, l2BlockNumber, dbTx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBatchByForcedBatchNum")
+		panic("no return value specified for GetArchivedBalance")
 	}
 
-	var r0 *state.Batch
+	var r0 *big.Int
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) (*state.Batch, error)); ok {
-		return rf(ctx, forcedBatchNumber, dbTx)
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address, uint64, pgx.Tx) (*big.Int, error)); ok {
+		return rf(ctx, address, l2BlockNumber, dbTx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) *state.Batch); ok {
-		r0 = rf(ctx, forcedBatchNumber, dbTx)
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address, uint64, pgx.Tx) *big.Int); ok {
+		r0 = rf(ctx, address, l2BlockNumber, dbTx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*state.Batch)
+			r0 = ret.Get(0).(*big.Int)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uint64, pgx.Tx) error); ok {
-		r1 = rf(ctx, forcedBatchNumber, dbTx)
+	if rf, ok := ret.Get(1).(func(context.Context, common.Address, uint64, pgx.Tx) error); ok {
+		r1 = rf(ctx, address, l2BlockNumber, dbTx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1448,59 +1758,60 @@ func (_m *StorageMock) GetBatchByForcedBatchNum(ctx context.Context, forcedBatch
 	return r0, r1
 }
 
-// StorageMock_GetBatchByForcedBatchNum_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBatchByForcedBatchNum'
-type StorageMock_GetBatchByForcedBatchNum_Call struct {
+// StorageMock_GetArchivedBalance_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetArchivedBalance'
+type StorageMock_GetArchivedBalance_Call struct {
 	*mock.Call
 }
 
-// GetBatchByForcedBatchNum is a helper method to define mock.On call
+// GetArchivedBalance is a helper method to define mock.On call
 //   - ctx context.Context
-//   - forcedBatchNumber uint64
+//   - address common.Address
+//   - l2BlockNumber uint64
 //   - dbTx pgx.Tx
-func (_e *StorageMock_Expecter) GetBatchByForcedBatchNum(ctx interface{}, forcedBatchNumber interface{}, dbTx interface{}) *StorageMock_GetBatchByForcedBatchNum_Call {
-	return &StorageMock_GetBatchByForcedBatchNum_Call{Call: _e.mock.On("GetBatchByForcedBatchNum", ctx, forcedBatchNumber, dbTx)}
+func (_e *StorageMock_Expecter) GetArchivedBalance(ctx interface{}, address interface{}, l2BlockNumber interface{}, dbTx interface{}) *StorageMock_GetArchivedBalance_Call {
+	return &StorageMock_GetArchivedBalance_Call{Call: _e.mock.On("GetArchivedBalance", ctx, address, l2BlockNumber, dbTx)}
 }
 
-func (_c *StorageMock_GetBatchByForcedBatchNum_Call) Run(run func(ctx context.Context, forcedBatchNumber uint64, dbTx pgx.Tx)) *StorageMock_GetBatchByForcedBatchNum_Call {
+func (_c *StorageMock_GetArchivedBalance_Call) Run(run func(ctx context.Context, address common.Address, l2BlockNumber uint64, dbTx pgx.Tx)) *StorageMock_GetArchivedBalance_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uint64), args[2].(pgx.Tx))
+		run(args[0].(context.Context), args[1].(common.Address), args[2].(uint64), args[3].(pgx.Tx))
 	})
 	return _c
 }
 
-func (_c *StorageMock_GetBatchByForcedBatchNum_Call) Return(_a0 *state.Batch, _a1 error) *StorageMock_GetBatchByForcedBatchNum_Call {
+func (_c *StorageMock_GetArchivedBalance_Call) Return(_a0 *big.Int, _a1 error) *StorageMock_GetArchivedBalance_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *StorageMock_GetBatchByForcedBatchNum_Call) RunAndReturn(run func(context.Context, uint64, pgx.Tx) (*state.Batch, error)) *StorageMock_GetBatchByForcedBatchNum_Call {
+func (_c *StorageMock_GetArchivedBalance_Call) RunAndReturn(run func(context.Context, common.Address, uint64, pgx.Tx) (*big.Int, error)) *StorageMock_GetArchivedBalance_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBatchByL2BlockNumber provides a mock function with given fields: ctx, l2BlockNumber, dbTx
-func (_m *StorageMock) GetBatchByL2BlockNumber(ctx context.Context, l2BlockNumber uint64, dbTx pgx.Tx) (*state.Batch, error) {
-	ret := _m.Called(ctx, l2BlockNumber, dbTx)
+// GetArchivedCode provides a mock function with given fields: ctx, address, l2BlockNumber, dbTx
+func (_m *StorageMock) GetArchivedCode(ctx context.Context, address common.Address, l2BlockNumber uint64, dbTx pgx.Tx) ([]byte, error) {
+	ret := _m.Called(ctx, address, l2BlockNumber, dbTx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBatchByL2BlockNumber")
+		panic("no return value specified for GetArchivedCode")
 	}
 
-	var r0 *state.Batch
+	var r0 []byte
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) (*state.Batch, error)); ok {
-		return rf(ctx, l2BlockNumber, dbTx)
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address, uint64, pgx.Tx) ([]byte, error)); ok {
+		return rf(ctx, address, l2BlockNumber, dbTx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) *state.Batch); ok {
-		r0 = rf(ctx, l2BlockNumber, dbTx)
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address, uint64, pgx.Tx) []byte); ok {
+		r0 = rf(ctx, address, l2BlockNumber, dbTx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*state.Batch)
+			r0 = ret.Get(0).([]byte)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uint64, pgx.Tx) error); ok {
-		r1 = rf(ctx, l2BlockNumber, dbTx)
+	if rf, ok := ret.Get(1).(func(context.Context, common.Address, uint64, pgx.Tx) error); ok {
+		r1 = rf(ctx, address, l2BlockNumber, dbTx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1508,50 +1819,350 @@ func (_m *StorageMock) GetBatchByL2BlockNumber(ctx context.Context, l2BlockNumbe
 	return r0, r1
 }
 
-// StorageMock_GetBatchByL2BlockNumber_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBatchByL2BlockNumber'
-type StorageMock_GetBatchByL2BlockNumber_Call struct {
+// StorageMock_GetArchivedCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetArchivedCode'
+type StorageMock_GetArchivedCode_Call struct {
 	*mock.Call
 }
 
-// GetBatchByL2BlockNumber is a helper method to define mock.On call
+// GetArchivedCode is a helper method to define mock.On call
 //   - ctx context.Context
+//   - address common.Address
 //   - l2BlockNumber uint64
 //   - dbTx pgx.Tx
-func (_e *StorageMock_Expecter) GetBatchByL2BlockNumber(ctx interface{}, l2BlockNumber interface{}, dbTx interface{}) *StorageMock_GetBatchByL2BlockNumber_Call {
-	return &StorageMock_GetBatchByL2BlockNumber_Call{Call: _e.mock.On("GetBatchByL2BlockNumber", ctx, l2BlockNumber, dbTx)}
+func (_e *StorageMock_Expecter) GetArchivedCode(ctx interface{}, address interface{}, l2BlockNumber interface{}, dbTx interface{}) *StorageMock_GetArchivedCode_Call {
+	return &StorageMock_GetArchivedCode_Call{Call: _e.mock.On("GetArchivedCode", ctx, address, l2BlockNumber, dbTx)}
 }
 
-func (_c *StorageMock_GetBatchByL2BlockNumber_Call) Run(run func(ctx context.Context, l2BlockNumber uint64, dbTx pgx.Tx)) *StorageMock_GetBatchByL2BlockNumber_Call {
+func (_c *StorageMock_GetArchivedCode_Call) Run(run func(ctx context.Context, address common.Address, l2BlockNumber uint64, dbTx pgx.Tx)) *StorageMock_GetArchivedCode_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uint64), args[2].(pgx.Tx))
+		run(args[0].(context.Context), args[1].(common.Address), args[2].(uint64), args[3].(pgx.Tx))
 	})
 	return _c
 }
 
-func (_c *StorageMock_GetBatchByL2BlockNumber_Call) Return(_a0 *state.Batch, _a1 error) *StorageMock_GetBatchByL2BlockNumber_Call {
+func (_c *StorageMock_GetArchivedCode_Call) Return(_a0 []byte, _a1 error) *StorageMock_GetArchivedCode_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *StorageMock_GetBatchByL2BlockNumber_Call) RunAndReturn(run func(context.Context, uint64, pgx.Tx) (*state.Batch, error)) *StorageMock_GetBatchByL2BlockNumber_Call {
+func (_c *StorageMock_GetArchivedCode_Call) RunAndReturn(run func(context.Context, common.Address, uint64, pgx.Tx) ([]byte, error)) *StorageMock_GetArchivedCode_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBatchByNumber provides a mock function with given fields: ctx, batchNumber, dbTx
-func (_m *StorageMock) GetBatchByNumber(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (*state.Batch, error) {
-	ret := _m.Called(ctx, batchNumber, dbTx)
+// GetArchivedL2BlockNumberByStateRoot provides a mock function with given fields: ctx, stateRoot, dbTx
+func (_m *StorageMock) GetArchivedL2BlockNumberByStateRoot(ctx context.Context, stateRoot common.Hash, dbTx pgx.Tx) (uint64, error) {
+	ret := _m.Called(ctx, stateRoot, dbTx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBatchByNumber")
+		panic("no return value specified for GetArchivedL2BlockNumberByStateRoot")
 	}
 
-	var r0 *state.Batch
+	var r0 uint64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) (*state.Batch, error)); ok {
-		return rf(ctx, batchNumber, dbTx)
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash, pgx.Tx) (uint64, error)); ok {
+		return rf(ctx, stateRoot, dbTx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) *state.Batch); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash, pgx.Tx) uint64); ok {
+		r0 = rf(ctx, stateRoot, dbTx)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Hash, pgx.Tx) error); ok {
+		r1 = rf(ctx, stateRoot, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StorageMock_GetArchivedL2BlockNumberByStateRoot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetArchivedL2BlockNumberByStateRoot'
+type StorageMock_GetArchivedL2BlockNumberByStateRoot_Call struct {
+	*mock.Call
+}
+
+// GetArchivedL2BlockNumberByStateRoot is a helper method to define mock.On call
+//   - ctx context.Context
+//   - stateRoot common.Hash
+//   - dbTx pgx.Tx
+func (_e *StorageMock_Expecter) GetArchivedL2BlockNumberByStateRoot(ctx interface{}, stateRoot interface{}, dbTx interface{}) *StorageMock_GetArchivedL2BlockNumberByStateRoot_Call {
+	return &StorageMock_GetArchivedL2BlockNumberByStateRoot_Call{Call: _e.mock.On("GetArchivedL2BlockNumberByStateRoot", ctx, stateRoot, dbTx)}
+}
+
+func (_c *StorageMock_GetArchivedL2BlockNumberByStateRoot_Call) Run(run func(ctx context.Context, stateRoot common.Hash, dbTx pgx.Tx)) *StorageMock_GetArchivedL2BlockNumberByStateRoot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(common.Hash), args[2].(pgx.Tx))
+	})
+	return _c
+}
+
+func (_c *StorageMock_GetArchivedL2BlockNumberByStateRoot_Call) Return(_a0 uint64, _a1 error) *StorageMock_GetArchivedL2BlockNumberByStateRoot_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *StorageMock_GetArchivedL2BlockNumberByStateRoot_Call) RunAndReturn(run func(context.Context, common.Hash, pgx.Tx) (uint64, error)) *StorageMock_GetArchivedL2BlockNumberByStateRoot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetArchivedNonce provides a mock function with given fields: ctx, address, l2BlockNumber, dbTx
+func (_m *StorageMock) GetArchivedNonce(ctx context.Context, address common.Address, l2BlockNumber uint64, dbTx pgx.Tx) (uint64, error) {
+	ret := _m.Called(ctx, address, l2BlockNumber, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetArchivedNonce")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address, uint64, pgx.Tx) (uint64, error)); ok {
+		return rf(ctx, address, l2BlockNumber, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address, uint64, pgx.Tx) uint64); ok {
+		r0 = rf(ctx, address, l2BlockNumber, dbTx)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Address, uint64, pgx.Tx) error); ok {
+		r1 = rf(ctx, address, l2BlockNumber, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StorageMock_GetArchivedNonce_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetArchivedNonce'
+type StorageMock_GetArchivedNonce_Call struct {
+	*mock.Call
+}
+
+// GetArchivedNonce is a helper method to define mock.On call
+//   - ctx context.Context
+//   - address common.Address
+//   - l2BlockNumber uint64
+//   - dbTx pgx.Tx
+func (_e *StorageMock_Expecter) GetArchivedNonce(ctx interface{}, address interface{}, l2BlockNumber interface{}, dbTx interface{}) *StorageMock_GetArchivedNonce_Call {
+	return &StorageMock_GetArchivedNonce_Call{Call: _e.mock.On("GetArchivedNonce", ctx, address, l2BlockNumber, dbTx)}
+}
+
+func (_c *StorageMock_GetArchivedNonce_Call) Run(run func(ctx context.Context, address common.Address, l2BlockNumber uint64, dbTx pgx.Tx)) *StorageMock_GetArchivedNonce_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(common.Address), args[2].(uint64), args[3].(pgx.Tx))
+	})
+	return _c
+}
+
+func (_c *StorageMock_GetArchivedNonce_Call) Return(_a0 uint64, _a1 error) *StorageMock_GetArchivedNonce_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *StorageMock_GetArchivedNonce_Call) RunAndReturn(run func(context.Context, common.Address, uint64, pgx.Tx) (uint64, error)) *StorageMock_GetArchivedNonce_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetArchivedStorageAt provides a mock function with given fields: ctx, address, position, l2BlockNumber, dbTx
+func (_m *StorageMock) GetArchivedStorageAt(ctx context.Context, address common.Address, position common.Hash, l2BlockNumber uint64, dbTx pgx.Tx) (common.Hash, error) {
+	ret := _m.Called(ctx, address, position, l2BlockNumber, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetArchivedStorageAt")
+	}
+
+	var r0 common.Hash
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address, common.Hash, uint64, pgx.Tx) (common.Hash, error)); ok {
+		return rf(ctx, address, position, l2BlockNumber, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address, common.Hash, uint64, pgx.Tx) common.Hash); ok {
+		r0 = rf(ctx, address, position, l2BlockNumber, dbTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(common.Hash)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Address, common.Hash, uint64, pgx.Tx) error); ok {
+		r1 = rf(ctx, address, position, l2BlockNumber, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StorageMock_GetArchivedStorageAt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetArchivedStorageAt'
+type StorageMock_GetArchivedStorageAt_Call struct {
+	*mock.Call
+}
+
+// GetArchivedStorageAt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - address common.Address
+//   - position common.Hash
+//   - l2BlockNumber uint64
+//   - dbTx pgx.Tx
+func (_e *StorageMock_Expecter) GetArchivedStorageAt(ctx interface{}, address interface{}, position interface{}, l2BlockNumber interface{}, dbTx interface{}) *StorageMock_GetArchivedStorageAt_Call {
+	return &StorageMock_GetArchivedStorageAt_Call{Call: _e.mock.On("GetArchivedStorageAt", ctx, address, position, l2BlockNumber, dbTx)}
+}
+
+func (_c *StorageMock_GetArchivedStorageAt_Call) Run(run func(ctx context.Context, address common.Address, position common.Hash, l2BlockNumber uint64, dbTx pgx.Tx)) *StorageMock_GetArchivedStorageAt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(common.Address), args[2].(common.Hash), args[3].(uint64), args[4].(pgx.Tx))
+	})
+	return _c
+}
+
+func (_c *StorageMock_GetArchivedStorageAt_Call) Return(_a0 common.Hash, _a1 error) *StorageMock_GetArchivedStorageAt_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *StorageMock_GetArchivedStorageAt_Call) RunAndReturn(run func(context.Context, common.Address, common.Hash, uint64, pgx.Tx) (common.Hash, error)) *StorageMock_GetArchivedStorageAt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBatchByForcedBatchNum provides a mock function with given fields: ctx, forcedBatchNumber, dbTx
+func (_m *StorageMock) GetBatchByForcedBatchNum(ctx context.Context, forcedBatchNumber uint64, dbTx pgx.Tx) (*state.Batch, error) {
+	ret := _m.Called(ctx, forcedBatchNumber, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBatchByForcedBatchNum")
+	}
+
+	var r0 *state.Batch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) (*state.Batch, error)); ok {
+		return rf(ctx, forcedBatchNumber, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) *state.Batch); ok {
+		r0 = rf(ctx, forcedBatchNumber, dbTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*state.Batch)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, pgx.Tx) error); ok {
+		r1 = rf(ctx, forcedBatchNumber, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StorageMock_GetBatchByForcedBatchNum_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBatchByForcedBatchNum'
+type StorageMock_GetBatchByForcedBatchNum_Call struct {
+	*mock.Call
+}
+
+// GetBatchByForcedBatchNum is a helper method to define mock.On call
+//   - ctx context.Context
+//   - forcedBatchNumber uint64
+//   - dbTx pgx.Tx
+func (_e *StorageMock_Expecter) GetBatchByForcedBatchNum(ctx interface{}, forcedBatchNumber interface{}, dbTx interface{}) *StorageMock_GetBatchByForcedBatchNum_Call {
+	return &StorageMock_GetBatchByForcedBatchNum_Call{Call: _e.mock.On("GetBatchByForcedBatchNum", ctx, forcedBatchNumber, dbTx)}
+}
+
+func (_c *StorageMock_GetBatchByForcedBatchNum_Call) Run(run func(ctx context.Context, forcedBatchNumber uint64, dbTx pgx.Tx)) *StorageMock_GetBatchByForcedBatchNum_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].(pgx.Tx))
+	})
+	return _c
+}
+
+func (_c *StorageMock_GetBatchByForcedBatchNum_Call) Return(_a0 *state.Batch, _a1 error) *StorageMock_GetBatchByForcedBatchNum_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *StorageMock_GetBatchByForcedBatchNum_Call) RunAndReturn(run func(context.Context, uint64, pgx.Tx) (*state.Batch, error)) *StorageMock_GetBatchByForcedBatchNum_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBatchByL2BlockNumber provides a mock function with given fields: ctx, l2BlockNumber, dbTx
+func (_m *StorageMock) GetBatchByL2BlockNumber(ctx context.Context, l2BlockNumber uint64, dbTx pgx.Tx) (*state.Batch, error) {
+	ret := _m.Called(ctx, l2BlockNumber, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBatchByL2BlockNumber")
+	}
+
+	var r0 *state.Batch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) (*state.Batch, error)); ok {
+		return rf(ctx, l2BlockNumber, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) *state.Batch); ok {
+		r0 = rf(ctx, l2BlockNumber, dbTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*state.Batch)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, pgx.Tx) error); ok {
+		r1 = rf(ctx, l2BlockNumber, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StorageMock_GetBatchByL2BlockNumber_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBatchByL2BlockNumber'
+type StorageMock_GetBatchByL2BlockNumber_Call struct {
+	*mock.Call
+}
+
+// GetBatchByL2BlockNumber is a helper method to define mock.On call
+//   - ctx context.Context
+//   - l2BlockNumber uint64
+//   - dbTx pgx.Tx
+func (_e *StorageMock_Expecter) GetBatchByL2BlockNumber(ctx interface{}, l2BlockNumber interface{}, dbTx interface{}) *StorageMock_GetBatchByL2BlockNumber_Call {
+	return &StorageMock_GetBatchByL2BlockNumber_Call{Call: _e.mock.On("GetBatchByL2BlockNumber", ctx, l2BlockNumber, dbTx)}
+}
+
+func (_c *StorageMock_GetBatchByL2BlockNumber_Call) Run(run func(ctx context.Context, l2BlockNumber uint64, dbTx pgx.Tx)) *StorageMock_GetBatchByL2BlockNumber_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].(pgx.Tx))
+	})
+	return _c
+}
+
+func (_c *StorageMock_GetBatchByL2BlockNumber_Call) Return(_a0 *state.Batch, _a1 error) *StorageMock_GetBatchByL2BlockNumber_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *StorageMock_GetBatchByL2BlockNumber_Call) RunAndReturn(run func(context.Context, uint64, pgx.Tx) (*state.Batch, error)) *StorageMock_GetBatchByL2BlockNumber_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBatchByNumber provides a mock function with given fields: ctx, batchNumber, dbTx
+func (_m *StorageMock) GetBatchByNumber(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (*state.Batch, error) {
+	ret := _m.Called(ctx, batchNumber, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBatchByNumber")
+	}
+
+	var r0 *state.Batch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) (*state.Batch, error)); ok {
+		return rf(ctx, batchNumber, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) *state.Batch); ok {
 		r0 = rf(ctx, batchNumber, dbTx)
 	} else {
 		if ret.Get(0) != nil {
@@ -1716,6 +2327,66 @@ func (_c *StorageMock_GetBatchNumberOfL2Block_Call) RunAndReturn(run func(contex
 	return _c
 }
 
+// GetBatchProof provides a mock function with given fields: ctx, batchNumber, dbTx
+func (_m *StorageMock) GetBatchProof(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (*state.Proof, error) {
+	ret := _m.Called(ctx, batchNumber, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBatchProof")
+	}
+
+	var r0 *state.Proof
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) (*state.Proof, error)); ok {
+		return rf(ctx, batchNumber, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) *state.Proof); ok {
+		r0 = rf(ctx, batchNumber, dbTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*state.Proof)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, pgx.Tx) error); ok {
+		r1 = rf(ctx, batchNumber, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StorageMock_GetBatchProof_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBatchProof'
+type StorageMock_GetBatchProof_Call struct {
+	*mock.Call
+}
+
+// GetBatchProof is a helper method to define mock.On call
+//   - ctx context.Context
+//   - batchNumber uint64
+//   - dbTx pgx.Tx
+func (_e *StorageMock_Expecter) GetBatchProof(ctx interface{}, batchNumber interface{}, dbTx interface{}) *StorageMock_GetBatchProof_Call {
+	return &StorageMock_GetBatchProof_Call{Call: _e.mock.On("GetBatchProof", ctx, batchNumber, dbTx)}
+}
+
+func (_c *StorageMock_GetBatchProof_Call) Run(run func(ctx context.Context, batchNumber uint64, dbTx pgx.Tx)) *StorageMock_GetBatchProof_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].(pgx.Tx))
+	})
+	return _c
+}
+
+func (_c *StorageMock_GetBatchProof_Call) Return(_a0 *state.Proof, _a1 error) *StorageMock_GetBatchProof_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *StorageMock_GetBatchProof_Call) RunAndReturn(run func(context.Context, uint64, pgx.Tx) (*state.Proof, error)) *StorageMock_GetBatchProof_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetBatchProofsToAggregate provides a mock function with given fields: ctx, dbTx
 func (_m *StorageMock) GetBatchProofsToAggregate(ctx context.Context, dbTx pgx.Tx) (*state.Proof, *state.Proof, error) {
 	ret := _m.Called(ctx, dbTx)
@@ -2895,6 +3566,64 @@ func (_c *StorageMock_GetL1InfoRootLeafByL1InfoRoot_Call) RunAndReturn(run func(
 	return _c
 }
 
+// GetL1InfoTreeLeafByGlobalExitRoot provides a mock function with given fields: ctx, ger, dbTx
+func (_m *StorageMock) GetL1InfoTreeLeafByGlobalExitRoot(ctx context.Context, ger common.Hash, dbTx pgx.Tx) (state.L1InfoTreeExitRootStorageEntry, error) {
+	ret := _m.Called(ctx, ger, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetL1InfoTreeLeafByGlobalExitRoot")
+	}
+
+	var r0 state.L1InfoTreeExitRootStorageEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash, pgx.Tx) (state.L1InfoTreeExitRootStorageEntry, error)); ok {
+		return rf(ctx, ger, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash, pgx.Tx) state.L1InfoTreeExitRootStorageEntry); ok {
+		r0 = rf(ctx, ger, dbTx)
+	} else {
+		r0 = ret.Get(0).(state.L1InfoTreeExitRootStorageEntry)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Hash, pgx.Tx) error); ok {
+		r1 = rf(ctx, ger, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StorageMock_GetL1InfoTreeLeafByGlobalExitRoot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetL1InfoTreeLeafByGlobalExitRoot'
+type StorageMock_GetL1InfoTreeLeafByGlobalExitRoot_Call struct {
+	*mock.Call
+}
+
+// GetL1InfoTreeLeafByGlobalExitRoot is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ger common.Hash
+//   - dbTx pgx.Tx
+func (_e *StorageMock_Expecter) GetL1InfoTreeLeafByGlobalExitRoot(ctx interface{}, ger interface{}, dbTx interface{}) *StorageMock_GetL1InfoTreeLeafByGlobalExitRoot_Call {
+	return &StorageMock_GetL1InfoTreeLeafByGlobalExitRoot_Call{Call: _e.mock.On("GetL1InfoTreeLeafByGlobalExitRoot", ctx, ger, dbTx)}
+}
+
+func (_c *StorageMock_GetL1InfoTreeLeafByGlobalExitRoot_Call) Run(run func(ctx context.Context, ger common.Hash, dbTx pgx.Tx)) *StorageMock_GetL1InfoTreeLeafByGlobalExitRoot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(common.Hash), args[2].(pgx.Tx))
+	})
+	return _c
+}
+
+func (_c *StorageMock_GetL1InfoTreeLeafByGlobalExitRoot_Call) Return(_a0 state.L1InfoTreeExitRootStorageEntry, _a1 error) *StorageMock_GetL1InfoTreeLeafByGlobalExitRoot_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *StorageMock_GetL1InfoTreeLeafByGlobalExitRoot_Call) RunAndReturn(run func(context.Context, common.Hash, pgx.Tx) (state.L1InfoTreeExitRootStorageEntry, error)) *StorageMock_GetL1InfoTreeLeafByGlobalExitRoot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetL2BlockByHash provides a mock function with given fields: ctx, hash, dbTx
 func (_m *StorageMock) GetL2BlockByHash(ctx context.Context, hash common.Hash, dbTx pgx.Tx) (*state.L2Block, error) {
 	ret := _m.Called(ctx, hash, dbTx)
@@ -3159,98 +3888,232 @@ func (_m *StorageMock) GetL2BlockHeaderByHash(ctx context.Context, hash common.H
 	if rf, ok := ret.Get(1).(func(context.Context, common.Hash, pgx.Tx) error); ok {
 		r1 = rf(ctx, hash, dbTx)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StorageMock_GetL2BlockHeaderByHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetL2BlockHeaderByHash'
+type StorageMock_GetL2BlockHeaderByHash_Call struct {
+	*mock.Call
+}
+
+// GetL2BlockHeaderByHash is a helper method to define mock.On call
+//   - ctx context.Context
+//   - hash common.Hash
+//   - dbTx pgx.Tx
+func (_e *StorageMock_Expecter) GetL2BlockHeaderByHash(ctx interface{}, hash interface{}, dbTx interface{}) *StorageMock_GetL2BlockHeaderByHash_Call {
+	return &StorageMock_GetL2BlockHeaderByHash_Call{Call: _e.mock.On("GetL2BlockHeaderByHash", ctx, hash, dbTx)}
+}
+
+func (_c *StorageMock_GetL2BlockHeaderByHash_Call) Run(run func(ctx context.Context, hash common.Hash, dbTx pgx.Tx)) *StorageMock_GetL2BlockHeaderByHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(common.Hash), args[2].(pgx.Tx))
+	})
+	return _c
+}
+
+func (_c *StorageMock_GetL2BlockHeaderByHash_Call) Return(_a0 *state.L2Header, _a1 error) *StorageMock_GetL2BlockHeaderByHash_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *StorageMock_GetL2BlockHeaderByHash_Call) RunAndReturn(run func(context.Context, common.Hash, pgx.Tx) (*state.L2Header, error)) *StorageMock_GetL2BlockHeaderByHash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetL2BlockHeaderByNumber provides a mock function with given fields: ctx, blockNumber, dbTx
+func (_m *StorageMock) GetL2BlockHeaderByNumber(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) (*state.L2Header, error) {
+	ret := _m.Called(ctx, blockNumber, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetL2BlockHeaderByNumber")
+	}
+
+	var r0 *state.L2Header
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) (*state.L2Header, error)); ok {
+		return rf(ctx, blockNumber, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) *state.L2Header); ok {
+		r0 = rf(ctx, blockNumber, dbTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*state.L2Header)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, pgx.Tx) error); ok {
+		r1 = rf(ctx, blockNumber, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StorageMock_GetL2BlockHeaderByNumber_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetL2BlockHeaderByNumber'
+type StorageMock_GetL2BlockHeaderByNumber_Call struct {
+	*mock.Call
+}
+
+// GetL2BlockHeaderByNumber is a helper method to define mock.On call
+//   - ctx context.Context
+//   - blockNumber uint64
+//   - dbTx pgx.Tx
+func (_e *StorageMock_Expecter) GetL2BlockHeaderByNumber(ctx interface{}, blockNumber interface{}, dbTx interface{}) *StorageMock_GetL2BlockHeaderByNumber_Call {
+	return &StorageMock_GetL2BlockHeaderByNumber_Call{Call: _e.mock.On("GetL2BlockHeaderByNumber", ctx, blockNumber, dbTx)}
+}
+
+func (_c *StorageMock_GetL2BlockHeaderByNumber_Call) Run(run func(ctx context.Context, blockNumber uint64, dbTx pgx.Tx)) *StorageMock_GetL2BlockHeaderByNumber_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].(pgx.Tx))
+	})
+	return _c
+}
+
+func (_c *StorageMock_GetL2BlockHeaderByNumber_Call) Return(_a0 *state.L2Header, _a1 error) *StorageMock_GetL2BlockHeaderByNumber_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *StorageMock_GetL2BlockHeaderByNumber_Call) RunAndReturn(run func(context.Context, uint64, pgx.Tx) (*state.L2Header, error)) *StorageMock_GetL2BlockHeaderByNumber_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetL2BlockRangeByBatchNumber provides a mock function with given fields: ctx, batchNumber, dbTx
+func (_m *StorageMock) GetL2BlockRangeByBatchNumber(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (uint64, uint64, error) {
+	ret := _m.Called(ctx, batchNumber, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetL2BlockRangeByBatchNumber")
+	}
+
+	var r0 uint64
+	var r1 uint64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) (uint64, uint64, error)); ok {
+		return rf(ctx, batchNumber, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) uint64); ok {
+		r0 = rf(ctx, batchNumber, dbTx)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, pgx.Tx) uint64); ok {
+		r1 = rf(ctx, batchNumber, dbTx)
+	} else {
+		r1 = ret.Get(1).(uint64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, uint64, pgx.Tx) error); ok {
+		r2 = rf(ctx, batchNumber, dbTx)
+	} else {
+		r2 = ret.Error(2)
 	}
 
-	return r0, r1
+	return r0, r1, r2
 }
 
-// StorageMock_GetL2BlockHeaderByHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetL2BlockHeaderByHash'
-type StorageMock_GetL2BlockHeaderByHash_Call struct {
+// StorageMock_GetL2BlockRangeByBatchNumber_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetL2BlockRangeByBatchNumber'
+type StorageMock_GetL2BlockRangeByBatchNumber_Call struct {
 	*mock.Call
 }
 
-// GetL2BlockHeaderByHash is a helper method to define mock.On call
+// GetL2BlockRangeByBatchNumber is a helper method to define mock.On call
 //   - ctx context.Context
-//   - hash common.Hash
+//   - batchNumber uint64
 //   - dbTx pgx.Tx
-func (_e *StorageMock_Expecter) GetL2BlockHeaderByHash(ctx interface{}, hash interface{}, dbTx interface{}) *StorageMock_GetL2BlockHeaderByHash_Call {
-	return &StorageMock_GetL2BlockHeaderByHash_Call{Call: _e.mock.On("GetL2BlockHeaderByHash", ctx, hash, dbTx)}
+func (_e *StorageMock_Expecter) GetL2BlockRangeByBatchNumber(ctx interface{}, batchNumber interface{}, dbTx interface{}) *StorageMock_GetL2BlockRangeByBatchNumber_Call {
+	return &StorageMock_GetL2BlockRangeByBatchNumber_Call{Call: _e.mock.On("GetL2BlockRangeByBatchNumber", ctx, batchNumber, dbTx)}
 }
 
-func (_c *StorageMock_GetL2BlockHeaderByHash_Call) Run(run func(ctx context.Context, hash common.Hash, dbTx pgx.Tx)) *StorageMock_GetL2BlockHeaderByHash_Call {
+func (_c *StorageMock_GetL2BlockRangeByBatchNumber_Call) Run(run func(ctx context.Context, batchNumber uint64, dbTx pgx.Tx)) *StorageMock_GetL2BlockRangeByBatchNumber_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(common.Hash), args[2].(pgx.Tx))
+		run(args[0].(context.Context), args[1].(uint64), args[2].(pgx.Tx))
 	})
 	return _c
 }
 
-func (_c *StorageMock_GetL2BlockHeaderByHash_Call) Return(_a0 *state.L2Header, _a1 error) *StorageMock_GetL2BlockHeaderByHash_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *StorageMock_GetL2BlockRangeByBatchNumber_Call) Return(_a0 uint64, _a1 uint64, _a2 error) *StorageMock_GetL2BlockRangeByBatchNumber_Call {
+	_c.Call.Return(_a0, _a1, _a2)
 	return _c
 }
 
-func (_c *StorageMock_GetL2BlockHeaderByHash_Call) RunAndReturn(run func(context.Context, common.Hash, pgx.Tx) (*state.L2Header, error)) *StorageMock_GetL2BlockHeaderByHash_Call {
+func (_c *StorageMock_GetL2BlockRangeByBatchNumber_Call) RunAndReturn(run func(context.Context, uint64, pgx.Tx) (uint64, uint64, error)) *StorageMock_GetL2BlockRangeByBatchNumber_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetL2BlockHeaderByNumber provides a mock function with given fields: ctx, blockNumber, dbTx
-func (_m *StorageMock) GetL2BlockHeaderByNumber(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) (*state.L2Header, error) {
+// GetL2BlockReceipts provides a mock function with given fields: ctx, blockNumber, dbTx
+func (_m *StorageMock) GetL2BlockReceipts(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) ([]*types.Transaction, []*types.Receipt, error) {
 	ret := _m.Called(ctx, blockNumber, dbTx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetL2BlockHeaderByNumber")
+		panic("no return value specified for GetL2BlockReceipts")
 	}
 
-	var r0 *state.L2Header
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) (*state.L2Header, error)); ok {
+	var r0 []*types.Transaction
+	var r1 []*types.Receipt
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) ([]*types.Transaction, []*types.Receipt, error)); ok {
 		return rf(ctx, blockNumber, dbTx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) *state.L2Header); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) []*types.Transaction); ok {
 		r0 = rf(ctx, blockNumber, dbTx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*state.L2Header)
+			r0 = ret.Get(0).([]*types.Transaction)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uint64, pgx.Tx) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, pgx.Tx) []*types.Receipt); ok {
 		r1 = rf(ctx, blockNumber, dbTx)
 	} else {
-		r1 = ret.Error(1)
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]*types.Receipt)
+		}
 	}
 
-	return r0, r1
+	if rf, ok := ret.Get(2).(func(context.Context, uint64, pgx.Tx) error); ok {
+		r2 = rf(ctx, blockNumber, dbTx)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
-// StorageMock_GetL2BlockHeaderByNumber_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetL2BlockHeaderByNumber'
-type StorageMock_GetL2BlockHeaderByNumber_Call struct {
+// StorageMock_GetL2BlockReceipts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetL2BlockReceipts'
+type StorageMock_GetL2BlockReceipts_Call struct {
 	*mock.Call
 }
 
-// GetL2BlockHeaderByNumber is a helper method to define mock.On call
+// GetL2BlockReceipts is a helper method to define mock.On call
 //   - ctx context.Context
 //   - blockNumber uint64
 //   - dbTx pgx.Tx
-func (_e *StorageMock_Expecter) GetL2BlockHeaderByNumber(ctx interface{}, blockNumber interface{}, dbTx interface{}) *StorageMock_GetL2BlockHeaderByNumber_Call {
-	return &StorageMock_GetL2BlockHeaderByNumber_Call{Call: _e.mock.On("GetL2BlockHeaderByNumber", ctx, blockNumber, dbTx)}
+func (_e *StorageMock_Expecter) GetL2BlockReceipts(ctx interface{}, blockNumber interface{}, dbTx interface{}) *StorageMock_GetL2BlockReceipts_Call {
+	return &StorageMock_GetL2BlockReceipts_Call{Call: _e.mock.On("GetL2BlockReceipts", ctx, blockNumber, dbTx)}
 }
 
-func (_c *StorageMock_GetL2BlockHeaderByNumber_Call) Run(run func(ctx context.Context, blockNumber uint64, dbTx pgx.Tx)) *StorageMock_GetL2BlockHeaderByNumber_Call {
+func (_c *StorageMock_GetL2BlockReceipts_Call) Run(run func(ctx context.Context, blockNumber uint64, dbTx pgx.Tx)) *StorageMock_GetL2BlockReceipts_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(context.Context), args[1].(uint64), args[2].(pgx.Tx))
 	})
 	return _c
 }
 
-func (_c *StorageMock_GetL2BlockHeaderByNumber_Call) Return(_a0 *state.L2Header, _a1 error) *StorageMock_GetL2BlockHeaderByNumber_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *StorageMock_GetL2BlockReceipts_Call) Return(txs []*types.Transaction, receipts []*types.Receipt, err error) *StorageMock_GetL2BlockReceipts_Call {
+	_c.Call.Return(txs, receipts, err)
 	return _c
 }
 
-func (_c *StorageMock_GetL2BlockHeaderByNumber_Call) RunAndReturn(run func(context.Context, uint64, pgx.Tx) (*state.L2Header, error)) *StorageMock_GetL2BlockHeaderByNumber_Call {
+func (_c *StorageMock_GetL2BlockReceipts_Call) RunAndReturn(run func(context.Context, uint64, pgx.Tx) ([]*types.Transaction, []*types.Receipt, error)) *StorageMock_GetL2BlockReceipts_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -3431,6 +4294,67 @@ func (_c *StorageMock_GetL2BlocksByBatchNumber_Call) RunAndReturn(run func(conte
 	return _c
 }
 
+// GetL2BlocksFeeDataInRange provides a mock function with given fields: ctx, fromBlockNumber, toBlockNumber, dbTx
+func (_m *StorageMock) GetL2BlocksFeeDataInRange(ctx context.Context, fromBlockNumber uint64, toBlockNumber uint64, dbTx pgx.Tx) ([]state.L2BlockFeeData, error) {
+	ret := _m.Called(ctx, fromBlockNumber, toBlockNumber, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetL2BlocksFeeDataInRange")
+	}
+
+	var r0 []state.L2BlockFeeData
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, pgx.Tx) ([]state.L2BlockFeeData, error)); ok {
+		return rf(ctx, fromBlockNumber, toBlockNumber, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, pgx.Tx) []state.L2BlockFeeData); ok {
+		r0 = rf(ctx, fromBlockNumber, toBlockNumber, dbTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]state.L2BlockFeeData)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64, pgx.Tx) error); ok {
+		r1 = rf(ctx, fromBlockNumber, toBlockNumber, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StorageMock_GetL2BlocksFeeDataInRange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetL2BlocksFeeDataInRange'
+type StorageMock_GetL2BlocksFeeDataInRange_Call struct {
+	*mock.Call
+}
+
+// GetL2BlocksFeeDataInRange is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fromBlockNumber uint64
+//   - toBlockNumber uint64
+//   - dbTx pgx.Tx
+func (_e *StorageMock_Expecter) GetL2BlocksFeeDataInRange(ctx interface{}, fromBlockNumber interface{}, toBlockNumber interface{}, dbTx interface{}) *StorageMock_GetL2BlocksFeeDataInRange_Call {
+	return &StorageMock_GetL2BlocksFeeDataInRange_Call{Call: _e.mock.On("GetL2BlocksFeeDataInRange", ctx, fromBlockNumber, toBlockNumber, dbTx)}
+}
+
+func (_c *StorageMock_GetL2BlocksFeeDataInRange_Call) Run(run func(ctx context.Context, fromBlockNumber uint64, toBlockNumber uint64, dbTx pgx.Tx)) *StorageMock_GetL2BlocksFeeDataInRange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].(uint64), args[3].(pgx.Tx))
+	})
+	return _c
+}
+
+func (_c *StorageMock_GetL2BlocksFeeDataInRange_Call) Return(_a0 []state.L2BlockFeeData, _a1 error) *StorageMock_GetL2BlocksFeeDataInRange_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *StorageMock_GetL2BlocksFeeDataInRange_Call) RunAndReturn(run func(context.Context, uint64, uint64, pgx.Tx) ([]state.L2BlockFeeData, error)) *StorageMock_GetL2BlocksFeeDataInRange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetL2TxHashByTxHash provides a mock function with given fields: ctx, hash, dbTx
 func (_m *StorageMock) GetL2TxHashByTxHash(ctx context.Context, hash common.Hash, dbTx pgx.Tx) (*common.Hash, error) {
 	ret := _m.Called(ctx, hash, dbTx)
@@ -5757,6 +6681,63 @@ func (_c *StorageMock_GetReorgedTransactions_Call) RunAndReturn(run func(context
 	return _c
 }
 
+// GetResetEpoch provides a mock function with given fields: ctx, dbTx
+func (_m *StorageMock) GetResetEpoch(ctx context.Context, dbTx pgx.Tx) (uint64, error) {
+	ret := _m.Called(ctx, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetResetEpoch")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx) (uint64, error)); ok {
+		return rf(ctx, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx) uint64); ok {
+		r0 = rf(ctx, dbTx)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, pgx.Tx) error); ok {
+		r1 = rf(ctx, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StorageMock_GetResetEpoch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetResetEpoch'
+type StorageMock_GetResetEpoch_Call struct {
+	*mock.Call
+}
+
+// GetResetEpoch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - dbTx pgx.Tx
+func (_e *StorageMock_Expecter) GetResetEpoch(ctx interface{}, dbTx interface{}) *StorageMock_GetResetEpoch_Call {
+	return &StorageMock_GetResetEpoch_Call{Call: _e.mock.On("GetResetEpoch", ctx, dbTx)}
+}
+
+func (_c *StorageMock_GetResetEpoch_Call) Run(run func(ctx context.Context, dbTx pgx.Tx)) *StorageMock_GetResetEpoch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgx.Tx))
+	})
+	return _c
+}
+
+func (_c *StorageMock_GetResetEpoch_Call) Return(_a0 uint64, _a1 error) *StorageMock_GetResetEpoch_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *StorageMock_GetResetEpoch_Call) RunAndReturn(run func(context.Context, pgx.Tx) (uint64, error)) *StorageMock_GetResetEpoch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetSequences provides a mock function with given fields: ctx, lastVerifiedBatchNumber, dbTx
 func (_m *StorageMock) GetSequences(ctx context.Context, lastVerifiedBatchNumber uint64, dbTx pgx.Tx) ([]state.Sequence, error) {
 	ret := _m.Called(ctx, lastVerifiedBatchNumber, dbTx)
@@ -6624,7 +7605,128 @@ func (_m *StorageMock) GetTxsOlderThanNL1Blocks(ctx context.Context, nL1Blocks u
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, uint64, pgx.Tx) error); ok {
-		r1 = rf(ctx, nL1Blocks, dbTx)
+		r1 = rf(ctx, nL1Blocks, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StorageMock_GetTxsOlderThanNL1Blocks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTxsOlderThanNL1Blocks'
+type StorageMock_GetTxsOlderThanNL1Blocks_Call struct {
+	*mock.Call
+}
+
+// GetTxsOlderThanNL1Blocks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - nL1Blocks uint64
+//   - dbTx pgx.Tx
+func (_e *StorageMock_Expecter) GetTxsOlderThanNL1Blocks(ctx interface{}, nL1Blocks interface{}, dbTx interface{}) *StorageMock_GetTxsOlderThanNL1Blocks_Call {
+	return &StorageMock_GetTxsOlderThanNL1Blocks_Call{Call: _e.mock.On("GetTxsOlderThanNL1Blocks", ctx, nL1Blocks, dbTx)}
+}
+
+func (_c *StorageMock_GetTxsOlderThanNL1Blocks_Call) Run(run func(ctx context.Context, nL1Blocks uint64, dbTx pgx.Tx)) *StorageMock_GetTxsOlderThanNL1Blocks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].(pgx.Tx))
+	})
+	return _c
+}
+
+func (_c *StorageMock_GetTxsOlderThanNL1Blocks_Call) Return(_a0 []common.Hash, _a1 error) *StorageMock_GetTxsOlderThanNL1Blocks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *StorageMock_GetTxsOlderThanNL1Blocks_Call) RunAndReturn(run func(context.Context, uint64, pgx.Tx) ([]common.Hash, error)) *StorageMock_GetTxsOlderThanNL1Blocks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTxsOlderThanNL1BlocksUntilTxHash provides a mock function with given fields: ctx, nL1Blocks, earliestTxHash, dbTx
+func (_m *StorageMock) GetTxsOlderThanNL1BlocksUntilTxHash(ctx context.Context, nL1Blocks uint64, earliestTxHash common.Hash, dbTx pgx.Tx) ([]common.Hash, error) {
+	ret := _m.Called(ctx, nL1Blocks, earliestTxHash, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTxsOlderThanNL1BlocksUntilTxHash")
+	}
+
+	var r0 []common.Hash
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, common.Hash, pgx.Tx) ([]common.Hash, error)); ok {
+		return rf(ctx, nL1Blocks, earliestTxHash, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, common.Hash, pgx.Tx) []common.Hash); ok {
+		r0 = rf(ctx, nL1Blocks, earliestTxHash, dbTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]common.Hash)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, common.Hash, pgx.Tx) error); ok {
+		r1 = rf(ctx, nL1Blocks, earliestTxHash, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StorageMock_GetTxsOlderThanNL1BlocksUntilTxHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTxsOlderThanNL1BlocksUntilTxHash'
+type StorageMock_GetTxsOlderThanNL1BlocksUntilTxHash_Call struct {
+	*mock.Call
+}
+
+// GetTxsOlderThanNL1BlocksUntilTxHash is a helper method to define mock.On call
+//   - ctx context.Context
+//   - nL1Blocks uint64
+//   - earliestTxHash common.Hash
+//   - dbTx pgx.Tx
+func (_e *StorageMock_Expecter) GetTxsOlderThanNL1BlocksUntilTxHash(ctx interface{}, nL1Blocks interface{}, earliestTxHash interface{}, dbTx interface{}) *StorageMock_GetTxsOlderThanNL1BlocksUntilTxHash_Call {
+	return &StorageMock_GetTxsOlderThanNL1BlocksUntilTxHash_Call{Call: _e.mock.On("GetTxsOlderThanNL1BlocksUntilTxHash", ctx, nL1Blocks, earliestTxHash, dbTx)}
+}
+
+func (_c *StorageMock_GetTxsOlderThanNL1BlocksUntilTxHash_Call) Run(run func(ctx context.Context, nL1Blocks uint64, earliestTxHash common.Hash, dbTx pgx.Tx)) *StorageMock_GetTxsOlderThanNL1BlocksUntilTxHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].(common.Hash), args[3].(pgx.Tx))
+	})
+	return _c
+}
+
+func (_c *StorageMock_GetTxsOlderThanNL1BlocksUntilTxHash_Call) Return(_a0 []common.Hash, _a1 error) *StorageMock_GetTxsOlderThanNL1BlocksUntilTxHash_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *StorageMock_GetTxsOlderThanNL1BlocksUntilTxHash_Call) RunAndReturn(run func(context.Context, uint64, common.Hash, pgx.Tx) ([]common.Hash, error)) *StorageMock_GetTxsOlderThanNL1BlocksUntilTxHash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetVerifiedBatch provides a mock function with given fields: ctx, batchNumber, dbTx
+func (_m *StorageMock) GetVerifiedBatch(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (*state.VerifiedBatch, error) {
+	ret := _m.Called(ctx, batchNumber, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetVerifiedBatch")
+	}
+
+	var r0 *state.VerifiedBatch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) (*state.VerifiedBatch, error)); ok {
+		return rf(ctx, batchNumber, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) *state.VerifiedBatch); ok {
+		r0 = rf(ctx, batchNumber, dbTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*state.VerifiedBatch)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, pgx.Tx) error); ok {
+		r1 = rf(ctx, batchNumber, dbTx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -6632,120 +7734,126 @@ func (_m *StorageMock) GetTxsOlderThanNL1Blocks(ctx context.Context, nL1Blocks u
 	return r0, r1
 }
 
-// StorageMock_GetTxsOlderThanNL1Blocks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTxsOlderThanNL1Blocks'
-type StorageMock_GetTxsOlderThanNL1Blocks_Call struct {
+// StorageMock_GetVerifiedBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetVerifiedBatch'
+type StorageMock_GetVerifiedBatch_Call struct {
 	*mock.Call
 }
 
-// GetTxsOlderThanNL1Blocks is a helper method to define mock.On call
+// GetVerifiedBatch is a helper method to define mock.On call
 //   - ctx context.Context
-//   - nL1Blocks uint64
+//   - batchNumber uint64
 //   - dbTx pgx.Tx
-func (_e *StorageMock_Expecter) GetTxsOlderThanNL1Blocks(ctx interface{}, nL1Blocks interface{}, dbTx interface{}) *StorageMock_GetTxsOlderThanNL1Blocks_Call {
-	return &StorageMock_GetTxsOlderThanNL1Blocks_Call{Call: _e.mock.On("GetTxsOlderThanNL1Blocks", ctx, nL1Blocks, dbTx)}
+func (_e *StorageMock_Expecter) GetVerifiedBatch(ctx interface{}, batchNumber interface{}, dbTx interface{}) *StorageMock_GetVerifiedBatch_Call {
+	return &StorageMock_GetVerifiedBatch_Call{Call: _e.mock.On("GetVerifiedBatch", ctx, batchNumber, dbTx)}
 }
 
-func (_c *StorageMock_GetTxsOlderThanNL1Blocks_Call) Run(run func(ctx context.Context, nL1Blocks uint64, dbTx pgx.Tx)) *StorageMock_GetTxsOlderThanNL1Blocks_Call {
+func (_c *StorageMock_GetVerifiedBatch_Call) Run(run func(ctx context.Context, batchNumber uint64, dbTx pgx.Tx)) *StorageMock_GetVerifiedBatch_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(context.Context), args[1].(uint64), args[2].(pgx.Tx))
 	})
 	return _c
 }
 
-func (_c *StorageMock_GetTxsOlderThanNL1Blocks_Call) Return(_a0 []common.Hash, _a1 error) *StorageMock_GetTxsOlderThanNL1Blocks_Call {
+func (_c *StorageMock_GetVerifiedBatch_Call) Return(_a0 *state.VerifiedBatch, _a1 error) *StorageMock_GetVerifiedBatch_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *StorageMock_GetTxsOlderThanNL1Blocks_Call) RunAndReturn(run func(context.Context, uint64, pgx.Tx) ([]common.Hash, error)) *StorageMock_GetTxsOlderThanNL1Blocks_Call {
+func (_c *StorageMock_GetVerifiedBatch_Call) RunAndReturn(run func(context.Context, uint64, pgx.Tx) (*state.VerifiedBatch, error)) *StorageMock_GetVerifiedBatch_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetTxsOlderThanNL1BlocksUntilTxHash provides a mock function with given fields: ctx, nL1Blocks, earliestTxHash, dbTx
-func (_m *StorageMock) GetTxsOlderThanNL1BlocksUntilTxHash(ctx context.Context, nL1Blocks uint64, earliestTxHash common.Hash, dbTx pgx.Tx) ([]common.Hash, error) {
-	ret := _m.Called(ctx, nL1Blocks, earliestTxHash, dbTx)
+// GetVerifiedBatchCoveringBatch provides a mock function with given fields: ctx, batchNumber, dbTx
+func (_m *StorageMock) GetVerifiedBatchCoveringBatch(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (*state.VerifiedBatch, uint64, error) {
+	ret := _m.Called(ctx, batchNumber, dbTx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetTxsOlderThanNL1BlocksUntilTxHash")
+		panic("no return value specified for GetVerifiedBatchCoveringBatch")
 	}
 
-	var r0 []common.Hash
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uint64, common.Hash, pgx.Tx) ([]common.Hash, error)); ok {
-		return rf(ctx, nL1Blocks, earliestTxHash, dbTx)
+	var r0 *state.VerifiedBatch
+	var r1 uint64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) (*state.VerifiedBatch, uint64, error)); ok {
+		return rf(ctx, batchNumber, dbTx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uint64, common.Hash, pgx.Tx) []common.Hash); ok {
-		r0 = rf(ctx, nL1Blocks, earliestTxHash, dbTx)
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) *state.VerifiedBatch); ok {
+		r0 = rf(ctx, batchNumber, dbTx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]common.Hash)
+			r0 = ret.Get(0).(*state.VerifiedBatch)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uint64, common.Hash, pgx.Tx) error); ok {
-		r1 = rf(ctx, nL1Blocks, earliestTxHash, dbTx)
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, pgx.Tx) uint64); ok {
+		r1 = rf(ctx, batchNumber, dbTx)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(uint64)
 	}
 
-	return r0, r1
+	if rf, ok := ret.Get(2).(func(context.Context, uint64, pgx.Tx) error); ok {
+		r2 = rf(ctx, batchNumber, dbTx)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
-// StorageMock_GetTxsOlderThanNL1BlocksUntilTxHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTxsOlderThanNL1BlocksUntilTxHash'
-type StorageMock_GetTxsOlderThanNL1BlocksUntilTxHash_Call struct {
+// StorageMock_GetVerifiedBatchCoveringBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetVerifiedBatchCoveringBatch'
+type StorageMock_GetVerifiedBatchCoveringBatch_Call struct {
 	*mock.Call
 }
 
-// GetTxsOlderThanNL1BlocksUntilTxHash is a helper method to define mock.On call
+// GetVerifiedBatchCoveringBatch is a helper method to define mock.On call
 //   - ctx context.Context
-//   - nL1Blocks uint64
-//   - earliestTxHash common.Hash
+//   - batchNumber uint64
 //   - dbTx pgx.Tx
-func (_e *StorageMock_Expecter) GetTxsOlderThanNL1BlocksUntilTxHash(ctx interface{}, nL1Blocks interface{}, earliestTxHash interface{}, dbTx interface{}) *StorageMock_GetTxsOlderThanNL1BlocksUntilTxHash_Call {
-	return &StorageMock_GetTxsOlderThanNL1BlocksUntilTxHash_Call{Call: _e.mock.On("GetTxsOlderThanNL1BlocksUntilTxHash", ctx, nL1Blocks, earliestTxHash, dbTx)}
+func (_e *StorageMock_Expecter) GetVerifiedBatchCoveringBatch(ctx interface{}, batchNumber interface{}, dbTx interface{}) *StorageMock_GetVerifiedBatchCoveringBatch_Call {
+	return &StorageMock_GetVerifiedBatchCoveringBatch_Call{Call: _e.mock.On("GetVerifiedBatchCoveringBatch", ctx, batchNumber, dbTx)}
 }
 
-func (_c *StorageMock_GetTxsOlderThanNL1BlocksUntilTxHash_Call) Run(run func(ctx context.Context, nL1Blocks uint64, earliestTxHash common.Hash, dbTx pgx.Tx)) *StorageMock_GetTxsOlderThanNL1BlocksUntilTxHash_Call {
+func (_c *StorageMock_GetVerifiedBatchCoveringBatch_Call) Run(run func(ctx context.Context, batchNumber uint64, dbTx pgx.Tx)) *StorageMock_GetVerifiedBatchCoveringBatch_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uint64), args[2].(common.Hash), args[3].(pgx.Tx))
+		run(args[0].(context.Context), args[1].(uint64), args[2].(pgx.Tx))
 	})
 	return _c
 }
 
-func (_c *StorageMock_GetTxsOlderThanNL1BlocksUntilTxHash_Call) Return(_a0 []common.Hash, _a1 error) *StorageMock_GetTxsOlderThanNL1BlocksUntilTxHash_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *StorageMock_GetVerifiedBatchCoveringBatch_Call) Return(_a0 *state.VerifiedBatch, _a1 uint64, _a2 error) *StorageMock_GetVerifiedBatchCoveringBatch_Call {
+	_c.Call.Return(_a0, _a1, _a2)
 	return _c
 }
 
-func (_c *StorageMock_GetTxsOlderThanNL1BlocksUntilTxHash_Call) RunAndReturn(run func(context.Context, uint64, common.Hash, pgx.Tx) ([]common.Hash, error)) *StorageMock_GetTxsOlderThanNL1BlocksUntilTxHash_Call {
+func (_c *StorageMock_GetVerifiedBatchCoveringBatch_Call) RunAndReturn(run func(context.Context, uint64, pgx.Tx) (*state.VerifiedBatch, uint64, error)) *StorageMock_GetVerifiedBatchCoveringBatch_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetVerifiedBatch provides a mock function with given fields: ctx, batchNumber, dbTx
-func (_m *StorageMock) GetVerifiedBatch(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (*state.VerifiedBatch, error) {
-	ret := _m.Called(ctx, batchNumber, dbTx)
+// GetVerifiedBatchesByL1BlockRange provides a mock function with given fields: ctx, fromL1BlockNumber, toL1BlockNumber, dbTx
+func (_m *StorageMock) GetVerifiedBatchesByL1BlockRange(ctx context.Context, fromL1BlockNumber uint64, toL1BlockNumber uint64, dbTx pgx.Tx) ([]state.VerifiedBatch, error) {
+	ret := _m.Called(ctx, fromL1BlockNumber, toL1BlockNumber, dbTx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetVerifiedBatch")
+		panic("no return value specified for GetVerifiedBatchesByL1BlockRange")
 	}
 
-	var r0 *state.VerifiedBatch
+	var r0 []state.VerifiedBatch
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) (*state.VerifiedBatch, error)); ok {
-		return rf(ctx, batchNumber, dbTx)
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, pgx.Tx) ([]state.VerifiedBatch, error)); ok {
+		return rf(ctx, fromL1BlockNumber, toL1BlockNumber, dbTx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) *state.VerifiedBatch); ok {
-		r0 = rf(ctx, batchNumber, dbTx)
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, pgx.Tx) []state.VerifiedBatch); ok {
+		r0 = rf(ctx, fromL1BlockNumber, toL1BlockNumber, dbTx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*state.VerifiedBatch)
+			r0 = ret.Get(0).([]state.VerifiedBatch)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uint64, pgx.Tx) error); ok {
-		r1 = rf(ctx, batchNumber, dbTx)
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64, pgx.Tx) error); ok {
+		r1 = rf(ctx, fromL1BlockNumber, toL1BlockNumber, dbTx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -6753,32 +7861,33 @@ func (_m *StorageMock) GetVerifiedBatch(ctx context.Context, batchNumber uint64,
 	return r0, r1
 }
 
-// StorageMock_GetVerifiedBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetVerifiedBatch'
-type StorageMock_GetVerifiedBatch_Call struct {
+// StorageMock_GetVerifiedBatchesByL1BlockRange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetVerifiedBatchesByL1BlockRange'
+type StorageMock_GetVerifiedBatchesByL1BlockRange_Call struct {
 	*mock.Call
 }
 
-// GetVerifiedBatch is a helper method to define mock.On call
+// GetVerifiedBatchesByL1BlockRange is a helper method to define mock.On call
 //   - ctx context.Context
-//   - batchNumber uint64
+//   - fromL1BlockNumber uint64
+//   - toL1BlockNumber uint64
 //   - dbTx pgx.Tx
-func (_e *StorageMock_Expecter) GetVerifiedBatch(ctx interface{}, batchNumber interface{}, dbTx interface{}) *StorageMock_GetVerifiedBatch_Call {
-	return &StorageMock_GetVerifiedBatch_Call{Call: _e.mock.On("GetVerifiedBatch", ctx, batchNumber, dbTx)}
+func (_e *StorageMock_Expecter) GetVerifiedBatchesByL1BlockRange(ctx interface{}, fromL1BlockNumber interface{}, toL1BlockNumber interface{}, dbTx interface{}) *StorageMock_GetVerifiedBatchesByL1BlockRange_Call {
+	return &StorageMock_GetVerifiedBatchesByL1BlockRange_Call{Call: _e.mock.On("GetVerifiedBatchesByL1BlockRange", ctx, fromL1BlockNumber, toL1BlockNumber, dbTx)}
 }
 
-func (_c *StorageMock_GetVerifiedBatch_Call) Run(run func(ctx context.Context, batchNumber uint64, dbTx pgx.Tx)) *StorageMock_GetVerifiedBatch_Call {
+func (_c *StorageMock_GetVerifiedBatchesByL1BlockRange_Call) Run(run func(ctx context.Context, fromL1BlockNumber uint64, toL1BlockNumber uint64, dbTx pgx.Tx)) *StorageMock_GetVerifiedBatchesByL1BlockRange_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uint64), args[2].(pgx.Tx))
+		run(args[0].(context.Context), args[1].(uint64), args[2].(uint64), args[3].(pgx.Tx))
 	})
 	return _c
 }
 
-func (_c *StorageMock_GetVerifiedBatch_Call) Return(_a0 *state.VerifiedBatch, _a1 error) *StorageMock_GetVerifiedBatch_Call {
+func (_c *StorageMock_GetVerifiedBatchesByL1BlockRange_Call) Return(_a0 []state.VerifiedBatch, _a1 error) *StorageMock_GetVerifiedBatchesByL1BlockRange_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *StorageMock_GetVerifiedBatch_Call) RunAndReturn(run func(context.Context, uint64, pgx.Tx) (*state.VerifiedBatch, error)) *StorageMock_GetVerifiedBatch_Call {
+func (_c *StorageMock_GetVerifiedBatchesByL1BlockRange_Call) RunAndReturn(run func(context.Context, uint64, uint64, pgx.Tx) ([]state.VerifiedBatch, error)) *StorageMock_GetVerifiedBatchesByL1BlockRange_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -7024,6 +8133,67 @@ func (_c *StorageMock_GetVirtualBatchToProve_Call) RunAndReturn(run func(context
 	return _c
 }
 
+// GetVirtualBatchesByL1BlockRange provides a mock function with given fields: ctx, fromL1BlockNumber, toL1BlockNumber, dbTx
+func (_m *StorageMock) GetVirtualBatchesByL1BlockRange(ctx context.Context, fromL1BlockNumber uint64, toL1BlockNumber uint64, dbTx pgx.Tx) ([]state.VirtualBatch, error) {
+	ret := _m.Called(ctx, fromL1BlockNumber, toL1BlockNumber, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetVirtualBatchesByL1BlockRange")
+	}
+
+	var r0 []state.VirtualBatch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, pgx.Tx) ([]state.VirtualBatch, error)); ok {
+		return rf(ctx, fromL1BlockNumber, toL1BlockNumber, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, pgx.Tx) []state.VirtualBatch); ok {
+		r0 = rf(ctx, fromL1BlockNumber, toL1BlockNumber, dbTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]state.VirtualBatch)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64, pgx.Tx) error); ok {
+		r1 = rf(ctx, fromL1BlockNumber, toL1BlockNumber, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StorageMock_GetVirtualBatchesByL1BlockRange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetVirtualBatchesByL1BlockRange'
+type StorageMock_GetVirtualBatchesByL1BlockRange_Call struct {
+	*mock.Call
+}
+
+// GetVirtualBatchesByL1BlockRange is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fromL1BlockNumber uint64
+//   - toL1BlockNumber uint64
+//   - dbTx pgx.Tx
+func (_e *StorageMock_Expecter) GetVirtualBatchesByL1BlockRange(ctx interface{}, fromL1BlockNumber interface{}, toL1BlockNumber interface{}, dbTx interface{}) *StorageMock_GetVirtualBatchesByL1BlockRange_Call {
+	return &StorageMock_GetVirtualBatchesByL1BlockRange_Call{Call: _e.mock.On("GetVirtualBatchesByL1BlockRange", ctx, fromL1BlockNumber, toL1BlockNumber, dbTx)}
+}
+
+func (_c *StorageMock_GetVirtualBatchesByL1BlockRange_Call) Run(run func(ctx context.Context, fromL1BlockNumber uint64, toL1BlockNumber uint64, dbTx pgx.Tx)) *StorageMock_GetVirtualBatchesByL1BlockRange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].(uint64), args[3].(pgx.Tx))
+	})
+	return _c
+}
+
+func (_c *StorageMock_GetVirtualBatchesByL1BlockRange_Call) Return(_a0 []state.VirtualBatch, _a1 error) *StorageMock_GetVirtualBatchesByL1BlockRange_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *StorageMock_GetVirtualBatchesByL1BlockRange_Call) RunAndReturn(run func(context.Context, uint64, uint64, pgx.Tx) ([]state.VirtualBatch, error)) *StorageMock_GetVirtualBatchesByL1BlockRange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetWIPBatchInStorage provides a mock function with given fields: ctx, batchNumber, dbTx
 func (_m *StorageMock) GetWIPBatchInStorage(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (*state.Batch, error) {
 	ret := _m.Called(ctx, batchNumber, dbTx)
@@ -7084,6 +8254,64 @@ func (_c *StorageMock_GetWIPBatchInStorage_Call) RunAndReturn(run func(context.C
 	return _c
 }
 
+// HasArchivedCodeAndStorage provides a mock function with given fields: ctx, l2BlockNumber, dbTx
+func (_m *StorageMock) HasArchivedCodeAndStorage(ctx context.Context, l2BlockNumber uint64, dbTx pgx.Tx) (bool, error) {
+	ret := _m.Called(ctx, l2BlockNumber, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HasArchivedCodeAndStorage")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) (bool, error)); ok {
+		return rf(ctx, l2BlockNumber, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) bool); ok {
+		r0 = rf(ctx, l2BlockNumber, dbTx)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, pgx.Tx) error); ok {
+		r1 = rf(ctx, l2BlockNumber, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StorageMock_HasArchivedCodeAndStorage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HasArchivedCodeAndStorage'
+type StorageMock_HasArchivedCodeAndStorage_Call struct {
+	*mock.Call
+}
+
+// HasArchivedCodeAndStorage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - l2BlockNumber uint64
+//   - dbTx pgx.Tx
+func (_e *StorageMock_Expecter) HasArchivedCodeAndStorage(ctx interface{}, l2BlockNumber interface{}, dbTx interface{}) *StorageMock_HasArchivedCodeAndStorage_Call {
+	return &StorageMock_HasArchivedCodeAndStorage_Call{Call: _e.mock.On("HasArchivedCodeAndStorage", ctx, l2BlockNumber, dbTx)}
+}
+
+func (_c *StorageMock_HasArchivedCodeAndStorage_Call) Run(run func(ctx context.Context, l2BlockNumber uint64, dbTx pgx.Tx)) *StorageMock_HasArchivedCodeAndStorage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].(pgx.Tx))
+	})
+	return _c
+}
+
+func (_c *StorageMock_HasArchivedCodeAndStorage_Call) Return(_a0 bool, _a1 error) *StorageMock_HasArchivedCodeAndStorage_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *StorageMock_HasArchivedCodeAndStorage_Call) RunAndReturn(run func(context.Context, uint64, pgx.Tx) (bool, error)) *StorageMock_HasArchivedCodeAndStorage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // IsBatchChecked provides a mock function with given fields: ctx, batchNum, dbTx
 func (_m *StorageMock) IsBatchChecked(ctx context.Context, batchNum uint64, dbTx pgx.Tx) (bool, error) {
 	ret := _m.Called(ctx, batchNum, dbTx)
@@ -7316,6 +8544,64 @@ func (_c *StorageMock_IsBatchVirtualized_Call) RunAndReturn(run func(context.Con
 	return _c
 }
 
+// IsL2BlockArchived provides a mock function with given fields: ctx, l2BlockNumber, dbTx
+func (_m *StorageMock) IsL2BlockArchived(ctx context.Context, l2BlockNumber uint64, dbTx pgx.Tx) (bool, error) {
+	ret := _m.Called(ctx, l2BlockNumber, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsL2BlockArchived")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) (bool, error)); ok {
+		return rf(ctx, l2BlockNumber, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) bool); ok {
+		r0 = rf(ctx, l2BlockNumber, dbTx)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, pgx.Tx) error); ok {
+		r1 = rf(ctx, l2BlockNumber, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StorageMock_IsL2BlockArchived_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsL2BlockArchived'
+type StorageMock_IsL2BlockArchived_Call struct {
+	*mock.Call
+}
+
+// IsL2BlockArchived is a helper method to define mock.On call
+//   - ctx context.Context
+//   - l2BlockNumber uint64
+//   - dbTx pgx.Tx
+func (_e *StorageMock_Expecter) IsL2BlockArchived(ctx interface{}, l2BlockNumber interface{}, dbTx interface{}) *StorageMock_IsL2BlockArchived_Call {
+	return &StorageMock_IsL2BlockArchived_Call{Call: _e.mock.On("IsL2BlockArchived", ctx, l2BlockNumber, dbTx)}
+}
+
+func (_c *StorageMock_IsL2BlockArchived_Call) Run(run func(ctx context.Context, l2BlockNumber uint64, dbTx pgx.Tx)) *StorageMock_IsL2BlockArchived_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].(pgx.Tx))
+	})
+	return _c
+}
+
+func (_c *StorageMock_IsL2BlockArchived_Call) Return(_a0 bool, _a1 error) *StorageMock_IsL2BlockArchived_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *StorageMock_IsL2BlockArchived_Call) RunAndReturn(run func(context.Context, uint64, pgx.Tx) (bool, error)) *StorageMock_IsL2BlockArchived_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // IsL2BlockConsolidated provides a mock function with given fields: ctx, blockNumber, dbTx
 func (_m *StorageMock) IsL2BlockConsolidated(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) (bool, error) {
 	ret := _m.Called(ctx, blockNumber, dbTx)
@@ -7716,6 +9002,56 @@ func (_c *StorageMock_QueryRow_Call) RunAndReturn(run func(context.Context, stri
 	return _c
 }
 
+// RenewSequencerLease provides a mock function with given fields: ctx, nodeID, fencingToken, leaseDuration, dbTx
+func (_m *StorageMock) RenewSequencerLease(ctx context.Context, nodeID string, fencingToken uint64, leaseDuration time.Duration, dbTx pgx.Tx) error {
+	ret := _m.Called(ctx, nodeID, fencingToken, leaseDuration, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RenewSequencerLease")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint64, time.Duration, pgx.Tx) error); ok {
+		r0 = rf(ctx, nodeID, fencingToken, leaseDuration, dbTx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StorageMock_RenewSequencerLease_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RenewSequencerLease'
+type StorageMock_RenewSequencerLease_Call struct {
+	*mock.Call
+}
+
+// RenewSequencerLease is a helper method to define mock.On call
+//   - ctx context.Context
+//   - nodeID string
+//   - fencingToken uint64
+//   - leaseDuration time.Duration
+//   - dbTx pgx.Tx
+func (_e *StorageMock_Expecter) RenewSequencerLease(ctx interface{}, nodeID interface{}, fencingToken interface{}, leaseDuration interface{}, dbTx interface{}) *StorageMock_RenewSequencerLease_Call {
+	return &StorageMock_RenewSequencerLease_Call{Call: _e.mock.On("RenewSequencerLease", ctx, nodeID, fencingToken, leaseDuration, dbTx)}
+}
+
+func (_c *StorageMock_RenewSequencerLease_Call) Run(run func(ctx context.Context, nodeID string, fencingToken uint64, leaseDuration time.Duration, dbTx pgx.Tx)) *StorageMock_RenewSequencerLease_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(uint64), args[3].(time.Duration), args[4].(pgx.Tx))
+	})
+	return _c
+}
+
+func (_c *StorageMock_RenewSequencerLease_Call) Return(_a0 error) *StorageMock_RenewSequencerLease_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *StorageMock_RenewSequencerLease_Call) RunAndReturn(run func(context.Context, string, uint64, time.Duration, pgx.Tx) error) *StorageMock_RenewSequencerLease_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ResetForkID provides a mock function with given fields: ctx, batchNumber, dbTx
 func (_m *StorageMock) ResetForkID(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) error {
 	ret := _m.Called(ctx, batchNumber, dbTx)
@@ -0,0 +1,201 @@
+package pgstatestorage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/0xPolygonHermez/zkevm-node/encoding"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jackc/pgx/v4"
+)
+
+// AddArchivedL2Block adds the provided l2 block to the archive, hasCodeAndStorage defines
+// if the code and storage modified by the l2 block are archived along with the accounts
+func (p *PostgresStorage) AddArchivedL2Block(ctx context.Context, l2BlockNumber uint64, hasCodeAndStorage bool, dbTx pgx.Tx) error {
+	const addArchivedL2BlockSQL = `
+		INSERT INTO state.archive_l2block (l2_block_num, state_root, has_code_and_storage)
+		SELECT block_num, state_root, $2 FROM state.l2block WHERE block_num = $1`
+
+	e := p.getExecQuerier(dbTx)
+	commandTag, err := e.Exec(ctx, addArchivedL2BlockSQL, l2BlockNumber, hasCodeAndStorage)
+	if err != nil {
+		return err
+	}
+	if commandTag.RowsAffected() == 0 {
+		return state.ErrNotFound
+	}
+	return nil
+}
+
+// IsL2BlockArchived checks if the provided l2 block has been added to the archive
+func (p *PostgresStorage) IsL2BlockArchived(ctx context.Context, l2BlockNumber uint64, dbTx pgx.Tx) (bool, error) {
+	const isL2BlockArchivedSQL = "SELECT EXISTS (SELECT 1 FROM state.archive_l2block WHERE l2_block_num = $1)"
+
+	var exists bool
+	e := p.getExecQuerier(dbTx)
+	err := e.QueryRow(ctx, isL2BlockArchivedSQL, l2BlockNumber).Scan(&exists)
+	return exists, err
+}
+
+// HasArchivedCodeAndStorage checks if the code and storage modified by all the archived
+// l2 blocks up to the provided one have been archived
+func (p *PostgresStorage) HasArchivedCodeAndStorage(ctx context.Context, l2BlockNumber uint64, dbTx pgx.Tx) (bool, error) {
+	const hasArchivedCodeAndStorageSQL = "SELECT NOT EXISTS (SELECT 1 FROM state.archive_l2block WHERE l2_block_num <= $1 AND NOT has_code_and_storage)"
+
+	var hasCodeAndStorage bool
+	e := p.getExecQuerier(dbTx)
+	err := e.QueryRow(ctx, hasArchivedCodeAndStorageSQL, l2BlockNumber).Scan(&hasCodeAndStorage)
+	return hasCodeAndStorage, err
+}
+
+// AddArchivedAccount adds to the archive the nonce and balance of an account at the
+// provided l2 block, nil values mean the value was not reported at this block
+func (p *PostgresStorage) AddArchivedAccount(ctx context.Context, l2BlockNumber uint64, address common.Address, nonce *uint64, balance *big.Int, dbTx pgx.Tx) error {
+	const addArchivedAccountSQL = `
+		INSERT INTO state.archive_account (address, l2_block_num, nonce, balance)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (address, l2_block_num) DO UPDATE
+		SET nonce = COALESCE(EXCLUDED.nonce, state.archive_account.nonce), balance = COALESCE(EXCLUDED.balance, state.archive_account.balance)`
+
+	var balanceStr *string
+	if balance != nil {
+		s := balance.String()
+		balanceStr = &s
+	}
+
+	e := p.getExecQuerier(dbTx)
+	_, err := e.Exec(ctx, addArchivedAccountSQL, address.String(), l2BlockNumber, nonce, balanceStr)
+	return err
+}
+
+// AddArchivedCode adds to the archive the code of a smart contract at the provided l2 block
+func (p *PostgresStorage) AddArchivedCode(ctx context.Context, l2BlockNumber uint64, address common.Address, code []byte, dbTx pgx.Tx) error {
+	const addArchivedCodeSQL = `
+		INSERT INTO state.archive_code (address, l2_block_num, code)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (address, l2_block_num) DO UPDATE SET code = EXCLUDED.code`
+
+	e := p.getExecQuerier(dbTx)
+	_, err := e.Exec(ctx, addArchivedCodeSQL, address.String(), l2BlockNumber, code)
+	return err
+}
+
+// AddArchivedStorage adds to the archive the value of a storage position of a smart contract at the provided l2 block
+func (p *PostgresStorage) AddArchivedStorage(ctx context.Context, l2BlockNumber uint64, address common.Address, position, value common.Hash, dbTx pgx.Tx) error {
+	const addArchivedStorageSQL = `
+		INSERT INTO state.archive_storage (address, position, l2_block_num, value)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (address, position, l2_block_num) DO UPDATE SET value = EXCLUDED.value`
+
+	e := p.getExecQuerier(dbTx)
+	_, err := e.Exec(ctx, addArchivedStorageSQL, address.String(), position.String(), l2BlockNumber, value.String())
+	return err
+}
+
+// GetArchivedL2BlockNumberByStateRoot returns the number of the archived l2 block with the provided state root
+func (p *PostgresStorage) GetArchivedL2BlockNumberByStateRoot(ctx context.Context, stateRoot common.Hash, dbTx pgx.Tx) (uint64, error) {
+	const getArchivedL2BlockNumberByStateRootSQL = `
+		SELECT l2_block_num
+		  FROM state.archive_l2block
+		 WHERE state_root = $1
+		 ORDER BY l2_block_num DESC
+		 LIMIT 1`
+
+	var l2BlockNumber uint64
+	e := p.getExecQuerier(dbTx)
+	err := e.QueryRow(ctx, getArchivedL2BlockNumberByStateRootSQL, stateRoot.String()).Scan(&l2BlockNumber)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, state.ErrNotFound
+	} else if err != nil {
+		return 0, err
+	}
+	return l2BlockNumber, nil
+}
+
+// GetArchivedBalance returns the last archived balance of the account up to the provided l2 block
+func (p *PostgresStorage) GetArchivedBalance(ctx context.Context, address common.Address, l2BlockNumber uint64, dbTx pgx.Tx) (*big.Int, error) {
+	const getArchivedBalanceSQL = `
+		SELECT balance::VARCHAR
+		  FROM state.archive_account
+		 WHERE address = $1 AND l2_block_num <= $2 AND balance IS NOT NULL
+		 ORDER BY l2_block_num DESC
+		 LIMIT 1`
+
+	var balanceStr string
+	e := p.getExecQuerier(dbTx)
+	err := e.QueryRow(ctx, getArchivedBalanceSQL, address.String(), l2BlockNumber).Scan(&balanceStr)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, state.ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	balance, ok := new(big.Int).SetString(balanceStr, encoding.Base10)
+	if !ok {
+		return nil, fmt.Errorf("failed to parse the archived balance %v", balanceStr)
+	}
+	return balance, nil
+}
+
+// GetArchivedNonce returns the last archived nonce of the account up to the provided l2 block
+func (p *PostgresStorage) GetArchivedNonce(ctx context.Context, address common.Address, l2BlockNumber uint64, dbTx pgx.Tx) (uint64, error) {
+	const getArchivedNonceSQL = `
+		SELECT nonce
+		  FROM state.archive_account
+		 WHERE address = $1 AND l2_block_num <= $2 AND nonce IS NOT NULL
+		 ORDER BY l2_block_num DESC
+		 LIMIT 1`
+
+	var nonce uint64
+	e := p.getExecQuerier(dbTx)
+	err := e.QueryRow(ctx, getArchivedNonceSQL, address.String(), l2BlockNumber).Scan(&nonce)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, state.ErrNotFound
+	} else if err != nil {
+		return 0, err
+	}
+	return nonce, nil
+}
+
+// GetArchivedCode returns the last archived code of the smart contract up to the provided l2 block
+func (p *PostgresStorage) GetArchivedCode(ctx context.Context, address common.Address, l2BlockNumber uint64, dbTx pgx.Tx) ([]byte, error) {
+	const getArchivedCodeSQL = `
+		SELECT code
+		  FROM state.archive_code
+		 WHERE address = $1 AND l2_block_num <= $2
+		 ORDER BY l2_block_num DESC
+		 LIMIT 1`
+
+	var code []byte
+	e := p.getExecQuerier(dbTx)
+	err := e.QueryRow(ctx, getArchivedCodeSQL, address.String(), l2BlockNumber).Scan(&code)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, state.ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return code, nil
+}
+
+// GetArchivedStorageAt returns the last archived value of the storage position of the smart contract up to the provided l2 block
+func (p *PostgresStorage) GetArchivedStorageAt(ctx context.Context, address common.Address, position common.Hash, l2BlockNumber uint64, dbTx pgx.Tx) (common.Hash, error) {
+	const getArchivedStorageAtSQL = `
+		SELECT value
+		  FROM state.archive_storage
+		 WHERE address = $1 AND position = $2 AND l2_block_num <= $3
+		 ORDER BY l2_block_num DESC
+		 LIMIT 1`
+
+	var value string
+	e := p.getExecQuerier(dbTx)
+	err := e.QueryRow(ctx, getArchivedStorageAtSQL, address.String(), position.String(), l2BlockNumber).Scan(&value)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return common.Hash{}, state.ErrNotFound
+	} else if err != nil {
+		return common.Hash{}, err
+	}
+	return common.HexToHash(value), nil
+}
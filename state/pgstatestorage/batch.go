@@ -81,6 +81,76 @@ func (p *PostgresStorage) GetVerifiedBatch(ctx context.Context, batchNumber uint
 	return &verifiedBatch, nil
 }
 
+// GetVerifiedBatchCoveringBatch gets the L1 verifiedBatch that verified the provided
+// batch number, a single verification can verify multiple batches, so it also returns
+// the number of the last batch verified before it, the verification covers all the
+// batches after it up to the verified batch number.
+func (p *PostgresStorage) GetVerifiedBatchCoveringBatch(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (*state.VerifiedBatch, uint64, error) {
+	var (
+		verifiedBatch               state.VerifiedBatch
+		previousVerifiedBatchNumber uint64
+		txHash                      string
+		agg                         string
+		sr                          string
+	)
+
+	const getVerifiedBatchCoveringBatchSQL = `
+    SELECT v.block_num, v.batch_num, v.tx_hash, v.aggregator, v.state_root, v.is_trusted,
+           COALESCE((SELECT MAX(p.batch_num) FROM state.verified_batch p WHERE p.batch_num < v.batch_num), 0)
+      FROM state.verified_batch v
+     WHERE v.batch_num >= $1
+     ORDER BY v.batch_num ASC
+     LIMIT 1`
+
+	e := p.getExecQuerier(dbTx)
+	err := e.QueryRow(ctx, getVerifiedBatchCoveringBatchSQL, batchNumber).Scan(&verifiedBatch.BlockNumber, &verifiedBatch.BatchNumber, &txHash, &agg, &sr, &verifiedBatch.IsTrusted, &previousVerifiedBatchNumber)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, 0, state.ErrNotFound
+	} else if err != nil {
+		return nil, 0, err
+	}
+	verifiedBatch.Aggregator = common.HexToAddress(agg)
+	verifiedBatch.TxHash = common.HexToHash(txHash)
+	verifiedBatch.StateRoot = common.HexToHash(sr)
+	return &verifiedBatch, previousVerifiedBatchNumber, nil
+}
+
+// GetVerifiedBatchesByL1BlockRange gets the L1 verifiedBatches of the batches verified
+// in the provided L1 block range, ordered by batch number.
+func (p *PostgresStorage) GetVerifiedBatchesByL1BlockRange(ctx context.Context, fromL1BlockNumber, toL1BlockNumber uint64, dbTx pgx.Tx) ([]state.VerifiedBatch, error) {
+	const getVerifiedBatchesByL1BlockRangeSQL = `
+    SELECT block_num, batch_num, tx_hash, aggregator, state_root, is_trusted
+      FROM state.verified_batch
+     WHERE block_num BETWEEN $1 AND $2
+     ORDER BY batch_num ASC`
+
+	e := p.getExecQuerier(dbTx)
+	rows, err := e.Query(ctx, getVerifiedBatchesByL1BlockRangeSQL, fromL1BlockNumber, toL1BlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	verifiedBatches := make([]state.VerifiedBatch, 0)
+	for rows.Next() {
+		var (
+			verifiedBatch state.VerifiedBatch
+			txHash        string
+			agg           string
+			sr            string
+		)
+		err := rows.Scan(&verifiedBatch.BlockNumber, &verifiedBatch.BatchNumber, &txHash, &agg, &sr, &verifiedBatch.IsTrusted)
+		if err != nil {
+			return nil, err
+		}
+		verifiedBatch.Aggregator = common.HexToAddress(agg)
+		verifiedBatch.TxHash = common.HexToHash(txHash)
+		verifiedBatch.StateRoot = common.HexToHash(sr)
+		verifiedBatches = append(verifiedBatches, verifiedBatch)
+	}
+	return verifiedBatches, rows.Err()
+}
+
 // GetLastNBatches returns the last numBatches batches.
 func (p *PostgresStorage) GetLastNBatches(ctx context.Context, numBatches uint, dbTx pgx.Tx) ([]*state.Batch, error) {
 	const getLastNBatchesSQL = "SELECT batch_num, global_exit_root, local_exit_root, acc_input_hash, state_root, timestamp, coinbase, raw_txs_data, forced_batch_num, batch_resources, wip from state.batch ORDER BY batch_num DESC LIMIT $1"
@@ -557,6 +627,47 @@ func (p *PostgresStorage) GetVirtualBatch(ctx context.Context, batchNumber uint6
 	return &virtualBatch, nil
 }
 
+// GetVirtualBatchesByL1BlockRange gets the L1 virtualBatches of the batches sequenced
+// in the provided L1 block range, ordered by batch number.
+func (p *PostgresStorage) GetVirtualBatchesByL1BlockRange(ctx context.Context, fromL1BlockNumber, toL1BlockNumber uint64, dbTx pgx.Tx) ([]state.VirtualBatch, error) {
+	const getVirtualBatchesByL1BlockRangeSQL = `
+    SELECT block_num, batch_num, tx_hash, coinbase, sequencer_addr, timestamp_batch_etrog, l1_info_root
+      FROM state.virtual_batch
+     WHERE block_num BETWEEN $1 AND $2
+     ORDER BY batch_num ASC`
+
+	e := p.getExecQuerier(dbTx)
+	rows, err := e.Query(ctx, getVirtualBatchesByL1BlockRangeSQL, fromL1BlockNumber, toL1BlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	virtualBatches := make([]state.VirtualBatch, 0)
+	for rows.Next() {
+		var (
+			virtualBatch  state.VirtualBatch
+			txHash        string
+			coinbase      string
+			sequencerAddr string
+			l1InfoRoot    *string
+		)
+		err := rows.Scan(&virtualBatch.BlockNumber, &virtualBatch.BatchNumber, &txHash, &coinbase, &sequencerAddr, &virtualBatch.TimestampBatchEtrog, &l1InfoRoot)
+		if err != nil {
+			return nil, err
+		}
+		virtualBatch.Coinbase = common.HexToAddress(coinbase)
+		virtualBatch.SequencerAddr = common.HexToAddress(sequencerAddr)
+		virtualBatch.TxHash = common.HexToHash(txHash)
+		if l1InfoRoot != nil {
+			l1InfoR := common.HexToHash(*l1InfoRoot)
+			virtualBatch.L1InfoRoot = &l1InfoR
+		}
+		virtualBatches = append(virtualBatches, virtualBatch)
+	}
+	return virtualBatches, rows.Err()
+}
+
 func (p *PostgresStorage) StoreGenesisBatch(ctx context.Context, batch state.Batch, closingReason string, dbTx pgx.Tx) error {
 	const addGenesisBatchSQL = "INSERT INTO state.batch (batch_num, global_exit_root, local_exit_root, acc_input_hash, state_root, timestamp, coinbase, raw_txs_data, forced_batch_num,closing_reason, wip) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9,$10, FALSE)"
 
@@ -721,6 +832,22 @@ func (p *PostgresStorage) BatchNumberByL2BlockNumber(ctx context.Context, blockN
 	return batchNumber, nil
 }
 
+// GetL2BlockRangeByBatchNumber returns the numbers of the first and the last
+// L2 blocks of the provided batch.
+func (p *PostgresStorage) GetL2BlockRangeByBatchNumber(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (uint64, uint64, error) {
+	const getL2BlockRangeByBatchNumberSQL = "SELECT MIN(block_num), MAX(block_num) FROM state.l2block WHERE batch_num = $1"
+	var fromBlockNumber, toBlockNumber *uint64
+	q := p.getExecQuerier(dbTx)
+	err := q.QueryRow(ctx, getL2BlockRangeByBatchNumberSQL, batchNumber).Scan(&fromBlockNumber, &toBlockNumber)
+	if err != nil {
+		return 0, 0, err
+	}
+	if fromBlockNumber == nil || toBlockNumber == nil {
+		return 0, 0, state.ErrNotFound
+	}
+	return *fromBlockNumber, *toBlockNumber, nil
+}
+
 // GetLastVerifiedBatchNumberUntilL1Block gets the last batch number that was verified in
 // or before the provided l1 block number. This is used to identify if a batch is safe or finalized.
 func (p *PostgresStorage) GetLastVerifiedBatchNumberUntilL1Block(ctx context.Context, l1BlockNumber uint64, dbTx pgx.Tx) (uint64, error) {
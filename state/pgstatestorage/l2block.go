@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math/big"
 	"time"
 
 	"github.com/0xPolygonHermez/zkevm-node/hex"
@@ -560,3 +561,88 @@ func (p *PostgresStorage) GetFirstL2BlockNumberForBatchNumber(ctx context.Contex
 
 	return l2BlockNumber, nil
 }
+
+// GetL2BlocksFeeDataInRange returns the gas information of the l2 blocks in the
+// provided range and their txs, sorted by block number and tx index
+func (p *PostgresStorage) GetL2BlocksFeeDataInRange(ctx context.Context, fromBlockNumber, toBlockNumber uint64, dbTx pgx.Tx) ([]state.L2BlockFeeData, error) {
+	const getL2BlocksSQL = `
+		SELECT block_num, header
+		  FROM state.l2block
+		 WHERE block_num BETWEEN $1 AND $2
+		 ORDER BY block_num ASC`
+
+	const getTxsSQL = `
+		SELECT r.block_num, r.gas_used, r.effective_gas_price, t.encoded, COALESCE(t.effective_percentage, 255)
+		  FROM state.receipt r
+		 INNER JOIN state.transaction t
+		    ON t.hash = r.tx_hash
+		 WHERE r.block_num BETWEEN $1 AND $2
+		 ORDER BY r.block_num ASC, r.tx_index ASC`
+
+	if toBlockNumber < fromBlockNumber {
+		return nil, state.ErrInvalidBlockRange
+	}
+
+	q := p.getExecQuerier(dbTx)
+	rows, err := q.Query(ctx, getL2BlocksSQL, fromBlockNumber, toBlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	blocks := make([]state.L2BlockFeeData, 0, toBlockNumber-fromBlockNumber+1)
+	blockIndexes := make(map[uint64]int, toBlockNumber-fromBlockNumber+1)
+	for rows.Next() {
+		var blockNumber uint64
+		header := &state.L2Header{}
+		if err := rows.Scan(&blockNumber, &header); err != nil {
+			return nil, err
+		}
+		blockIndexes[blockNumber] = len(blocks)
+		blocks = append(blocks, state.L2BlockFeeData{
+			BlockNumber: blockNumber,
+			GasUsed:     header.GasUsed,
+			GasLimit:    header.GasLimit,
+		})
+	}
+	rows.Close()
+
+	txRows, err := q.Query(ctx, getTxsSQL, fromBlockNumber, toBlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	defer txRows.Close()
+
+	for txRows.Next() {
+		var (
+			blockNumber, gasUsed uint64
+			effectiveGasPrice    *uint64
+			encoded              string
+			effectivePercentage  uint8
+		)
+		if err := txRows.Scan(&blockNumber, &gasUsed, &effectiveGasPrice, &encoded, &effectivePercentage); err != nil {
+			return nil, err
+		}
+
+		idx, found := blockIndexes[blockNumber]
+		if !found {
+			continue
+		}
+
+		txFeeData := state.TxFeeData{GasUsed: gasUsed}
+		if effectiveGasPrice != nil {
+			txFeeData.EffectiveGasPrice = new(big.Int).SetUint64(*effectiveGasPrice)
+		} else {
+			// receipts stored before the effective gas price was added to
+			// them are computed from the tx gas price
+			tx, err := state.DecodeTx(encoded)
+			if err != nil {
+				return nil, err
+			}
+			txFeeData.EffectiveGasPrice = state.CalculateEffectiveGasPrice(state.TxGasPrice(tx), effectivePercentage)
+		}
+		blocks[idx].Txs = append(blocks[idx].Txs, txFeeData)
+	}
+
+	return blocks, nil
+}
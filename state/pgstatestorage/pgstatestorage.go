@@ -42,7 +42,7 @@ func (p *PostgresStorage) ResetToL1BlockNumber(ctx context.Context, blockNumber
 		return err
 	}
 
-	return nil
+	return p.increaseResetEpoch(ctx, dbTx)
 }
 
 // ResetForkID resets the state to reprocess the newer batches with the correct forkID
@@ -63,7 +63,29 @@ func (p *PostgresStorage) ResetForkID(ctx context.Context, batchNumber uint64, d
 		return err
 	}
 
-	return nil
+	return p.increaseResetEpoch(ctx, dbTx)
+}
+
+// increaseResetEpoch increases the reset epoch, so the processes monitoring
+// the state detect the reset even when the state is re-synced before they poll it
+func (p *PostgresStorage) increaseResetEpoch(ctx context.Context, dbTx pgx.Tx) error {
+	const increaseResetEpochSQL = "UPDATE state.reset_epoch SET epoch = epoch + 1 WHERE id = 1"
+	e := p.getExecQuerier(dbTx)
+	_, err := e.Exec(ctx, increaseResetEpochSQL)
+	return err
+}
+
+// GetResetEpoch returns the reset epoch, increased every time the virtual
+// state is reset
+func (p *PostgresStorage) GetResetEpoch(ctx context.Context, dbTx pgx.Tx) (uint64, error) {
+	const getResetEpochSQL = "SELECT epoch FROM state.reset_epoch WHERE id = 1"
+	var epoch uint64
+	e := p.getExecQuerier(dbTx)
+	err := e.QueryRow(ctx, getResetEpochSQL).Scan(&epoch)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, state.ErrNotFound
+	}
+	return epoch, err
 }
 
 // ResetTrustedState removes the batches with number greater than the given one
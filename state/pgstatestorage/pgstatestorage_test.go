@@ -1411,3 +1411,331 @@ func TestGetLastGER(t *testing.T) {
 	require.Equal(t, common.HexToHash("0x2").String(), ger.String())
 
 }
+
+func TestGetVerifiedBatchCoveringBatch(t *testing.T) {
+	initOrResetDB()
+
+	ctx := context.Background()
+	dbTx, err := testState.BeginStateTransaction(ctx)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, dbTx.Commit(ctx)) }()
+
+	block := &state.Block{
+		BlockNumber: 1,
+		BlockHash:   common.HexToHash("0x1"),
+		ParentHash:  common.HexToHash("0x0"),
+		ReceivedAt:  time.Now(),
+	}
+	err = testState.AddBlock(ctx, block, dbTx)
+	require.NoError(t, err)
+
+	for batchNumber := uint64(1); batchNumber <= 4; batchNumber++ {
+		_, err = dbTx.Exec(ctx, "INSERT INTO state.batch (batch_num, wip) VALUES ($1, FALSE)", batchNumber)
+		require.NoError(t, err)
+	}
+
+	verifiedBatches := []state.VerifiedBatch{
+		{BlockNumber: 1, BatchNumber: 2, TxHash: common.HexToHash("0x2"), Aggregator: common.HexToAddress("0x2"), StateRoot: common.HexToHash("0x2")},
+		{BlockNumber: 1, BatchNumber: 4, TxHash: common.HexToHash("0x4"), Aggregator: common.HexToAddress("0x4"), StateRoot: common.HexToHash("0x4")},
+	}
+	for i := range verifiedBatches {
+		err = testState.AddVerifiedBatch(ctx, &verifiedBatches[i], dbTx)
+		require.NoError(t, err)
+	}
+
+	testCases := []struct {
+		batchNumber                         uint64
+		expectedVerifiedBatch               *state.VerifiedBatch
+		expectedPreviousVerifiedBatchNumber uint64
+	}{
+		{batchNumber: 1, expectedVerifiedBatch: &verifiedBatches[0], expectedPreviousVerifiedBatchNumber: 0},
+		{batchNumber: 2, expectedVerifiedBatch: &verifiedBatches[0], expectedPreviousVerifiedBatchNumber: 0},
+		{batchNumber: 3, expectedVerifiedBatch: &verifiedBatches[1], expectedPreviousVerifiedBatchNumber: 2},
+		{batchNumber: 4, expectedVerifiedBatch: &verifiedBatches[1], expectedPreviousVerifiedBatchNumber: 2},
+		{batchNumber: 5, expectedVerifiedBatch: nil},
+	}
+
+	for _, tc := range testCases {
+		verifiedBatch, previousVerifiedBatchNumber, err := testState.GetVerifiedBatchCoveringBatch(ctx, tc.batchNumber, dbTx)
+		if tc.expectedVerifiedBatch == nil {
+			require.ErrorIs(t, err, state.ErrNotFound)
+			continue
+		}
+		require.NoError(t, err)
+		assert.Equal(t, *tc.expectedVerifiedBatch, *verifiedBatch)
+		assert.Equal(t, tc.expectedPreviousVerifiedBatchNumber, previousVerifiedBatchNumber)
+	}
+}
+
+func TestGetBatchProof(t *testing.T) {
+	initOrResetDB()
+
+	ctx := context.Background()
+	for batchNumber := uint64(1); batchNumber <= 4; batchNumber++ {
+		_, err := testState.Exec(ctx, "INSERT INTO state.batch (batch_num, wip) VALUES ($1, FALSE)", batchNumber)
+		require.NoError(t, err)
+	}
+
+	now := time.Now()
+	proofID := "proofID"
+	proofs := []state.Proof{
+		{BatchNumber: 1, BatchNumberFinal: 1, Proof: "proof1", ProofID: &proofID},
+		{BatchNumber: 1, BatchNumberFinal: 2, Proof: "proof1-2", ProofID: &proofID},
+		{BatchNumber: 3, BatchNumberFinal: 3, ProofID: &proofID, GeneratingSince: &now},
+	}
+	for i := range proofs {
+		err := testState.AddBatchProof(ctx, &proofs[i], nil)
+		require.NoError(t, err)
+	}
+
+	proof, err := testState.GetBatchProof(ctx, 1, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "proof1-2", proof.Proof)
+	assert.Equal(t, uint64(1), proof.BatchNumber)
+	assert.Equal(t, uint64(2), proof.BatchNumberFinal)
+
+	// proofs being generated are ignored
+	_, err = testState.GetBatchProof(ctx, 3, nil)
+	require.ErrorIs(t, err, state.ErrNotFound)
+
+	_, err = testState.GetBatchProof(ctx, 4, nil)
+	require.ErrorIs(t, err, state.ErrNotFound)
+}
+
+func TestGetL1InfoTreeLeafByGlobalExitRoot(t *testing.T) {
+	initOrResetDB()
+	ctx := context.Background()
+	dbTx, err := testState.BeginStateTransaction(ctx)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, dbTx.Rollback(ctx)) }()
+
+	err = testState.AddBlock(ctx, state.NewBlock(1), dbTx)
+	require.NoError(t, err)
+
+	ger := common.HexToHash("0x1")
+	_, err = testState.GetL1InfoTreeLeafByGlobalExitRoot(ctx, ger, dbTx)
+	require.ErrorIs(t, err, state.ErrNotFound)
+
+	for i, g := range []common.Hash{ger, common.HexToHash("0x2"), ger} {
+		entry := state.L1InfoTreeExitRootStorageEntry{
+			L1InfoTreeLeaf: state.L1InfoTreeLeaf{
+				GlobalExitRoot: state.GlobalExitRoot{
+					BlockNumber:    1,
+					Timestamp:      time.Now(),
+					GlobalExitRoot: g,
+				},
+			},
+			L1InfoTreeRoot:  common.BigToHash(big.NewInt(int64(i + 100))),
+			L1InfoTreeIndex: uint32(i),
+		}
+		require.NoError(t, testState.AddL1InfoRootToExitRoot(ctx, &entry, dbTx))
+	}
+
+	leaf, err := testState.GetL1InfoTreeLeafByGlobalExitRoot(ctx, ger, dbTx)
+	require.NoError(t, err)
+	require.Equal(t, uint32(2), leaf.L1InfoTreeIndex)
+	require.Equal(t, ger, leaf.GlobalExitRoot.GlobalExitRoot)
+	require.Equal(t, common.BigToHash(big.NewInt(102)), leaf.L1InfoTreeRoot)
+}
+
+func TestGetBatchesByL1BlockRange(t *testing.T) {
+	initOrResetDB()
+	ctx := context.Background()
+	dbTx, err := testState.BeginStateTransaction(ctx)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, dbTx.Commit(ctx)) }()
+
+	// prepare data
+	addr := common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+	for i := 1; i <= 5; i++ {
+		blockNumber := uint64(i)
+		batchNumber := uint64(i * 10)
+		hash := common.BigToHash(big.NewInt(int64(i)))
+
+		// add l1 block
+		err = testState.AddBlock(ctx, state.NewBlock(blockNumber), dbTx)
+		require.NoError(t, err)
+
+		// add batch
+		_, err = testState.Exec(ctx, "INSERT INTO state.batch (batch_num,wip) VALUES ($1, FALSE)", batchNumber)
+		require.NoError(t, err)
+
+		// add two l2 blocks per batch
+		for j := uint64(0); j < 2; j++ {
+			l2Header := state.NewL2Header(&types.Header{Number: big.NewInt(0).SetUint64(blockNumber*2 + j)})
+			l2Block := state.NewL2BlockWithHeader(l2Header)
+			err = testState.AddL2Block(ctx, batchNumber, l2Block, []*types.Receipt{}, []common.Hash{}, []state.StoreTxEGPData{}, []common.Hash{}, dbTx)
+			require.NoError(t, err)
+		}
+
+		virtualBatch := state.VirtualBatch{BlockNumber: blockNumber, BatchNumber: batchNumber, Coinbase: addr, SequencerAddr: addr, TxHash: hash}
+		err = testState.AddVirtualBatch(ctx, &virtualBatch, dbTx)
+		require.NoError(t, err)
+
+		// batches are verified in the next l1 block
+		if i > 1 {
+			verifiedBatch := state.VerifiedBatch{BlockNumber: blockNumber, BatchNumber: batchNumber - 10, TxHash: hash, Aggregator: addr}
+			err = testState.AddVerifiedBatch(ctx, &verifiedBatch, dbTx)
+			require.NoError(t, err)
+		}
+	}
+
+	virtualBatches, err := testState.GetVirtualBatchesByL1BlockRange(ctx, 2, 3, dbTx)
+	require.NoError(t, err)
+	require.Len(t, virtualBatches, 2)
+	assert.Equal(t, uint64(20), virtualBatches[0].BatchNumber)
+	assert.Equal(t, uint64(2), virtualBatches[0].BlockNumber)
+	assert.Equal(t, common.BigToHash(big.NewInt(2)), virtualBatches[0].TxHash)
+	assert.Equal(t, uint64(30), virtualBatches[1].BatchNumber)
+
+	verifiedBatches, err := testState.GetVerifiedBatchesByL1BlockRange(ctx, 1, 2, dbTx)
+	require.NoError(t, err)
+	require.Len(t, verifiedBatches, 1)
+	assert.Equal(t, uint64(10), verifiedBatches[0].BatchNumber)
+	assert.Equal(t, uint64(2), verifiedBatches[0].BlockNumber)
+	assert.Equal(t, addr, verifiedBatches[0].Aggregator)
+
+	virtualBatches, err = testState.GetVirtualBatchesByL1BlockRange(ctx, 6, 10, dbTx)
+	require.NoError(t, err)
+	require.Empty(t, virtualBatches)
+
+	fromBlockNumber, toBlockNumber, err := testState.GetL2BlockRangeByBatchNumber(ctx, 30, dbTx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(6), fromBlockNumber)
+	assert.Equal(t, uint64(7), toBlockNumber)
+
+	_, _, err = testState.GetL2BlockRangeByBatchNumber(ctx, 31, dbTx)
+	require.ErrorIs(t, err, state.ErrNotFound)
+}
+
+func TestArchive(t *testing.T) {
+	initOrResetDB()
+	ctx := context.Background()
+	dbTx, err := testState.BeginStateTransaction(ctx)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, dbTx.Rollback(ctx)) }()
+
+	_, err = testState.Exec(ctx, "INSERT INTO state.batch (batch_num, wip) VALUES (1, FALSE)")
+	require.NoError(t, err)
+	for blockNumber := uint64(1); blockNumber <= 3; blockNumber++ {
+		l2Header := state.NewL2Header(&types.Header{Number: big.NewInt(0).SetUint64(blockNumber), Root: common.BigToHash(big.NewInt(0).SetUint64(blockNumber))})
+		l2Block := state.NewL2BlockWithHeader(l2Header)
+		err = testState.AddL2Block(ctx, 1, l2Block, []*types.Receipt{}, []common.Hash{}, []state.StoreTxEGPData{}, []common.Hash{}, dbTx)
+		require.NoError(t, err)
+	}
+
+	addr := common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+	nonce := uint64(1)
+	position := common.HexToHash("0x1")
+	require.NoError(t, testState.AddArchivedL2Block(ctx, 1, true, dbTx))
+	require.NoError(t, testState.AddArchivedAccount(ctx, 1, addr, &nonce, big.NewInt(1000), dbTx))
+	require.NoError(t, testState.AddArchivedCode(ctx, 1, addr, []byte{0x60, 0x80}, dbTx))
+	require.NoError(t, testState.AddArchivedStorage(ctx, 1, addr, position, common.HexToHash("0x2"), dbTx))
+	require.NoError(t, testState.AddArchivedL2Block(ctx, 2, true, dbTx))
+	require.NoError(t, testState.AddArchivedL2Block(ctx, 3, false, dbTx))
+	require.NoError(t, testState.AddArchivedAccount(ctx, 3, addr, nil, big.NewInt(500), dbTx))
+	require.NoError(t, testState.AddArchivedStorage(ctx, 3, addr, position, common.HexToHash("0x3"), dbTx))
+	require.ErrorIs(t, testState.AddArchivedL2Block(ctx, 4, true, dbTx), state.ErrNotFound)
+
+	archived, err := testState.IsL2BlockArchived(ctx, 2, dbTx)
+	require.NoError(t, err)
+	assert.True(t, archived)
+	archived, err = testState.IsL2BlockArchived(ctx, 4, dbTx)
+	require.NoError(t, err)
+	assert.False(t, archived)
+
+	// the code and storage of l2 block 3 are not archived
+	hasCodeAndStorage, err := testState.HasArchivedCodeAndStorage(ctx, 2, dbTx)
+	require.NoError(t, err)
+	assert.True(t, hasCodeAndStorage)
+	hasCodeAndStorage, err = testState.HasArchivedCodeAndStorage(ctx, 3, dbTx)
+	require.NoError(t, err)
+	assert.False(t, hasCodeAndStorage)
+
+	l2BlockNumber, err := testState.GetArchivedL2BlockNumberByStateRoot(ctx, common.BigToHash(big.NewInt(2)), dbTx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), l2BlockNumber)
+	_, err = testState.GetArchivedL2BlockNumberByStateRoot(ctx, common.BigToHash(big.NewInt(4)), dbTx)
+	require.ErrorIs(t, err, state.ErrNotFound)
+
+	balance, err := testState.GetArchivedBalance(ctx, addr, 1, dbTx)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(1000), balance)
+	balance, err = testState.GetArchivedBalance(ctx, addr, 3, dbTx)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(500), balance)
+
+	// the nonce was not modified at l2 block 3
+	archivedNonce, err := testState.GetArchivedNonce(ctx, addr, 3, dbTx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), archivedNonce)
+
+	_, err = testState.GetArchivedBalance(ctx, common.HexToAddress("0x1"), 3, dbTx)
+	require.ErrorIs(t, err, state.ErrNotFound)
+	_, err = testState.GetArchivedNonce(ctx, common.HexToAddress("0x1"), 3, dbTx)
+	require.ErrorIs(t, err, state.ErrNotFound)
+
+	// the code was not modified at l2 block 3
+	code, err := testState.GetArchivedCode(ctx, addr, 3, dbTx)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x60, 0x80}, code)
+	_, err = testState.GetArchivedCode(ctx, common.HexToAddress("0x1"), 3, dbTx)
+	require.ErrorIs(t, err, state.ErrNotFound)
+
+	value, err := testState.GetArchivedStorageAt(ctx, addr, position, 2, dbTx)
+	require.NoError(t, err)
+	assert.Equal(t, common.HexToHash("0x2"), value)
+	value, err = testState.GetArchivedStorageAt(ctx, addr, position, 3, dbTx)
+	require.NoError(t, err)
+	assert.Equal(t, common.HexToHash("0x3"), value)
+	_, err = testState.GetArchivedStorageAt(ctx, addr, common.HexToHash("0x2"), 3, dbTx)
+	require.ErrorIs(t, err, state.ErrNotFound)
+}
+
+func TestSequencerLease(t *testing.T) {
+	initOrResetDB()
+	ctx := context.Background()
+	// The lease is checked against NOW(), which doesn't change inside a db tx, so no db tx is used
+	_, err := testState.Exec(ctx, "DELETE FROM state.sequencer_lease")
+	require.NoError(t, err)
+
+	fencingToken, err := testState.AcquireSequencerLease(ctx, "node1", time.Hour, nil)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), fencingToken)
+
+	// the lease is held by node1
+	_, err = testState.AcquireSequencerLease(ctx, "node2", time.Hour, nil)
+	require.ErrorIs(t, err, state.ErrSequencerLeaseHeld)
+	require.NoError(t, testState.RenewSequencerLease(ctx, "node1", 1, time.Hour, nil))
+	require.NoError(t, testState.CheckSequencerLease(ctx, "node1", 1, nil))
+	require.ErrorIs(t, testState.CheckSequencerLease(ctx, "node2", 1, nil), state.ErrSequencerLeaseLost)
+
+	// the lease expires and node2 takes over
+	_, err = testState.Exec(ctx, "UPDATE state.sequencer_lease SET expires_at = NOW() - INTERVAL '1 second'")
+	require.NoError(t, err)
+	require.ErrorIs(t, testState.RenewSequencerLease(ctx, "node1", 1, time.Hour, nil), state.ErrSequencerLeaseLost)
+	fencingToken, err = testState.AcquireSequencerLease(ctx, "node2", time.Hour, nil)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), fencingToken)
+	require.ErrorIs(t, testState.CheckSequencerLease(ctx, "node1", 1, nil), state.ErrSequencerLeaseLost)
+	require.NoError(t, testState.CheckSequencerLease(ctx, "node2", 2, nil))
+}
+
+func TestResetEpoch(t *testing.T) {
+	initOrResetDB()
+	ctx := context.Background()
+	dbTx, err := testState.BeginStateTransaction(ctx)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, dbTx.Rollback(ctx)) }()
+
+	epoch, err := testState.GetResetEpoch(ctx, dbTx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), epoch)
+
+	// every reset increases the epoch, even when no block is removed
+	require.NoError(t, testState.ResetToL1BlockNumber(ctx, 100, dbTx))
+	require.NoError(t, testState.ResetToL1BlockNumber(ctx, 100, dbTx))
+	epoch, err = testState.GetResetEpoch(ctx, dbTx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), epoch)
+}
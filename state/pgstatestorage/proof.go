@@ -60,6 +60,42 @@ func (p *PostgresStorage) GetProofReadyForFinal(ctx context.Context, lastVerfied
 	return proof, err
 }
 
+// GetBatchProof returns the widest generated proof that includes the provided batch number
+func (p *PostgresStorage) GetBatchProof(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (*state.Proof, error) {
+	const getBatchProofSQL = `
+		SELECT 
+			p.batch_num, 
+			p.batch_num_final,
+			p.proof,
+			p.proof_id,
+			p.input_prover,
+			p.prover,
+			p.prover_id,
+			p.generating_since,
+			p.created_at,
+			p.updated_at
+		FROM state.batch_proof p
+		WHERE p.batch_num <= $1 AND p.batch_num_final >= $1 AND
+			  p.generating_since IS NULL AND p.proof IS NOT NULL
+		ORDER BY p.batch_num_final - p.batch_num DESC
+		LIMIT 1
+		`
+
+	var proof *state.Proof = &state.Proof{}
+
+	e := p.getExecQuerier(dbTx)
+	row := e.QueryRow(ctx, getBatchProofSQL, batchNumber)
+	err := row.Scan(&proof.BatchNumber, &proof.BatchNumberFinal, &proof.Proof, &proof.ProofID, &proof.InputProver, &proof.Prover, &proof.ProverID, &proof.GeneratingSince, &proof.CreatedAt, &proof.UpdatedAt)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, state.ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	return proof, err
+}
+
 // GetBatchProofsToAggregate return the next 2 batch proofs that it are possible to aggregate
 func (p *PostgresStorage) GetBatchProofsToAggregate(ctx context.Context, dbTx pgx.Tx) (*state.Proof, *state.Proof, error) {
 	var (
@@ -0,0 +1,69 @@
+package pgstatestorage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/jackc/pgx/v4"
+)
+
+// AcquireSequencerLease acquires the sequencer lease for the provided node if there is no lease
+// or the current one has expired, returning the new fencing token. The fencing token is increased
+// each time the lease changes of holder, so the stale leaders can be detected
+func (p *PostgresStorage) AcquireSequencerLease(ctx context.Context, nodeID string, leaseDuration time.Duration, dbTx pgx.Tx) (uint64, error) {
+	const acquireSequencerLeaseSQL = `
+		INSERT INTO state.sequencer_lease (id, node_id, fencing_token, expires_at)
+		VALUES (1, $1, 1, NOW() + $2 * INTERVAL '1 millisecond')
+		ON CONFLICT (id) DO UPDATE
+		SET node_id = EXCLUDED.node_id, fencing_token = state.sequencer_lease.fencing_token + 1, expires_at = EXCLUDED.expires_at
+		WHERE state.sequencer_lease.expires_at < NOW()
+		RETURNING fencing_token`
+
+	var fencingToken uint64
+	e := p.getExecQuerier(dbTx)
+	err := e.QueryRow(ctx, acquireSequencerLeaseSQL, nodeID, leaseDuration.Milliseconds()).Scan(&fencingToken)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, state.ErrSequencerLeaseHeld
+	} else if err != nil {
+		return 0, err
+	}
+	return fencingToken, nil
+}
+
+// RenewSequencerLease extends the expiration of the sequencer lease held by the provided node
+// and fencing token. It returns state.ErrSequencerLeaseLost if the lease has already expired
+func (p *PostgresStorage) RenewSequencerLease(ctx context.Context, nodeID string, fencingToken uint64, leaseDuration time.Duration, dbTx pgx.Tx) error {
+	const renewSequencerLeaseSQL = `
+		UPDATE state.sequencer_lease SET expires_at = NOW() + $3 * INTERVAL '1 millisecond'
+		WHERE id = 1 AND node_id = $1 AND fencing_token = $2 AND expires_at >= NOW()`
+
+	e := p.getExecQuerier(dbTx)
+	commandTag, err := e.Exec(ctx, renewSequencerLeaseSQL, nodeID, fencingToken, leaseDuration.Milliseconds())
+	if err != nil {
+		return err
+	}
+	if commandTag.RowsAffected() == 0 {
+		return state.ErrSequencerLeaseLost
+	}
+	return nil
+}
+
+// CheckSequencerLease checks the sequencer lease is still held by the provided node and fencing token.
+// The lease row is locked until the end of the provided db tx, so it can't be acquired by another node
+// before the data stored in the same db tx is committed
+func (p *PostgresStorage) CheckSequencerLease(ctx context.Context, nodeID string, fencingToken uint64, dbTx pgx.Tx) error {
+	const checkSequencerLeaseSQL = `
+		SELECT 1 FROM state.sequencer_lease
+		WHERE id = 1 AND node_id = $1 AND fencing_token = $2 AND expires_at >= NOW()
+		FOR SHARE`
+
+	var exists int
+	e := p.getExecQuerier(dbTx)
+	err := e.QueryRow(ctx, checkSequencerLeaseSQL, nodeID, fencingToken).Scan(&exists)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return state.ErrSequencerLeaseLost
+	}
+	return err
+}
@@ -317,6 +317,146 @@ func (p *PostgresStorage) GetTransactionReceipt(ctx context.Context, transaction
 	return &receipt, nil
 }
 
+// GetL2BlockReceipts gets the txs and the receipts, including the logs, of all the
+// txs in the l2 block with the provided number, sorted by tx index. All the data is
+// loaded with a single query to avoid a round trip per tx
+func (p *PostgresStorage) GetL2BlockReceipts(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) (txs []*types.Transaction, receipts []*types.Receipt, err error) {
+	const getL2BlockReceiptsSQL = `
+		SELECT
+			b.block_hash,
+			r.tx_index,
+			r.tx_hash,
+			r.type,
+			r.post_state,
+			r.status,
+			r.cumulative_gas_used,
+			r.gas_used,
+			r.contract_address,
+			r.effective_gas_price,
+			t.encoded,
+			l.log_index,
+			l.address,
+			l.data,
+			l.topic0,
+			l.topic1,
+			l.topic2,
+			l.topic3
+		  FROM state.l2block b
+		  LEFT JOIN state.receipt r
+		    ON r.block_num = b.block_num
+		  LEFT JOIN state.transaction t
+		    ON t.hash = r.tx_hash
+		  LEFT JOIN state.log l
+		    ON l.tx_hash = r.tx_hash
+		 WHERE b.block_num = $1
+		 ORDER BY r.tx_index ASC, l.log_index ASC`
+
+	q := p.getExecQuerier(dbTx)
+	rows, err := q.Query(ctx, getL2BlockReceiptsSQL, blockNumber)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	blockFound := false
+	txs = []*types.Transaction{}
+	receipts = []*types.Receipt{}
+	var receipt *types.Receipt
+	for rows.Next() {
+		blockFound = true
+
+		var (
+			blockHash                                             string
+			txIndex                                               *uint
+			txHash, contractAddress, encodedTx                    *string
+			receiptType                                           *uint8
+			postState                                             []byte
+			status, cumulativeGasUsed, gasUsed, effectiveGasPrice *uint64
+			logIndex                                              *uint
+			logAddress, logData                                   *string
+			topic0, topic1, topic2, topic3                        *string
+		)
+		err := rows.Scan(&blockHash, &txIndex, &txHash, &receiptType, &postState, &status,
+			&cumulativeGasUsed, &gasUsed, &contractAddress, &effectiveGasPrice, &encodedTx,
+			&logIndex, &logAddress, &logData, &topic0, &topic1, &topic2, &topic3)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		// the block has no txs
+		if txHash == nil {
+			continue
+		}
+
+		// the rows of the same tx are consecutive, one per log
+		if receipt == nil || receipt.TxHash != common.HexToHash(*txHash) {
+			tx, err := state.DecodeTx(*encodedTx)
+			if err != nil {
+				return nil, nil, err
+			}
+			txs = append(txs, tx)
+
+			receipt = &types.Receipt{
+				Type:              *receiptType,
+				PostState:         postState,
+				Status:            *status,
+				CumulativeGasUsed: *cumulativeGasUsed,
+				TxHash:            common.HexToHash(*txHash),
+				ContractAddress:   common.HexToAddress(*contractAddress),
+				GasUsed:           *gasUsed,
+				BlockHash:         common.HexToHash(blockHash),
+				BlockNumber:       new(big.Int).SetUint64(blockNumber),
+				TransactionIndex:  *txIndex,
+				Logs:              []*types.Log{},
+			}
+			if effectiveGasPrice != nil {
+				receipt.EffectiveGasPrice = new(big.Int).SetUint64(*effectiveGasPrice)
+			}
+			receipts = append(receipts, receipt)
+		}
+
+		// the tx has no logs
+		if logIndex == nil {
+			continue
+		}
+
+		log := &types.Log{
+			Address:     common.HexToAddress(*logAddress),
+			Topics:      []common.Hash{},
+			BlockNumber: blockNumber,
+			TxHash:      receipt.TxHash,
+			TxIndex:     receipt.TransactionIndex,
+			BlockHash:   receipt.BlockHash,
+			Index:       *logIndex,
+		}
+		if logData != nil {
+			log.Data, err = hex.DecodeHex(*logData)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+		for _, topic := range []*string{topic0, topic1, topic2, topic3} {
+			if topic != nil {
+				log.Topics = append(log.Topics, common.HexToHash(*topic))
+			}
+		}
+		receipt.Logs = append(receipt.Logs, log)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	if !blockFound {
+		return nil, nil, state.ErrNotFound
+	}
+
+	for _, receipt := range receipts {
+		receipt.Bloom = types.CreateBloom(types.Receipts{receipt})
+	}
+
+	return txs, receipts, nil
+}
+
 // GetTransactionByL2BlockHashAndIndex gets a transaction accordingly to the block hash and transaction index provided.
 // since we only have a single transaction per l2 block, any index different from 0 will return a not found result
 func (p *PostgresStorage) GetTransactionByL2BlockHashAndIndex(ctx context.Context, blockHash common.Hash, index uint64, dbTx pgx.Tx) (*types.Transaction, error) {
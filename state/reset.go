@@ -19,6 +19,10 @@ func (s *State) Reset(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) erro
 		// is going to be a commit or a rollback. So is going to be rebuild on the next
 		// request that needs it.
 		s.l1InfoTree = nil
+
+		// Notify the handlers running in this process, the handlers running in
+		// other processes are notified when the monitor detects the new reset epoch
+		s.triggerZKEVMEvent(ZKEVMEvent{Type: ZKEVMEventTypeReset})
 	}
 	return err
 }
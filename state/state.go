@@ -2,6 +2,7 @@ package state
 
 import (
 	"context"
+	"errors"
 	"math/big"
 	"sync"
 
@@ -39,6 +40,7 @@ type State struct {
 
 	newL2BlockEvents        chan NewL2BlockEvent
 	newL2BlockEventHandlers []NewL2BlockEventHandler
+	zkEVMEventHandlers      []ZKEVMEventHandler
 }
 
 // NewState creates a new State
@@ -73,6 +75,12 @@ func (s *State) BeginStateTransaction(ctx context.Context) (pgx.Tx, error) {
 
 // GetBalance from a given address
 func (s *State) GetBalance(ctx context.Context, address common.Address, root common.Hash) (*big.Int, error) {
+	balance, err := s.getArchivedBalance(ctx, address, root)
+	if err == nil {
+		return balance, nil
+	} else if !errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
 	if s.tree == nil {
 		return nil, ErrStateTreeNil
 	}
@@ -81,6 +89,12 @@ func (s *State) GetBalance(ctx context.Context, address common.Address, root com
 
 // GetCode from a given address
 func (s *State) GetCode(ctx context.Context, address common.Address, root common.Hash) ([]byte, error) {
+	code, err := s.getArchivedCode(ctx, address, root)
+	if err == nil {
+		return code, nil
+	} else if !errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
 	if s.tree == nil {
 		return nil, ErrStateTreeNil
 	}
@@ -89,24 +103,45 @@ func (s *State) GetCode(ctx context.Context, address common.Address, root common
 
 // GetNonce returns the nonce of the given account at the given block number
 func (s *State) GetNonce(ctx context.Context, address common.Address, root common.Hash) (uint64, error) {
+	nonce, err := s.getArchivedNonce(ctx, address, root)
+	if err == nil {
+		return nonce, nil
+	} else if !errors.Is(err, ErrNotFound) {
+		return 0, err
+	}
 	if s.tree == nil {
 		return 0, ErrStateTreeNil
 	}
-	nonce, err := s.tree.GetNonce(ctx, address, root.Bytes())
+	treeNonce, err := s.tree.GetNonce(ctx, address, root.Bytes())
 	if err != nil {
 		return 0, err
 	}
-	return nonce.Uint64(), nil
+	return treeNonce.Uint64(), nil
 }
 
 // GetStorageAt from a given address
 func (s *State) GetStorageAt(ctx context.Context, address common.Address, position *big.Int, root common.Hash) (*big.Int, error) {
+	value, err := s.getArchivedStorageAt(ctx, address, position, root)
+	if err == nil {
+		return value, nil
+	} else if !errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
 	if s.tree == nil {
 		return nil, ErrStateTreeNil
 	}
 	return s.tree.GetStorageAt(ctx, address, position, root.Bytes())
 }
 
+// GetAccountProof returns the merkle tree proofs of the balance, nonce, code hash
+// and the provided storage positions of an account at the given state root
+func (s *State) GetAccountProof(ctx context.Context, address common.Address, positions []*big.Int, root common.Hash) (*merkletree.AccountProof, error) {
+	if s.tree == nil {
+		return nil, ErrStateTreeNil
+	}
+	return s.tree.GetAccountProof(ctx, address, positions, root.Bytes())
+}
+
 // GetLastStateRoot returns the latest state root
 func (s *State) GetLastStateRoot(ctx context.Context, dbTx pgx.Tx) (common.Hash, error) {
 	lastBlockHeader, err := s.GetLastL2BlockHeader(ctx, dbTx)
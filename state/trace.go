@@ -82,32 +82,8 @@ func (s *State) DebugTransaction(ctx context.Context, transactionHash common.Has
 	var response *ProcessTransactionResponse
 	var startTime, endTime time.Time
 	if forkId < FORKID_ETROG {
-		traceConfigRequest := &executor.TraceConfig{
-			TxHashToGenerateFullTrace: transactionHash.Bytes(),
-			// set the defaults to the maximum information we can have.
-			// this is needed to process custom tracers later
-			DisableStorage:   cFalse,
-			DisableStack:     cFalse,
-			EnableMemory:     cTrue,
-			EnableReturnData: cTrue,
-		}
-
-		// if the default tracer is used, then we review the information
-		// we want to have in the trace related to the parameters we received.
-		if traceConfig.IsDefaultTracer() {
-			if traceConfig.DisableStorage {
-				traceConfigRequest.DisableStorage = cTrue
-			}
-			if traceConfig.DisableStack {
-				traceConfigRequest.DisableStack = cTrue
-			}
-			if !traceConfig.EnableMemory {
-				traceConfigRequest.EnableMemory = cFalse
-			}
-			if !traceConfig.EnableReturnData {
-				traceConfigRequest.EnableReturnData = cFalse
-			}
-		}
+		traceConfigRequest := newExecutorTraceConfig(traceConfig, transactionHash)
+
 		// generate batch l2 data for the transaction
 		batchL2Data, err := EncodeTransactions(txsToEncode, effectivePercentage, forkId)
 		if err != nil {
@@ -161,32 +137,7 @@ func (s *State) DebugTransaction(ctx context.Context, transactionHash common.Has
 		}
 		response = convertedResponse.BlockResponses[0].TransactionResponses[0]
 	} else {
-		traceConfigRequestV2 := &executor.TraceConfigV2{
-			TxHashToGenerateFullTrace: transactionHash.Bytes(),
-			// set the defaults to the maximum information we can have.
-			// this is needed to process custom tracers later
-			DisableStorage:   cFalse,
-			DisableStack:     cFalse,
-			EnableMemory:     cTrue,
-			EnableReturnData: cTrue,
-		}
-
-		// if the default tracer is used, then we review the information
-		// we want to have in the trace related to the parameters we received.
-		if traceConfig.IsDefaultTracer() {
-			if traceConfig.DisableStorage {
-				traceConfigRequestV2.DisableStorage = cTrue
-			}
-			if traceConfig.DisableStack {
-				traceConfigRequestV2.DisableStack = cTrue
-			}
-			if !traceConfig.EnableMemory {
-				traceConfigRequestV2.EnableMemory = cFalse
-			}
-			if !traceConfig.EnableReturnData {
-				traceConfigRequestV2.EnableReturnData = cFalse
-			}
-		}
+		traceConfigRequestV2 := newExecutorTraceConfigV2(traceConfig, transactionHash)
 
 		// if the l2 block number is 1, it means this is a network that started
 		// at least on Etrog fork, in this case the l2 block 1 will contain the
@@ -346,7 +297,7 @@ func (s *State) DebugTransaction(ctx context.Context, transactionHash common.Has
 		Gas:          tx.Gas(),
 		Value:        tx.Value(),
 		Output:       result.ReturnValue,
-		GasPrice:     tx.GasPrice().String(),
+		GasPrice:     TxGasPrice(tx).String(),
 		OldStateRoot: oldStateRoot,
 		Time:         uint64(endTime.Sub(startTime)),
 		GasUsed:      result.GasUsed,
@@ -363,14 +314,7 @@ func (s *State) DebugTransaction(ctx context.Context, transactionHash common.Has
 
 	result.FullTrace.Context = context
 
-	gasPrice, ok := new(big.Int).SetString(context.GasPrice, encoding.Base10)
-	if !ok {
-		log.Errorf("debug transaction: failed to parse gasPrice")
-		return nil, fmt.Errorf("failed to parse gasPrice")
-	}
-
 	// select and prepare tracer
-	var tracer tracers.Tracer
 	tracerContext := &tracers.Context{
 		BlockHash:   receipt.BlockHash,
 		BlockNumber: receipt.BlockNumber,
@@ -378,6 +322,212 @@ func (s *State) DebugTransaction(ctx context.Context, transactionHash common.Has
 		TxHash:      transactionHash,
 	}
 
+	traceResult, err := s.parseTrace(result, *receipt, tracerContext, traceConfig, batch.StateRoot.Bytes(), nil)
+	if err != nil {
+		return nil, err
+	}
+	result.TraceResult = traceResult
+
+	return result, nil
+}
+
+// DebugCall executes an unsigned tx on top of the state of the provided l2 block,
+// or the last one if not provided, to generate its trace. The tx is not stored.
+func (s *State) DebugCall(ctx context.Context, tx *types.Transaction, senderAddress common.Address, l2BlockNumber *uint64, traceConfig TraceConfig, stateOverride StateOverride, dbTx pgx.Tx) (*runtime.ExecutionResult, error) {
+	l2Block, txHash, result, err := s.traceUnsignedTransaction(ctx, tx, senderAddress, l2BlockNumber, traceConfig, stateOverride, dbTx)
+	if err != nil {
+		return nil, err
+	}
+
+	// the tx is not stored, so a receipt is built with
+	// the information required by the tracers
+	receipt := types.Receipt{
+		Status:      types.ReceiptStatusSuccessful,
+		GasUsed:     result.GasUsed,
+		TxHash:      txHash,
+		BlockHash:   l2Block.Hash(),
+		BlockNumber: l2Block.Number(),
+	}
+	if result.Failed() {
+		receipt.Status = types.ReceiptStatusFailed
+	}
+
+	tracerContext := &tracers.Context{
+		BlockHash:   receipt.BlockHash,
+		BlockNumber: receipt.BlockNumber,
+		TxIndex:     0,
+		TxHash:      receipt.TxHash,
+	}
+
+	traceResult, err := s.parseTrace(result, receipt, tracerContext, traceConfig, l2Block.Root().Bytes(), stateOverride)
+	if err != nil {
+		return nil, err
+	}
+	result.TraceResult = traceResult
+
+	return result, nil
+}
+
+// maxAccessListIterations is the max number of times a tx is executed to generate its access list
+const maxAccessListIterations = 10
+
+// CreateAccessList executes an unsigned tx on top of the state of the provided l2 block,
+// or the last one if not provided, and returns the access list with the accounts and
+// storage keys touched by it. The sender, the receiver and the precompiled contracts
+// are not included in the list since they are always warm. As the accounts and storage
+// keys touched can depend on the gas available, the tx is executed again with the access
+// list generated until it doesn't change. The gas used of the result includes the intrinsic
+// gas of the access list. The tx is not stored.
+func (s *State) CreateAccessList(ctx context.Context, tx *types.Transaction, senderAddress common.Address, l2BlockNumber *uint64, dbTx pgx.Tx) (types.AccessList, *runtime.ExecutionResult, error) {
+	var prevTracer *native.AccessListTracer
+	accessList := tx.AccessList()
+	for i := 0; i < maxAccessListIterations; i++ {
+		tracer, result, err := s.traceAccessList(ctx, types.NewTx(newAccessListTxData(tx, accessList)), senderAddress, l2BlockNumber, accessList, dbTx)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		accessList = tracer.AccessList()
+		if prevTracer != nil && tracer.Equal(prevTracer) {
+			result.GasUsed += accessListGas(accessList)
+			return accessList, result, nil
+		}
+		prevTracer = tracer
+	}
+
+	return nil, nil, ErrAccessListNotConverged
+}
+
+// traceAccessList executes an unsigned tx and returns the access list tracer, initialized
+// with the provided access list, filled with the accounts and storage keys touched by it
+func (s *State) traceAccessList(ctx context.Context, tx *types.Transaction, senderAddress common.Address, l2BlockNumber *uint64, accessList types.AccessList, dbTx pgx.Tx) (*native.AccessListTracer, *runtime.ExecutionResult, error) {
+	l2Block, _, result, err := s.traceUnsignedTransaction(ctx, tx, senderAddress, l2BlockNumber, TraceConfig{}, nil, dbTx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	to := result.CreateAddress
+	if tx.To() != nil {
+		to = *tx.To()
+	}
+	tracer := native.NewAccessListTracer(accessList, senderAddress, to, fakevm.PrecompiledAddressesBerlin)
+
+	fakeDB := &FakeDB{State: s, stateRoot: l2Block.Root().Bytes()}
+	evm := fakevm.NewFakeEVM(fakevm.BlockContext{BlockNumber: big.NewInt(1)}, fakevm.TxContext{GasPrice: TxGasPrice(tx)}, fakeDB, params.TestChainConfig, fakevm.Config{Debug: true, Tracer: tracer})
+
+	_, err = s.buildTrace(evm, result, tracer)
+	if err != nil {
+		log.Errorf("create access list: failed parse the trace using the tracer: %v", err)
+		return nil, nil, fmt.Errorf("failed parse the trace using the tracer: %v", err)
+	}
+
+	return tracer, result, nil
+}
+
+// newAccessListTxData returns the data of an access list tx with the fields of the
+// provided tx and the provided access list
+func newAccessListTxData(tx *types.Transaction, accessList types.AccessList) *types.AccessListTx {
+	return &types.AccessListTx{
+		ChainID:    tx.ChainId(),
+		Nonce:      tx.Nonce(),
+		GasPrice:   tx.GasPrice(),
+		Gas:        tx.Gas(),
+		To:         tx.To(),
+		Value:      tx.Value(),
+		Data:       tx.Data(),
+		AccessList: accessList,
+	}
+}
+
+// accessListGas returns the intrinsic gas of an access list. The unsigned txs are executed
+// as legacy txs, so the accesses are charged as cold and the gas used plus the intrinsic gas
+// of the access list is an upper bound of the gas used by the tx with the access list
+func accessListGas(accessList types.AccessList) uint64 {
+	return uint64(len(accessList))*params.TxAccessListAddressGas + uint64(accessList.StorageKeys())*params.TxAccessListStorageKeyGas
+}
+
+// traceUnsignedTransaction executes an unsigned tx on top of the state of the provided
+// l2 block, or the last one if not provided, requesting the executor to generate its
+// full trace. It returns the l2 block used, the hash of the tx and the execution result
+// with the full trace context filled
+func (s *State) traceUnsignedTransaction(ctx context.Context, tx *types.Transaction, senderAddress common.Address, l2BlockNumber *uint64, traceConfig TraceConfig, stateOverride StateOverride, dbTx pgx.Tx) (*L2Block, common.Hash, *runtime.ExecutionResult, error) {
+	var l2Block *L2Block
+	var err error
+	if l2BlockNumber == nil {
+		l2Block, err = s.GetLastL2Block(ctx, dbTx)
+	} else {
+		l2Block, err = s.GetL2BlockByNumber(ctx, *l2BlockNumber, dbTx)
+	}
+	if err != nil {
+		return nil, common.Hash{}, nil, err
+	}
+	blockNumber := l2Block.NumberU64()
+
+	startTime := time.Now()
+	response, err := s.internalProcessUnsignedTransaction(ctx, tx, senderAddress, &blockNumber, true, &traceConfig, stateOverride, dbTx)
+	endTime := time.Now()
+
+	var txResponse *ProcessTransactionResponse
+	if response != nil && len(response.BlockResponses) > 0 && len(response.BlockResponses[0].TransactionResponses) > 0 {
+		txResponse = response.BlockResponses[0].TransactionResponses[0]
+	}
+
+	// failed txs are traced as well, so only the errors that prevented
+	// the tx from being executed are returned
+	if err != nil && (txResponse == nil || !errors.Is(err, txResponse.RomError)) {
+		return nil, common.Hash{}, nil, err
+	} else if txResponse == nil {
+		return nil, common.Hash{}, nil, fmt.Errorf("tx not found in executor response")
+	}
+
+	result := &runtime.ExecutionResult{
+		CreateAddress: txResponse.CreateAddress,
+		GasLeft:       txResponse.GasLeft,
+		GasUsed:       txResponse.GasUsed,
+		ReturnValue:   txResponse.ReturnValue,
+		StateRoot:     txResponse.StateRoot.Bytes(),
+		FullTrace:     txResponse.FullTrace,
+		Err:           txResponse.RomError,
+	}
+
+	context := instrumentation.Context{
+		From:         senderAddress.String(),
+		Input:        tx.Data(),
+		Gas:          tx.Gas(),
+		Value:        tx.Value(),
+		Output:       result.ReturnValue,
+		GasPrice:     TxGasPrice(tx).String(),
+		OldStateRoot: l2Block.Root(),
+		Time:         uint64(endTime.Sub(startTime)),
+		GasUsed:      result.GasUsed,
+	}
+
+	// Fill trace context
+	if tx.To() == nil {
+		context.Type = "CREATE"
+		context.To = result.CreateAddress.Hex()
+	} else {
+		context.Type = "CALL"
+		context.To = tx.To().Hex()
+	}
+
+	result.FullTrace.Context = context
+
+	return l2Block, txResponse.TxHash, result, nil
+}
+
+// parseTrace parses the full trace of the execution result with the tracer
+// selected in the trace config. The state overrides of an unsigned tx are
+// applied to the state read by the tracer at the provided state root
+func (s *State) parseTrace(result *runtime.ExecutionResult, receipt types.Receipt, tracerContext *tracers.Context, traceConfig TraceConfig, stateRoot []byte, stateOverride StateOverride) (json.RawMessage, error) {
+	gasPrice, ok := new(big.Int).SetString(result.FullTrace.Context.GasPrice, encoding.Base10)
+	if !ok {
+		log.Errorf("debug transaction: failed to parse gasPrice")
+		return nil, fmt.Errorf("failed to parse gasPrice")
+	}
+
+	var tracer tracers.Tracer
+	var err error
 	if traceConfig.IsDefaultTracer() {
 		structLoggerCfg := structlogger.Config{
 			EnableMemory:     traceConfig.EnableMemory,
@@ -386,12 +536,7 @@ func (s *State) DebugTransaction(ctx context.Context, transactionHash common.Has
 			EnableReturnData: traceConfig.EnableReturnData,
 		}
 		tracer := structlogger.NewStructLogger(structLoggerCfg)
-		traceResult, err := tracer.ParseTrace(result, *receipt)
-		if err != nil {
-			return nil, err
-		}
-		result.TraceResult = traceResult
-		return result, nil
+		return tracer.ParseTrace(result, receipt)
 	} else if traceConfig.Is4ByteTracer() {
 		tracer, err = native.NewFourByteTracer(tracerContext, traceConfig.TracerConfig)
 		if err != nil {
@@ -404,6 +549,12 @@ func (s *State) DebugTransaction(ctx context.Context, transactionHash common.Has
 			log.Errorf("debug transaction: failed to create callTracer, err: %v", err)
 			return nil, fmt.Errorf("failed to create callTracer, err: %v", err)
 		}
+	} else if traceConfig.IsFlatCallTracer() {
+		tracer, err = tracers.DefaultDirectory.New(*traceConfig.Tracer, tracerContext, traceConfig.TracerConfig)
+		if err != nil {
+			log.Errorf("debug transaction: failed to create flatCallTracer, err: %v", err)
+			return nil, fmt.Errorf("failed to create flatCallTracer, err: %v", err)
+		}
 	} else if traceConfig.IsNoopTracer() {
 		tracer, err = native.NewNoopTracer(tracerContext, traceConfig.TracerConfig)
 		if err != nil {
@@ -426,7 +577,7 @@ func (s *State) DebugTransaction(ctx context.Context, transactionHash common.Has
 		return nil, fmt.Errorf("invalid tracer: %v, err: %v", traceConfig.Tracer, err)
 	}
 
-	fakeDB := &FakeDB{State: s, stateRoot: batch.StateRoot.Bytes()}
+	fakeDB := &FakeDB{State: s, stateRoot: stateRoot, stateOverride: stateOverride, overriddenStateRoot: stateRoot}
 	evm := fakevm.NewFakeEVM(fakevm.BlockContext{BlockNumber: big.NewInt(1)}, fakevm.TxContext{GasPrice: gasPrice}, fakeDB, params.TestChainConfig, fakevm.Config{Debug: true, Tracer: tracer})
 
 	traceResult, err := s.buildTrace(evm, result, tracer)
@@ -435,9 +586,73 @@ func (s *State) DebugTransaction(ctx context.Context, transactionHash common.Has
 		return nil, fmt.Errorf("failed parse the trace using the tracer: %v", err)
 	}
 
-	result.TraceResult = traceResult
+	return traceResult, nil
+}
 
-	return result, nil
+// newExecutorTraceConfig builds the executor trace config to generate the
+// full trace of the provided tx hash
+func newExecutorTraceConfig(traceConfig TraceConfig, txHash common.Hash) *executor.TraceConfig {
+	traceConfigRequest := &executor.TraceConfig{
+		TxHashToGenerateFullTrace: txHash.Bytes(),
+		// set the defaults to the maximum information we can have.
+		// this is needed to process custom tracers later
+		DisableStorage:   cFalse,
+		DisableStack:     cFalse,
+		EnableMemory:     cTrue,
+		EnableReturnData: cTrue,
+	}
+
+	// if the default tracer is used, then we review the information
+	// we want to have in the trace related to the parameters we received.
+	if traceConfig.IsDefaultTracer() {
+		if traceConfig.DisableStorage {
+			traceConfigRequest.DisableStorage = cTrue
+		}
+		if traceConfig.DisableStack {
+			traceConfigRequest.DisableStack = cTrue
+		}
+		if !traceConfig.EnableMemory {
+			traceConfigRequest.EnableMemory = cFalse
+		}
+		if !traceConfig.EnableReturnData {
+			traceConfigRequest.EnableReturnData = cFalse
+		}
+	}
+
+	return traceConfigRequest
+}
+
+// newExecutorTraceConfigV2 builds the executor trace config to generate the
+// full trace of the provided tx hash
+func newExecutorTraceConfigV2(traceConfig TraceConfig, txHash common.Hash) *executor.TraceConfigV2 {
+	traceConfigRequestV2 := &executor.TraceConfigV2{
+		TxHashToGenerateFullTrace: txHash.Bytes(),
+		// set the defaults to the maximum information we can have.
+		// this is needed to process custom tracers later
+		DisableStorage:   cFalse,
+		DisableStack:     cFalse,
+		EnableMemory:     cTrue,
+		EnableReturnData: cTrue,
+	}
+
+	// if the default tracer is used, then we review the information
+	// we want to have in the trace related to the parameters we received.
+	if traceConfig.IsDefaultTracer() {
+		if traceConfig.DisableStorage {
+			traceConfigRequestV2.DisableStorage = cTrue
+		}
+		if traceConfig.DisableStack {
+			traceConfigRequestV2.DisableStack = cTrue
+		}
+		if !traceConfig.EnableMemory {
+			traceConfigRequestV2.EnableMemory = cFalse
+		}
+		if !traceConfig.EnableReturnData {
+			traceConfigRequestV2.EnableReturnData = cFalse
+		}
+	}
+
+	return traceConfigRequestV2
 }
 
 // ParseTheTraceUsingTheTracer parses the given trace with the given tracer.
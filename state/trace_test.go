@@ -0,0 +1,94 @@
+package state
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/state/runtime"
+	"github.com/0xPolygonHermez/zkevm-node/state/runtime/fakevm"
+	"github.com/0xPolygonHermez/zkevm-node/state/runtime/instrumentation"
+	"github.com/0xPolygonHermez/zkevm-node/state/runtime/instrumentation/tracers"
+	"github.com/0xPolygonHermez/zkevm-node/state/runtime/instrumentation/tracers/native"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccessListConvergence(t *testing.T) {
+	from := common.HexToAddress("0x1")
+	to := common.HexToAddress("0x2")
+	accessList := types.AccessList{
+		{Address: common.HexToAddress("0x300"), StorageKeys: []common.Hash{common.HexToHash("0x1"), common.HexToHash("0x2")}},
+		{Address: common.HexToAddress("0x400"), StorageKeys: []common.Hash{}},
+	}
+
+	prevTracer := native.NewAccessListTracer(accessList, from, to, fakevm.PrecompiledAddressesBerlin)
+	assert.True(t, native.NewAccessListTracer(prevTracer.AccessList(), from, to, fakevm.PrecompiledAddressesBerlin).Equal(prevTracer))
+	assert.False(t, native.NewAccessListTracer(accessList[:1], from, to, fakevm.PrecompiledAddressesBerlin).Equal(prevTracer))
+
+	expectedGas := 2*params.TxAccessListAddressGas + 2*params.TxAccessListStorageKeyGas
+	assert.Equal(t, expectedGas, accessListGas(accessList))
+	assert.Equal(t, uint64(0), accessListGas(nil))
+}
+
+func TestParseTraceWithFlatCallTracer(t *testing.T) {
+	from := common.HexToAddress("0x100")
+	to := common.HexToAddress("0x200")
+	inner := common.HexToAddress("0x300")
+	txHash := common.HexToHash("0x1")
+
+	result := &runtime.ExecutionResult{
+		FullTrace: instrumentation.FullTrace{
+			Context: instrumentation.Context{
+				Type:     "CALL",
+				From:     from.String(),
+				To:       to.String(),
+				Gas:      100000,
+				GasUsed:  30000,
+				Value:    big.NewInt(1),
+				GasPrice: "1",
+			},
+			Steps: []instrumentation.Step{
+				{Depth: 1, Pc: 0, Gas: 79000, OpCode: "CALL", Op: uint64(fakevm.CALL), GasCost: 2600, Contract: instrumentation.Contract{Address: to, Caller: from, Value: big.NewInt(1)}},
+				{Depth: 2, Pc: 0, Gas: 70000, OpCode: "STOP", Op: uint64(fakevm.STOP), Contract: instrumentation.Contract{Address: inner, Caller: to, Value: big.NewInt(0)}},
+				{Depth: 1, Pc: 1, Gas: 76400, OpCode: "STOP", Op: uint64(fakevm.STOP), Contract: instrumentation.Contract{Address: to, Caller: from, Value: big.NewInt(1)}},
+			},
+		},
+	}
+
+	tracer := "flatCallTracer"
+	traceConfig := TraceConfig{Tracer: &tracer, TracerConfig: json.RawMessage(`{"convertParityErrors":true}`)}
+	assert.True(t, traceConfig.IsFlatCallTracer())
+
+	s := &State{}
+	tracerContext := &tracers.Context{BlockNumber: big.NewInt(1), TxIndex: 0, TxHash: txHash}
+	trace, err := s.parseTrace(result, types.Receipt{}, tracerContext, traceConfig, nil, nil)
+	require.NoError(t, err)
+
+	var frames []struct {
+		Action struct {
+			CallType string         `json:"callType"`
+			From     common.Address `json:"from"`
+			To       common.Address `json:"to"`
+		} `json:"action"`
+		TraceAddress    []int       `json:"traceAddress"`
+		TransactionHash common.Hash `json:"transactionHash"`
+		Type            string      `json:"type"`
+	}
+	require.NoError(t, json.Unmarshal(trace, &frames))
+	require.Len(t, frames, 2)
+
+	assert.Equal(t, "call", frames[0].Type)
+	assert.Equal(t, from, frames[0].Action.From)
+	assert.Equal(t, to, frames[0].Action.To)
+	assert.Empty(t, frames[0].TraceAddress)
+	assert.Equal(t, txHash, frames[0].TransactionHash)
+
+	assert.Equal(t, "call", frames[1].Type)
+	assert.Equal(t, to, frames[1].Action.From)
+	assert.Equal(t, inner, frames[1].Action.To)
+	assert.Equal(t, []int{0}, frames[1].TraceAddress)
+}
@@ -24,7 +24,7 @@ import (
 
 // GetSender gets the sender from the transaction's signature
 func GetSender(tx types.Transaction) (common.Address, error) {
-	signer := types.NewEIP155Signer(tx.ChainId())
+	signer := types.NewLondonSigner(tx.ChainId())
 	sender, err := signer.Sender(&tx)
 	if err != nil {
 		return common.Address{}, err
@@ -290,7 +290,7 @@ func (s *State) StoreL2Block(ctx context.Context, batchNumber uint64, l2Block *P
 
 // PreProcessUnsignedTransaction processes the unsigned transaction in order to calculate its zkCounters
 func (s *State) PreProcessUnsignedTransaction(ctx context.Context, tx *types.Transaction, sender common.Address, l2BlockNumber *uint64, dbTx pgx.Tx) (*ProcessBatchResponse, error) {
-	response, err := s.internalProcessUnsignedTransaction(ctx, tx, sender, l2BlockNumber, false, dbTx)
+	response, err := s.internalProcessUnsignedTransaction(ctx, tx, sender, l2BlockNumber, false, nil, nil, dbTx)
 	if err != nil {
 		return response, err
 	}
@@ -305,7 +305,7 @@ func (s *State) PreProcessTransaction(ctx context.Context, tx *types.Transaction
 		return nil, err
 	}
 
-	response, err := s.internalProcessUnsignedTransaction(ctx, tx, sender, nil, false, dbTx)
+	response, err := s.internalProcessUnsignedTransaction(ctx, tx, sender, nil, false, nil, nil, dbTx)
 	if err != nil {
 		return response, err
 	}
@@ -314,9 +314,10 @@ func (s *State) PreProcessTransaction(ctx context.Context, tx *types.Transaction
 }
 
 // ProcessUnsignedTransaction processes the given unsigned transaction.
-func (s *State) ProcessUnsignedTransaction(ctx context.Context, tx *types.Transaction, senderAddress common.Address, l2BlockNumber *uint64, noZKEVMCounters bool, dbTx pgx.Tx) (*runtime.ExecutionResult, error) {
+// The accounts of the state override, if any, are overridden before executing it.
+func (s *State) ProcessUnsignedTransaction(ctx context.Context, tx *types.Transaction, senderAddress common.Address, l2BlockNumber *uint64, noZKEVMCounters bool, stateOverride StateOverride, dbTx pgx.Tx) (*runtime.ExecutionResult, error) {
 	result := new(runtime.ExecutionResult)
-	response, err := s.internalProcessUnsignedTransaction(ctx, tx, senderAddress, l2BlockNumber, noZKEVMCounters, dbTx)
+	response, err := s.internalProcessUnsignedTransaction(ctx, tx, senderAddress, l2BlockNumber, noZKEVMCounters, nil, stateOverride, dbTx)
 	if err != nil {
 		return nil, err
 	}
@@ -338,7 +339,9 @@ func (s *State) ProcessUnsignedTransaction(ctx context.Context, tx *types.Transa
 }
 
 // internalProcessUnsignedTransaction processes the given unsigned transaction.
-func (s *State) internalProcessUnsignedTransaction(ctx context.Context, tx *types.Transaction, senderAddress common.Address, l2BlockNumber *uint64, noZKEVMCounters bool, dbTx pgx.Tx) (*ProcessBatchResponse, error) {
+// When the trace config is provided, the executor generates the full trace of the
+// transaction and the accounts of the state override are overridden before executing it.
+func (s *State) internalProcessUnsignedTransaction(ctx context.Context, tx *types.Transaction, senderAddress common.Address, l2BlockNumber *uint64, noZKEVMCounters bool, traceConfig *TraceConfig, stateOverride StateOverride, dbTx pgx.Tx) (*ProcessBatchResponse, error) {
 	var l2Block *L2Block
 	var err error
 	if l2BlockNumber == nil {
@@ -357,15 +360,15 @@ func (s *State) internalProcessUnsignedTransaction(ctx context.Context, tx *type
 
 	forkID := s.GetForkIDByBatchNumber(batch.BatchNumber)
 	if forkID < FORKID_ETROG {
-		return s.internalProcessUnsignedTransactionV1(ctx, tx, senderAddress, *batch, *l2Block, forkID, noZKEVMCounters, dbTx)
+		return s.internalProcessUnsignedTransactionV1(ctx, tx, senderAddress, *batch, *l2Block, forkID, noZKEVMCounters, traceConfig, stateOverride, dbTx)
 	} else {
-		return s.internalProcessUnsignedTransactionV2(ctx, tx, senderAddress, *batch, *l2Block, forkID, noZKEVMCounters, dbTx)
+		return s.internalProcessUnsignedTransactionV2(ctx, tx, senderAddress, *batch, *l2Block, forkID, noZKEVMCounters, traceConfig, stateOverride, dbTx)
 	}
 }
 
 // internalProcessUnsignedTransactionV1 processes the given unsigned transaction.
 // pre ETROG
-func (s *State) internalProcessUnsignedTransactionV1(ctx context.Context, tx *types.Transaction, senderAddress common.Address, batch Batch, l2Block L2Block, forkID uint64, noZKEVMCounters bool, dbTx pgx.Tx) (*ProcessBatchResponse, error) {
+func (s *State) internalProcessUnsignedTransactionV1(ctx context.Context, tx *types.Transaction, senderAddress common.Address, batch Batch, l2Block L2Block, forkID uint64, noZKEVMCounters bool, traceConfig *TraceConfig, stateOverride StateOverride, dbTx pgx.Tx) (*ProcessBatchResponse, error) {
 	var attempts = 1
 
 	if s.executorClient == nil {
@@ -385,11 +388,10 @@ func (s *State) internalProcessUnsignedTransactionV1(ctx context.Context, tx *ty
 		timestamp = uint64(time.Now().Unix())
 	}
 
-	loadedNonce, err := s.tree.GetNonce(ctx, senderAddress, l2Block.Root().Bytes())
+	nonce, err := s.getUnsignedTxNonce(ctx, senderAddress, l2Block.Root(), stateOverride)
 	if err != nil {
 		return nil, err
 	}
-	nonce := loadedNonce.Uint64()
 
 	batchL2Data, err := EncodeUnsignedTransaction(*tx, s.cfg.ChainID, &nonce, forkID)
 	if err != nil {
@@ -417,6 +419,10 @@ func (s *State) internalProcessUnsignedTransactionV1(ctx context.Context, tx *ty
 	if noZKEVMCounters {
 		processBatchRequestV1.NoCounters = cTrue
 	}
+	if traceConfig != nil {
+		processBatchRequestV1.TraceConfig = newExecutorTraceConfig(*traceConfig, unsignedTxHash(*tx, s.cfg.ChainID, nonce))
+	}
+	processBatchRequestV1.StateOverride = convertToExecutorStateOverride(stateOverride)
 	log.Debugf("internalProcessUnsignedTransactionV1[processBatchRequestV1.From]: %v", processBatchRequestV1.From)
 	log.Debugf("internalProcessUnsignedTransactionV1[processBatchRequestV1.OldBatchNum]: %v", processBatchRequestV1.OldBatchNum)
 	log.Debugf("internalProcessUnsignedTransactionV1[processBatchRequestV1.OldStateRoot]: %v", hex.EncodeToHex(processBatchRequestV1.OldStateRoot))
@@ -493,7 +499,7 @@ func (s *State) internalProcessUnsignedTransactionV1(ctx context.Context, tx *ty
 
 // internalProcessUnsignedTransactionV2 processes the given unsigned transaction.
 // post ETROG
-func (s *State) internalProcessUnsignedTransactionV2(ctx context.Context, tx *types.Transaction, senderAddress common.Address, batch Batch, l2Block L2Block, forkID uint64, noZKEVMCounters bool, dbTx pgx.Tx) (*ProcessBatchResponse, error) {
+func (s *State) internalProcessUnsignedTransactionV2(ctx context.Context, tx *types.Transaction, senderAddress common.Address, batch Batch, l2Block L2Block, forkID uint64, noZKEVMCounters bool, traceConfig *TraceConfig, stateOverride StateOverride, dbTx pgx.Tx) (*ProcessBatchResponse, error) {
 	var attempts = 1
 
 	if s.executorClient == nil {
@@ -503,11 +509,10 @@ func (s *State) internalProcessUnsignedTransactionV2(ctx context.Context, tx *ty
 		return nil, ErrStateTreeNil
 	}
 
-	loadedNonce, err := s.tree.GetNonce(ctx, senderAddress, l2Block.Root().Bytes())
+	nonce, err := s.getUnsignedTxNonce(ctx, senderAddress, l2Block.Root(), stateOverride)
 	if err != nil {
 		return nil, err
 	}
-	nonce := loadedNonce.Uint64()
 
 	deltaTimestamp := uint32(uint64(time.Now().Unix()) - l2Block.Time())
 	transactions := s.BuildChangeL2Block(deltaTimestamp, uint32(0))
@@ -542,6 +547,10 @@ func (s *State) internalProcessUnsignedTransactionV2(ctx context.Context, tx *ty
 	if noZKEVMCounters {
 		processBatchRequestV2.NoCounters = cTrue
 	}
+	if traceConfig != nil {
+		processBatchRequestV2.TraceConfig = newExecutorTraceConfigV2(*traceConfig, unsignedTxHash(*tx, s.cfg.ChainID, nonce))
+	}
+	processBatchRequestV2.StateOverride = convertToExecutorStateOverrideV2(stateOverride)
 
 	log.Debugf("internalProcessUnsignedTransactionV2[processBatchRequestV2.From]: %v", processBatchRequestV2.From)
 	log.Debugf("internalProcessUnsignedTransactionV2[processBatchRequestV2.OldBatchNum]: %v", processBatchRequestV2.OldBatchNum)
@@ -629,6 +638,20 @@ func (s *State) internalProcessUnsignedTransactionV2(ctx context.Context, tx *ty
 	return response, nil
 }
 
+// getUnsignedTxNonce returns the nonce used to encode an unsigned tx, which is the
+// current nonce of the sender unless it's overridden
+func (s *State) getUnsignedTxNonce(ctx context.Context, senderAddress common.Address, root common.Hash, stateOverride StateOverride) (uint64, error) {
+	if account, found := stateOverride[senderAddress]; found && account.Nonce != nil {
+		return *account.Nonce, nil
+	}
+
+	loadedNonce, err := s.tree.GetNonce(ctx, senderAddress, root.Bytes())
+	if err != nil {
+		return 0, err
+	}
+	return loadedNonce.Uint64(), nil
+}
+
 // isContractCreation checks if the tx is a contract creation
 func (s *State) isContractCreation(tx *types.Transaction) bool {
 	return tx.To() == nil && len(tx.Data()) > 0
@@ -703,8 +726,9 @@ func CheckSupersetBatchTransactions(existingTxHashes []common.Hash, processedTxs
 	return nil
 }
 
-// EstimateGas for a transaction
-func (s *State) EstimateGas(transaction *types.Transaction, senderAddress common.Address, l2BlockNumber *uint64, dbTx pgx.Tx) (uint64, []byte, error) {
+// EstimateGas for a transaction. The accounts of the state override, if any,
+// are overridden before executing it.
+func (s *State) EstimateGas(transaction *types.Transaction, senderAddress common.Address, l2BlockNumber *uint64, stateOverride StateOverride, dbTx pgx.Tx) (uint64, []byte, error) {
 	const ethTransferGas = 21000
 
 	ctx := context.Background()
@@ -731,11 +755,10 @@ func (s *State) EstimateGas(transaction *types.Transaction, senderAddress common
 		return 0, nil, err
 	}
 
-	loadedNonce, err := s.tree.GetNonce(ctx, senderAddress, l2Block.Root().Bytes())
+	nonce, err := s.getUnsignedTxNonce(ctx, senderAddress, l2Block.Root(), stateOverride)
 	if err != nil {
 		return 0, nil, err
 	}
-	nonce := loadedNonce.Uint64()
 
 	highEnd := MaxTxGasLimit
 
@@ -743,11 +766,16 @@ func (s *State) EstimateGas(transaction *types.Transaction, senderAddress common
 	// of the account afford
 	isGasPriceSet := transaction.GasPrice().BitLen() != 0
 	if isGasPriceSet {
-		senderBalance, err := s.tree.GetBalance(ctx, senderAddress, l2Block.Root().Bytes())
-		if errors.Is(err, ErrNotFound) {
-			senderBalance = big.NewInt(0)
-		} else if err != nil {
-			return 0, nil, err
+		var senderBalance *big.Int
+		if account, found := stateOverride[senderAddress]; found && account.Balance != nil {
+			senderBalance = account.Balance
+		} else {
+			senderBalance, err = s.tree.GetBalance(ctx, senderAddress, l2Block.Root().Bytes())
+			if errors.Is(err, ErrNotFound) {
+				senderBalance = big.NewInt(0)
+			} else if err != nil {
+				return 0, nil, err
+			}
 		}
 
 		availableBalance := new(big.Int).Set(senderBalance)
@@ -786,7 +814,12 @@ func (s *State) EstimateGas(transaction *types.Transaction, senderAddress common
 	if lowEnd == ethTransferGas && transaction.To() != nil {
 		receiver := *transaction.To()
 		// check if the receiver address is not a smart contract
-		code, err := s.tree.GetCode(ctx, receiver, l2Block.Root().Bytes())
+		var code []byte
+		if account, found := stateOverride[receiver]; found && account.Code != nil {
+			code = account.Code
+		} else {
+			code, err = s.tree.GetCode(ctx, receiver, l2Block.Root().Bytes())
+		}
 		if err != nil {
 			log.Warnf("error while getting code for address %v: %v", receiver.String(), err)
 		} else if len(code) == 0 {
@@ -809,9 +842,9 @@ func (s *State) EstimateGas(transaction *types.Transaction, senderAddress common
 	var gasUsed uint64
 	var returnValue []byte
 	if forkID < FORKID_ETROG {
-		failed, reverted, gasUsed, returnValue, err = s.internalTestGasEstimationTransactionV1(ctx, batch, l2Block, latestL2BlockNumber, transaction, forkID, senderAddress, highEnd, nonce, false)
+		failed, reverted, gasUsed, returnValue, err = s.internalTestGasEstimationTransactionV1(ctx, batch, l2Block, latestL2BlockNumber, transaction, forkID, senderAddress, highEnd, nonce, stateOverride, false)
 	} else {
-		failed, reverted, gasUsed, returnValue, err = s.internalTestGasEstimationTransactionV2(ctx, batch, l2Block, latestL2BlockNumber, transaction, forkID, senderAddress, highEnd, nonce, false)
+		failed, reverted, gasUsed, returnValue, err = s.internalTestGasEstimationTransactionV2(ctx, batch, l2Block, latestL2BlockNumber, transaction, forkID, senderAddress, highEnd, nonce, stateOverride, false)
 	}
 
 	if failed {
@@ -844,9 +877,9 @@ func (s *State) EstimateGas(transaction *types.Transaction, senderAddress common
 
 		log.Debugf("Estimate gas. Trying to execute TX with %v gas", mid)
 		if forkID < FORKID_ETROG {
-			failed, reverted, _, _, err = s.internalTestGasEstimationTransactionV1(ctx, batch, l2Block, latestL2BlockNumber, transaction, forkID, senderAddress, mid, nonce, true)
+			failed, reverted, _, _, err = s.internalTestGasEstimationTransactionV1(ctx, batch, l2Block, latestL2BlockNumber, transaction, forkID, senderAddress, mid, nonce, stateOverride, true)
 		} else {
-			failed, reverted, _, _, err = s.internalTestGasEstimationTransactionV2(ctx, batch, l2Block, latestL2BlockNumber, transaction, forkID, senderAddress, mid, nonce, true)
+			failed, reverted, _, _, err = s.internalTestGasEstimationTransactionV2(ctx, batch, l2Block, latestL2BlockNumber, transaction, forkID, senderAddress, mid, nonce, stateOverride, true)
 		}
 		executionTime := time.Since(txExecutionStart)
 		totalExecutionTime += executionTime
@@ -880,7 +913,7 @@ func (s *State) EstimateGas(transaction *types.Transaction, senderAddress common
 // before ETROG
 func (s *State) internalTestGasEstimationTransactionV1(ctx context.Context, batch *Batch, l2Block *L2Block, latestL2BlockNumber uint64,
 	transaction *types.Transaction, forkID uint64, senderAddress common.Address,
-	gas uint64, nonce uint64, shouldOmitErr bool) (failed, reverted bool, gasUsed uint64, returnValue []byte, err error) {
+	gas uint64, nonce uint64, stateOverride StateOverride, shouldOmitErr bool) (failed, reverted bool, gasUsed uint64, returnValue []byte, err error) {
 	timestamp := l2Block.Time()
 	if l2Block.NumberU64() == latestL2BlockNumber {
 		timestamp = uint64(time.Now().Unix())
@@ -917,6 +950,7 @@ func (s *State) internalTestGasEstimationTransactionV1(ctx context.Context, batc
 		// v1 fields
 		GlobalExitRoot: batch.GlobalExitRoot.Bytes(),
 		EthTimestamp:   timestamp,
+		StateOverride:  convertToExecutorStateOverride(stateOverride),
 	}
 
 	log.Debugf("EstimateGas[processBatchRequestV1.From]: %v", processBatchRequestV1.From)
@@ -976,7 +1010,7 @@ func (s *State) internalTestGasEstimationTransactionV1(ctx context.Context, batc
 // after ETROG
 func (s *State) internalTestGasEstimationTransactionV2(ctx context.Context, batch *Batch, l2Block *L2Block, latestL2BlockNumber uint64,
 	transaction *types.Transaction, forkID uint64, senderAddress common.Address,
-	gas uint64, nonce uint64, shouldOmitErr bool) (failed, reverted bool, gasUsed uint64, returnValue []byte, err error) {
+	gas uint64, nonce uint64, stateOverride StateOverride, shouldOmitErr bool) (failed, reverted bool, gasUsed uint64, returnValue []byte, err error) {
 	deltaTimestamp := uint32(uint64(time.Now().Unix()) - l2Block.Time())
 	transactions := s.BuildChangeL2Block(deltaTimestamp, uint32(0))
 
@@ -1015,6 +1049,7 @@ func (s *State) internalTestGasEstimationTransactionV2(ctx context.Context, batc
 		TimestampLimit:         uint64(time.Now().Unix()),
 		SkipFirstChangeL2Block: cTrue,
 		SkipWriteBlockInfoRoot: cTrue,
+		StateOverride:          convertToExecutorStateOverrideV2(stateOverride),
 	}
 
 	log.Debugf("EstimateGas[processBatchRequestV2.From]: %v", processBatchRequestV2.From)
@@ -293,11 +293,30 @@ func (r *BatchResources) SumUp(other BatchResources) {
 	r.ZKCounters.SumUp(other.ZKCounters)
 }
 
+// L2BlockFeeData contains the gas information of a l2 block and its txs
+// required to compute the fee history of the block
+type L2BlockFeeData struct {
+	BlockNumber uint64
+	GasUsed     uint64
+	GasLimit    uint64
+	Txs         []TxFeeData
+}
+
+// TxFeeData contains the gas used by a tx and the effective gas price paid for it
+type TxFeeData struct {
+	GasUsed           uint64
+	EffectiveGasPrice *big.Int
+}
+
 // InfoReadWrite has information about modified addresses during the execution
 type InfoReadWrite struct {
 	Address common.Address
 	Nonce   *uint64
 	Balance *big.Int
+	// Code is nil when the code of the address was not modified
+	Code []byte
+	// Storage contains the modified storage positions and their new values
+	Storage map[common.Hash]common.Hash
 }
 
 // TraceConfig sets the debug configuration for the executor
@@ -325,6 +344,11 @@ func (t *TraceConfig) IsCallTracer() bool {
 	return t.Tracer != nil && *t.Tracer == "callTracer"
 }
 
+// IsFlatCallTracer returns true when should use flatCallTracer
+func (t *TraceConfig) IsFlatCallTracer() bool {
+	return t.Tracer != nil && *t.Tracer == "flatCallTracer"
+}
+
 // IsNoopTracer returns true when should use noopTracer
 func (t *TraceConfig) IsNoopTracer() bool {
 	return t.Tracer != nil && *t.Tracer == "noopTracer"
@@ -340,6 +364,20 @@ func (t *TraceConfig) IsJSCustomTracer() bool {
 	return t.Tracer != nil && strings.Contains(*t.Tracer, "result") && strings.Contains(*t.Tracer, "fault")
 }
 
+// OverrideAccount indicates the fields of an account that are overridden
+// during the execution of an unsigned tx
+type OverrideAccount struct {
+	Nonce     *uint64
+	Code      []byte
+	Balance   *big.Int
+	State     map[common.Hash]common.Hash
+	StateDiff map[common.Hash]common.Hash
+}
+
+// StateOverride is the collection of accounts overridden during the
+// execution of an unsigned tx
+type StateOverride map[common.Address]OverrideAccount
+
 // TrustedReorg represents a trusted reorg
 type TrustedReorg struct {
 	BatchNumber uint64
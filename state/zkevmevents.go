@@ -0,0 +1,192 @@
+package state
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/log"
+)
+
+const zkEVMEventsCheckInterval = time.Second
+
+// ZKEVMEventType represents the type of a ZKEVMEvent
+type ZKEVMEventType string
+
+const (
+	// ZKEVMEventTypeVirtualizedBatch is triggered when a batch is virtualized
+	ZKEVMEventTypeVirtualizedBatch ZKEVMEventType = "virtualizedBatch"
+	// ZKEVMEventTypeVerifiedBatch is triggered when a batch is verified
+	ZKEVMEventTypeVerifiedBatch ZKEVMEventType = "verifiedBatch"
+	// ZKEVMEventTypeL1InfoTreeLeaf is triggered when a leaf is added to the L1 info tree
+	ZKEVMEventTypeL1InfoTreeLeaf ZKEVMEventType = "l1InfoTreeLeaf"
+	// ZKEVMEventTypeReset is triggered when the state is reset due to a L1 reorg
+	ZKEVMEventTypeReset ZKEVMEventType = "reset"
+)
+
+// ZKEVMEventHandler represent a func that will be called by the
+// state when a ZKEVMEvent is triggered
+type ZKEVMEventHandler func(e ZKEVMEvent)
+
+// ZKEVMEvent is a struct provided from the state to the ZKEVMEventHandler
+// when a batch is virtualized or verified or a new leaf is added to the
+// L1 info tree, only the field related to the event type is set. The reset
+// events have no field set
+type ZKEVMEvent struct {
+	Type           ZKEVMEventType
+	VirtualBatch   *VirtualBatch
+	VerifiedBatch  *VerifiedBatch
+	L1InfoTreeLeaf *L1InfoTreeExitRootStorageEntry
+}
+
+// StartToMonitorZKEVMEvents starts a go routine that will monitor the
+// virtualized batches, the verified batches and the L1 info tree leaves
+// and execute the handlers registered to be executed when a new one is
+// detected. This is used by the RPC WebSocket zkEVM subscriptions.
+func (s *State) StartToMonitorZKEVMEvents() {
+	go InfiniteSafeRun(s.monitorZKEVMEvents, "fail to monitor zkevm events: %v", time.Second)
+}
+
+// RegisterZKEVMEventHandler add the provided handler to the list of handlers
+// that will be triggered when a zkevm event is triggered
+func (s *State) RegisterZKEVMEventHandler(h ZKEVMEventHandler) {
+	log.Info("zkevm event handler registered")
+	s.zkEVMEventHandlers = append(s.zkEVMEventHandlers, h)
+}
+
+func (s *State) monitorZKEVMEvents() {
+	ctx := context.Background()
+
+	lastResetEpochSeen, err := s.GetResetEpoch(ctx, nil)
+	if err != nil {
+		log.Fatalf("failed to load the reset epoch: %v", err)
+	}
+	lastVirtualBatchNumSeen, err := s.GetLastVirtualBatchNum(ctx, nil)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		log.Fatalf("failed to load the last virtual batch: %v", err)
+	}
+	lastVerifiedBatchNumSeen, err := s.getLastVerifiedBatchNum(ctx)
+	if err != nil {
+		log.Fatalf("failed to load the last verified batch: %v", err)
+	}
+	nextL1InfoTreeIndex, err := s.getNextL1InfoTreeIndex(ctx)
+	if err != nil {
+		log.Fatalf("failed to load the last l1 info tree index: %v", err)
+	}
+
+	for {
+		time.Sleep(zkEVMEventsCheckInterval)
+
+		if len(s.zkEVMEventHandlers) == 0 {
+			continue
+		}
+
+		// resets, the epoch detects them even when the state is re-synced
+		// to the same or a higher batch number between two checks
+		resetEpoch, err := s.GetResetEpoch(ctx, nil)
+		if err != nil {
+			log.Errorf("failed to get the reset epoch while monitoring zkevm events: %v", err)
+			continue
+		}
+		if resetEpoch != lastResetEpochSeen {
+			lastResetEpochSeen = resetEpoch
+			s.triggerZKEVMEvent(ZKEVMEvent{Type: ZKEVMEventTypeReset})
+		}
+
+		// virtualized batches
+		lastVirtualBatchNum, err := s.GetLastVirtualBatchNum(ctx, nil)
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			log.Errorf("failed to get last virtual batch while monitoring zkevm events: %v", err)
+			continue
+		}
+		if lastVirtualBatchNum < lastVirtualBatchNumSeen {
+			// reorg, already notified by the reset epoch
+			lastVirtualBatchNumSeen = lastVirtualBatchNum
+		}
+		for batchNum := lastVirtualBatchNumSeen + 1; batchNum <= lastVirtualBatchNum; batchNum++ {
+			virtualBatch, err := s.GetVirtualBatch(ctx, batchNum, nil)
+			if err != nil {
+				log.Errorf("failed to get virtual batch %v while monitoring zkevm events: %v", batchNum, err)
+				break
+			}
+			s.triggerZKEVMEvent(ZKEVMEvent{Type: ZKEVMEventTypeVirtualizedBatch, VirtualBatch: virtualBatch})
+			lastVirtualBatchNumSeen = batchNum
+		}
+
+		// verified batches, a single verification can verify multiple batches,
+		// so only the batches with a verification registered trigger an event
+		lastVerifiedBatchNum, err := s.getLastVerifiedBatchNum(ctx)
+		if err != nil {
+			log.Errorf("failed to get last verified batch while monitoring zkevm events: %v", err)
+			continue
+		}
+		if lastVerifiedBatchNum < lastVerifiedBatchNumSeen {
+			// reorg, already notified by the reset epoch
+			lastVerifiedBatchNumSeen = lastVerifiedBatchNum
+		}
+		for batchNum := lastVerifiedBatchNumSeen + 1; batchNum <= lastVerifiedBatchNum; batchNum++ {
+			verifiedBatch, err := s.GetVerifiedBatch(ctx, batchNum, nil)
+			if errors.Is(err, ErrNotFound) {
+				lastVerifiedBatchNumSeen = batchNum
+				continue
+			} else if err != nil {
+				log.Errorf("failed to get verified batch %v while monitoring zkevm events: %v", batchNum, err)
+				break
+			}
+			s.triggerZKEVMEvent(ZKEVMEvent{Type: ZKEVMEventTypeVerifiedBatch, VerifiedBatch: verifiedBatch})
+			lastVerifiedBatchNumSeen = batchNum
+		}
+
+		// l1 info tree leaves
+		lastL1InfoTreeIndex, err := s.GetLatestIndex(ctx, nil)
+		if errors.Is(err, ErrNotFound) {
+			nextL1InfoTreeIndex = 0
+			continue
+		} else if err != nil {
+			log.Errorf("failed to get last l1 info tree index while monitoring zkevm events: %v", err)
+			continue
+		}
+		if lastL1InfoTreeIndex+1 < nextL1InfoTreeIndex {
+			// reorg
+			nextL1InfoTreeIndex = lastL1InfoTreeIndex + 1
+		}
+		for ; nextL1InfoTreeIndex <= lastL1InfoTreeIndex; nextL1InfoTreeIndex++ {
+			leaf, err := s.GetL1InfoRootLeafByIndex(ctx, nextL1InfoTreeIndex, nil)
+			if err != nil {
+				log.Errorf("failed to get l1 info tree leaf %v while monitoring zkevm events: %v", nextL1InfoTreeIndex, err)
+				break
+			}
+			s.triggerZKEVMEvent(ZKEVMEvent{Type: ZKEVMEventTypeL1InfoTreeLeaf, L1InfoTreeLeaf: &leaf})
+		}
+	}
+}
+
+// getLastVerifiedBatchNum returns the number of the last verified batch or
+// zero if there is no verified batch yet
+func (s *State) getLastVerifiedBatchNum(ctx context.Context) (uint64, error) {
+	verifiedBatch, err := s.GetLastVerifiedBatch(ctx, nil)
+	if errors.Is(err, ErrNotFound) {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+	return verifiedBatch.BatchNumber, nil
+}
+
+// getNextL1InfoTreeIndex returns the index the next leaf added to the L1 info tree will have
+func (s *State) getNextL1InfoTreeIndex(ctx context.Context) (uint32, error) {
+	lastIndex, err := s.GetLatestIndex(ctx, nil)
+	if errors.Is(err, ErrNotFound) {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+	return lastIndex + 1, nil
+}
+
+func (s *State) triggerZKEVMEvent(e ZKEVMEvent) {
+	log.Debugf("[triggerZKEVMEvent] triggering zkevm event %v", e.Type)
+	for _, handler := range s.zkEVMEventHandlers {
+		SafeRun(func() { handler(e) }, "failed and recovered in ZKEVMEventHandler: %v")
+	}
+}
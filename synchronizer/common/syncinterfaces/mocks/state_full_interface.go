@@ -2550,6 +2550,56 @@ func (_c *StateFullInterface_SetLastBatchInfoSeenOnEthereum_Call) RunAndReturn(r
 	return _c
 }
 
+// StoreArchiveStateDiff provides a mock function with given fields: ctx, fromL2BlockNumber, toL2BlockNumber, readWriteAddresses, dbTx
+func (_m *StateFullInterface) StoreArchiveStateDiff(ctx context.Context, fromL2BlockNumber uint64, toL2BlockNumber uint64, readWriteAddresses map[common.Address]*state.InfoReadWrite, dbTx pgx.Tx) error {
+	ret := _m.Called(ctx, fromL2BlockNumber, toL2BlockNumber, readWriteAddresses, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StoreArchiveStateDiff")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, map[common.Address]*state.InfoReadWrite, pgx.Tx) error); ok {
+		r0 = rf(ctx, fromL2BlockNumber, toL2BlockNumber, readWriteAddresses, dbTx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StateFullInterface_StoreArchiveStateDiff_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StoreArchiveStateDiff'
+type StateFullInterface_StoreArchiveStateDiff_Call struct {
+	*mock.Call
+}
+
+// StoreArchiveStateDiff is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fromL2BlockNumber uint64
+//   - toL2BlockNumber uint64
+//   - readWriteAddresses map[common.Address]*state.InfoReadWrite
+//   - dbTx pgx.Tx
+func (_e *StateFullInterface_Expecter) StoreArchiveStateDiff(ctx interface{}, fromL2BlockNumber interface{}, toL2BlockNumber interface{}, readWriteAddresses interface{}, dbTx interface{}) *StateFullInterface_StoreArchiveStateDiff_Call {
+	return &StateFullInterface_StoreArchiveStateDiff_Call{Call: _e.mock.On("StoreArchiveStateDiff", ctx, fromL2BlockNumber, toL2BlockNumber, readWriteAddresses, dbTx)}
+}
+
+func (_c *StateFullInterface_StoreArchiveStateDiff_Call) Run(run func(ctx context.Context, fromL2BlockNumber uint64, toL2BlockNumber uint64, readWriteAddresses map[common.Address]*state.InfoReadWrite, dbTx pgx.Tx)) *StateFullInterface_StoreArchiveStateDiff_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].(uint64), args[3].(map[common.Address]*state.InfoReadWrite), args[4].(pgx.Tx))
+	})
+	return _c
+}
+
+func (_c *StateFullInterface_StoreArchiveStateDiff_Call) Return(_a0 error) *StateFullInterface_StoreArchiveStateDiff_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *StateFullInterface_StoreArchiveStateDiff_Call) RunAndReturn(run func(context.Context, uint64, uint64, map[common.Address]*state.InfoReadWrite, pgx.Tx) error) *StateFullInterface_StoreArchiveStateDiff_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // StoreL2Block provides a mock function with given fields: ctx, batchNumber, l2Block, txsEGPLog, dbTx
 func (_m *StateFullInterface) StoreL2Block(ctx context.Context, batchNumber uint64, l2Block *state.ProcessBlockResponse, txsEGPLog []*state.EffectiveGasPriceLog, dbTx pgx.Tx) error {
 	ret := _m.Called(ctx, batchNumber, l2Block, txsEGPLog, dbTx)
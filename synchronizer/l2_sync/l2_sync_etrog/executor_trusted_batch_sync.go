@@ -38,6 +38,7 @@ type StateInterface interface {
 	OpenBatch(ctx context.Context, processingContext state.ProcessingContext, dbTx pgx.Tx) error
 	ProcessBatchV2(ctx context.Context, request state.ProcessRequest, updateMerkleTree bool) (*state.ProcessBatchResponse, error)
 	StoreL2Block(ctx context.Context, batchNumber uint64, l2Block *state.ProcessBlockResponse, txsEGPLog []*state.EffectiveGasPriceLog, dbTx pgx.Tx) error
+	StoreArchiveStateDiff(ctx context.Context, fromL2BlockNumber, toL2BlockNumber uint64, readWriteAddresses map[common.Address]*state.InfoReadWrite, dbTx pgx.Tx) error
 	GetL1InfoTreeDataFromBatchL2Data(ctx context.Context, batchL2Data []byte, dbTx pgx.Tx) (map[uint32]state.L1DataV2, common.Hash, common.Hash, error)
 	GetLastVirtualBatchNum(ctx context.Context, dbTx pgx.Tx) (uint64, error)
 }
@@ -399,6 +400,15 @@ func (b *SyncTrustedBatchExecutorForEtrog) processAndStoreTxs(ctx context.Contex
 			return nil, newErr
 		}
 	}
+	if len(processBatchResp.BlockResponses) > 0 {
+		firstBlockNumber := processBatchResp.BlockResponses[0].BlockNumber
+		lastBlockNumber := processBatchResp.BlockResponses[len(processBatchResp.BlockResponses)-1].BlockNumber
+		if err = b.state.StoreArchiveStateDiff(ctx, firstBlockNumber, lastBlockNumber, processBatchResp.ReadWriteAddresses, dbTx); err != nil {
+			newErr := fmt.Errorf("%s failed to archive l2blocks [%v-%v] err:%w", debugPrefix, firstBlockNumber, lastBlockNumber, err)
+			log.Error(newErr.Error())
+			return nil, newErr
+		}
+	}
 	log.Infof("%s Batch %v: batchl2data len:%d processed and stored: %s oldStateRoot: %s -> newStateRoot:%s", debugPrefix, request.BatchNumber, len(request.Transactions), getResponseInfo(processBatchResp),
 		request.OldStateRoot.String(), processBatchResp.NewStateRoot.String())
 	return processBatchResp, nil
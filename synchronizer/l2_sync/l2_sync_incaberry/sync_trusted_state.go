@@ -34,6 +34,7 @@ type syncTrustedBatchesStateInterface interface {
 	CloseBatch(ctx context.Context, receipt state.ProcessingReceipt, dbTx pgx.Tx) error
 	ProcessBatch(ctx context.Context, request state.ProcessRequest, updateMerkleTree bool) (*state.ProcessBatchResponse, error)
 	StoreTransaction(ctx context.Context, batchNumber uint64, processedTx *state.ProcessTransactionResponse, coinbase common.Address, timestamp uint64, egpLog *state.EffectiveGasPriceLog, globalExitRoot, blockInfoRoot common.Hash, dbTx pgx.Tx) (*state.L2Header, error)
+	StoreArchiveStateDiff(ctx context.Context, fromL2BlockNumber, toL2BlockNumber uint64, readWriteAddresses map[common.Address]*state.InfoReadWrite, dbTx pgx.Tx) error
 	GetBatchByNumber(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (*state.Batch, error)
 	GetForkIDByBatchNumber(batchNumber uint64) uint64
 	ResetTrustedState(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) error
@@ -458,18 +459,30 @@ func (s *SyncTrustedBatchesAction) processAndStoreTxs(ctx context.Context, trust
 		log.Warn("romOOCError detected. Avoid store txs...")
 		return processBatchResp, nil
 	}
+	var firstL2Block, lastL2Block *state.L2Header
 	for _, block := range processBatchResp.BlockResponses {
 		for _, tx := range block.TransactionResponses {
 			if state.IsStateRootChanged(executor.RomErrorCode(tx.RomError)) {
 				log.Infof("TrustedBatch info: %+v", processBatchResp)
 				log.Infof("Storing trusted tx %+v", tx)
-				if _, err = s.state.StoreTransaction(ctx, uint64(trustedBatch.Number), tx, trustedBatch.Coinbase, uint64(trustedBatch.Timestamp), nil, block.GlobalExitRoot, block.BlockInfoRoot, dbTx); err != nil {
+				l2Block, err := s.state.StoreTransaction(ctx, uint64(trustedBatch.Number), tx, trustedBatch.Coinbase, uint64(trustedBatch.Timestamp), nil, block.GlobalExitRoot, block.BlockInfoRoot, dbTx)
+				if err != nil {
 					log.Errorf("failed to store transactions for batch: %v. Tx: %s", trustedBatch.Number, tx.TxHash.String())
 					return nil, err
 				}
+				if firstL2Block == nil {
+					firstL2Block = l2Block
+				}
+				lastL2Block = l2Block
 			}
 		}
 	}
+	if firstL2Block != nil && lastL2Block != nil {
+		if err = s.state.StoreArchiveStateDiff(ctx, firstL2Block.Number.Uint64(), lastL2Block.Number.Uint64(), processBatchResp.ReadWriteAddresses, dbTx); err != nil {
+			log.Errorf("failed to archive the l2 blocks of batch: %v. Error: %v", trustedBatch.Number, err)
+			return nil, err
+		}
+	}
 	return processBatchResp, nil
 }
 
@@ -857,6 +857,8 @@ func expectedCallsForsyncTrustedState(t *testing.T, m *mocks, sync *ClientSynchr
 			Return(&processedBatch, nil).Times(1)
 		m.State.EXPECT().StoreL2Block(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 			Return(nil).Times(1)
+		m.State.EXPECT().StoreArchiveStateDiff(mock.Anything, block1.BlockNumber, block1.BlockNumber, mock.Anything, mock.Anything).
+			Return(nil).Times(1)
 		m.State.EXPECT().UpdateWIPBatch(mock.Anything, mock.Anything, mock.Anything).
 			Return(nil).Times(1)
 		m.State.EXPECT().GetBatchByNumber(mock.Anything, mock.Anything, mock.Anything).
@@ -868,7 +870,11 @@ func expectedCallsForsyncTrustedState(t *testing.T, m *mocks, sync *ClientSynchr
 			Once()
 		m.State.
 			On("StoreTransaction", sync.ctx, stateBatchInTrustedNode.BatchNumber, mock.Anything, stateBatchInTrustedNode.Coinbase, uint64(batchInTrustedNode.Timestamp), common.Hash{}, common.Hash{}, mock.Anything, m.DbTx).
-			Return(&state.L2Header{}, nil).
+			Return(state.NewL2Header(&ethTypes.Header{Number: big.NewInt(1)}), nil).
+			Once()
+		m.State.
+			On("StoreArchiveStateDiff", sync.ctx, uint64(1), uint64(1), mock.Anything, m.DbTx).
+			Return(nil).
 			Once()
 	}
 